@@ -79,14 +79,19 @@ func runAnalysisPool(env poolEnv, slots []analysisSlot, deps analysisPoolDeps) {
 					Error:     err,
 				})
 			}
+			env.progress.done(i, inputPath, err)
 			return
 		}
 
 		clone := env.base.CloneForWorker(wlog)
 
 		var cb processor.ProgressCallback
-		if env.p != nil {
+		if env.p != nil || env.progress != nil {
 			cb = func(update processor.ProgressUpdate) {
+				env.progress.progress(i, inputPath, update)
+				if env.p == nil {
+					return
+				}
 				wlog("[ANALYSIS-POOL] Progress: Pass %d (%s), %.1f%%, Level %.1f dB", update.Pass, update.PassName, update.Progress*100, update.Level)
 				env.p.Send(ui.AnalysisProgressMsg{
 					FileIndex: i,
@@ -98,6 +103,7 @@ func runAnalysisPool(env poolEnv, slots []analysisSlot, deps analysisPoolDeps) {
 
 		wlog("[ANALYSIS-POOL] Starting AnalyseOnlyDetailed for %s", inputPath)
 		slots[i].result, slots[i].err = deps.analyse(env.ctx, inputPath, clone, cb)
+		env.progress.done(i, inputPath, slots[i].err)
 
 		if env.p != nil {
 			wlog("[ANALYSIS-POOL] Sending AnalysisCompleteMsg for file %d", i)
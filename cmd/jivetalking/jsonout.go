@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"io"
+
+	"github.com/linuxmatters/jivetalking/internal/processor"
+	"github.com/linuxmatters/jivetalking/internal/report"
+)
+
+// jsonResult is the one JSON object --json writes to stdout per input. File is
+// the 0-based position of the input on the command line, matching the
+// --progress-fd events. Record is the same run record written beside the
+// output as .json: the measurements by stage, the noise profile, and the
+// resolved filter configuration.
+type jsonResult struct {
+	File    int                  `json:"file"`
+	Input   string               `json:"input"`
+	Output  string               `json:"output,omitempty"`
+	Error   string               `json:"error,omitempty"`
+	Skipped bool                 `json:"skipped,omitempty"`
+	Timings *jsonTimings         `json:"timings,omitempty"`
+	Record  *processor.RunRecord `json:"record,omitempty"`
+}
+
+// jsonTimings carries report.Timings in seconds. The processing passes and the
+// analysis-only Analysis/Adaptation figures are each zero when not run.
+type jsonTimings struct {
+	Pass1S         float64 `json:"pass1_s,omitempty"`
+	Pass2S         float64 `json:"pass2_s,omitempty"`
+	Pass3S         float64 `json:"pass3_s,omitempty"`
+	Pass4S         float64 `json:"pass4_s,omitempty"`
+	AnalysisS      float64 `json:"analysis_s,omitempty"`
+	AdaptationS    float64 `json:"adaptation_s,omitempty"`
+	RealTimeFactor float64 `json:"real_time_factor,omitempty"`
+}
+
+func newJSONTimings(t report.Timings) *jsonTimings {
+	return &jsonTimings{
+		Pass1S:         t.Pass1.Seconds(),
+		Pass2S:         t.Pass2.Seconds(),
+		Pass3S:         t.Pass3.Seconds(),
+		Pass4S:         t.Pass4.Seconds(),
+		AnalysisS:      t.Analysis.Seconds(),
+		AdaptationS:    t.Adaptation.Seconds(),
+		RealTimeFactor: t.RealTimeFactor,
+	}
+}
+
+// resultSink is the shared stdout writer for --json. Each input yields exactly
+// one compact object on its own line, in completion order, so a consumer can
+// stream the results with json.Decoder. A nil sink is off and every method is
+// a no-op.
+type resultSink struct {
+	lines jsonLineWriter
+}
+
+// newResultSink builds a sink over w.
+func newResultSink(w io.Writer) *resultSink {
+	return &resultSink{lines: jsonLineWriter{w: w}}
+}
+
+// emit writes one result. The record is sanitised like the .json run record,
+// so NaN and ±Inf measurements (silent stretches, absent stages) are null
+// rather than invalid JSON.
+func (s *resultSink) emit(r jsonResult) {
+	if s == nil {
+		return
+	}
+	s.lines.writeLine(processor.SanitiseJSON(r))
+}
+
+// complete writes a finished file: its output path, per-pass timings, and run
+// record. Analysis-only results carry no output path.
+func (s *resultSink) complete(file int, input, output string, rec *processor.RunRecord, t report.Timings) {
+	s.emit(jsonResult{
+		File:    file,
+		Input:   input,
+		Output:  output,
+		Timings: newJSONTimings(t),
+		Record:  rec,
+	})
+}
+
+// failed writes a file that produced no result: an error, or skipped under
+// --on-exists=skip.
+func (s *resultSink) failed(file int, input string, err error) {
+	r := jsonResult{File: file, Input: input, Error: err.Error()}
+	if errors.Is(err, processor.ErrOutputSkipped) {
+		r.Skipped = true
+	}
+	s.emit(r)
+}
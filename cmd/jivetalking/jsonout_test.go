@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linuxmatters/jivetalking/internal/audio"
+	"github.com/linuxmatters/jivetalking/internal/processor"
+	"github.com/linuxmatters/jivetalking/internal/report"
+)
+
+// decodeResults streams every object the sink wrote, as a consumer would.
+func decodeResults(t *testing.T, r io.Reader) []map[string]any {
+	t.Helper()
+	var results []map[string]any
+	dec := json.NewDecoder(r)
+	for {
+		var obj map[string]any
+		if err := dec.Decode(&obj); errors.Is(err, io.EOF) {
+			return results
+		} else if err != nil {
+			t.Fatalf("result stream is not JSON: %v", err)
+		}
+		results = append(results, obj)
+	}
+}
+
+func TestResultSinkStreamsOneObjectPerFile(t *testing.T) {
+	var buf bytes.Buffer
+	sink := newResultSink(&buf)
+
+	rec := &processor.RunRecord{SchemaVersion: 1}
+	rec.Loudness.TargetILUFS = math.Inf(-1)
+	rec.Run.DurationS = math.NaN()
+	sink.complete(0, "a.wav", "a-LUFS-18-processed.flac", rec, report.Timings{
+		Pass1: 2 * time.Second,
+		Pass2: 1500 * time.Millisecond,
+	})
+	sink.failed(1, "b.wav", errors.New("unsupported format"))
+	sink.failed(2, "c.wav", fmt.Errorf("%w: c-LUFS-18-processed.flac", processor.ErrOutputSkipped))
+
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Fatalf("got %d lines, want one per file:\n%s", lines, buf.String())
+	}
+	results := decodeResults(t, &buf)
+	if len(results) != 3 {
+		t.Fatalf("decoded %d objects, want 3", len(results))
+	}
+
+	done := results[0]
+	if done["input"] != "a.wav" || done["output"] != "a-LUFS-18-processed.flac" {
+		t.Errorf("complete object = %v", done)
+	}
+	timings := done["timings"].(map[string]any)
+	if timings["pass1_s"] != 2.0 || timings["pass2_s"] != 1.5 {
+		t.Errorf("timings = %v, want pass1_s 2 and pass2_s 1.5", timings)
+	}
+	if _, ok := timings["pass3_s"]; ok {
+		t.Errorf("timings carry an unrun pass: %v", timings)
+	}
+	record := done["record"].(map[string]any)
+	if v, ok := record["loudness"].(map[string]any)["target_i_lufs"]; !ok || v != nil {
+		t.Errorf("-Inf target_i_lufs = %v, want null", v)
+	}
+	if v, ok := record["run"].(map[string]any)["duration_s"]; !ok || v != nil {
+		t.Errorf("NaN duration_s = %v, want null", v)
+	}
+
+	if results[1]["error"] != "unsupported format" || results[1]["skipped"] != nil {
+		t.Errorf("error object = %v", results[1])
+	}
+	if results[2]["skipped"] != true || results[2]["file"] != 2.0 {
+		t.Errorf("skipped object = %v", results[2])
+	}
+}
+
+func TestResultSinkNilIsOff(t *testing.T) {
+	var sink *resultSink
+	sink.complete(0, "a.wav", "", &processor.RunRecord{}, report.Timings{})
+	sink.failed(0, "a.wav", errors.New("boom"))
+}
+
+func TestRunAnalysisOnlyWithDeps_JSONOwnsStdout(t *testing.T) {
+	inputPath := "sample.wav"
+	var stdout, results bytes.Buffer
+
+	runAnalysisOnlyWithDeps([]string{inputPath}, processor.DefaultFilterConfig(), func(string, ...any) {}, 1, false, analysisOnlyDeps{
+		stdout: &stdout,
+		// A terminal is present, but --json must still skip the progress UI.
+		hasTTY: func() bool { return true },
+		openMetadata: func(string) (*audio.Metadata, error) {
+			return &audio.Metadata{Duration: 120, SampleRate: 48000, Channels: 1}, nil
+		},
+		analyse: func(_ context.Context, _ string, cfg *processor.BaseFilterConfig, _ processor.ProgressCallback) (*processor.AnalysisResult, error) {
+			effective, diagnostics := processor.AdaptConfig(cfg, makeAnalysisOnlyTestMeasurements())
+			return &processor.AnalysisResult{
+				Measurements:       makeAnalysisOnlyTestMeasurements(),
+				Config:             effective,
+				Diagnostics:        diagnostics,
+				AnalysisDuration:   2 * time.Second,
+				AdaptationDuration: 100 * time.Millisecond,
+			}, nil
+		},
+		printError: func(message string) {
+			t.Fatalf("printError called: %s", message)
+		},
+		writeMarkdownReport: func(*processor.RunRecord, report.Timings, string) error { return nil },
+		writeRunRecord:      func(*processor.RunRecord, string) error { return nil },
+		writeSidecars:       func(*processor.AudioMeasurements, string) error { return nil },
+		results:             newResultSink(&results),
+	})
+
+	if stdout.Len() != 0 {
+		t.Errorf("banner or confirmation written alongside --json:\n%s", stdout.String())
+	}
+	objs := decodeResults(t, &results)
+	if len(objs) != 1 {
+		t.Fatalf("got %d result objects, want 1", len(objs))
+	}
+	if _, ok := objs[0]["output"]; ok {
+		t.Errorf("analysis-only result carries an output path: %v", objs[0])
+	}
+	if got := objs[0]["timings"].(map[string]any)["analysis_s"]; got != 2.0 {
+		t.Errorf("analysis_s = %v, want 2", got)
+	}
+	if _, ok := objs[0]["record"].(map[string]any)["run"]; !ok {
+		t.Errorf("result carries no run record: %v", objs[0])
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -37,17 +38,79 @@ var createDebugLogFile = os.Create
 
 // CLI defines the command-line interface parsed by kong.
 type CLI struct {
-	Version      bool     `short:"v" help:"Show version information"`
-	Debug        bool     `short:"d" help:"Enable debug logging to jivetalking-debug.log"`
-	AnalysisOnly bool     `short:"a" help:"Run analysis only (Pass 1), display results, skip processing"`
-	Diagnostics  bool     `name:"diagnostics" help:"Write bulk diagnostic artefacts for sweeps and quality comparison: the .intervals.jsonl and .candidates.jsonl sidecars plus before/after spectrogram PNGs (whole-file and elected room-tone/speech regions). Adds extra FFmpeg passes. Off by default." default:"false"`
-	Files        []string `arg:"" name:"files" help:"Audio files to process" type:"existingfile" optional:""`
+	Version           bool          `short:"v" help:"Show version information"`
+	ListFilters       bool          `name:"list-filters" help:"List every processing stage with its parameters, defaults, and the measurements that tune it, then exit"`
+	Debug             bool          `short:"d" help:"Enable debug logging to jivetalking-debug.log"`
+	Jobs              int           `short:"j" name:"jobs" help:"Process at most N files at once (default: one per file, up to the CPU count)" placeholder:"N"`
+	AnalysisOnly      bool          `short:"a" help:"Run analysis only (Pass 1), display results, skip processing"`
+	Diagnostics       bool          `name:"diagnostics" help:"Write bulk diagnostic artefacts for sweeps and quality comparison: the .intervals.jsonl and .candidates.jsonl sidecars plus before/after spectrogram PNGs (whole-file and elected room-tone/speech regions). Adds extra FFmpeg passes. Off by default." default:"false"`
+	ExportIntervals   string        `name:"export-intervals" help:"Write the Pass 1 analysis intervals (every 250 ms: levels, the 13 spectral metrics, loudness, and peaks) to this CSV file, for plotting. One input only" placeholder:"PATH"`
+	ProgressFD        int           `name:"progress-fd" help:"Write newline-delimited JSON progress events to file descriptor N (e.g. 3) for an external front end, alongside the TUI" placeholder:"N"`
+	JSON              bool          `name:"json" help:"Write one JSON object per file to stdout instead of showing the TUI: the run record (measurements, noise profile, and resolved filters) and the per-pass timings. Non-finite values are null"`
+	GateThreshold     string        `name:"gate-threshold" help:"Pin the speech gate threshold in dBFS (e.g. -45dB) instead of deriving it; ratio, attack, release, and depth stay adaptive" placeholder:"DB"`
+	PickRoomTone      bool          `name:"pick-room-tone" help:"After analysis, choose the room-tone region used for the noise profile from the detected quiet regions"`
+	AnalysisSegments  int           `name:"analysis-segments" help:"Analyse each input in up to N time segments at once (at least 5 minutes each) and merge the measurements, so a multi-hour file's analysis uses several cores. 0 or 1 analyses in one pass, the default" placeholder:"N"`
+	NoiseRegion       string        `name:"noise-region" help:"Read the noise profile from this stretch of room tone instead of detecting one, given as START:DURATION in seconds or Go durations (e.g. 120.5:10 or 2m0.5s:10s)" placeholder:"START:DURATION"`
+	NoiseWindow       time.Duration `name:"noise-window" help:"Refine the detected room tone to its quietest stretch of this length (default 10s), in steps of 250ms" placeholder:"DURATION"`
+	NoiseWindowMin    time.Duration `name:"noise-window-min" help:"Shortest refined room tone accepted, no longer than --noise-window (default 8s, or --noise-window when shorter)" placeholder:"DURATION"`
+	NoiseFloor        string        `name:"noise-floor" help:"Set the noise floor in dBFS (e.g. -65dBFS) for a recording with no usable room tone; no room tone is profiled" placeholder:"DBFS"`
+	SaveNoiseProfile  string        `name:"save-noise-profile" help:"Write the noise profile read from the room tone to this JSON file, for --load-noise-profile on later recordings made in the same room. One input only" placeholder:"PATH"`
+	LoadNoiseProfile  string        `name:"load-noise-profile" help:"Use a noise profile written by --save-noise-profile instead of reading this recording's room tone; warns when the sample rate or noise floor do not match" placeholder:"PATH"`
+	Disable           string        `name:"disable" help:"Drop these stages from the chain after adaptation, for troubleshooting: highpass, humnotch, lowpass, denoise, afftdn, gate, compressor, deesser, declick" placeholder:"STAGE,..."`
+	NoiseStem         bool          `name:"noise-stem" help:"Also write the audio the noise reduction removed (input minus denoised) as <name>-noise-stem.flac"`
+	KeepIntermediate  string        `name:"keep-intermediate" help:"Keep each file's filtered audio from before normalisation in this directory as <name>-pass2.flac, with its measurements as <name>-pass2.json" placeholder:"DIR"`
+	LoudnormMode      string        `name:"loudnorm-mode" enum:"linear,dynamic" default:"linear" help:"Loudness normalisation mode: linear keeps the dynamics but can miss the target on difficult material; dynamic hits the target more reliably but alters the dynamics"`
+	Spec              string        `name:"spec" help:"Normalise to a named delivery target and check the result against its tolerances: ${specs}" placeholder:"NAME"`
+	TargetLUFS        string        `name:"target-lufs" help:"Integrated loudness target for the output in LUFS (e.g. -19, between -31 and -9; default -16)" placeholder:"LUFS"`
+	TargetRMS         string        `name:"target-rms" help:"Normalise the output RMS level to this value in dBFS (e.g. -20dBFS) instead of the integrated loudness, for workflows and datasets specified in RMS" placeholder:"DBFS"`
+	TargetTP          string        `name:"target-tp" help:"True-peak ceiling for the output in dBTP (e.g. -2dBTP, between -9 and 0; default -1), enforced by the final brickwall limiter" placeholder:"DBTP"`
+	Targets           string        `name:"targets" help:"Render one output per integrated loudness target in LUFS (e.g. -16,-14) from a single analysis; only the normalisation repeats" placeholder:"LUFS,..."`
+	SpeechLoud        bool          `name:"speech-loudness" help:"Normalise the speech to the loudness target: measure the integrated loudness over the detected speech only, so long pauses do not pull it down"`
+	BitDepth          string        `name:"bit-depth" enum:"16,24" default:"16" help:"Output bit depth: 16 for distribution, 24 for further editing. 16-bit output is always dithered"`
+	Channels          string        `name:"channels" enum:"mono,stereo,same,split" default:"mono" help:"Output channel layout: mono, stereo (dual-mono), or same as the input, all processed in mono; or split, which processes each channel of a stereo input on its own, for one speaker per side, and delivers them as L/R"`
+	OutputFormat      string        `name:"output-format" enum:"flac,wav" default:"flac" help:"Output file format: flac, or wav for editors and broadcast systems that want PCM. Both are lossless, at the chosen bit depth"`
+	OutputRate        string        `name:"output-rate" help:"Output sample rate: 44100, 48000, 96000, or same as the input. Converted with the soxr resampler; without it the output is 44.1 kHz" placeholder:"HZ"`
+	DeclickMethod     string        `name:"declick-method" help:"Click repair interpolation: s (spline, the default) for short clicks, a (autoregression) for heavier damage such as vinyl crackle or digital dropouts; slower" placeholder:"METHOD"`
+	DeclickOrder      string        `name:"declick-order" help:"Click repair autoregression order, as a percentage of the window (FFmpeg default 2, up to 25). Higher models longer damage" placeholder:"PCT"`
+	DeclickOverlap    string        `name:"declick-overlap" help:"Click repair window overlap percentage (default 50, up to 95). Higher catches more clicks at more cost" placeholder:"PCT"`
+	DeclickBurst      string        `name:"declick-burst" help:"Click repair burst fusion, as a percentage of the window (FFmpeg default 2, up to 10): clicks this close are repaired as one" placeholder:"PCT"`
+	Slate             bool          `name:"slate" help:"Prepend a line-up tone at the delivery loudness and a short silence to the output, for broadcast delivery"`
+	SlateTone         time.Duration `name:"slate-tone" default:"10s" help:"Length of the slate line-up tone; 0 for silence only" placeholder:"DURATION"`
+	SlateFrequency    float64       `name:"slate-frequency" default:"1000" help:"Frequency of the slate line-up tone in Hz" placeholder:"HZ"`
+	SlateSilence      time.Duration `name:"slate-silence" default:"2s" help:"Length of the silence between the slate tone and the programme; 0 for none" placeholder:"DURATION"`
+	LimiterNoiseGuard string        `name:"limiter-noise-guard" help:"Keep the levelling limiter's ceiling at least this far above the room-tone peak (default 6dB, 0 turns it off), so it never limits amplified noise" placeholder:"DB"`
+	Clarity           bool          `name:"clarity" help:"Score the speech clarity of the input and the output (0-100, from speech-to-noise ratio, sibilance balance, and spectral tilt) in the report and summary. Adds short band measurements"`
+	FixRegion         string        `name:"fix-region" help:"Process only this stretch of the input, given as START:DURATION in seconds or Go durations (e.g. 83:20 or 1m23s:20s), and crossfade it back into a copy of the original written as <name>-fixed.flac" placeholder:"START:DURATION"`
+	Excerpt           string        `name:"excerpt" help:"Process only this stretch of the input, given as START:DURATION in seconds or Go durations (e.g. 720:180 or 12m:3m), and write it on its own as <name>-excerpt-LUFS-NN-processed.flac" placeholder:"START:DURATION"`
+	ChunkOver         time.Duration `name:"chunk-over" help:"Render inputs longer than this through the filter chain in overlapping 30-minute chunks, so no filter graph spans the whole file, joined gaplessly and normalised as one programme; off by default" placeholder:"DURATION"`
+	CacheDir          string        `name:"cache-dir" env:"JIVETALKING_CACHE_DIR" help:"Keep Pass 1 analyses here, keyed by a hash of the input, the analysis settings and the jivetalking build, so re-runs that change only rendering options skip the analysis. Off unless given; never pruned" placeholder:"DIR"`
+	NoCache           bool          `name:"no-cache" help:"Neither read nor write the analysis cache for this run, even when --cache-dir or JIVETALKING_CACHE_DIR names one"`
+	SkipOutput        bool          `name:"skip-output-analysis" help:"Skip the separate loudness measurement pass and normalise from an estimate based on the filtered-audio analysis. Faster, for previews; the report marks the input loudness as estimated"`
+	ComfortNoise      bool          `name:"comfort-noise" help:"Add a very low bed of noise shaped like the measured room tone after the gate, so pauses sound like a quiet room rather than dead silence"`
+	FixPolarity       bool          `name:"fix-polarity" help:"Invert the output when the speech reads as polarity-inverted, so the track does not cancel against others in a multitrack mix. The report shows the polarity reading either way"`
+	Mains             string        `name:"mains" help:"Notch out mains hum at 50 or 60 Hz and its harmonics (50, 60, or auto); auto reads the frequency from the room tone and leaves the notch off when no hum stands out" placeholder:"HZ"`
+	SafeMode          bool          `name:"safe-mode" help:"If analysis fails on a file, process it with loudness normalisation only (with a warning) instead of skipping it"`
+	QuietPreGain      bool          `name:"quiet-pre-gain" help:"Lift a very quiet input (below -35 LUFS) before the analysis and filtering, then take the lift back off, so the filters are tuned on a healthy level"`
+	Output            string        `short:"o" name:"output" help:"Write the outputs into this directory instead of beside each input, creating it if needed; a path ending in .flac (or .wav) names the output file itself for a single input. Temp files and side artefacts follow the output" placeholder:"PATH"`
+	OnExists          string        `name:"on-exists" enum:"overwrite,skip,rename,error" default:"overwrite" help:"When the output file already exists: overwrite it, skip the input, rename the new output with \" (1)\", \" (2)\"..., or error"`
+	InPlace           bool          `name:"in-place" help:"Allow the output to replace its input file when the output path resolves to it; the input is kept as <input>.orig"`
+	PrintFiltergraph  bool          `name:"print-filtergraph" help:"Print each output's resolved Pass 2 and Pass 4 filter graphs to stderr after the run, to reproduce the processing in ffmpeg"`
+	EmitFFmpeg        bool          `name:"emit-ffmpeg-command" help:"Also write a runnable ffmpeg command that reproduces the render as <name>-ffmpeg-command.sh"`
+	Receipt           bool          `name:"receipt" help:"Print a processing receipt per output after the run: the jivetalking version, SHA-256 hashes of the input, the resolved render, and the output, and the output loudness, true peak, and loudness range"`
+	ReceiptFile       bool          `name:"receipt-file" help:"Also write the processing receipt beside each output as <output>.receipt.json"`
+	KeepCoverArt      bool          `name:"keep-cover-art" help:"Copy the cover art of a FLAC input onto the output; by default the output carries audio only"`
+	Files             []string      `arg:"" name:"files" help:"Audio files to process" type:"existingfile" optional:""`
 }
 
 // resolveJobs derives the worker count from the number of input files, capped
-// at numCPU so we never spawn more workers than CPUs, floored at 1. numCPU is a
-// parameter so the function is pure and table-testable.
-func resolveJobs(numFiles, numCPU int) int {
+// at numCPU so we never spawn more workers than CPUs, floored at 1. A positive
+// requested count (--jobs) replaces the CPU cap; more workers than files would
+// sit idle, so it is still capped at numFiles. numCPU is a parameter so the
+// function is pure and table-testable.
+func resolveJobs(numFiles, numCPU, requested int) int {
+	if requested > 0 {
+		return max(1, min(numFiles, requested))
+	}
 	return max(1, min(numFiles, numCPU))
 }
 
@@ -70,6 +133,7 @@ func main() {
 		kong.UsageOnError(),
 		kong.Vars{
 			"version": version,
+			"specs":   strings.Join(processor.LoudnessSpecNames(), ", "),
 		},
 		kong.Help(cli.StyledHelpPrinter()),
 	)
@@ -79,10 +143,23 @@ func main() {
 		os.Exit(0)
 	}
 
+	if cliArgs.ListFilters {
+		if err := processor.WriteFilterCatalogue(os.Stdout); err != nil {
+			cli.PrintError(err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Stamp the same version string the run records carry, so the report run
 	// section matches --version output.
 	processor.RunVersion = version
 
+	if cliArgs.Jobs < 0 {
+		cli.PrintError(fmt.Sprintf("invalid --jobs: %d is not a worker count", cliArgs.Jobs))
+		os.Exit(1)
+	}
+
 	if len(cliArgs.Files) == 0 {
 		cli.PrintError("No input files specified")
 		_ = ctx.PrintUsage(false)
@@ -90,6 +167,21 @@ func main() {
 	}
 
 	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(cliArgs, config); err != nil {
+		cli.PrintError(err.Error())
+		os.Exit(1)
+	}
+
+	// Name any filter missing from the linked FFmpeg up front: a required one
+	// would fail every graph, an optional one switches its feature off.
+	filters := processor.CheckFilters()
+	if len(filters.MissingRequired) > 0 {
+		cli.PrintError("FFmpeg build lacks required filters: " + strings.Join(filters.MissingRequired, ", "))
+		os.Exit(1)
+	}
+	for _, warning := range filters.Degrade(config) {
+		cli.PrintWarning(warning)
+	}
 
 	debugLog, err := openDebugLog(cliArgs.Debug)
 	if err != nil {
@@ -107,19 +199,45 @@ func main() {
 	// Route the filter chain's debug output through the same serialised sink.
 	config.SetLogger(log)
 
+	progress, err := openProgressSink(cliArgs.ProgressFD)
+	if err != nil {
+		cli.PrintError(fmt.Sprintf("invalid --progress-fd: %v", err))
+		os.Exit(1)
+	}
+
+	var results *resultSink
+	if cliArgs.JSON {
+		if cliArgs.PickRoomTone {
+			cli.PrintError("--pick-room-tone cannot be combined with --json")
+			os.Exit(1)
+		}
+		results = newResultSink(os.Stdout)
+	}
+
 	if cliArgs.AnalysisOnly {
-		runAnalysisOnly(cliArgs.Files, config, log, resolveJobs(len(cliArgs.Files), runtime.NumCPU()), cliArgs.Diagnostics)
+		if name := analysisOnlyConflict(cliArgs); name != "" {
+			cli.PrintError(name + " cannot be combined with --analysis-only")
+			os.Exit(1)
+		}
+		runAnalysisOnly(cliArgs.Files, config, log, resolveJobs(len(cliArgs.Files), runtime.NumCPU(), cliArgs.Jobs), cliArgs.Diagnostics, progress, results)
 		return
 	}
 
 	model := ui.NewModel(cliArgs.Files)
 
 	p := tea.NewProgram(model)
-	reportWarnings := make(chan string, len(cliArgs.Files))
+	if cliArgs.JSON {
+		// --json owns stdout: the pool still drives the model, which quits on
+		// AllCompleteMsg, but nothing is drawn and no keys are read.
+		p = tea.NewProgram(model, tea.WithoutRenderer(), tea.WithInput(nil), tea.WithOutput(io.Discard))
+	}
+	reportWarnings := &messageLog{}
+	receipts := &messageLog{}
+	filtergraphs := &messageLog{}
 
 	runCtx, cancel := context.WithCancel(context.Background())
 
-	jobs := resolveJobs(len(cliArgs.Files), runtime.NumCPU())
+	jobs := resolveJobs(len(cliArgs.Files), runtime.NumCPU(), cliArgs.Jobs)
 
 	env := poolEnv{
 		ctx:       runCtx,
@@ -128,6 +246,16 @@ func main() {
 		base:      config,
 		sharedLog: log,
 		jobs:      jobs,
+
+		pickRoomTone: cliArgs.PickRoomTone,
+		progress:     progress,
+		results:      results,
+	}
+	if cliArgs.Receipt {
+		env.receipts = receipts
+	}
+	if cliArgs.PrintFiltergraph {
+		env.filtergraphs = filtergraphs
 	}
 	poolDone := launchWorkerPool(env, cliArgs.Diagnostics, reportWarnings, defaultWorkerPoolDeps())
 
@@ -148,7 +276,6 @@ func main() {
 	// AllComplete sends do not block, and the acquire-time ctx.Done() select
 	// lets not-yet-started workers exit at once.
 	<-poolDone
-	close(reportWarnings)
 
 	if runErr != nil {
 		cli.PrintError(fmt.Sprintf("UI error: %v", runErr))
@@ -162,15 +289,406 @@ func main() {
 	// alt-screen restore on exit. Only on natural completion (Done == true); an
 	// early user quit (q/ctrl+c) leaves Done == false and must skip the print to
 	// avoid a misleading "complete" summary. A non-ui.Model also skips.
-	if m, ok := finalModel.(ui.Model); ok && m.Done {
+	// Under --json stdout carries only the result objects, so the summary is
+	// dropped and the receipts move to stderr.
+	if m, ok := finalModel.(ui.Model); ok && m.Done && !cliArgs.JSON {
 		fmt.Fprintln(colorprofile.NewWriter(os.Stdout, os.Environ()), ui.FinalSummary(m))
 	}
 
-	for warning := range reportWarnings {
+	receiptOut := io.Writer(os.Stdout)
+	if cliArgs.JSON {
+		receiptOut = os.Stderr
+	}
+	for _, receipt := range receipts.all() {
+		fmt.Fprintln(receiptOut, receipt)
+	}
+	for _, graphs := range filtergraphs.all() {
+		fmt.Fprintln(os.Stderr, graphs)
+	}
+
+	for _, warning := range reportWarnings.all() {
 		cli.PrintWarning(warning)
 	}
 }
 
+// applyUserOptions copies the user-facing processing flags onto the base config
+// seed every worker clones. An unparseable or out-of-range value is an error so
+// the run stops before any file is touched.
+func applyUserOptions(cliArgs *CLI, config *processor.BaseFilterConfig) error {
+	if cliArgs.GateThreshold != "" {
+		db, err := parseDecibels(cliArgs.GateThreshold)
+		if err != nil {
+			return fmt.Errorf("invalid --gate-threshold: %w", err)
+		}
+		if err := config.SetSpeechGateThreshold(db); err != nil {
+			return fmt.Errorf("invalid --gate-threshold: %w", err)
+		}
+	}
+	if err := config.SetAnalysisSegments(cliArgs.AnalysisSegments); err != nil {
+		return fmt.Errorf("invalid --analysis-segments: %w", err)
+	}
+	config.NoiseStem = cliArgs.NoiseStem
+	config.IntermediateDir = cliArgs.KeepIntermediate
+	config.SafeMode = cliArgs.SafeMode
+	config.InPlace = cliArgs.InPlace
+	if cliArgs.Output != "" {
+		if err := config.SetOutput(cliArgs.Output); err != nil {
+			return fmt.Errorf("invalid --output: %w", err)
+		}
+		if config.OutputFile != "" && len(cliArgs.Files) > 1 {
+			return fmt.Errorf("--output names one file but %d inputs were given; give a directory", len(cliArgs.Files))
+		}
+		if config.OutputFile != "" && cliArgs.Targets != "" {
+			return fmt.Errorf("--output names one file, but --targets writes one output per target; give a directory")
+		}
+	}
+	if cliArgs.OutputFormat != "" {
+		if err := config.SetOutputFormat(cliArgs.OutputFormat); err != nil {
+			return fmt.Errorf("invalid --output-format: %w", err)
+		}
+		if config.OutputFile != "" && !strings.EqualFold(filepath.Ext(config.OutputFile), "."+cliArgs.OutputFormat) {
+			return fmt.Errorf("--output %s does not end in .%s for --output-format=%s", config.OutputFile, cliArgs.OutputFormat, cliArgs.OutputFormat)
+		}
+		if cliArgs.KeepCoverArt && config.OutputFormat == processor.OutputFormatWAV {
+			return fmt.Errorf("--keep-cover-art needs a FLAC output, not --output-format=wav")
+		}
+	}
+	if cliArgs.OnExists != "" {
+		if err := config.SetOnExists(cliArgs.OnExists); err != nil {
+			return fmt.Errorf("invalid --on-exists: %w", err)
+		}
+	}
+	if !cliArgs.AnalysisOnly {
+		if err := checkOutputCollisions(cliArgs.Files, config); err != nil {
+			return err
+		}
+	}
+	config.EmitFFmpegCommand = cliArgs.EmitFFmpeg
+	config.Receipt = cliArgs.Receipt
+	config.WriteReceipt = cliArgs.ReceiptFile
+	config.KeepCoverArt = cliArgs.KeepCoverArt
+	config.Loudnorm.SpeechOnly = cliArgs.SpeechLoud
+	config.ComfortNoise = cliArgs.ComfortNoise
+	config.FixPolarity = cliArgs.FixPolarity
+	if cliArgs.Mains != "" {
+		if err := config.SetMains(cliArgs.Mains); err != nil {
+			return fmt.Errorf("invalid --mains: %w", err)
+		}
+	}
+	config.Clarity = cliArgs.Clarity
+	config.QuietPreGain = cliArgs.QuietPreGain
+	if err := config.SetChunkOver(cliArgs.ChunkOver); err != nil {
+		return fmt.Errorf("invalid --chunk-over: %w", err)
+	}
+	config.Loudnorm.EstimateMeasurement = cliArgs.SkipOutput
+	if !cliArgs.NoCache {
+		config.AnalysisCacheDir = cliArgs.CacheDir
+	}
+	if cliArgs.BitDepth != "" {
+		bits, err := strconv.Atoi(cliArgs.BitDepth)
+		if err != nil {
+			return fmt.Errorf("invalid --bit-depth: %w", err)
+		}
+		if err := config.SetOutputBitDepth(bits); err != nil {
+			return fmt.Errorf("invalid --bit-depth: %w", err)
+		}
+	}
+	if cliArgs.Channels == processor.OutputChannelsSplit {
+		if cliArgs.TargetRMS != "" || cliArgs.Targets != "" || cliArgs.SpeechLoud {
+			return fmt.Errorf("--channels split cannot be combined with --target-rms, --targets or --speech-loudness; the joined channels are trimmed to one integrated loudness target")
+		}
+		if cliArgs.Slate || cliArgs.NoiseStem || cliArgs.KeepIntermediate != "" || cliArgs.EmitFFmpeg || cliArgs.Receipt || cliArgs.ReceiptFile || cliArgs.Clarity || cliArgs.ExportIntervals != "" || cliArgs.SaveNoiseProfile != "" {
+			return fmt.Errorf("--channels split cannot be combined with --slate, --noise-stem, --keep-intermediate, --emit-ffmpeg-command, --receipt, --clarity, --export-intervals or --save-noise-profile, which describe a single processed channel")
+		}
+	}
+	if cliArgs.Channels != "" {
+		if err := config.SetOutputChannels(cliArgs.Channels); err != nil {
+			return fmt.Errorf("invalid --channels: %w", err)
+		}
+	}
+	if cliArgs.OutputRate != "" {
+		if err := config.SetOutputRate(cliArgs.OutputRate); err != nil {
+			return fmt.Errorf("invalid --output-rate: %w", err)
+		}
+	}
+	if cliArgs.DeclickMethod != "" {
+		if err := config.SetDeclickMethod(cliArgs.DeclickMethod); err != nil {
+			return fmt.Errorf("invalid --declick-method: %w", err)
+		}
+	}
+	for _, opt := range []struct {
+		name, value string
+		set         func(float64) error
+	}{
+		{"--declick-order", cliArgs.DeclickOrder, config.SetDeclickAROrder},
+		{"--declick-overlap", cliArgs.DeclickOverlap, config.SetDeclickOverlap},
+		{"--declick-burst", cliArgs.DeclickBurst, config.SetDeclickBurst},
+	} {
+		if opt.value == "" {
+			continue
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(opt.value, "%"), 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", opt.name, err)
+		}
+		if err := opt.set(percent); err != nil {
+			return fmt.Errorf("invalid %s: %w", opt.name, err)
+		}
+	}
+	if cliArgs.Spec != "" {
+		if err := config.SetLoudnessSpec(cliArgs.Spec); err != nil {
+			return fmt.Errorf("invalid --spec: %w", err)
+		}
+	}
+	if cliArgs.TargetLUFS != "" {
+		if cliArgs.Spec != "" || cliArgs.TargetRMS != "" || cliArgs.Targets != "" {
+			return fmt.Errorf("--target-lufs cannot be combined with --spec, --target-rms or --targets, which set their own target")
+		}
+		lufs, err := parseLUFS(cliArgs.TargetLUFS)
+		if err != nil {
+			return fmt.Errorf("invalid --target-lufs: %w", err)
+		}
+		if err := config.SetTargetLUFS(lufs); err != nil {
+			return fmt.Errorf("invalid --target-lufs: %w", err)
+		}
+	}
+	if cliArgs.TargetRMS != "" {
+		if cliArgs.Spec != "" || cliArgs.SpeechLoud {
+			return fmt.Errorf("--target-rms cannot be combined with --spec or --speech-loudness, which set a loudness target")
+		}
+		db, err := parseDecibels(cliArgs.TargetRMS)
+		if err != nil {
+			return fmt.Errorf("invalid --target-rms: %w", err)
+		}
+		if err := config.SetTargetRMS(db); err != nil {
+			return fmt.Errorf("invalid --target-rms: %w", err)
+		}
+	}
+	if cliArgs.TargetTP != "" {
+		if cliArgs.Spec != "" {
+			return fmt.Errorf("--target-tp cannot be combined with --spec, which sets its own true-peak ceiling")
+		}
+		db, err := parseDecibels(cliArgs.TargetTP)
+		if err != nil {
+			return fmt.Errorf("invalid --target-tp: %w", err)
+		}
+		if err := config.SetTargetTP(db); err != nil {
+			return fmt.Errorf("invalid --target-tp: %w", err)
+		}
+	}
+	if cliArgs.Targets != "" {
+		if cliArgs.Spec != "" || cliArgs.TargetRMS != "" {
+			return fmt.Errorf("--targets cannot be combined with --spec or --target-rms, which set a single target")
+		}
+		var targets []float64
+		for _, field := range strings.Split(cliArgs.Targets, ",") {
+			lufs, err := parseLUFS(field)
+			if err != nil {
+				return fmt.Errorf("invalid --targets: %w", err)
+			}
+			targets = append(targets, lufs)
+		}
+		if err := config.SetLoudnessTargets(targets); err != nil {
+			return fmt.Errorf("invalid --targets: %w", err)
+		}
+	}
+	// After the targets, which set the level the slate tone must fit under.
+	if cliArgs.Slate {
+		if err := config.SetSlate(cliArgs.SlateTone, cliArgs.SlateFrequency, cliArgs.SlateSilence); err != nil {
+			return fmt.Errorf("invalid --slate: %w", err)
+		}
+	}
+	if cliArgs.LimiterNoiseGuard != "" {
+		db, err := parseDecibels(cliArgs.LimiterNoiseGuard)
+		if err != nil {
+			return fmt.Errorf("invalid --limiter-noise-guard: %w", err)
+		}
+		if err := config.SetLimiterNoiseGuard(db); err != nil {
+			return fmt.Errorf("invalid --limiter-noise-guard: %w", err)
+		}
+	}
+	if cliArgs.FixRegion != "" {
+		if cliArgs.Targets != "" || cliArgs.Slate || cliArgs.NoiseStem || cliArgs.KeepIntermediate != "" || cliArgs.EmitFFmpeg || cliArgs.Receipt || cliArgs.ReceiptFile {
+			return fmt.Errorf("--fix-region cannot be combined with --targets, --slate, --noise-stem, --keep-intermediate, --emit-ffmpeg-command, or --receipt, which describe a whole-file output")
+		}
+		start, duration, err := parseFixRegion(cliArgs.FixRegion)
+		if err != nil {
+			return fmt.Errorf("invalid --fix-region: %w", err)
+		}
+		if err := config.SetFixRegion(start, duration); err != nil {
+			return fmt.Errorf("invalid --fix-region: %w", err)
+		}
+	}
+	if cliArgs.Excerpt != "" {
+		if cliArgs.FixRegion != "" || cliArgs.InPlace || cliArgs.EmitFFmpeg || cliArgs.Diagnostics {
+			return fmt.Errorf("--excerpt cannot be combined with --fix-region, --in-place, --emit-ffmpeg-command, or --diagnostics")
+		}
+		start, duration, err := parseFixRegion(cliArgs.Excerpt)
+		if err != nil {
+			return fmt.Errorf("invalid --excerpt: %w", err)
+		}
+		if err := config.SetExcerpt(start, duration); err != nil {
+			return fmt.Errorf("invalid --excerpt: %w", err)
+		}
+	}
+	if cliArgs.ExportIntervals != "" {
+		if len(cliArgs.Files) > 1 {
+			return fmt.Errorf("--export-intervals names one file but %d inputs were given", len(cliArgs.Files))
+		}
+		if cliArgs.FixRegion != "" {
+			return fmt.Errorf("--export-intervals cannot be combined with --fix-region, which analyses only the region")
+		}
+		config.IntervalsCSV = cliArgs.ExportIntervals
+	}
+	if cliArgs.NoiseRegion != "" {
+		if cliArgs.PickRoomTone || cliArgs.FixRegion != "" {
+			return fmt.Errorf("--noise-region cannot be combined with --pick-room-tone or --fix-region")
+		}
+		start, duration, err := parseFixRegion(cliArgs.NoiseRegion)
+		if err != nil {
+			return fmt.Errorf("invalid --noise-region: %w", err)
+		}
+		if err := config.SetNoiseRegion(start, duration); err != nil {
+			return fmt.Errorf("invalid --noise-region: %w", err)
+		}
+	}
+	if cliArgs.NoiseWindow != 0 || cliArgs.NoiseWindowMin != 0 {
+		if cliArgs.NoiseRegion != "" || cliArgs.NoiseFloor != "" || cliArgs.LoadNoiseProfile != "" {
+			return fmt.Errorf("--noise-window cannot be combined with --noise-region, --noise-floor, or --load-noise-profile, which replace the detected room tone")
+		}
+		if err := config.SetNoiseWindow(cliArgs.NoiseWindow, cliArgs.NoiseWindowMin); err != nil {
+			return fmt.Errorf("invalid --noise-window: %w", err)
+		}
+	}
+	if cliArgs.NoiseFloor != "" {
+		if cliArgs.NoiseRegion != "" || cliArgs.PickRoomTone {
+			return fmt.Errorf("--noise-floor cannot be combined with --noise-region or --pick-room-tone")
+		}
+		db, err := parseDecibels(cliArgs.NoiseFloor)
+		if err != nil {
+			return fmt.Errorf("invalid --noise-floor: %w", err)
+		}
+		if err := config.SetNoiseFloor(db); err != nil {
+			return fmt.Errorf("invalid --noise-floor: %w", err)
+		}
+	}
+	if cliArgs.SaveNoiseProfile != "" {
+		if len(cliArgs.Files) > 1 {
+			return fmt.Errorf("--save-noise-profile names one file but %d inputs were given", len(cliArgs.Files))
+		}
+		if cliArgs.FixRegion != "" || cliArgs.LoadNoiseProfile != "" {
+			return fmt.Errorf("--save-noise-profile cannot be combined with --fix-region or --load-noise-profile")
+		}
+		config.NoiseProfileOut = cliArgs.SaveNoiseProfile
+	}
+	if cliArgs.LoadNoiseProfile != "" {
+		if cliArgs.NoiseRegion != "" || cliArgs.NoiseFloor != "" || cliArgs.PickRoomTone || cliArgs.FixRegion != "" {
+			return fmt.Errorf("--load-noise-profile cannot be combined with --noise-region, --noise-floor, --pick-room-tone, or --fix-region")
+		}
+		saved, err := processor.LoadNoiseProfile(cliArgs.LoadNoiseProfile)
+		if err != nil {
+			return fmt.Errorf("invalid --load-noise-profile: %w", err)
+		}
+		config.NoiseProfileIn = saved
+	}
+	if cliArgs.Disable != "" {
+		var stages []string
+		for _, field := range strings.Split(cliArgs.Disable, ",") {
+			stages = append(stages, strings.ToLower(strings.TrimSpace(field)))
+		}
+		if err := config.SetDisabledStages(stages); err != nil {
+			return fmt.Errorf("invalid --disable: %w", err)
+		}
+	}
+	if cliArgs.LoudnormMode != "" {
+		if err := config.SetLoudnormMode(cliArgs.LoudnormMode); err != nil {
+			return fmt.Errorf("invalid --loudnorm-mode: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseDecibels parses a dB value written with or without a unit suffix
+// ("-45", "-45dB", "-45 dBFS", "-2dBTP"). The suffix is case-insensitive.
+func parseDecibels(s string) (float64, error) {
+	v := strings.TrimSpace(s)
+	lower := strings.ToLower(v)
+	for _, suffix := range []string{"dbfs", "dbtp", "db"} {
+		if strings.HasSuffix(lower, suffix) {
+			v = strings.TrimSpace(v[:len(v)-len(suffix)])
+			break
+		}
+	}
+	db, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a dB value", s)
+	}
+	return db, nil
+}
+
+// parseLUFS parses a loudness such as "-16", "-16LUFS" or "-16 lufs"; the
+// unit is optional and case-insensitive, as in parseDecibels.
+func parseLUFS(s string) (float64, error) {
+	v := strings.TrimSpace(s)
+	if strings.HasSuffix(strings.ToLower(v), "lufs") {
+		v = strings.TrimSpace(v[:len(v)-len("lufs")])
+	}
+	lufs, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a LUFS value", s)
+	}
+	return lufs, nil
+}
+
+// parseFixRegion parses START:DURATION, each part in seconds ("83.5") or as a
+// Go duration ("1m23.5s"). --noise-region shares the form.
+func parseFixRegion(s string) (start, duration time.Duration, err error) {
+	startField, durationField, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q is not START:DURATION", s)
+	}
+	if start, err = parseRegionTime(startField); err != nil {
+		return 0, 0, err
+	}
+	if duration, err = parseRegionTime(durationField); err != nil {
+		return 0, 0, err
+	}
+	return start, duration, nil
+}
+
+// checkOutputCollisions refuses a batch in which two inputs would write the
+// same outputs: one file given twice, episode.wav beside episode.flac, or two
+// inputs sharing a name under --output=DIR. Their jobs run concurrently and
+// would overwrite each other's output and report.
+func checkOutputCollisions(files []string, config *processor.BaseFilterConfig) error {
+	seen := make(map[string]string, len(files))
+	for _, file := range files {
+		stem := config.OutputStem(file)
+		if abs, err := filepath.Abs(stem); err == nil {
+			stem = abs
+		}
+		if earlier, ok := seen[stem]; ok {
+			return fmt.Errorf("%s and %s would both write %s-*; process them in separate runs or into separate --output directories",
+				earlier, file, filepath.Base(stem))
+		}
+		seen[stem] = file
+	}
+	return nil
+}
+
+// parseRegionTime parses a time in seconds or as a Go duration.
+func parseRegionTime(s string) (time.Duration, error) {
+	v := strings.TrimSpace(s)
+	if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time in seconds or a duration", s)
+	}
+	return d, nil
+}
+
 func openDebugLog(enabled bool) (*os.File, error) {
 	if !enabled {
 		return nil, nil
@@ -192,6 +710,13 @@ type analysisOnlyDeps struct {
 	writeMarkdownReport func(*processor.RunRecord, report.Timings, string) error
 	writeRunRecord      func(*processor.RunRecord, string) error
 	writeSidecars       func(*processor.AudioMeasurements, string) error
+
+	// progress receives the --progress-fd JSON events; nil when off.
+	progress *progressSink
+
+	// results receives each file's --json result object in place of the
+	// progress UI and the stdout confirmations; nil when off.
+	results *resultSink
 }
 
 func defaultAnalysisOnlyDeps() analysisOnlyDeps {
@@ -255,6 +780,10 @@ type progressHandler struct {
 	// update (chain + analysis rows). The pool reads it back at completion to merge
 	// the limiter ceiling before the final AdaptedSummaryMsg.
 	summary ui.AdaptedSummary
+
+	// progress and path feed the --progress-fd events; progress is nil when off.
+	progress *progressSink
+	path     string
 }
 
 // passCompleteThreshold treats any progress at or above this value as a pass-end
@@ -301,6 +830,8 @@ func (ph *progressHandler) callback(update processor.ProgressUpdate) {
 		}
 	}
 
+	ph.progress.progress(ph.fileIndex, ph.path, update)
+
 	ph.p.Send(ui.ProgressMsg{
 		FileIndex:    ph.fileIndex,
 		Pass:         update.Pass,
@@ -337,11 +868,57 @@ func (ph *progressHandler) callback(update processor.ProgressUpdate) {
 	}
 }
 
+// analysisOnlyConflict names the first option given that only shapes the
+// processed output, which --analysis-only never writes; empty when none is.
+func analysisOnlyConflict(cliArgs *CLI) string {
+	for _, option := range []struct {
+		set  bool
+		name string
+	}{
+		{cliArgs.PickRoomTone, "--pick-room-tone"},
+		{cliArgs.NoiseStem, "--noise-stem"},
+		{cliArgs.KeepIntermediate != "", "--keep-intermediate"},
+		{cliArgs.TargetRMS != "", "--target-rms"},
+		{cliArgs.TargetTP != "", "--target-tp"},
+		{cliArgs.Targets != "", "--targets"},
+		{cliArgs.OutputRate != "", "--output-rate"},
+		{cliArgs.OutputFormat != "" && cliArgs.OutputFormat != processor.OutputFormatFLAC, "--output-format"},
+		{cliArgs.Disable != "", "--disable"},
+		{cliArgs.Slate, "--slate"},
+		{cliArgs.DeclickMethod != "" || cliArgs.DeclickOrder != "" || cliArgs.DeclickOverlap != "" || cliArgs.DeclickBurst != "", "--declick-* options"},
+		{cliArgs.LimiterNoiseGuard != "", "--limiter-noise-guard"},
+		{cliArgs.SpeechLoud, "--speech-loudness"},
+		{cliArgs.SkipOutput, "--skip-output-analysis"},
+		{cliArgs.Clarity, "--clarity"},
+		{cliArgs.QuietPreGain, "--quiet-pre-gain"},
+		{cliArgs.Output != "", "--output"},
+		{cliArgs.OnExists != "" && cliArgs.OnExists != processor.OnExistsOverwrite, "--on-exists"},
+		{cliArgs.ComfortNoise, "--comfort-noise"},
+		{cliArgs.FixPolarity, "--fix-polarity"},
+		{cliArgs.Mains != "", "--mains"},
+		{cliArgs.EmitFFmpeg, "--emit-ffmpeg-command"},
+		{cliArgs.PrintFiltergraph, "--print-filtergraph"},
+		{cliArgs.Receipt, "--receipt"},
+		{cliArgs.ReceiptFile, "--receipt-file"},
+		{cliArgs.FixRegion != "", "--fix-region"},
+		{cliArgs.Excerpt != "", "--excerpt"},
+		{cliArgs.Channels == processor.OutputChannelsSplit, "--channels split"},
+	} {
+		if option.set {
+			return option.name
+		}
+	}
+	return ""
+}
+
 // runAnalysisOnly performs Pass 1 analysis on each file under a bounded worker
 // pool, then displays results to console in input order. Skips full 4-pass
 // processing.
-func runAnalysisOnly(files []string, config *processor.BaseFilterConfig, log func(string, ...any), jobs int, diagnostics bool) {
-	runAnalysisOnlyWithDeps(files, config, log, jobs, diagnostics, defaultAnalysisOnlyDeps())
+func runAnalysisOnly(files []string, config *processor.BaseFilterConfig, log func(string, ...any), jobs int, diagnostics bool, progress *progressSink, results *resultSink) {
+	deps := defaultAnalysisOnlyDeps()
+	deps.progress = progress
+	deps.results = results
+	runAnalysisOnlyWithDeps(files, config, log, jobs, diagnostics, deps)
 }
 
 // runAnalysisOnlyWithDeps drives the analysis-only path with injected
@@ -374,13 +951,14 @@ func runAnalysisOnlyWithDeps(files []string, config *processor.BaseFilterConfig,
 		specCancel()
 	}()
 
-	tty := deps.hasTTY()
+	// --json owns stdout, so it runs like the no-TTY path without the banner.
+	tty := deps.hasTTY() && deps.results == nil
 
 	if tty {
 		model := ui.NewAnalysisModel(files)
 		p := tea.NewProgram(model)
 
-		env := poolEnv{ctx: runCtx, p: p, files: files, base: config, sharedLog: log, jobs: jobs}
+		env := poolEnv{ctx: runCtx, p: p, files: files, base: config, sharedLog: log, jobs: jobs, progress: deps.progress}
 		poolDone := make(chan struct{})
 		go func() {
 			runAnalysisPool(env, slots, poolDeps)
@@ -403,9 +981,11 @@ func runAnalysisOnlyWithDeps(files []string, config *processor.BaseFilterConfig,
 	} else {
 		// No terminal: one up-front banner, then the pool runs synchronously.
 		log("[ANALYSIS] No TTY available, running without progress UI")
-		fmt.Fprintf(deps.stdout, "Analysing %d files…\n", len(files))
+		if deps.results == nil {
+			fmt.Fprintf(deps.stdout, "Analysing %d files…\n", len(files))
+		}
 
-		env := poolEnv{ctx: runCtx, p: nil, files: files, base: config, sharedLog: log, jobs: jobs}
+		env := poolEnv{ctx: runCtx, p: nil, files: files, base: config, sharedLog: log, jobs: jobs, progress: deps.progress}
 		runAnalysisPool(env, slots, poolDeps)
 
 		cancel()
@@ -429,6 +1009,7 @@ func runAnalysisOnlyWithDeps(files []string, config *processor.BaseFilterConfig,
 				continue
 			}
 			deps.printError(fmt.Sprintf("Analysis failed for %s: %v", files[i], slots[i].err))
+			deps.results.failed(i, files[i], slots[i].err)
 			continue
 		}
 
@@ -436,7 +1017,7 @@ func runAnalysisOnlyWithDeps(files []string, config *processor.BaseFilterConfig,
 			continue // cancelled before analysis ran
 		}
 
-		emitAnalysisReport(files[i], slots[i].result, slots[i].meta, diagnostics, noTTY, deps, render)
+		emitAnalysisReport(i, files[i], slots[i].result, slots[i].meta, diagnostics, noTTY, deps, render)
 	}
 }
 
@@ -455,9 +1036,10 @@ type analysisRenderScheduler struct {
 // artefact-emission spine (emitReportArtefacts: always-on .md/.json, opt-in
 // .jsonl sidecars and input-only spectrogram PNGs under --diagnostics), and (in
 // no-TTY mode, when the report landed) prints the one-line stdout confirmation.
+// Under --json the file's result object replaces the confirmation.
 // Every write failure is non-fatal and isolated so the remaining artefacts still
 // emit, matching the processing path in pool.go.
-func emitAnalysisReport(inputPath string, result *processor.AnalysisResult, meta *audio.Metadata, diagnostics, noTTY bool, deps analysisOnlyDeps, render analysisRenderScheduler) {
+func emitAnalysisReport(i int, inputPath string, result *processor.AnalysisResult, meta *audio.Metadata, diagnostics, noTTY bool, deps analysisOnlyDeps, render analysisRenderScheduler) {
 	// Emit the Pass-1-only run record beside the analysis report. The .json
 	// path is derived from AnalysisReportPath by swapping the .md extension, so
 	// both share the <stem>-<ext>-analysis basename. meta supplies provenance
@@ -485,15 +1067,16 @@ func emitAnalysisReport(inputPath string, result *processor.AnalysisResult, meta
 	// report-write failure. Only the "source → report" confirmation is
 	// suppressed below, so detect the report write here.
 	reportWritten := true
+	timings := report.Timings{
+		Analysis:   result.AnalysisDuration,
+		Adaptation: result.AdaptationDuration,
+	}
 	emitReportArtefacts(reportArtefacts{
 		rec:         record,
 		stem:        stem,
 		stages:      processor.AnalysisSpectrogramStages,
 		sidecarMeas: result.Measurements,
-		timings: report.Timings{
-			Analysis:   result.AnalysisDuration,
-			Adaptation: result.AdaptationDuration,
-		},
+		timings:     timings,
 		diagnostics: diagnostics,
 		renderCtx:   render.ctx,
 		renderSem:   render.sem,
@@ -515,6 +1098,10 @@ func emitAnalysisReport(inputPath string, result *processor.AnalysisResult, meta
 		onReportFail:  func() { reportWritten = false },
 	})
 
+	if deps.results != nil {
+		deps.results.complete(i, inputPath, "", record, timings)
+		return
+	}
 	if noTTY && reportWritten {
 		printAnalysisConfirmation(deps.stdout, inputPath, reportPath, result.Measurements)
 	}
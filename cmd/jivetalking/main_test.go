@@ -128,6 +128,32 @@ func TestProgressCallbackBoundariesUseProcessorEvent(t *testing.T) {
 	}
 }
 
+func TestAnalysisOnlyConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		cli  CLI
+		want string
+	}{
+		{"none", CLI{}, ""},
+		{"default output format", CLI{OutputFormat: processor.OutputFormatFLAC}, ""},
+		{"default on-exists", CLI{OnExists: processor.OnExistsOverwrite}, ""},
+		{"analysis segments", CLI{AnalysisSegments: 8}, ""},
+		{"pick room tone", CLI{PickRoomTone: true}, "--pick-room-tone"},
+		{"output", CLI{Output: "out.flac"}, "--output"},
+		{"declick", CLI{DeclickBurst: "4"}, "--declick-* options"},
+		{"receipt", CLI{Receipt: true}, "--receipt"},
+		{"receipt file", CLI{ReceiptFile: true}, "--receipt-file"},
+		{"split channels", CLI{Channels: processor.OutputChannelsSplit}, "--channels split"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := analysisOnlyConflict(&tt.cli); got != tt.want {
+				t.Errorf("analysisOnlyConflict = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRunAnalysisOnlyWithDeps_NonTTYOmitsBenchPath(t *testing.T) {
 	inputPath := ".bench/analysis/input/sample.wav"
 	config := processor.DefaultFilterConfig()
@@ -718,6 +744,7 @@ func TestResolveJobs(t *testing.T) {
 		name     string
 		numFiles int
 		numCPU   int
+		jobs     int
 		want     int
 	}{
 		{name: "fewer files than CPUs uses file count", numFiles: 3, numCPU: 8, want: 3},
@@ -725,16 +752,360 @@ func TestResolveJobs(t *testing.T) {
 		{name: "files equal CPUs uses that count", numFiles: 8, numCPU: 8, want: 8},
 		{name: "single file stays one", numFiles: 1, numCPU: 8, want: 1},
 		{name: "zero files floors to one", numFiles: 0, numCPU: 8, want: 1},
+		{name: "--jobs below the CPU count wins", numFiles: 16, numCPU: 8, jobs: 2, want: 2},
+		{name: "--jobs may exceed the CPU count", numFiles: 16, numCPU: 8, jobs: 12, want: 12},
+		{name: "--jobs still caps at file count", numFiles: 3, numCPU: 8, jobs: 6, want: 3},
+		{name: "--jobs=1 runs serially", numFiles: 4, numCPU: 8, jobs: 1, want: 1},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := resolveJobs(tt.numFiles, tt.numCPU); got != tt.want {
-				t.Fatalf("resolveJobs(%d, %d) = %d, want %d", tt.numFiles, tt.numCPU, got, tt.want)
+			if got := resolveJobs(tt.numFiles, tt.numCPU, tt.jobs); got != tt.want {
+				t.Fatalf("resolveJobs(%d, %d, %d) = %d, want %d", tt.numFiles, tt.numCPU, tt.jobs, got, tt.want)
 			}
 		})
 	}
 }
 
+func TestParseDecibels(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "-45", want: -45},
+		{in: "-45dB", want: -45},
+		{in: "-45.5 dBFS", want: -45.5},
+		{in: " -30DB ", want: -30},
+		{in: "-2dBTP", want: -2},
+		{in: "loud", wantErr: true},
+		{in: "dB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseDecibels(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDecibels(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("parseDecibels(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLUFS(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "-16", want: -16},
+		{in: "-16LUFS", want: -16},
+		{in: "-16 LUFS", want: -16},
+		{in: "-16 lufs", want: -16},
+		{in: " -23.5Lufs ", want: -23.5},
+		{in: "loud", wantErr: true},
+		{in: "LUFS", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseLUFS(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLUFS(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("parseLUFS(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyUserOptionsAnalysisSegments(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{AnalysisSegments: 8}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.AnalysisSegments != 8 {
+		t.Errorf("AnalysisSegments = %d, want 8", config.AnalysisSegments)
+	}
+	for _, n := range []int{-1, 65} {
+		if err := applyUserOptions(&CLI{AnalysisSegments: n}, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("--analysis-segments=%d accepted, want an error", n)
+		}
+	}
+}
+
+func TestApplyUserOptionsGateThreshold(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{GateThreshold: "-45dB"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.SpeechGateThresholdDB != -45 {
+		t.Fatalf("SpeechGateThresholdDB = %v, want -45", config.SpeechGateThresholdDB)
+	}
+
+	for _, bad := range []string{"-10dB", "quiet"} {
+		if err := applyUserOptions(&CLI{GateThreshold: bad}, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%q) = nil, want error", bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsLoudnormMode(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{LoudnormMode: processor.LoudnormModeDynamic}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Loudnorm.Linear {
+		t.Error("Loudnorm.Linear = true after --loudnorm-mode=dynamic, want false")
+	}
+
+	if err := applyUserOptions(&CLI{LoudnormMode: "adaptive"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("applyUserOptions(adaptive) = nil, want error")
+	}
+}
+
+func TestApplyUserOptionsSpec(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Spec: "ebu-r128"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Loudnorm.Spec != "ebu-r128" || config.Loudnorm.TargetI != -23 || config.Loudnorm.TargetTP != -1 {
+		t.Errorf("Loudnorm = %q %.1f LUFS %.1f dBTP, want ebu-r128 -23 LUFS -1 dBTP",
+			config.Loudnorm.Spec, config.Loudnorm.TargetI, config.Loudnorm.TargetTP)
+	}
+
+	if err := applyUserOptions(&CLI{Spec: "netflix"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("applyUserOptions(netflix) = nil, want error")
+	}
+}
+
+func TestApplyUserOptionsTargetRMS(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{TargetRMS: "-20dBFS"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Loudnorm.TargetRMS != -20 || config.Loudnorm.TargetMode() != processor.TargetModeRMS {
+		t.Errorf("Loudnorm.TargetRMS = %v (%s), want -20 in rms mode", config.Loudnorm.TargetRMS, config.Loudnorm.TargetMode())
+	}
+
+	for _, bad := range []*CLI{
+		{TargetRMS: "-2dB"},
+		{TargetRMS: "loud"},
+		{TargetRMS: "-20", Spec: "apple"},
+		{TargetRMS: "-20", SpeechLoud: true},
+	} {
+		if err := applyUserOptions(bad, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%+v) = nil, want error", *bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsTargetLUFS(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{TargetLUFS: "-19LUFS"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Loudnorm.TargetI != -19 {
+		t.Errorf("Loudnorm.TargetI = %v, want -19", config.Loudnorm.TargetI)
+	}
+
+	for _, bad := range []*CLI{
+		{TargetLUFS: "-40"},
+		{TargetLUFS: "-5"},
+		{TargetLUFS: "loud"},
+		{TargetLUFS: "-19", Spec: "apple"},
+		{TargetLUFS: "-19", TargetRMS: "-20"},
+		{TargetLUFS: "-19", Targets: "-16,-14"},
+	} {
+		if err := applyUserOptions(bad, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%+v) = nil, want error", *bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsTargetTP(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{TargetTP: "-2dBTP"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Loudnorm.TargetTP != -2 {
+		t.Errorf("Loudnorm.TargetTP = %v, want -2", config.Loudnorm.TargetTP)
+	}
+
+	config = processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{TargetLUFS: "-23", TargetTP: "-2"}, config); err != nil {
+		t.Fatalf("applyUserOptions with --target-lufs: %v", err)
+	}
+	if config.Loudnorm.TargetI != -23 || config.Loudnorm.TargetTP != -2 {
+		t.Errorf("targets = %v LUFS / %v dBTP, want -23 / -2", config.Loudnorm.TargetI, config.Loudnorm.TargetTP)
+	}
+
+	for _, bad := range []*CLI{
+		{TargetTP: "-12"},
+		{TargetTP: "1dBTP"},
+		{TargetTP: "-2", Spec: "apple"},
+	} {
+		if err := applyUserOptions(bad, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%+v) = nil, want error", *bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsBitDepth(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{BitDepth: "24"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Resample.BitDepth != processor.OutputBitDepth24 || config.Resample.Format != "s32" {
+		t.Errorf("Resample = %d-bit %q, want 24-bit s32", config.Resample.BitDepth, config.Resample.Format)
+	}
+
+	if err := applyUserOptions(&CLI{BitDepth: "32f"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("applyUserOptions(32f) = nil, want error")
+	}
+}
+
+func TestApplyUserOptionsChannels(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Channels: processor.OutputChannelsSame}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Resample.Channels != processor.OutputChannelsSame {
+		t.Errorf("Resample.Channels = %q, want %q", config.Resample.Channels, processor.OutputChannelsSame)
+	}
+
+	if err := applyUserOptions(&CLI{Channels: "5.1"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("applyUserOptions(5.1) = nil, want error")
+	}
+
+	config = processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Channels: processor.OutputChannelsSplit, Spec: "apple"}, config); err != nil {
+		t.Fatalf("applyUserOptions(split): %v", err)
+	}
+	if config.Resample.Channels != processor.OutputChannelsSplit {
+		t.Errorf("Resample.Channels = %q, want %q", config.Resample.Channels, processor.OutputChannelsSplit)
+	}
+	for _, bad := range []*CLI{
+		{Channels: processor.OutputChannelsSplit, TargetRMS: "-20"},
+		{Channels: processor.OutputChannelsSplit, Targets: "-16,-14"},
+		{Channels: processor.OutputChannelsSplit, Slate: true},
+		{Channels: processor.OutputChannelsSplit, NoiseStem: true},
+	} {
+		if err := applyUserOptions(bad, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%+v) = nil, want error", *bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsOutput(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Output: "processed", Files: []string{"a.wav", "b.wav"}}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.OutputDir != "processed" || config.OutputFile != "" {
+		t.Errorf("OutputDir %q, OutputFile %q; want the directory", config.OutputDir, config.OutputFile)
+	}
+
+	config = processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Output: "final.flac", Files: []string{"a.wav"}}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.OutputFile != "final.flac" {
+		t.Errorf("OutputFile = %q, want final.flac", config.OutputFile)
+	}
+
+	for name, cliArgs := range map[string]*CLI{
+		"several inputs": {Output: "final.flac", Files: []string{"a.wav", "b.wav"}},
+		"--targets":      {Output: "final.flac", Files: []string{"a.wav"}, Targets: "-16,-14"},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("%s: applyUserOptions = nil, want error", name)
+		}
+	}
+}
+
+func TestApplyUserOptionsMains(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Mains: processor.MainsAuto}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Mains != processor.MainsAuto {
+		t.Errorf("Mains = %q, want %q", config.Mains, processor.MainsAuto)
+	}
+
+	if err := applyUserOptions(&CLI{Mains: "55"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("applyUserOptions(55) = nil, want error")
+	}
+}
+
+func TestApplyUserOptionsOutputFormat(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{OutputFormat: processor.OutputFormatWAV, Output: "final.wav", Files: []string{"a.flac"}}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.OutputFormat != processor.OutputFormatWAV || config.OutputFile != "final.wav" {
+		t.Errorf("OutputFormat %q, OutputFile %q; want wav to final.wav", config.OutputFormat, config.OutputFile)
+	}
+
+	for name, cliArgs := range map[string]*CLI{
+		"mp3":              {OutputFormat: "mp3"},
+		"mismatched file":  {OutputFormat: processor.OutputFormatFLAC, Output: "final.wav", Files: []string{"a.flac"}},
+		"--keep-cover-art": {OutputFormat: processor.OutputFormatWAV, KeepCoverArt: true},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("%s: applyUserOptions = nil, want error", name)
+		}
+	}
+}
+
+func TestApplyUserOptionsOutputRate(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{OutputRate: "48000"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Resample.SampleRate != processor.OutputRate48000 || !config.Resample.Soxr {
+		t.Errorf("Resample = %d Hz (soxr %v), want 48000 Hz with soxr", config.Resample.SampleRate, config.Resample.Soxr)
+	}
+
+	if err := applyUserOptions(&CLI{OutputRate: "22050"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("applyUserOptions(22050) = nil, want error")
+	}
+}
+
+func TestApplyUserOptionsDeclick(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	cliArgs := &CLI{DeclickMethod: "a", DeclickOrder: "8", DeclickOverlap: "75%", DeclickBurst: "4"}
+	if err := applyUserOptions(cliArgs, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	got := config.Adeclick
+	if got.Method != processor.DeclickMethodAutoregression || got.AROrder != 8 || got.Overlap != 75 || got.Burst != 4 {
+		t.Errorf("Adeclick = %+v, want m=a, order 8, overlap 75, burst 4", got)
+	}
+
+	for _, bad := range []*CLI{{DeclickMethod: "x"}, {DeclickOrder: "lots"}, {DeclickOverlap: "20"}} {
+		if err := applyUserOptions(bad, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%+v) = nil, want error", bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsSlate(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	cliArgs := &CLI{Slate: true, SlateTone: 30 * time.Second, SlateFrequency: 1000, SlateSilence: time.Second}
+	if err := applyUserOptions(cliArgs, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if !config.Slate.Enabled || config.Slate.Tone != 30*time.Second || config.Slate.Silence != time.Second {
+		t.Errorf("Slate = %+v, want a 30 s tone and 1 s silence", config.Slate)
+	}
+
+	if err := applyUserOptions(&CLI{Slate: true, SlateFrequency: 1000}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("applyUserOptions(empty slate) = nil, want error")
+	}
+}
+
 func makeAnalysisOnlyTestMeasurements() *processor.AudioMeasurements {
 	return &processor.AudioMeasurements{
 		Dynamics: processor.DynamicsMetrics{
@@ -755,3 +1126,314 @@ func makeAnalysisOnlyTestMeasurements() *processor.AudioMeasurements {
 		},
 	}
 }
+
+func TestApplyUserOptionsTargets(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Targets: "-16, -14LUFS"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Loudnorm.TargetI != -16 || len(config.ExtraTargets) != 1 || config.ExtraTargets[0] != -14 {
+		t.Errorf("targets = %.1f + %v, want -16 + [-14]", config.Loudnorm.TargetI, config.ExtraTargets)
+	}
+
+	for _, bad := range []*CLI{{Targets: "-16,loud"}, {Targets: "-16,-16.2"}, {Targets: "-16,-14", Spec: "apple"}, {Targets: "-16", TargetRMS: "-20"}} {
+		if err := applyUserOptions(bad, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%+v) = nil, want error", bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsLimiterNoiseGuard(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{LimiterNoiseGuard: "10dB"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.Loudnorm.LimiterNoiseGuardDB != 10 {
+		t.Errorf("LimiterNoiseGuardDB = %.1f, want 10", config.Loudnorm.LimiterNoiseGuardDB)
+	}
+
+	for _, bad := range []string{"-3", "40", "wide"} {
+		if err := applyUserOptions(&CLI{LimiterNoiseGuard: bad}, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("applyUserOptions(%q) = nil, want error", bad)
+		}
+	}
+}
+
+func TestApplyUserOptionsClarity(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Clarity: true}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if !config.Clarity {
+		t.Error("Clarity = false, want true")
+	}
+}
+
+func TestApplyUserOptionsOnExists(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{OnExists: processor.OnExistsSkip}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.OnExists != processor.OnExistsSkip {
+		t.Errorf("OnExists = %q, want %q", config.OnExists, processor.OnExistsSkip)
+	}
+	if err := applyUserOptions(&CLI{OnExists: "append"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("--on-exists=append accepted, want an error")
+	}
+}
+
+func TestApplyUserOptionsFixRegion(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{FixRegion: "1m23s:20"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if !config.FixRegion.Enabled || config.FixRegion.Start != 83*time.Second || config.FixRegion.Duration != 20*time.Second {
+		t.Errorf("FixRegion = %+v, want 20 s from 83 s", config.FixRegion)
+	}
+
+	for _, cliArgs := range []*CLI{
+		{FixRegion: "83"},
+		{FixRegion: "83:soon"},
+		{FixRegion: "83:2"},
+		{FixRegion: "83:20", Slate: true, SlateFrequency: 1000, SlateTone: time.Second},
+		{FixRegion: "83:20", Targets: "-16,-14"},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("--fix-region=%q with %+v accepted, want an error", cliArgs.FixRegion, cliArgs)
+		}
+	}
+}
+
+func TestCheckOutputCollisions(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := checkOutputCollisions([]string{"/a/episode.wav", "/b/episode.wav", "/a/interview.wav"}, config); err != nil {
+		t.Errorf("inputs beside themselves: %v", err)
+	}
+	for name, files := range map[string][]string{
+		"same file twice":  {"/a/episode.wav", "/a/episode.wav"},
+		"same stem":        {"/a/episode.wav", "/a/episode.flac"},
+		"relative and abs": {"episode.wav", mustAbs(t, "episode.wav")},
+	} {
+		if err := checkOutputCollisions(files, config); err == nil {
+			t.Errorf("%s: accepted, want a collision", name)
+		}
+	}
+
+	if err := applyUserOptions(&CLI{Output: t.TempDir(), Files: []string{"/a/episode.wav", "/b/episode.wav"}}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("two episode.wav inputs into one --output directory accepted")
+	}
+	if err := applyUserOptions(&CLI{AnalysisOnly: true, Files: []string{"/a/episode.wav", "/a/episode.flac"}}, processor.DefaultFilterConfig()); err != nil {
+		t.Errorf("--analysis-only reports are named with the extension, want no collision: %v", err)
+	}
+}
+
+func mustAbs(t *testing.T, path string) string {
+	t.Helper()
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return abs
+}
+
+func TestApplyUserOptionsNoiseWindow(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{NoiseWindow: 15 * time.Second, NoiseWindowMin: 12 * time.Second}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.NoiseWindow != 15*time.Second || config.NoiseWindowMin != 12*time.Second {
+		t.Errorf("NoiseWindow = %v, %v; want 15 s, 12 s", config.NoiseWindow, config.NoiseWindowMin)
+	}
+
+	for _, cliArgs := range []*CLI{
+		{NoiseWindow: 4 * time.Second, NoiseWindowMin: 6 * time.Second},
+		{NoiseWindow: 4100 * time.Millisecond},
+		{NoiseWindow: 15 * time.Second, NoiseRegion: "30:10"},
+		{NoiseWindowMin: 4 * time.Second, NoiseFloor: "-60"},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("--noise-window=%v --noise-window-min=%v with %+v accepted, want an error", cliArgs.NoiseWindow, cliArgs.NoiseWindowMin, cliArgs)
+		}
+	}
+}
+
+func TestApplyUserOptionsExcerpt(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Excerpt: "12m:3m"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if !config.Excerpt.Enabled || config.Excerpt.Start != 12*time.Minute || config.Excerpt.Duration != 3*time.Minute {
+		t.Errorf("Excerpt = %+v, want 3 min from 12 min", config.Excerpt)
+	}
+
+	for _, cliArgs := range []*CLI{
+		{Excerpt: "720"},
+		{Excerpt: "720:2"},
+		{Excerpt: "720:180", FixRegion: "83:20"},
+		{Excerpt: "720:180", InPlace: true},
+		{Excerpt: "720:180", Diagnostics: true},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("--excerpt=%q with %+v accepted, want an error", cliArgs.Excerpt, cliArgs)
+		}
+	}
+}
+
+func TestApplyUserOptionsExportIntervals(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{ExportIntervals: "take.csv", Files: []string{"take.flac"}}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.IntervalsCSV != "take.csv" {
+		t.Errorf("IntervalsCSV = %q, want take.csv", config.IntervalsCSV)
+	}
+
+	for name, cliArgs := range map[string]*CLI{
+		"several inputs": {ExportIntervals: "take.csv", Files: []string{"a.flac", "b.flac"}},
+		"--fix-region":   {ExportIntervals: "take.csv", Files: []string{"a.flac"}, FixRegion: "83:20"},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("%s: applyUserOptions = nil, want error", name)
+		}
+	}
+}
+
+func TestApplyUserOptionsNoiseRegion(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{NoiseRegion: "120.5:10"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if !config.NoiseRegion.Enabled || config.NoiseRegion.Start != 120500*time.Millisecond || config.NoiseRegion.Duration != 10*time.Second {
+		t.Errorf("NoiseRegion = %+v, want 10 s from 120.5 s", config.NoiseRegion)
+	}
+
+	for _, cliArgs := range []*CLI{
+		{NoiseRegion: "120"},
+		{NoiseRegion: "-5:10"},
+		{NoiseRegion: "120:1"},
+		{NoiseRegion: "120:10", PickRoomTone: true},
+		{NoiseRegion: "120:10", FixRegion: "83:20"},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("--noise-region=%q with %+v accepted, want an error", cliArgs.NoiseRegion, cliArgs)
+		}
+	}
+}
+
+func TestApplyUserOptionsNoiseFloor(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{NoiseFloor: "-65dBFS"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.NoiseFloorDB != -65 {
+		t.Errorf("NoiseFloorDB = %.1f, want -65", config.NoiseFloorDB)
+	}
+
+	for _, cliArgs := range []*CLI{
+		{NoiseFloor: "quiet"},
+		{NoiseFloor: "-20"},
+		{NoiseFloor: "-120"},
+		{NoiseFloor: "-65", NoiseRegion: "120:10"},
+		{NoiseFloor: "-65", PickRoomTone: true},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("--noise-floor=%q with %+v accepted, want an error", cliArgs.NoiseFloor, cliArgs)
+		}
+	}
+}
+
+func TestApplyUserOptionsNoiseProfile(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Files: []string{"ep1.wav"}, SaveNoiseProfile: "room.json"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.NoiseProfileOut != "room.json" {
+		t.Errorf("NoiseProfileOut = %q, want room.json", config.NoiseProfileOut)
+	}
+
+	for _, cliArgs := range []*CLI{
+		{Files: []string{"ep1.wav", "ep2.wav"}, SaveNoiseProfile: "room.json"},
+		{SaveNoiseProfile: "room.json", FixRegion: "10:5"},
+		{LoadNoiseProfile: filepath.Join(t.TempDir(), "missing.json")},
+		{LoadNoiseProfile: "room.json", NoiseFloor: "-65"},
+		{LoadNoiseProfile: "room.json", PickRoomTone: true},
+	} {
+		if err := applyUserOptions(cliArgs, processor.DefaultFilterConfig()); err == nil {
+			t.Errorf("noise profile options %+v accepted, want an error", cliArgs)
+		}
+	}
+}
+
+func TestApplyUserOptionsChunkOver(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{ChunkOver: 3 * time.Hour}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.ChunkOver != 3*time.Hour {
+		t.Errorf("ChunkOver = %v, want 3h", config.ChunkOver)
+	}
+	if err := applyUserOptions(&CLI{ChunkOver: 10 * time.Minute}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("--chunk-over=10m accepted, want an error")
+	}
+}
+
+func TestApplyUserOptionsAnalysisCache(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{CacheDir: "/tmp/jt-cache"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.AnalysisCacheDir != "/tmp/jt-cache" {
+		t.Errorf("AnalysisCacheDir = %q, want /tmp/jt-cache", config.AnalysisCacheDir)
+	}
+
+	config = processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.AnalysisCacheDir != "" {
+		t.Errorf("AnalysisCacheDir = %q without --cache-dir, want the cache off", config.AnalysisCacheDir)
+	}
+
+	config = processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{CacheDir: "/tmp/jt-cache", NoCache: true}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if config.AnalysisCacheDir != "" {
+		t.Errorf("AnalysisCacheDir = %q with --no-cache, want the cache off", config.AnalysisCacheDir)
+	}
+}
+
+func TestApplyUserOptionsReceipt(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Receipt: true}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if !config.Receipt || config.WriteReceipt {
+		t.Errorf("Receipt, WriteReceipt = %v, %v, want true, false", config.Receipt, config.WriteReceipt)
+	}
+
+	config = processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{ReceiptFile: true}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if !config.WriteReceipt {
+		t.Error("WriteReceipt = false with --receipt-file, want true")
+	}
+
+	if err := applyUserOptions(&CLI{Receipt: true, FixRegion: "10:20"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("--receipt with --fix-region accepted, want an error")
+	}
+}
+
+func TestApplyUserOptionsDisable(t *testing.T) {
+	config := processor.DefaultFilterConfig()
+	if err := applyUserOptions(&CLI{Disable: "gate, DeEsser"}, config); err != nil {
+		t.Fatalf("applyUserOptions: %v", err)
+	}
+	if strings.Join(config.DisabledStages, ",") != "gate,deesser" {
+		t.Errorf("DisabledStages = %v, want [gate deesser]", config.DisabledStages)
+	}
+	if err := applyUserOptions(&CLI{Disable: "gate,dolbysr"}, processor.DefaultFilterConfig()); err == nil {
+		t.Error("--disable=gate,dolbysr accepted, want an unknown-stage error")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -14,18 +15,35 @@ import (
 	"github.com/linuxmatters/jivetalking/internal/ui"
 )
 
-// sendWarning delivers a non-fatal warning to the reportWarnings channel without
-// ever blocking the sending worker. The channel is buffered to len(files) and is
-// only drained after the pool fully unwinds, yet a single file emits several
-// warnings (report, run record, sidecars, one per spectrogram), so a burst of
-// failures could fill the buffer and block a worker - which would stall
-// wg.Wait()/specWG.Wait() and deadlock the run. The warnings are best-effort
-// diagnostics, so dropping one under saturation is the safe trade.
-func sendWarning(ch chan<- string, msg string) {
-	select {
-	case ch <- msg:
-	default:
+// messageLog collects the lines workers hand back for printing once the pool
+// has unwound and the TUI has exited: warnings, receipts and filter graphs.
+// A file can raise any number of them (adaptation warnings, report and
+// sidecar failures, one per spectrogram), so they are appended under a mutex
+// rather than sent on a buffered channel: add never blocks a worker and never
+// drops a line. A nil log discards, for an output that is off.
+type messageLog struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+// add appends msg.
+func (l *messageLog) add(msg string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, msg)
+}
+
+// all returns a copy of the lines in the order they were added.
+func (l *messageLog) all() []string {
+	if l == nil {
+		return nil
 	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.lines...)
 }
 
 // launchSpectrogramRenders schedules each image in imgs as a bounded background
@@ -76,6 +94,24 @@ type poolEnv struct {
 	base      *processor.BaseFilterConfig
 	sharedLog func(string, ...any)
 	jobs      int
+
+	// pickRoomTone opens the interactive room-tone prompt in Pass 1
+	// (--pick-room-tone). Processing pool only; the analysis pool ignores it.
+	pickRoomTone bool
+
+	// progress receives the --progress-fd JSON events; nil when off.
+	progress *progressSink
+
+	// results receives each file's --json result object; nil when off.
+	results *resultSink
+
+	// receipts collects each completed file's printed receipt (--receipt),
+	// printed after the summary; nil when off.
+	receipts *messageLog
+
+	// filtergraphs collects each completed file's filter graphs
+	// (--print-filtergraph), printed to stderr after the run; nil when off.
+	filtergraphs *messageLog
 }
 
 // workerPoolDeps injects the pool's processing entry point so tests can
@@ -95,7 +131,7 @@ func defaultWorkerPoolDeps() workerPoolDeps {
 // cancelling the context so all workers' deferred temp cleanup runs before the
 // process exits, giving the no-residue-on-cancel guarantee. Keeping the launch
 // and join in one helper makes the wiring unit-testable apart from main().
-func launchWorkerPool(env poolEnv, diagnostics bool, reportWarnings chan<- string, deps workerPoolDeps) <-chan struct{} {
+func launchWorkerPool(env poolEnv, diagnostics bool, reportWarnings *messageLog, deps workerPoolDeps) <-chan struct{} {
 	done := make(chan struct{})
 	go func() {
 		runWorkerPool(env, diagnostics, reportWarnings, deps)
@@ -168,7 +204,7 @@ func runBoundedPool(env poolEnv, afterWait func(), body func(i int, inputPath st
 // diagnostics gates the bulk diagnostic artefacts (the .jsonl sidecars and the
 // spectrogram PNGs). When false the always-on set (.flac/.md/.json) still
 // writes; only the opt-in sidecars are skipped.
-func runWorkerPool(env poolEnv, diagnostics bool, reportWarnings chan<- string, deps workerPoolDeps) {
+func runWorkerPool(env poolEnv, diagnostics bool, reportWarnings *messageLog, deps workerPoolDeps) {
 	// Spectrogram renders run in background goroutines off the file-worker critical
 	// path. specSem bounds them to the jobs budget shared across ALL files - one
 	// pool-level semaphore, never one unbounded goroutine per PNG, so ffmpeg is not
@@ -178,6 +214,10 @@ func runWorkerPool(env poolEnv, diagnostics bool, reportWarnings chan<- string,
 	var specWG sync.WaitGroup
 	render := processingRenderScheduler{sem: specSem, wg: &specWG}
 
+	// pickMu serialises the room-tone prompts so concurrent workers queue for
+	// the one picker the TUI shows.
+	var pickMu sync.Mutex
+
 	runBoundedPool(env,
 		// Gate program exit on the spectrogram renders: every file's per-file
 		// FileCompleteMsg has already fired (wg drained), so the file-worker TUI
@@ -200,28 +240,68 @@ func runWorkerPool(env poolEnv, diagnostics bool, reportWarnings chan<- string,
 				p:         env.p,
 				log:       wlog,
 				fileIndex: i,
+				progress:  env.progress,
+				path:      inputPath,
 			}
 
 			clone := env.base.CloneForWorker(wlog)
+			if env.pickRoomTone {
+				clone.SetRoomToneSelector(func(candidates []processor.RoomToneCandidate) int {
+					return promptRoomTone(env.ctx, env.p, &pickMu, i, candidates)
+				})
+			}
 
 			wlog("[POOL] Starting ProcessAudio for %s", inputPath)
 			result, err := deps.processAudio(env.ctx, inputPath, clone, ph.callback)
 			if err != nil {
 				wlog("[POOL] ProcessAudio failed: %v", err)
 				env.p.Send(ui.FileCompleteMsg{
-					FileIndex:        i,
-					CompletionResult: ui.CompletionResult{Error: err},
+					FileIndex: i,
+					CompletionResult: ui.CompletionResult{
+						Error:   err,
+						Skipped: errors.Is(err, processor.ErrOutputSkipped),
+					},
 				})
+				env.progress.done(i, inputPath, err)
+				env.results.failed(i, inputPath, err)
 				return
 			}
 
+			// Surface adaptation warnings (e.g. a pinned gate threshold outside
+			// the measured noise/speech gap) after the run; the record and report
+			// carry them too.
+			if result.Diagnostics != nil {
+				for _, w := range result.Diagnostics.Warnings {
+					reportWarnings.add(fmt.Sprintf("%s: %s", inputPath, w))
+				}
+			}
+
 			// Pass 2 is bracketed directly by the progress handler (the Pass-2
 			// start/end updates), matching passes 1/3/4, so a missed timer cannot
 			// silently land in Pass 2.
 			emitProcessingReport(env, inputPath, result, ph, processingTimings{fileStart: fileStartTime, pass2: ph.pass2Time}, diagnostics, reportWarnings, render)
+			env.progress.done(i, inputPath, nil)
 		})
 }
 
+// promptRoomTone asks the TUI to show the room-tone picker for file i and blocks
+// until the user answers. mu holds other workers back while a prompt is open.
+// Cancellation (user quit) returns -1, keeping the automatic region, so the
+// worker unwinds through its normal ctx.Done() path.
+func promptRoomTone(ctx context.Context, p *tea.Program, mu *sync.Mutex, i int, candidates []processor.RoomToneCandidate) int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	reply := make(chan int, 1)
+	p.Send(ui.RoomTonePickMsg{FileIndex: i, Candidates: candidates, Reply: reply})
+	select {
+	case idx := <-reply:
+		return idx
+	case <-ctx.Done():
+		return -1
+	}
+}
+
 // processingRenderScheduler bundles the pool-level background spectrogram-render
 // state shared across workers: the jobs-sized semaphore bounding concurrent
 // renders and the WaitGroup the pool drains before AllCompleteMsg so every PNG
@@ -237,7 +317,7 @@ type processingRenderScheduler struct {
 // record, the path stem the .md/.json derive from, the spectrogram stages
 // constant, the sidecar measurements, the report.Timings, the render context +
 // pool-level render scheduler, the render closure (varies: output path vs ""),
-// and a single error-reporting callback (the reportWarnings send on the
+// and a single error-reporting callback (reportWarnings.add on the
 // processing path, deps.printError on the analysis-only path). errMsgs supplies
 // the four per-artefact warning templates so each mode keeps its own wording.
 type reportArtefacts struct {
@@ -358,7 +438,7 @@ type processingTimings struct {
 // so the remaining artefacts still emit, mirroring emitAnalysisReport on the
 // analysis-only path. ph supplies the per-pass timings and the retained
 // filter-chain summary captured during ProcessAudio.
-func emitProcessingReport(env poolEnv, inputPath string, result *processor.ProcessingResult, ph *progressHandler, t processingTimings, diagnostics bool, reportWarnings chan<- string, render processingRenderScheduler) {
+func emitProcessingReport(env poolEnv, inputPath string, result *processor.ProcessingResult, ph *progressHandler, t processingTimings, diagnostics bool, reportWarnings *messageLog, render processingRenderScheduler) {
 	wlog := ph.log
 	i := ph.fileIndex
 
@@ -369,13 +449,14 @@ func emitProcessingReport(env poolEnv, inputPath string, result *processor.Proce
 
 	outputStem := strings.TrimSuffix(result.OutputPath, filepath.Ext(result.OutputPath))
 	destDir := filepath.Dir(result.OutputPath)
+	timings := ph.timings(t.pass2, t.fileStart, result)
 
 	emitReportArtefacts(reportArtefacts{
 		rec:         rec,
 		stem:        outputStem,
 		stages:      processor.ProcessingSpectrogramStages,
 		sidecarMeas: result.Measurements,
-		timings:     ph.timings(t.pass2, t.fileStart, result),
+		timings:     timings,
 		diagnostics: diagnostics,
 		renderCtx:   env.ctx,
 		renderSem:   render.sem,
@@ -388,7 +469,7 @@ func emitProcessingReport(env poolEnv, inputPath string, result *processor.Proce
 		writeSidecars: processor.WriteRunRecordSidecars,
 		reportErr: func(msg string) {
 			wlog("[POOL] %s", msg)
-			sendWarning(reportWarnings, msg)
+			reportWarnings.add(msg)
 		},
 		errMsgs: reportErrorMessages{
 			inputPath:   inputPath,
@@ -424,6 +505,20 @@ func emitProcessingReport(env poolEnv, inputPath string, result *processor.Proce
 		Summary:   ph.summary.WithLimiter(result.NormResult),
 	})
 
+	var inputClarity, outputClarity float64
+	if result.Clarity != nil {
+		inputClarity, outputClarity = result.Clarity.Input.Score, result.Clarity.Final.Score
+	}
+
+	env.results.complete(i, inputPath, result.OutputPath, rec, timings)
+
+	if env.receipts != nil && result.Receipt != nil {
+		env.receipts.add(result.Receipt.String())
+	}
+	if env.filtergraphs != nil && result.Pass2FilterSpec != "" {
+		env.filtergraphs.add(filterGraphText(inputPath, result))
+	}
+
 	wlog("[POOL] Sending FileCompleteMsg for file %d", i)
 	env.p.Send(ui.FileCompleteMsg{
 		FileIndex: i,
@@ -437,9 +532,26 @@ func emitProcessingReport(env poolEnv, inputPath string, result *processor.Proce
 			OutputTP:            outputTP,
 			OutputLRA:           outputLRA,
 			OutputPath:          result.OutputPath,
+			TargetPaths:         strings.Join(result.TargetPaths(), "\n"),
+			InputClarity:        inputClarity,
+			OutputClarity:       outputClarity,
+			HaveClarity:         result.Clarity != nil,
 			Quality:             processor.ComputeQualityScore(result),
 			RecordingQuality:    processor.ComputeRecordingScore(result.Measurements),
+			Advice:              processor.RecordingAdvice(result.Measurements, result.Diagnostics),
 			ProcessingTime:      time.Since(t.fileStart),
 		},
 	})
 }
+
+// filterGraphText formats a processed file's filter graphs for
+// --print-filtergraph: one line per pass, each ready to paste into an
+// ffmpeg -af.
+func filterGraphText(inputPath string, result *processor.ProcessingResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n  pass 2: %s", inputPath, result.Pass2FilterSpec)
+	if result.NormResult != nil && result.NormResult.FilterSpec != "" {
+		fmt.Fprintf(&b, "\n  pass 4: %s", result.NormResult.FilterSpec)
+	}
+	return b.String()
+}
@@ -120,7 +120,7 @@ func TestRunWorkerPool_ConcurrentRaceClean(t *testing.T) {
 	// `go test` with no TTY. It still quits on ui.AllCompleteMsg.
 	p := tea.NewProgram(model, tea.WithoutRenderer(), tea.WithInput(nil))
 
-	reportWarnings := make(chan string, len(files))
+	reportWarnings := &messageLog{}
 
 	// jobs == 2 so both workers run concurrently, forcing concurrent p.Send,
 	// sink writes, and CloneForWorker calls.
@@ -131,8 +131,7 @@ func TestRunWorkerPool_ConcurrentRaceClean(t *testing.T) {
 		t.Fatalf("p.Run() error = %v", err)
 	}
 
-	close(reportWarnings)
-	for warning := range reportWarnings {
+	for _, warning := range reportWarnings.all() {
 		t.Errorf("report warning: %s", warning)
 	}
 
@@ -243,7 +242,7 @@ func TestRunWorkerPool_CancellationNoTempResidue(t *testing.T) {
 	p := tea.NewProgram(model, tea.WithoutRenderer(), tea.WithInput(nil))
 
 	ctx, cancel := context.WithCancel(context.Background())
-	reportWarnings := make(chan string, copies)
+	reportWarnings := &messageLog{}
 
 	env := poolEnv{ctx: ctx, p: p, files: files, base: base, sharedLog: sharedLog, jobs: 2}
 	poolDone := make(chan struct{})
@@ -271,8 +270,7 @@ func TestRunWorkerPool_CancellationNoTempResidue(t *testing.T) {
 	cancel()
 	<-poolDone
 
-	close(reportWarnings)
-	for warning := range reportWarnings {
+	for _, warning := range reportWarnings.all() {
 		t.Logf("report warning (non-fatal): %s", warning)
 	}
 
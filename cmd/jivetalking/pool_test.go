@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -98,7 +99,7 @@ func runPoolWithFake(t *testing.T, jobs, n int) (*inflightFake, int, bool) {
 	p := tea.NewProgram(model, tea.WithoutRenderer(), tea.WithInput(nil))
 
 	base := processor.DefaultFilterConfig()
-	reportWarnings := make(chan string, n)
+	reportWarnings := &messageLog{}
 
 	env := poolEnv{ctx: context.Background(), p: p, files: files, base: base, sharedLog: func(string, ...any) {}, jobs: jobs}
 	go runWorkerPool(env, false, reportWarnings, workerPoolDeps{processAudio: fake.fn})
@@ -107,8 +108,6 @@ func runPoolWithFake(t *testing.T, jobs, n int) (*inflightFake, int, bool) {
 		t.Fatalf("p.Run() error = %v", err)
 	}
 
-	close(reportWarnings)
-
 	mu.Lock()
 	defer mu.Unlock()
 	return fake, fileComplete, allComplete
@@ -239,7 +238,7 @@ func TestRunWorkerPool_FailureIsolation(t *testing.T) {
 	p := tea.NewProgram(model, tea.WithoutRenderer(), tea.WithInput(nil))
 
 	base := processor.DefaultFilterConfig()
-	reportWarnings := make(chan string, n)
+	reportWarnings := &messageLog{}
 
 	env := poolEnv{ctx: context.Background(), p: p, files: files, base: base, sharedLog: func(string, ...any) {}, jobs: 3}
 	go runWorkerPool(env, false, reportWarnings, workerPoolDeps{processAudio: fake.fn})
@@ -248,8 +247,7 @@ func TestRunWorkerPool_FailureIsolation(t *testing.T) {
 		t.Fatalf("p.Run() error = %v", err)
 	}
 
-	close(reportWarnings)
-	for warning := range reportWarnings {
+	for _, warning := range reportWarnings.all() {
 		t.Errorf("unexpected report warning: %s", warning)
 	}
 
@@ -344,7 +342,7 @@ func TestLaunchWorkerPool_DoneClosesAfterPoolUnwinds(t *testing.T) {
 	dir := t.TempDir()
 	files := []string{filepath.Join(dir, "fake.flac")}
 	base := processor.DefaultFilterConfig()
-	reportWarnings := make(chan string, len(files))
+	reportWarnings := &messageLog{}
 
 	env := poolEnv{ctx: context.Background(), p: p, files: files, base: base, sharedLog: func(string, ...any) {}, jobs: 1}
 	done := launchWorkerPool(env, false, reportWarnings, deps)
@@ -396,7 +394,7 @@ func TestLaunchWorkerPool_DoneClosesOnPreCancelledContext(t *testing.T) {
 	dir := t.TempDir()
 	files := []string{filepath.Join(dir, "a.flac"), filepath.Join(dir, "b.flac")}
 	base := processor.DefaultFilterConfig()
-	reportWarnings := make(chan string, len(files))
+	reportWarnings := &messageLog{}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -413,3 +411,43 @@ func TestLaunchWorkerPool_DoneClosesOnPreCancelledContext(t *testing.T) {
 	p.Quit()
 	p.Wait()
 }
+
+// TestMessageLog_KeepsEveryLine: many workers each raising many warnings lose
+// none of them, where a channel buffered to one per file would have dropped all
+// but the first.
+func TestMessageLog_KeepsEveryLine(t *testing.T) {
+	const workers, perWorker = 4, 50
+	log := &messageLog{}
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				log.add(fmt.Sprintf("file %d: warning %d", w, i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(log.all()); got != workers*perWorker {
+		t.Errorf("kept %d lines, want %d", got, workers*perWorker)
+	}
+
+	var off *messageLog
+	off.add("discarded")
+	if got := off.all(); got != nil {
+		t.Errorf("nil log kept %q, want nothing", got)
+	}
+}
+
+func TestFilterGraphText(t *testing.T) {
+	result := &processor.ProcessingResult{Pass2FilterSpec: "highpass=f=80"}
+	if got := filterGraphText("ep.wav", result); got != "ep.wav\n  pass 2: highpass=f=80" {
+		t.Errorf("without Pass 4 = %q", got)
+	}
+	result.NormResult = &processor.NormalisationResult{FilterSpec: "loudnorm=I=-16"}
+	if got := filterGraphText("ep.wav", result); !strings.HasSuffix(got, "\n  pass 4: loudnorm=I=-16") {
+		t.Errorf("with Pass 4 = %q", got)
+	}
+}
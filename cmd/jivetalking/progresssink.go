@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/linuxmatters/jivetalking/internal/processor"
+)
+
+// Progress event kinds written by progressSink.
+const (
+	progressEventProgress = "progress"
+	progressEventComplete = "complete"
+	progressEventError    = "error"
+	progressEventSkipped  = "skipped"
+)
+
+// progressEvent is one newline-delimited JSON line on --progress-fd. File is
+// the 0-based position of the input on the command line; Pass and Progress
+// mirror the processor's ProgressUpdate.
+type progressEvent struct {
+	File     int     `json:"file"`
+	Path     string  `json:"path"`
+	Event    string  `json:"event"`
+	Pass     int     `json:"pass,omitempty"`
+	PassName string  `json:"pass_name,omitempty"`
+	Progress float64 `json:"progress"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// jsonLineWriter writes newline-delimited JSON for the --progress-fd and
+// --json sinks, serialised so concurrent workers each emit whole lines. A
+// write blocks like any other, so a front end must keep reading; write errors
+// (a reader that has gone away) are dropped rather than failing the run.
+type jsonLineWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// writeLine marshals v and writes it as one line.
+func (l *jsonLineWriter) writeLine(v any) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(append(line, '\n'))
+}
+
+// progressSink is the shared writer for --progress-fd, so an external front
+// end can follow progress without scraping the TUI. A nil sink is off and
+// every method is a no-op.
+type progressSink struct {
+	lines jsonLineWriter
+}
+
+// newProgressSink builds a sink over w.
+func newProgressSink(w io.Writer) *progressSink {
+	return &progressSink{lines: jsonLineWriter{w: w}}
+}
+
+// openProgressSink opens file descriptor fd for progress events. fd 0 (the
+// default) leaves progress output off and returns a nil sink.
+func openProgressSink(fd int) (*progressSink, error) {
+	if fd == 0 {
+		return nil, nil
+	}
+	if fd < 0 {
+		return nil, fmt.Errorf("file descriptor %d is negative", fd)
+	}
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("progress-fd-%d", fd))
+	if _, err := f.Stat(); err != nil {
+		return nil, fmt.Errorf("file descriptor %d is not open: %w", fd, err)
+	}
+	return newProgressSink(f), nil
+}
+
+// emit writes one event as a JSON line.
+func (s *progressSink) emit(e progressEvent) {
+	if s == nil {
+		return
+	}
+	s.lines.writeLine(e)
+}
+
+// progress relays one processor progress update for file.
+func (s *progressSink) progress(file int, path string, update processor.ProgressUpdate) {
+	s.emit(progressEvent{
+		File:     file,
+		Path:     path,
+		Event:    progressEventProgress,
+		Pass:     int(update.Pass),
+		PassName: update.PassName,
+		Progress: update.Progress,
+	})
+}
+
+// done marks file finished: complete, skipped (--on-exists=skip), or error
+// carrying err.
+func (s *progressSink) done(file int, path string, err error) {
+	e := progressEvent{File: file, Path: path, Event: progressEventComplete, Progress: 1}
+	if errors.Is(err, processor.ErrOutputSkipped) {
+		e.Event = progressEventSkipped
+	} else if err != nil {
+		e.Event = progressEventError
+		e.Progress = 0
+		e.Error = err.Error()
+	}
+	s.emit(e)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/linuxmatters/jivetalking/internal/processor"
+)
+
+// decodeProgressEvents parses every JSON line the sink wrote.
+func decodeProgressEvents(t *testing.T, out string) []progressEvent {
+	t.Helper()
+	var events []progressEvent
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e progressEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line %q is not JSON: %v", line, err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestProgressSinkEvents(t *testing.T) {
+	var buf bytes.Buffer
+	sink := newProgressSink(&buf)
+	sink.progress(2, "b.wav", processor.ProgressUpdate{Pass: processor.PassAnalysis, PassName: "Analysing", Progress: 0.45})
+	sink.done(2, "b.wav", nil)
+	sink.done(3, "c.wav", errors.New("unsupported format"))
+	sink.done(4, "d.wav", fmt.Errorf("%w: d-LUFS-16-processed.flac", processor.ErrOutputSkipped))
+
+	if !strings.HasPrefix(buf.String(), `{"file":2,"path":"b.wav","event":"progress","pass":1,"pass_name":"Analysing","progress":0.45}`+"\n") {
+		t.Errorf("progress line = %q", buf.String())
+	}
+	events := decodeProgressEvents(t, buf.String())
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+	if events[1].Event != progressEventComplete || events[1].Progress != 1 {
+		t.Errorf("complete event = %+v", events[1])
+	}
+	if events[2].Event != progressEventError || events[2].Error != "unsupported format" {
+		t.Errorf("error event = %+v", events[2])
+	}
+	if events[3].Event != progressEventSkipped || events[3].Progress != 1 {
+		t.Errorf("skipped event = %+v", events[3])
+	}
+}
+
+func TestProgressSinkNilIsOff(t *testing.T) {
+	var sink *progressSink
+	sink.progress(0, "a.wav", processor.ProgressUpdate{Progress: 0.5})
+	sink.done(0, "a.wav", nil)
+
+	if s, err := openProgressSink(0); s != nil || err != nil {
+		t.Errorf("openProgressSink(0) = %v, %v; want off", s, err)
+	}
+	if _, err := openProgressSink(-1); err == nil {
+		t.Error("openProgressSink(-1) = nil error, want error")
+	}
+}
+
+// TestRunAnalysisPoolProgressEvents: with no TUI, the pool still relays the
+// analyser's progress and one completion event per file to the sink.
+func TestRunAnalysisPoolProgressEvents(t *testing.T) {
+	analyse := func(_ context.Context, _ string, _ *processor.BaseFilterConfig, cb processor.ProgressCallback) (*processor.AnalysisResult, error) {
+		if cb == nil {
+			t.Fatal("no progress callback with a progress sink")
+		}
+		cb(processor.ProgressUpdate{Pass: processor.PassAnalysis, Progress: 0.5})
+		return &processor.AnalysisResult{}, nil
+	}
+
+	var buf bytes.Buffer
+	files := makeAnalysisFiles(t, 2)
+	slots := make([]analysisSlot, len(files))
+	env := poolEnv{ctx: context.Background(), p: nil, files: files, base: processor.DefaultFilterConfig(), sharedLog: func(string, ...any) {}, jobs: 1, progress: newProgressSink(&buf)}
+	runAnalysisPool(env, slots, poolDepsWithAnalyse(t, analyse))
+
+	counts := map[string]int{}
+	for _, e := range decodeProgressEvents(t, buf.String()) {
+		counts[e.Event]++
+	}
+	if counts[progressEventProgress] != 2 || counts[progressEventComplete] != 2 {
+		t.Errorf("event counts = %v, want 2 progress and 2 complete", counts)
+	}
+}
@@ -0,0 +1,147 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FLAC metadata block layout (https://xiph.org/flac/format.html): the "fLaC"
+// marker, then blocks with a 4-byte header (1-bit last-block flag, 7-bit type,
+// 24-bit body length), then the audio frames. Cover art lives in PICTURE blocks,
+// which the filter-graph encoder never writes, so --keep-cover-art copies them
+// across as raw bytes.
+const (
+	flacMarker           = "fLaC"
+	flacBlockStreamInfo  = 0
+	flacBlockPicture     = 6
+	flacBlockHeaderBytes = 4
+	flacLastBlockFlag    = 0x80
+)
+
+// ErrNotFLAC is returned when a file does not start with the FLAC marker.
+var ErrNotFLAC = errors.New("not a FLAC file")
+
+// flacBlock is one metadata block: its type and body, without the header.
+type flacBlock struct {
+	kind byte
+	body []byte
+}
+
+// readFLACBlocks reads the marker and every metadata block, leaving r at the
+// first audio frame.
+func readFLACBlocks(r io.Reader) ([]flacBlock, error) {
+	marker := make([]byte, len(flacMarker))
+	if _, err := io.ReadFull(r, marker); err != nil || string(marker) != flacMarker {
+		return nil, ErrNotFLAC
+	}
+
+	var blocks []flacBlock
+	header := make([]byte, flacBlockHeaderBytes)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, fmt.Errorf("failed to read FLAC block header: %w", err)
+		}
+		length := int(binary.BigEndian.Uint32(header) & 0xFFFFFF)
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, fmt.Errorf("failed to read FLAC block: %w", err)
+		}
+		blocks = append(blocks, flacBlock{kind: header[0] &^ flacLastBlockFlag, body: body})
+		if header[0]&flacLastBlockFlag != 0 {
+			return blocks, nil
+		}
+	}
+}
+
+// ReadFLACPictures returns the raw PICTURE block bodies (cover art) of a FLAC
+// file, in file order. A file that is not FLAC returns ErrNotFLAC.
+func ReadFLACPictures(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	blocks, err := readFLACBlocks(bufio.NewReader(f))
+	if err != nil {
+		return nil, err
+	}
+	var pictures [][]byte
+	for _, b := range blocks {
+		if b.kind == flacBlockPicture {
+			pictures = append(pictures, b.body)
+		}
+	}
+	return pictures, nil
+}
+
+// WriteFLACPictures adds PICTURE blocks to a FLAC file, straight after its
+// STREAMINFO block. The file is rewritten through a sibling temp file and an
+// atomic rename, so a failure leaves it untouched.
+func WriteFLACPictures(path string, pictures [][]byte) error {
+	if len(pictures) == 0 {
+		return nil
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	src := bufio.NewReader(in)
+	blocks, err := readFLACBlocks(src)
+	if err != nil {
+		return err
+	}
+	if len(blocks) == 0 || blocks[0].kind != flacBlockStreamInfo {
+		return fmt.Errorf("FLAC file %s does not start with STREAMINFO", path)
+	}
+
+	merged := make([]flacBlock, 0, len(blocks)+len(pictures))
+	merged = append(merged, blocks[0])
+	for _, p := range pictures {
+		merged = append(merged, flacBlock{kind: flacBlockPicture, body: p})
+	}
+	merged = append(merged, blocks[1:]...)
+
+	var head bytes.Buffer
+	head.WriteString(flacMarker)
+	for i, b := range merged {
+		if len(b.body) > 0xFFFFFF {
+			return fmt.Errorf("FLAC block of %d bytes is too large", len(b.body))
+		}
+		header := uint32(b.kind)<<24 | uint32(len(b.body)) //nolint:gosec // length checked above
+		if i == len(merged)-1 {
+			header |= flacLastBlockFlag << 24
+		}
+		_ = binary.Write(&head, binary.BigEndian, header)
+		head.Write(b.body)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cover-art-*.tmp.flac")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary output next to %s: %w", path, err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(head.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write FLAC metadata: %w", err)
+	}
+	if _, err := io.Copy(tmp, src); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to copy FLAC audio: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary output %s: %w", tmpPath, err)
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,74 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildFLAC assembles a minimal FLAC byte stream: the marker, the given blocks
+// (the last flagged), then audio bytes.
+func buildFLAC(blocks []flacBlock, audio string) []byte {
+	var b bytes.Buffer
+	b.WriteString(flacMarker)
+	for i, blk := range blocks {
+		kind := blk.kind
+		if i == len(blocks)-1 {
+			kind |= flacLastBlockFlag
+		}
+		n := len(blk.body)
+		b.Write([]byte{kind, byte(n >> 16), byte(n >> 8), byte(n)})
+		b.Write(blk.body)
+	}
+	b.WriteString(audio)
+	return b.Bytes()
+}
+
+func TestFLACPicturesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	streamInfo := flacBlock{kind: flacBlockStreamInfo, body: bytes.Repeat([]byte{1}, 34)}
+	comment := flacBlock{kind: 4, body: []byte("vorbis")}
+
+	input := filepath.Join(dir, "in.flac")
+	cover := []byte("jpeg-bytes")
+	if err := os.WriteFile(input, buildFLAC([]flacBlock{streamInfo, {kind: flacBlockPicture, body: cover}}, "in-audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "out.flac")
+	if err := os.WriteFile(output, buildFLAC([]flacBlock{streamInfo, comment}, "out-audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pictures, err := ReadFLACPictures(input)
+	if err != nil || len(pictures) != 1 || !bytes.Equal(pictures[0], cover) {
+		t.Fatalf("ReadFLACPictures = %q, %v; want the one cover", pictures, err)
+	}
+	if err := WriteFLACPictures(output, pictures); err != nil {
+		t.Fatalf("WriteFLACPictures: %v", err)
+	}
+
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := buildFLAC([]flacBlock{streamInfo, {kind: flacBlockPicture, body: cover}, comment}, "out-audio")
+	if !bytes.Equal(got, want) {
+		t.Errorf("output =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestReadFLACPicturesNotFLAC(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "in.m4a")
+	if err := os.WriteFile(path, []byte("....ftypM4A "), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFLACPictures(path); !errors.Is(err, ErrNotFLAC) {
+		t.Errorf("ReadFLACPictures error = %v, want ErrNotFLAC", err)
+	}
+}
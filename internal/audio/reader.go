@@ -23,6 +23,38 @@ type Metadata struct {
 	Duration   float64 // seconds
 	SampleRate int
 	Channels   int
+
+	StreamIndex  int // Container index of the decoded audio stream
+	AudioStreams int // Audio streams in the container; above 1 the choice was ambiguous
+}
+
+// streamCandidate is one audio stream considered by selectAudioStream.
+type streamCandidate struct {
+	index      int
+	channels   int
+	sampleRate int
+}
+
+// selectAudioStream picks the stream to decode from the container's audio
+// streams: the most channels, then the highest sample rate, then the lowest
+// index. Video and attachment streams (cover art) never reach the candidate
+// list. Returns -1 when there are no candidates.
+func selectAudioStream(candidates []streamCandidate) int {
+	best := -1
+	for i, c := range candidates {
+		if best < 0 {
+			best = i
+			continue
+		}
+		b := candidates[best]
+		if c.channels > b.channels || (c.channels == b.channels && c.sampleRate > b.sampleRate) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return -1
+	}
+	return candidates[best].index
 }
 
 // OpenAudioFile opens an audio file for reading
@@ -50,22 +82,26 @@ func OpenAudioFile(filename string) (*Reader, *Metadata, error) {
 		return nil, nil, fmt.Errorf("failed to find stream info: %w", err)
 	}
 
-	streamIdx := -1
-	var audioStream *ffmpeg.AVStream
+	// Attached pictures are video streams, so only the audio streams compete.
+	var candidates []streamCandidate
 	streams := fmtCtx.Streams()
 	for i := range int(fmtCtx.NbStreams()) { //nolint:gosec // NbStreams is a small count, overflow impossible
-		stream := streams.Get(uintptr(i))
-		if stream.Codecpar().CodecType() == ffmpeg.AVMediaTypeAudio {
-			streamIdx = i
-			audioStream = stream
-			break
+		par := streams.Get(uintptr(i)).Codecpar()
+		if par.CodecType() == ffmpeg.AVMediaTypeAudio {
+			candidates = append(candidates, streamCandidate{
+				index:      i,
+				channels:   par.ChLayout().NbChannels(),
+				sampleRate: par.SampleRate(),
+			})
 		}
 	}
 
+	streamIdx := selectAudioStream(candidates)
 	if streamIdx == -1 {
 		cleanup()
 		return nil, nil, fmt.Errorf("no audio stream found in file: %s", filename)
 	}
+	audioStream := streams.Get(uintptr(streamIdx))
 
 	codecPar := audioStream.Codecpar()
 	decoder := ffmpeg.AVCodecFindDecoder(codecPar.CodecId())
@@ -96,6 +132,9 @@ func OpenAudioFile(filename string) (*Reader, *Metadata, error) {
 		Duration:   duration,
 		SampleRate: decCtx.SampleRate(),
 		Channels:   decCtx.ChLayout().NbChannels(),
+
+		StreamIndex:  streamIdx,
+		AudioStreams: len(candidates),
 	}
 
 	frame := ffmpeg.AVFrameAlloc()
@@ -185,3 +185,36 @@ func TestMetadata_FieldRoundTrip(t *testing.T) {
 		t.Errorf("Channels = %d, want 2", m.Channels)
 	}
 }
+
+func TestSelectAudioStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		candidates []streamCandidate
+		want       int
+	}{
+		{"none", nil, -1},
+		{"single stream", []streamCandidate{{index: 1, channels: 2, sampleRate: 44100}}, 1},
+		{"most channels wins", []streamCandidate{
+			{index: 0, channels: 1, sampleRate: 96000},
+			{index: 2, channels: 2, sampleRate: 44100},
+		}, 2},
+		{"then highest rate", []streamCandidate{
+			{index: 0, channels: 2, sampleRate: 44100},
+			{index: 1, channels: 2, sampleRate: 48000},
+		}, 1},
+		{"tie keeps the first", []streamCandidate{
+			{index: 3, channels: 2, sampleRate: 48000},
+			{index: 4, channels: 2, sampleRate: 48000},
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := selectAudioStream(tt.candidates); got != tt.want {
+				t.Errorf("selectAudioStream() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package processor
 
 import (
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -17,9 +18,14 @@ func AdaptConfig(config *BaseFilterConfig, measurements *AudioMeasurements) (*Ef
 	}
 	diagnostics := &AdaptiveDiagnostics{}
 
+	tunePolarity(effectiveConfig, diagnostics, measurements, config.FixPolarity)
+
 	// Tune each filter adaptively based on measurements
 	// Order matters: gate threshold calculated BEFORE denoise filters
-	// The rumble highpass is fixed (80 Hz, 12 dB/oct) from defaultRumbleHighPassConfig; no tuning step.
+	// The rumble highpass corner is fixed (80 Hz) from defaultRumbleHighPassConfig;
+	// only its slope steepens, and only on frequent wind/handling bursts.
+	tuneRumbleBursts(effectiveConfig, diagnostics, measurements)
+	tuneHumNotch(effectiveConfig, diagnostics, measurements, config.Mains)
 	tuneBandlimitLowPass(effectiveConfig, diagnostics, measurements) // Unconditional 20.5 kHz band-limit
 
 	// NoiseReduction (anlmdn + afftdn): anlmdn is fixed from spike validation and
@@ -27,11 +33,27 @@ func AdaptConfig(config *BaseFilterConfig, measurements *AudioMeasurements) (*Ef
 	// dropped on voice-activated captures, and otherwise its nf tracks the measured
 	// noise floor with track_noise off.
 	tuneNoiseReduction(effectiveConfig, diagnostics, measurements)
+	warnAGCPumping(diagnostics, measurements)
+	warnClipping(diagnostics, measurements)
+	warnLoadedNoiseProfile(diagnostics, measurements)
 
 	tuneSpeechGate(effectiveConfig, diagnostics, measurements) // Soft expander gate cleaning inter-speech gaps
+	if config.SpeechGateThresholdDB != 0 {
+		applySpeechGateThresholdOverride(effectiveConfig, diagnostics, measurements, config.SpeechGateThresholdDB)
+	}
+	warnGateTailTruncation(effectiveConfig, diagnostics, measurements)
+	if config.ComfortNoise {
+		// Sized against the final gate threshold, so after any override.
+		tuneComfortNoise(effectiveConfig, diagnostics, measurements)
+	}
 	tuneDeesser(effectiveConfig, measurements)
-	tuneLevellingCompressor(effectiveConfig, measurements)
+	// Phone/VoIP guests: drop the stages that assume full-band audio (low-pass,
+	// de-esser, afftdn). Runs after their tuners so it has the final word.
+	tuneNarrowbandSource(effectiveConfig, diagnostics, measurements)
+	tuneLevellingCompressor(effectiveConfig, diagnostics, measurements)
 	// The limiter lives in Pass 4 and is tuned from Pass 3 measurements, not here.
+	tuneOutputFormat(effectiveConfig, diagnostics, measurements) // Dither and depth check for --bit-depth
+	applyDisabledStages(effectiveConfig, diagnostics, config.DisabledStages)
 
 	// Final safety checks
 	sanitizeConfig(effectiveConfig)
@@ -60,6 +82,17 @@ const (
 	// custom profile. Below it the room tone is tonal (hum, resonance) rather than
 	// broadband, so a measured shape risks over-fitting tonal peaks.
 	afftdnCustomMinFlatness = 0.45
+
+	// afftdnNoisyBandMarginDB is how far a room-tone band must stand above the
+	// median band to count as noisy: a buzz or whine confined to a few bands.
+	// Such a room tone reads tonal on flatness, yet its measured shape is
+	// exactly what lets afftdn take more out of the noisy bands than the clean
+	// ones, so it elects the custom profile on its own.
+	afftdnNoisyBandMarginDB = 12.0
+	// afftdnNoisyBandMinHz is the lowest band centre a noisy band may sit at.
+	// Below it a tonal peak is mains hum or a room resonance, where fitting the
+	// profile to the peak is the over-fit afftdnCustomMinFlatness guards against.
+	afftdnNoisyBandMinHz = 1000.0
 )
 
 // afftdnBandShapeClipDB bounds each emitted bn value; afftdn clips bn to
@@ -111,8 +144,9 @@ func buildAfftdnBandNoise(bands []float64) string {
 
 // useCustomAfftdnProfile reports whether the measured room-tone spectrum is
 // trustworthy enough to drive afftdn's custom noise model: a NoiseProfile with
-// all bands measured, a wide enough speech/noise gap, and a flat enough (noise-
-// like) room-tone spectrum.
+// all bands measured, a wide enough speech/noise gap, and either a flat enough
+// (noise-like) room-tone spectrum or noise concentrated in a few bands
+// (noisyAfftdnBands).
 func useCustomAfftdnProfile(measurements *AudioMeasurements) bool {
 	profile := measurements.Regions.NoiseProfile
 	if profile == nil || !profile.BandsMeasured {
@@ -121,7 +155,42 @@ func useCustomAfftdnProfile(measurements *AudioMeasurements) bool {
 	if measurements.Regions.GateSeparationDB < afftdnCustomMinSeparationDB {
 		return false
 	}
-	return profile.Spectral.Flatness >= afftdnCustomMinFlatness
+	return profile.Spectral.Flatness >= afftdnCustomMinFlatness || len(noisyAfftdnBands(profile.BandNoise)) > 0
+}
+
+// noisyAfftdnBands returns the indices of the bands at or above
+// afftdnNoisyBandMinHz that stand afftdnNoisyBandMarginDB or more above the
+// median finite band.
+func noisyAfftdnBands(bands []float64) []int {
+	var finite []float64
+	for _, v := range bands {
+		if isFinite(v) {
+			finite = append(finite, v)
+		}
+	}
+	if len(finite) == 0 {
+		return nil
+	}
+	slices.Sort(finite)
+	median := finite[len(finite)/2]
+
+	var noisy []int
+	for i, v := range bands {
+		if i < len(afftdnBandCentresHz) && afftdnBandCentresHz[i] >= afftdnNoisyBandMinHz &&
+			isFinite(v) && v-median >= afftdnNoisyBandMarginDB {
+			noisy = append(noisy, i)
+		}
+	}
+	return noisy
+}
+
+// formatAfftdnBands lists band centres for the report, e.g. "7.5 kHz, 11.2 kHz".
+func formatAfftdnBands(indices []int) string {
+	parts := make([]string, len(indices))
+	for i, idx := range indices {
+		parts[i] = strconv.FormatFloat(afftdnBandCentresHz[idx]/1000, 'f', 1, 64) + " kHz"
+	}
+	return strings.Join(parts, ", ")
 }
 
 // tuneNoiseReduction adapts the afftdn FFT denoise tail to Pass 1 measurements.
@@ -158,12 +227,14 @@ func tuneNoiseReduction(config *EffectiveFilterConfig, diagnostics *AdaptiveDiag
 	// Measured custom noise profile: when the room-tone band spectrum is
 	// trustworthy, emit the measured spectral shape (nt=custom:bn) instead of
 	// white. nf (the absolute level, set above) and nr (the depth) still stack on
-	// top; bn carries only the shape. Otherwise the white path stands.
+	// top; bn carries only the shape, so bands that stand above the rest are
+	// reduced harder than clean ones. Otherwise the white path stands.
 	config.NoiseReduction.AfftdnNoiseType = "w"
 	if useCustomAfftdnProfile(measurements) {
 		if bn := buildAfftdnBandNoise(measurements.Regions.NoiseProfile.BandNoise); bn != "" {
 			config.NoiseReduction.AfftdnNoiseType = "custom"
 			config.NoiseReduction.AfftdnBandNoise = bn
+			diagnostics.AfftdnNoisyBands = formatAfftdnBands(noisyAfftdnBands(measurements.Regions.NoiseProfile.BandNoise))
 		}
 	}
 	diagnostics.AfftdnNoiseType = config.NoiseReduction.AfftdnNoiseType
@@ -224,6 +295,12 @@ func sanitizeLevellingCompressorConfig(config *LevellingCompressorConfig) {
 	config.Makeup = sanitizeFloat(config.Makeup, defaults.Makeup)
 	config.Knee = sanitizeFloat(config.Knee, defaults.Knee)
 	config.Mix = sanitizeFloat(config.Mix, defaults.Mix)
+	// A slow stage carrying NaN/Inf would emit a malformed acompressor; drop the
+	// stage rather than guess its parameters.
+	if config.SlowStageEnabled && (!isFinite(config.SlowStageThreshold) || !isFinite(config.SlowStageRatio) ||
+		!isFinite(config.SlowStageAttack) || !isFinite(config.SlowStageRelease)) {
+		config.SlowStageEnabled = false
+	}
 }
 
 func sanitizeDeesserConfig(config *DeesserConfig) {
@@ -0,0 +1,16 @@
+package processor
+
+import "fmt"
+
+// warnAGCPumping reports source AGC found in Pass 1 (measureAGCRise). The floor
+// is already read from the mid-speech troughs by then, so the noise reduction
+// and gate tune against the noise heard under the voice; the warning tells the
+// user the swell between phrases is in the recording and only partly removable.
+func warnAGCPumping(diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	if measurements == nil || !measurements.Noise.AGCDetected {
+		return
+	}
+	diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+		"automatic gain control detected in the source (noise swells %.1f dB through pauses): noise floor read from mid-speech troughs; turn off AGC on the recorder",
+		measurements.Noise.AGCRiseDB))
+}
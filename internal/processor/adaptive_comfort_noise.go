@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+)
+
+// Comfort noise (--comfort-noise). A deep gate can leave pauses at dead digital
+// silence, which reads as a dropout. A constant bed of noise shaped like the
+// measured room tone is added after the gate, far enough down that speech masks
+// it and the pauses sound like a quiet room rather than a cut.
+const (
+	// comfortNoiseBelowFloorDB is how far below the measured room-tone floor the
+	// bed sits: present, never a second noise floor.
+	comfortNoiseBelowFloorDB = 20.0
+
+	// comfortNoiseBelowGateDB keeps the bed this far under the gate threshold,
+	// so it can never hold the gate open or read as unremoved noise.
+	comfortNoiseBelowGateDB = 20.0
+
+	// Clamp on the bed's one-pole low-pass corner, taken from the room-tone
+	// spectral centroid. Below 200 Hz the bed is a rumble; above 8 kHz it hisses
+	// more than any room.
+	comfortNoiseMinCornerHz = 200.0
+	comfortNoiseMaxCornerHz = 8000.0
+)
+
+// tuneComfortNoise enables the comfort-noise bed when the room tone was
+// profiled: the level sits comfortNoiseBelowFloorDB under the profile floor and
+// at least comfortNoiseBelowGateDB under the gate threshold, and the spectral
+// tilt follows the profile centroid. Without a profile there is no room tone to
+// imitate, so the option is skipped with a warning.
+func tuneComfortNoise(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	if !config.SpeechGate.Enabled {
+		return
+	}
+	var profile *NoiseProfile
+	if measurements != nil {
+		profile = measurements.Regions.NoiseProfile
+	}
+	if profile == nil || profile.MeasuredNoiseFloor == 0 {
+		diagnostics.Warnings = append(diagnostics.Warnings,
+			"comfort noise skipped: no room tone was profiled to match")
+		return
+	}
+
+	gateDB := LinearAmplitude(config.SpeechGate.Threshold).Decibels().Float64()
+	level := min(profile.MeasuredNoiseFloor-comfortNoiseBelowFloorDB, gateDB-comfortNoiseBelowGateDB)
+	corner := comfortNoiseMaxCornerHz
+	if c := profile.Spectral.Centroid; c > 0 {
+		corner = max(comfortNoiseMinCornerHz, min(c, comfortNoiseMaxCornerHz))
+	}
+
+	config.SpeechGate.ComfortNoiseEnabled = true
+	config.SpeechGate.ComfortNoiseLevelDB = level
+	config.SpeechGate.ComfortNoiseCornerHz = corner
+	diagnostics.ComfortNoise = true
+}
+
+// buildComfortNoiseFilter adds the bed with aeval: uniform white noise
+// (2*random-1) through a one-pole low-pass at cornerHz, held in register 0. The
+// pole k = exp(-2*PI*fc/s) uses the stream rate s, and the gain sqrt(3) *
+// sqrt((1+k)/(1-k)) restores the RMS the low-pass takes away, so the bed's RMS
+// is levelDB. The expression's commas are escaped for the filtergraph parser.
+func buildComfortNoiseFilter(levelDB, cornerHz float64) string {
+	k := fmt.Sprintf("exp(-2*PI*%.1f/s)", cornerHz)
+	expr := fmt.Sprintf("val(ch)+%.8f*sqrt((1+%s)/(1-%s))*st(0,%s*ld(0)+(1-%s)*(2*random(1)-1))",
+		DbToLinear(levelDB)*math.Sqrt(3), k, k, k, k)
+	return "aeval=exprs=" + escapeFilterGraphOptionValue(expr) + ":channel_layout=same"
+}
@@ -19,6 +19,21 @@ const (
 	// audibly-active part of the curve.
 	deessIntensityMid = 0.6  // Intensity at deessExcessMidDB
 	deessIntensityMax = 0.85 // Ceiling at/above deessExcessMaxDB
+
+	// Intermittent-sibilance breakpoints on the peak excess (dB), the loudest
+	// 50 ms sibilant-band window minus the loudest body-band window. A dark voice
+	// whose "s" sounds are few but sharp sits below deessExcessOffDB on the
+	// averages while its worst "s" rivals its loudest vowel. Vowel peaks clear
+	// sibilant peaks comfortably in balanced speech, so the ramp starts only
+	// once the two are close, and tops out at the gentle-to-firm knee: the
+	// average path alone drives the stronger settings a consistently sibilant
+	// voice needs.
+	//
+	//	peak excess < -3      → OFF on this path
+	//	-3 .. +3              → ramp i 0.0 → 0.6
+	//	> +3                  → cap  (i = 0.6)
+	deessPeakExcessOffDB  = -3.0
+	deessPeakExcessFullDB = 3.0
 )
 
 // SibilanceExcessDB is the speech-region sibilance excess in dB: the sibilant-band
@@ -30,11 +45,19 @@ func (m *SpeechCandidateMetrics) SibilanceExcessDB() float64 {
 	return m.SibBandRMS - m.BodyBandRMS
 }
 
+// PeakSibilanceExcessDB is the loudest sibilant-band window minus the loudest
+// body-band window in dB. It means something only when BandPeaksMeasured.
+func (m *SpeechCandidateMetrics) PeakSibilanceExcessDB() float64 {
+	return m.SibBandPeakRMS - m.BodyBandPeakRMS
+}
+
 // tuneDeesser sets de-esser intensity from the speech-region sibilance excess
 // (sibilant-band RMS minus body-band RMS). It requires a SpeechProfile with both
 // bands measured; full-file metrics are diluted by silence/noise and produce
 // false positives, and unmeasured bands read as a spurious 0 dB excess, so
-// without measured bands the de-esser stays OFF.
+// without measured bands the de-esser stays OFF. When the band peaks were
+// measured too, intermittent sibilance can raise the intensity on its own
+// (deessPeakExcessOffDB); the higher of the two paths wins.
 //
 // Mapping (sibilanceExcess in dB):
 //
@@ -43,25 +66,36 @@ func (m *SpeechCandidateMetrics) SibilanceExcessDB() float64 {
 //	-3 ..  0              → linear ramp i 0.6 → 0.85
 //	>  0                  → i = 0.85 (cap)
 func tuneDeesser(config *EffectiveFilterConfig, measurements *AudioMeasurements) {
-	if measurements.Regions.SpeechProfile == nil || !measurements.Regions.SpeechProfile.BandsMeasured {
+	profile := measurements.Regions.SpeechProfile
+	if profile == nil || !profile.BandsMeasured {
 		config.Deesser.Intensity = 0.0
 		return
 	}
 
-	sibilanceExcess := measurements.Regions.SpeechProfile.SibilanceExcessDB()
+	config.Deesser.Intensity = deessIntensityForExcess(profile.SibilanceExcessDB())
+	if profile.BandPeaksMeasured {
+		peak := linearScore(profile.PeakSibilanceExcessDB(), deessPeakExcessFullDB, deessPeakExcessOffDB) * deessIntensityMid
+		config.Deesser.Intensity = max(config.Deesser.Intensity, peak)
+	}
+}
+
+// deessIntensityForExcess maps the average sibilance excess to an intensity.
+func deessIntensityForExcess(sibilanceExcess float64) float64 {
+	var intensity float64
 
 	switch {
 	case sibilanceExcess < deessExcessOffDB:
-		config.Deesser.Intensity = 0.0
+		intensity = 0.0
 	case sibilanceExcess < deessExcessMidDB:
 		// Ramp 0.0 → deessIntensityMid across [deessExcessOffDB, deessExcessMidDB].
 		frac := (sibilanceExcess - deessExcessOffDB) / (deessExcessMidDB - deessExcessOffDB)
-		config.Deesser.Intensity = frac * deessIntensityMid
+		intensity = frac * deessIntensityMid
 	case sibilanceExcess < deessExcessMaxDB:
 		// Ramp deessIntensityMid → deessIntensityMax across [deessExcessMidDB, deessExcessMaxDB].
 		frac := (sibilanceExcess - deessExcessMidDB) / (deessExcessMaxDB - deessExcessMidDB)
-		config.Deesser.Intensity = deessIntensityMid + frac*(deessIntensityMax-deessIntensityMid)
+		intensity = deessIntensityMid + frac*(deessIntensityMax-deessIntensityMid)
 	default:
-		config.Deesser.Intensity = deessIntensityMax
+		intensity = deessIntensityMax
 	}
+	return intensity
 }
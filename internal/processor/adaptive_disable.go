@@ -0,0 +1,82 @@
+package processor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Stage names --disable takes. Each drops one Pass 2 stage, or the Pass 4
+// click repair, after the adaptation has tuned it, so a troubleshooting run
+// hears the chain without that stage and nothing else changed. The Pass 4
+// limiter is not on the list: without it the true-peak ceiling is not met.
+const (
+	DisableHighPass   = "highpass"   // rumble high-pass
+	DisableHumNotch   = "humnotch"   // mains hum notch (--mains)
+	DisableLowPass    = "lowpass"    // 20.5 kHz band-limit
+	DisableDenoise    = "denoise"    // anlmdn and afftdn
+	DisableAfftdn     = "afftdn"     // afftdn alone; anlmdn stays
+	DisableGate       = "gate"       // speech gate, with any comfort noise
+	DisableCompressor = "compressor" // levelling compressor
+	DisableDeesser    = "deesser"    // de-esser
+	DisableDeclick    = "declick"    // Pass 4 click repair
+)
+
+// disableStageNames lists the --disable names in chain order.
+var disableStageNames = []string{
+	DisableHighPass, DisableHumNotch, DisableLowPass, DisableDenoise, DisableAfftdn,
+	DisableGate, DisableCompressor, DisableDeesser, DisableDeclick,
+}
+
+// SetDisabledStages names the stages to drop from the chain (--disable).
+// Unknown names are an error; repeats are kept once.
+func (cfg *BaseFilterConfig) SetDisabledStages(names []string) error {
+	var stages []string
+	for _, name := range names {
+		if !slices.Contains(disableStageNames, name) {
+			return fmt.Errorf("unknown stage %q, want one of %s", name, strings.Join(disableStageNames, ", "))
+		}
+		if !slices.Contains(stages, name) {
+			stages = append(stages, name)
+		}
+	}
+	cfg.DisabledStages = stages
+	return nil
+}
+
+// applyDisabledStages switches off the stages --disable names. It runs after
+// every tuner so it has the final word, and records the stages dropped.
+func applyDisabledStages(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, stages []string) {
+	for _, stage := range stages {
+		switch stage {
+		case DisableHighPass:
+			config.RumbleHighPass.Enabled = false
+		case DisableHumNotch:
+			config.HumNotch.Enabled = false
+		case DisableLowPass:
+			config.BandlimitLowPass.Enabled = false
+			diagnostics.BandlimitLPReason = "disabled: --disable"
+		case DisableDenoise, DisableAfftdn:
+			if stage == DisableDenoise {
+				config.NoiseReduction.Enabled = false
+			}
+			config.NoiseReduction.AfftdnEnabled = false
+			diagnostics.AfftdnEnabled = false
+			diagnostics.AfftdnDisableReason = "user"
+			diagnostics.AfftdnNoiseType = ""
+		case DisableGate:
+			config.SpeechGate.Enabled = false
+			config.SpeechGate.ComfortNoiseEnabled = false
+			diagnostics.ComfortNoise = false
+		case DisableCompressor:
+			config.LevellingCompressor.Enabled = false
+		case DisableDeesser:
+			config.Deesser.Enabled = false
+		case DisableDeclick:
+			config.Adeclick.Enabled = false
+		default:
+			continue
+		}
+		diagnostics.DisabledStages = append(diagnostics.DisabledStages, stage)
+	}
+}
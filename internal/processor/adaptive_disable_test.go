@@ -0,0 +1,44 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetDisabledStages(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetDisabledStages([]string{DisableGate, DisableDeesser, DisableGate}); err != nil {
+		t.Fatalf("SetDisabledStages: %v", err)
+	}
+	if strings.Join(cfg.DisabledStages, ",") != "gate,deesser" {
+		t.Errorf("DisabledStages = %v, want [gate deesser]", cfg.DisabledStages)
+	}
+	if err := cfg.SetDisabledStages([]string{"limiter"}); err == nil {
+		t.Error("limiter accepted, want an unknown-stage error")
+	}
+}
+
+func TestApplyDisabledStages(t *testing.T) {
+	config := DefaultEffectiveFilterConfig()
+	config.SpeechGate.ComfortNoiseEnabled = true
+	diagnostics := &AdaptiveDiagnostics{AfftdnEnabled: true, ComfortNoise: true}
+
+	applyDisabledStages(config, diagnostics, []string{DisableAfftdn, DisableGate, DisableLowPass})
+
+	if !config.NoiseReduction.Enabled || config.NoiseReduction.AfftdnEnabled {
+		t.Errorf("afftdn: NoiseReduction = %+v, want anlmdn kept and afftdn dropped", config.NoiseReduction)
+	}
+	if config.SpeechGate.Enabled || config.SpeechGate.ComfortNoiseEnabled || diagnostics.ComfortNoise {
+		t.Error("gate: speech gate or its comfort noise still on")
+	}
+	if config.BandlimitLowPass.Enabled {
+		t.Error("lowpass: band-limit still on")
+	}
+	spec := config.BuildFilterSpec()
+	if strings.Contains(spec, "afftdn") || strings.Contains(spec, "lowpass") || !strings.Contains(spec, "anlmdn") {
+		t.Errorf("BuildFilterSpec = %q, want anlmdn without afftdn or lowpass", spec)
+	}
+	if strings.Join(diagnostics.DisabledStages, ",") != "afftdn,gate,lowpass" || diagnostics.AfftdnEnabled {
+		t.Errorf("diagnostics = %+v, want the three stages recorded", diagnostics)
+	}
+}
@@ -0,0 +1,81 @@
+package processor
+
+import "fmt"
+
+// Mains hum notch (--mains). Hum from a ground loop or an unshielded cable is a
+// steady tone at the mains frequency and its harmonics: 50 Hz in Europe, most
+// of Africa, Asia and Australia, 60 Hz in North America and parts of South
+// America and Asia. The rumble high-pass only takes some of the fundamental;
+// the harmonics sit above its corner, so narrow notches remove them.
+const (
+	MainsHz50 = 50.0
+	MainsHz60 = 60.0
+
+	// --mains choices.
+	Mains50   = "50"
+	Mains60   = "60"
+	MainsAuto = "auto"
+
+	// humNotchHarmonics is how many notches are placed, including the
+	// fundamental: up to 200 Hz on 50 Hz mains, 240 Hz on 60 Hz.
+	humNotchHarmonics = 4
+
+	// humNotchQ keeps each notch about 2 Hz wide at 60 Hz (8 Hz at 240 Hz), so
+	// the voice harmonics gliding through it lose next to nothing.
+	humNotchQ = 30.0
+)
+
+// mainsFrequenciesHz are the mains frequencies auto chooses between.
+var mainsFrequenciesHz = [2]float64{MainsHz50, MainsHz60}
+
+// SetMains turns on the hum notch: "50" or "60" pins the mains frequency,
+// "auto" detects it from the room tone.
+func (cfg *BaseFilterConfig) SetMains(choice string) error {
+	switch choice {
+	case Mains50, Mains60, MainsAuto:
+		cfg.Mains = choice
+		return nil
+	}
+	return fmt.Errorf("mains %q is not one of %s, %s, %s", choice, Mains50, Mains60, MainsAuto)
+}
+
+// tuneHumNotch places the hum notch at the mains frequency --mains names, or
+// under auto at the one whose hum stands out in the room tone (MainsHumHz).
+// Auto leaves the notch off rather than guess when neither stands out, and
+// warns when there was no room tone to read.
+func tuneHumNotch(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements, mains string) {
+	var hz float64
+	switch mains {
+	case "":
+		return
+	case Mains50:
+		hz = MainsHz50
+		diagnostics.HumNotchReason = "--mains=50"
+	case Mains60:
+		hz = MainsHz60
+		diagnostics.HumNotchReason = "--mains=60"
+	case MainsAuto:
+		var profile *NoiseProfile
+		if measurements != nil {
+			profile = measurements.Regions.NoiseProfile
+		}
+		if profile == nil || !profile.HumMeasured {
+			diagnostics.HumNotchReason = "auto: no room tone to read"
+			diagnostics.Warnings = append(diagnostics.Warnings,
+				"hum notch skipped: no room tone was profiled to detect the mains frequency")
+			return
+		}
+		var margin float64
+		hz, margin = profile.MainsHumHz()
+		if hz == 0 {
+			diagnostics.HumNotchReason = fmt.Sprintf("auto: no mains hum (50 and 60 Hz sets within %.1f dB)", margin)
+			return
+		}
+		diagnostics.HumNotchReason = fmt.Sprintf("auto: %.0f Hz hum, %.1f dB over the other mains set", hz, margin)
+	default:
+		return
+	}
+
+	config.HumNotch.Enabled = true
+	config.HumNotch.Frequency = hz
+}
@@ -0,0 +1,103 @@
+package processor
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func humMeasurements(hum50, hum60 float64) *AudioMeasurements {
+	m := &AudioMeasurements{}
+	m.Regions.NoiseProfile = &NoiseProfile{Hum50RMS: hum50, Hum60RMS: hum60, HumMeasured: true}
+	return m
+}
+
+func TestTuneHumNotch(t *testing.T) {
+	tests := []struct {
+		name         string
+		mains        string
+		measurements *AudioMeasurements
+		wantHz       float64
+		wantWarning  bool
+	}{
+		{"off", "", humMeasurements(-60, -80), 0, false},
+		{"pinned 50", Mains50, nil, 50, false},
+		{"pinned 60", Mains60, humMeasurements(-60, -80), 60, false},
+		{"auto 50 Hz hum", MainsAuto, humMeasurements(-62, -80), 50, false},
+		{"auto 60 Hz hum", MainsAuto, humMeasurements(-81, -70), 60, false},
+		{"auto broadband only", MainsAuto, humMeasurements(-78, -79), 0, false},
+		{"auto without room tone", MainsAuto, &AudioMeasurements{}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := deriveEffectiveFilterConfig(DefaultFilterConfig())
+			diagnostics := &AdaptiveDiagnostics{}
+			tuneHumNotch(config, diagnostics, tt.measurements, tt.mains)
+
+			if config.HumNotch.Enabled != (tt.wantHz != 0) || config.HumNotch.Frequency != tt.wantHz {
+				t.Errorf("HumNotch = %+v, want %.0f Hz", config.HumNotch, tt.wantHz)
+			}
+			if (len(diagnostics.Warnings) > 0) != tt.wantWarning {
+				t.Errorf("warnings = %q, want warning %v", diagnostics.Warnings, tt.wantWarning)
+			}
+			if tt.mains != "" && diagnostics.HumNotchReason == "" {
+				t.Error("no hum notch reason recorded")
+			}
+		})
+	}
+}
+
+func TestBuildHumNotchFilter(t *testing.T) {
+	config := deriveEffectiveFilterConfig(DefaultFilterConfig())
+	if spec := config.buildHumNotchFilter(); spec != "" {
+		t.Errorf("disabled notch = %q, want empty", spec)
+	}
+
+	config.HumNotch.Enabled = true
+	config.HumNotch.Frequency = MainsHz60
+	want := "bandreject=f=60:width_type=q:w=30,bandreject=f=120:width_type=q:w=30," +
+		"bandreject=f=180:width_type=q:w=30,bandreject=f=240:width_type=q:w=30"
+	if spec := config.buildHumNotchFilter(); spec != want {
+		t.Errorf("notch = %q, want %q", spec, want)
+	}
+
+	config.HumNotch.Frequency = MainsHz50
+	if spec := config.buildHumNotchFilter(); !strings.HasSuffix(spec, "bandreject=f=200:width_type=q:w=30") {
+		t.Errorf("50 Hz notch = %q, want harmonics up to 200 Hz", spec)
+	}
+}
+
+func TestSetMains(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	for _, choice := range []string{Mains50, Mains60, MainsAuto} {
+		if err := cfg.SetMains(choice); err != nil || cfg.Mains != choice {
+			t.Errorf("SetMains(%q) = %v, Mains %q", choice, err, cfg.Mains)
+		}
+	}
+	if err := cfg.SetMains("55"); err == nil {
+		t.Error("SetMains(55) = nil, want error")
+	}
+}
+
+func TestMainsHumHz(t *testing.T) {
+	if hz, _ := (*NoiseProfile)(nil).MainsHumHz(); hz != 0 {
+		t.Errorf("nil profile = %.0f Hz, want 0", hz)
+	}
+	if hz, _ := (&NoiseProfile{Hum50RMS: -50, Hum60RMS: -80}).MainsHumHz(); hz != 0 {
+		t.Errorf("unmeasured profile = %.0f Hz, want 0", hz)
+	}
+
+	p := humMeasurements(-80, -72).Regions.NoiseProfile
+	if hz, margin := p.MainsHumHz(); hz != MainsHz60 || margin != 8 {
+		t.Errorf("MainsHumHz = %.0f Hz by %.1f dB, want 60 Hz by 8 dB", hz, margin)
+	}
+}
+
+func TestPowerSumDB(t *testing.T) {
+	if got := powerSumDB([]float64{-60, -60}); math.Abs(got-(-60+10*math.Log10(2))) > 1e-9 {
+		t.Errorf("two equal tones = %.3f dB, want 3 dB up", got)
+	}
+	if got := powerSumDB([]float64{-40, -100}); math.Abs(got-(-40)) > 0.01 {
+		t.Errorf("dominant tone = %.3f dB, want about -40", got)
+	}
+}
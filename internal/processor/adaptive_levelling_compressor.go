@@ -42,16 +42,33 @@ const (
 	levellingCompressorFixedKnee    = 4.0
 	levellingCompressorFixedMix     = 1.0
 	levellingCompressorFixedMakeup  = 0.0
+
+	// High-LRA path. Above this input loudness range the recording swings
+	// between whispered and shouted passages that a single 200 ms-release stage
+	// can only nudge, so a slow levelling stage rides the overall level ahead of
+	// the main compressor. 15 LU matches the gate's wide-dynamics boundary
+	// (speechGateLRAWide); typical podcast speech sits at 5-12 LU.
+	levellingHighLRAThreshold = 15.0 // LU
+
+	// Slow stage: threshold at the speech RMS itself (the main stage's threshold
+	// minus its speech offset), a low 2:1 ratio, and attack/release long enough
+	// to follow phrase-level loudness rather than syllables, so the rider evens
+	// out passages without pumping.
+	levellingSlowStageRatio   = 2.0
+	levellingSlowStageAttack  = 50.0   // ms
+	levellingSlowStageRelease = 1000.0 // ms
 )
 
-// tuneLevellingCompressor applies fixed gentle levelling compression with a single
-// genuine adaptation: the threshold.
+// tuneLevellingCompressor applies fixed gentle levelling compression with two
+// adaptations: the threshold, and the high-LRA slow stage.
 //
 // Ratio, attack, release, knee, mix and makeup are fixed in
 // defaultLevellingCompressorConfig() and left untouched here. The threshold is
 // anchored to speech-region RMS when a SpeechProfile exists, otherwise it falls
-// back to a peak-relative estimate.
-func tuneLevellingCompressor(config *EffectiveFilterConfig, measurements *AudioMeasurements) {
+// back to a peak-relative estimate. When the input LRA exceeds
+// levellingHighLRAThreshold a slow levelling stage is engaged ahead of the main
+// stage and the diagnostic records it.
+func tuneLevellingCompressor(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
 	config.LevellingCompressor.Ratio = levellingCompressorFixedRatio
 	config.LevellingCompressor.Attack = levellingCompressorFixedAttack
 	config.LevellingCompressor.Release = levellingCompressorFixedRelease
@@ -59,6 +76,30 @@ func tuneLevellingCompressor(config *EffectiveFilterConfig, measurements *AudioM
 	config.LevellingCompressor.Mix = levellingCompressorFixedMix
 	config.LevellingCompressor.Makeup = levellingCompressorFixedMakeup
 	tuneLevellingCompressorThreshold(config, measurements)
+	tuneLevellingSlowStage(config, diagnostics, measurements)
+}
+
+// tuneLevellingSlowStage engages the slow levelling stage on very wide loudness
+// range. The stage threshold sits at the speech RMS (the main threshold less its
+// speech offset), clamped to the same operating range as the main threshold.
+// Below the LRA threshold the stage stays off and the chain is a single
+// acompressor, unchanged.
+func tuneLevellingSlowStage(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	lc := &config.LevellingCompressor
+	lc.SlowStageEnabled = measurements.Loudness.InputLRA > levellingHighLRAThreshold
+	if !lc.SlowStageEnabled {
+		return
+	}
+
+	threshold := lc.Threshold - levellingCompressorThresholdSpeechOffsetDB
+	lc.SlowStageThreshold = max(levellingCompressorThresholdMin, min(threshold, levellingCompressorThresholdMax))
+	lc.SlowStageRatio = levellingSlowStageRatio
+	lc.SlowStageAttack = levellingSlowStageAttack
+	lc.SlowStageRelease = levellingSlowStageRelease
+
+	if diagnostics != nil {
+		diagnostics.LevellingHighLRA = true
+	}
 }
 
 // tuneLevellingCompressorThreshold sets the compressor threshold.
@@ -0,0 +1,70 @@
+package processor
+
+import "fmt"
+
+// Narrowband (phone/VoIP) source detection. Telephone and narrowband VoIP codecs
+// band-limit speech to roughly 300-3400 Hz, so the elected speech region has
+// almost no energy in the 6-9 kHz sibilant band and its spectral rolloff sits
+// below the top of the telephone band. Both signals must agree: a dark studio
+// voice can show a low rolloff, and a quiet sibilant band alone can be a soft
+// talker, but full-band speech never shows both at once.
+const (
+	// narrowbandMaxRolloffHz is the highest speech-region spectral rolloff still
+	// treated as narrowband. Full-band speech rolls off at 4-8 kHz
+	// (rolloffIdealMin/Max); telephone speech sits under the 3.4 kHz band edge.
+	narrowbandMaxRolloffHz = 3800.0
+
+	// narrowbandMaxSibExcessDB is the highest sibilance excess (sibilant-band RMS
+	// minus body-band RMS) still treated as narrowband. Full-band speech sits
+	// around -20 to 0 dB; a codec that discards everything above 4 kHz leaves
+	// the sibilant band at the noise floor, far below the body band.
+	narrowbandMaxSibExcessDB = -30.0
+)
+
+// isNarrowbandSource reports whether the elected speech region carries the
+// phone/VoIP bandwidth signature. It requires a SpeechProfile with both bands
+// and the spectral rolloff measured; without them the source is treated as
+// full-band.
+func isNarrowbandSource(measurements *AudioMeasurements) bool {
+	if measurements == nil {
+		return false
+	}
+	profile := measurements.Regions.SpeechProfile
+	if profile == nil || !profile.BandsMeasured {
+		return false
+	}
+	// A zero rolloff means the spectral average was never populated.
+	rolloff := profile.Spectral.Rolloff
+	return rolloff > 0 && rolloff <= narrowbandMaxRolloffHz &&
+		profile.SibilanceExcessDB() <= narrowbandMaxSibExcessDB
+}
+
+// tuneNarrowbandSource switches a phone/VoIP source to a bandwidth-appropriate
+// chain. It runs after the per-filter tuners and overrides only the stages that
+// assume full-band audio:
+//   - the band-limit low-pass is dropped; there is nothing above 4 kHz to limit.
+//   - the de-esser is turned off; the sibilant band holds only codec noise.
+//   - afftdn is dropped; codec artefacts are not stationary noise, and spectral
+//     subtraction on them warbles. anlmdn stays as the gentle denoise stage.
+//
+// The gate and levelling compressor are left to their own tuning: they work on
+// level, not bandwidth.
+func tuneNarrowbandSource(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	if !isNarrowbandSource(measurements) {
+		return
+	}
+
+	config.BandlimitLowPass.Enabled = false
+	config.Deesser.Intensity = 0.0
+	config.NoiseReduction.AfftdnEnabled = false
+
+	profile := measurements.Regions.SpeechProfile
+	diagnostics.NarrowbandSource = true
+	diagnostics.BandlimitLPReason = "disabled: narrowband source"
+	diagnostics.AfftdnEnabled = false
+	diagnostics.AfftdnDisableReason = "narrowband"
+	diagnostics.AfftdnNoiseType = ""
+	diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+		"VoIP-grade source detected (speech rolloff %.0f Hz, sibilance excess %.1f dB): low-pass, de-esser and afftdn disabled",
+		profile.Spectral.Rolloff, profile.SibilanceExcessDB()))
+}
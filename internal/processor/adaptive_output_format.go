@@ -0,0 +1,36 @@
+package processor
+
+import "fmt"
+
+// tuneOutputFormat settles the delivered bit depth (--bit-depth). Passes 2
+// through 4 run in float or s32, so every 16-bit output is a requantisation
+// whatever the source depth was: it always gets TPDF dither, so the truncation
+// error becomes benign noise rather than distortion on quiet passages. Asking
+// for more bits than the source's effective depth (astats Bit_depth) carries is
+// allowed but flagged: the extra bits hold only processing residue. An
+// unmeasured depth (zero) is not flagged. An --output-rate above the source
+// rate is flagged the same way: upsampling adds no bandwidth.
+func tuneOutputFormat(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	if config.Resample.BitDepth == OutputBitDepth16 {
+		config.Resample.Dither = true
+		diagnostics.OutputDither = true
+	}
+	if measurements == nil {
+		return
+	}
+	if rate := config.Resample.SampleRate; config.Resample.Soxr && measurements.SampleRate > 0 && rate > measurements.SampleRate {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+			"%d Hz output requested but the source is %d Hz: no quality benefit",
+			rate, measurements.SampleRate))
+	}
+
+	sourceBits := measurements.Dynamics.BitDepth
+	if sourceBits <= 0 {
+		return
+	}
+	if float64(config.Resample.BitDepth) > sourceBits {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+			"%d-bit output requested but the source carries only %.0f effective bits: no quality benefit",
+			config.Resample.BitDepth, sourceBits))
+	}
+}
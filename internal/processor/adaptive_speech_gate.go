@@ -1,5 +1,7 @@
 package processor
 
+import "fmt"
+
 const (
 	// LUFS gap threshold used only by the no-profile legacy threshold path: above
 	// this gap the peak-reference branch is disabled (the recording is too quiet
@@ -298,3 +300,61 @@ func calculateSpeechGateRangeDB(narrowGap bool) float64 {
 	}
 	return speechGateDepthFixedDB
 }
+
+// applySpeechGateThresholdOverride replaces the adaptive threshold with a
+// user-pinned dBFS value (--gate-threshold). It runs after tuneSpeechGate so
+// ratio, attack, release, knee, and depth keep their adaptive values; only the
+// threshold is pinned. The value is re-clamped to the global gate limits as a
+// final safety net (SetSpeechGateThreshold already rejects out-of-range input).
+//
+// Two placements are flagged as warnings rather than refused, because the user
+// asked for them: a threshold at or below the measured noise floor (the gate
+// never closes on the room tone), and a threshold above the voiced-speech low
+// percentile (the gate attenuates quiet words). Both comparisons read the
+// momentary-LUFS axis the floor and voiced p10 are measured on.
+func applySpeechGateThresholdOverride(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements, thresholdDB float64) {
+	thresholdDB = max(speechGateThresholdMinDB, min(thresholdDB, speechGateThresholdMaxDB))
+	config.SpeechGate.Threshold = Decibels(thresholdDB).LinearAmplitude().Float64()
+
+	if diagnostics == nil {
+		return
+	}
+
+	diagnostics.SpeechGateClampReason = "user_override"
+	diagnostics.SpeechGateThresholdUnclamped = thresholdDB
+
+	if measurements == nil {
+		return
+	}
+	if measurements.Regions.SpeechProfile != nil {
+		diagnostics.SpeechGateSpeechHeadroom = measurements.Regions.VoicedLowPercentile - thresholdDB
+	}
+
+	if floor := measurements.Noise.Floor; floor != 0 && thresholdDB <= floor {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+			"gate threshold %.1f dB is at or below the measured noise floor %.1f dB; the gate will not close on room tone",
+			thresholdDB, floor))
+	}
+	if measurements.Regions.SpeechProfile != nil && thresholdDB > measurements.Regions.VoicedLowPercentile {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+			"gate threshold %.1f dB is above quiet speech (voiced p10 %.1f dB); quiet words will be attenuated",
+			thresholdDB, measurements.Regions.VoicedLowPercentile))
+	}
+}
+
+// warnGateTailTruncation flags a live room whose speech decay (measureSpeechDecay)
+// outlasts the gate release: the gate closes while the reverb tail is still
+// audible and the word ends sound cut against the room. The release stays fixed;
+// the warning names the trade-off instead.
+func warnGateTailTruncation(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	if measurements == nil || !config.SpeechGate.Enabled {
+		return
+	}
+	decay := measurements.Noise.SpeechDecayMS
+	if decay <= config.SpeechGate.Release {
+		return
+	}
+	diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+		"speech decays over about %.0f ms in this room but the gate releases in %.0f ms: the gate may cut the reverb tail; a longer release or a shallower range would keep it",
+		decay, config.SpeechGate.Release))
+}
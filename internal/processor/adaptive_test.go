@@ -3,6 +3,7 @@ package processor
 import (
 	"math"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -521,6 +522,44 @@ func TestTuneDeesser(t *testing.T) {
 	}
 }
 
+// TestTuneDeesserIntermittentSibilance covers the peak path: a dark voice whose
+// average excess leaves the de-esser off still engages on sharp "s" peaks.
+func TestTuneDeesserIntermittentSibilance(t *testing.T) {
+	tests := []struct {
+		name          string
+		avgExcess     float64
+		peakExcess    float64
+		peaksMeasured bool
+		wantIntensity float64
+	}{
+		{"dark voice, tame peaks stays off", -12, -8, true, 0.0},
+		{"dark voice, sharp peaks engages", -12, 0, true, deessIntensityMid / 2},
+		{"peak path caps at the knee", -12, 9, true, deessIntensityMid},
+		{"peaks unmeasured keep the average path", -12, 9, false, 0.0},
+		{"average path wins when higher", 0, 0, true, deessIntensityMax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newTestConfig()
+			measurements := &AudioMeasurements{}
+			measurements.Regions.SpeechProfile = &SpeechCandidateMetrics{
+				BodyBandRMS:       -30,
+				SibBandRMS:        -30 + tt.avgExcess,
+				BandsMeasured:     true,
+				BodyBandPeakRMS:   -18,
+				SibBandPeakRMS:    -18 + tt.peakExcess,
+				BandPeaksMeasured: tt.peaksMeasured,
+			}
+
+			tuneDeesser(config, measurements)
+
+			if math.Abs(config.Deesser.Intensity-tt.wantIntensity) > 0.001 {
+				t.Errorf("Deesser.Intensity = %.3f, want %.3f", config.Deesser.Intensity, tt.wantIntensity)
+			}
+		})
+	}
+}
+
 func TestTuneSpeechGate(t *testing.T) {
 	// Tests the comprehensive gate tuning which calculates all gate parameters
 	// based on measurements including NoiseProfile (extracted from the elected
@@ -1129,6 +1168,79 @@ func TestTuneSpeechGateNewBasis(t *testing.T) {
 	})
 }
 
+// TestSpeechGateThresholdOverride checks the --gate-threshold path end to end
+// through AdaptConfig: the pinned threshold replaces the adaptive placement, the
+// other gate parameters stay adaptive, and placements outside the measured
+// noise/speech gap raise warnings rather than being refused.
+func TestSpeechGateThresholdOverride(t *testing.T) {
+	measurements := &AudioMeasurements{
+		Loudness: InputLoudnessMetrics{InputI: -20.0, InputLRA: 18.0},
+		Noise:    NoiseMetrics{Floor: -60.0},
+		Regions: RegionMetrics{
+			SpeechProfile:       &SpeechCandidateMetrics{RegionSample: RegionSample{RMSLevel: -24.0}},
+			VoicedLowPercentile: -34.0,
+			NoiseHighPercentile: -58.0,
+			GateSeparationDB:    24.0,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		thresholdDB  float64
+		wantWarnings int
+	}{
+		{"inside the gap", -45.0, 0},
+		{"below the noise floor", -65.0, 1},
+		{"above quiet speech", -30.0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := DefaultFilterConfig()
+			if err := base.SetSpeechGateThreshold(tt.thresholdDB); err != nil {
+				t.Fatalf("SetSpeechGateThreshold(%.1f): %v", tt.thresholdDB, err)
+			}
+			config, diag := AdaptConfig(base, measurements)
+
+			if gotDB := linearToDB(config.SpeechGate.Threshold); math.Abs(gotDB-tt.thresholdDB) > 0.01 {
+				t.Errorf("threshold = %.2f dB, want pinned %.2f dB", gotDB, tt.thresholdDB)
+			}
+			if config.SpeechGate.Ratio != speechGateRatioGentle {
+				t.Errorf("Ratio = %.2f, want adaptive %.2f for wide LRA", config.SpeechGate.Ratio, speechGateRatioGentle)
+			}
+			assertFixedGateParams(t, config)
+			if diag.SpeechGateClampReason != "user_override" {
+				t.Errorf("SpeechGateClampReason = %q, want \"user_override\"", diag.SpeechGateClampReason)
+			}
+			if len(diag.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %q, want %d warning(s)", diag.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+
+	t.Run("unset leaves the adaptive threshold", func(t *testing.T) {
+		config, diag := AdaptConfig(DefaultFilterConfig(), measurements)
+		wantDB := measurements.Regions.VoicedLowPercentile - speechGateThresholdSpeechMarginDB
+		if gotDB := linearToDB(config.SpeechGate.Threshold); math.Abs(gotDB-wantDB) > 0.01 {
+			t.Errorf("threshold = %.2f dB, want adaptive %.2f dB", gotDB, wantDB)
+		}
+		if len(diag.Warnings) != 0 {
+			t.Errorf("Warnings = %q, want none", diag.Warnings)
+		}
+	})
+
+	t.Run("out-of-range values are rejected", func(t *testing.T) {
+		for _, db := range []float64{-90.0, -10.0, 0.0, math.NaN()} {
+			base := DefaultFilterConfig()
+			if err := base.SetSpeechGateThreshold(db); err == nil {
+				t.Errorf("SetSpeechGateThreshold(%v) = nil, want error", db)
+			}
+			if base.SpeechGateThresholdDB != 0 {
+				t.Errorf("SetSpeechGateThreshold(%v) stored %v on error", db, base.SpeechGateThresholdDB)
+			}
+		}
+	})
+}
+
 // assertFixedGateParams checks the gate parameters that are fixed under the new
 // basis: attack 5 ms, release 200 ms, knee 3.0, detection rms.
 func assertFixedGateParams(t *testing.T, config *EffectiveFilterConfig) {
@@ -1475,6 +1587,51 @@ func TestTuneLevellingCompressorThresholdPeakFallbackNoProfile(t *testing.T) {
 	}
 }
 
+func TestTuneLevellingCompressorHighLRASlowStage(t *testing.T) {
+	speech := &SpeechCandidateMetrics{RegionSample: RegionSample{RMSLevel: -27.0}}
+
+	tests := []struct {
+		name     string
+		lra      float64
+		wantSlow bool
+	}{
+		{"typical podcast LRA stays single-stage", 9.0, false},
+		{"at the threshold stays single-stage", levellingHighLRAThreshold, false},
+		{"whisper-to-shout LRA engages the slow stage", 22.0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newTestConfig()
+			diag := &AdaptiveDiagnostics{}
+			tuneLevellingCompressor(config, diag, &AudioMeasurements{
+				Loudness: InputLoudnessMetrics{InputLRA: tt.lra},
+				Regions:  RegionMetrics{SpeechProfile: speech},
+			})
+
+			lc := config.LevellingCompressor
+			if lc.SlowStageEnabled != tt.wantSlow || diag.LevellingHighLRA != tt.wantSlow {
+				t.Fatalf("SlowStageEnabled=%v LevellingHighLRA=%v, want %v", lc.SlowStageEnabled, diag.LevellingHighLRA, tt.wantSlow)
+			}
+			if !tt.wantSlow {
+				return
+			}
+			// Slow stage sits at the speech RMS, below the main threshold.
+			if math.Abs(lc.SlowStageThreshold-speech.RMSLevel) > 0.001 {
+				t.Errorf("SlowStageThreshold = %.2f, want speech RMS %.2f", lc.SlowStageThreshold, speech.RMSLevel)
+			}
+			if lc.SlowStageRatio != levellingSlowStageRatio || lc.SlowStageRelease != levellingSlowStageRelease {
+				t.Errorf("slow stage ratio/release = %.1f/%.0f, want %.1f/%.0f",
+					lc.SlowStageRatio, lc.SlowStageRelease, levellingSlowStageRatio, levellingSlowStageRelease)
+			}
+			// The main stage keeps its fixed parameters.
+			if lc.Ratio != levellingCompressorFixedRatio || lc.Release != levellingCompressorFixedRelease {
+				t.Errorf("main stage ratio/release = %.1f/%.0f, want fixed %.1f/%.0f",
+					lc.Ratio, lc.Release, levellingCompressorFixedRatio, levellingCompressorFixedRelease)
+			}
+		})
+	}
+}
+
 func TestTuneLevellingCompressorThresholdAcceptsZeroDBPeak(t *testing.T) {
 	config := newTestConfig()
 	measurements := &AudioMeasurements{
@@ -1920,6 +2077,56 @@ func TestTuneNoiseReduction(t *testing.T) {
 		}
 	})
 
+	t.Run("band-concentrated noise elects the custom profile", func(t *testing.T) {
+		// A whine around 7.5 kHz reads tonal on flatness, but the bands above
+		// 1 kHz that stand clear of the rest justify the custom profile.
+		bands := make([]float64, len(afftdnBandCentresHz))
+		for i := range bands {
+			bands[i] = -70.0
+		}
+		idx := slices.Index(afftdnBandCentresHz, 7500.0)
+		if idx < 0 {
+			t.Fatal("no 7.5 kHz band centre")
+		}
+		bands[idx] = -50.0
+
+		config := &EffectiveFilterConfig{NoiseReduction: defaultNoiseReductionConfig()}
+		diag := &AdaptiveDiagnostics{}
+		measurements := &AudioMeasurements{
+			Noise: NoiseMetrics{Floor: -58.0},
+			Regions: RegionMetrics{
+				GateSeparationDB: 15.0,
+				NoiseProfile: &NoiseProfile{
+					Spectral:      SpectralMetrics{Flatness: 0.30},
+					BandsMeasured: true,
+					BandNoise:     bands,
+				},
+			},
+		}
+
+		tuneNoiseReduction(config, diag, measurements)
+
+		if config.NoiseReduction.AfftdnNoiseType != "custom" {
+			t.Errorf("AfftdnNoiseType = %q, want custom", config.NoiseReduction.AfftdnNoiseType)
+		}
+		if diag.AfftdnNoisyBands != "7.5 kHz" {
+			t.Errorf("AfftdnNoisyBands = %q, want 7.5 kHz", diag.AfftdnNoisyBands)
+		}
+
+		// The same peak in a low band is hum, not a reason to fit the profile.
+		bands[idx] = -70.0
+		bands[0] = -50.0
+		config = &EffectiveFilterConfig{NoiseReduction: defaultNoiseReductionConfig()}
+		diag = &AdaptiveDiagnostics{}
+		tuneNoiseReduction(config, diag, measurements)
+		if config.NoiseReduction.AfftdnNoiseType != "w" {
+			t.Errorf("low-band peak: AfftdnNoiseType = %q, want w", config.NoiseReduction.AfftdnNoiseType)
+		}
+		if diag.AfftdnNoisyBands != "" {
+			t.Errorf("low-band peak: AfftdnNoisyBands = %q, want empty", diag.AfftdnNoisyBands)
+		}
+	})
+
 	t.Run("non-qualifying measurements keep the white path", func(t *testing.T) {
 		// Each case fails exactly one gate; all else qualifies.
 		base := func() *AudioMeasurements {
@@ -1964,6 +2171,61 @@ func TestTuneNoiseReduction(t *testing.T) {
 }
 
 // TestBuildAfftdnBandNoise covers the bn mean-subtraction and clip maths.
+func TestTuneNarrowbandSource(t *testing.T) {
+	speech := func(rolloff, body, sib float64) *AudioMeasurements {
+		return &AudioMeasurements{
+			Noise: NoiseMetrics{Floor: -58.0},
+			Regions: RegionMetrics{SpeechProfile: &SpeechCandidateMetrics{
+				RegionSample:  RegionSample{RMSLevel: -27.0, Spectral: SpectralMetrics{Rolloff: rolloff}},
+				BodyBandRMS:   body,
+				SibBandRMS:    sib,
+				BandsMeasured: true,
+			}},
+		}
+	}
+
+	tests := []struct {
+		name string
+		m    *AudioMeasurements
+		want bool
+	}{
+		{"phone guest: low rolloff, empty sibilant band", speech(3200, -24, -70), true},
+		{"dark studio voice keeps its sibilant band", speech(3200, -24, -40), false},
+		{"soft talker with full-band rolloff", speech(6000, -24, -70), false},
+		{"unmeasured rolloff", speech(0, -24, -70), false},
+		{"no speech profile", &AudioMeasurements{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNarrowbandSource(tt.m); got != tt.want {
+				t.Errorf("isNarrowbandSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("narrowband chain drops full-band stages", func(t *testing.T) {
+		config, diag := AdaptConfig(DefaultFilterConfig(), speech(3200, -24, -70))
+
+		if config.BandlimitLowPass.Enabled {
+			t.Error("band-limit low-pass should be disabled on a narrowband source")
+		}
+		if config.Deesser.Intensity != 0 {
+			t.Errorf("de-esser intensity = %.2f, want 0", config.Deesser.Intensity)
+		}
+		if config.NoiseReduction.AfftdnEnabled || diag.AfftdnDisableReason != "narrowband" {
+			t.Errorf("afftdn enabled=%v reason=%q, want disabled for narrowband",
+				config.NoiseReduction.AfftdnEnabled, diag.AfftdnDisableReason)
+		}
+		if !config.NoiseReduction.Enabled {
+			t.Error("anlmdn should stay in the chain")
+		}
+		if !diag.NarrowbandSource || len(diag.Warnings) != 1 ||
+			!strings.Contains(diag.Warnings[0], "VoIP-grade source detected") {
+			t.Errorf("NarrowbandSource=%v Warnings=%q, want the VoIP notice", diag.NarrowbandSource, diag.Warnings)
+		}
+	})
+}
+
 func TestBuildAfftdnBandNoise(t *testing.T) {
 	t.Run("empty input yields empty string", func(t *testing.T) {
 		if got := buildAfftdnBandNoise(nil); got != "" {
@@ -2029,3 +2291,166 @@ func TestBuildAfftdnBandNoise(t *testing.T) {
 		}
 	})
 }
+
+func TestTuneRumbleBursts(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		perMinute float64
+		want      int
+	}{
+		{"frequent bursts cascade the high-pass", 12, 4.0, rumbleBurstHighPassStages},
+		{"occasional knock leaves the default", 3, 0.5, 1},
+		{"single thump in a short clip", 1, 6.0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &AudioMeasurements{Noise: NoiseMetrics{
+				Floor: -60, RumbleBurstCount: tt.count, RumbleBurstsPerMinute: tt.perMinute,
+			}}
+			config, diag := AdaptConfig(DefaultFilterConfig(), m)
+			if config.RumbleHighPass.Stages != tt.want {
+				t.Errorf("RumbleHighPass.Stages = %d, want %d", config.RumbleHighPass.Stages, tt.want)
+			}
+			if diag.RumbleBurstHighPass != (tt.want > 1) {
+				t.Errorf("diagnostics.RumbleBurstHighPass = %v, want %v", diag.RumbleBurstHighPass, tt.want > 1)
+			}
+		})
+	}
+
+	t.Run("cascade repeats the biquad", func(t *testing.T) {
+		cfg := defaultBiquadConfig(rumbleHPDefaultFreq)
+		single := buildBiquadFilter(cfg, "highpass")
+		cfg.Stages = 2
+		if got := buildBiquadFilter(cfg, "highpass"); got != single+","+single {
+			t.Errorf("2-stage spec = %q, want %q twice", got, single)
+		}
+	})
+}
+
+func TestTuneOutputFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputBits int
+		sourceBits float64
+		wantDither bool
+		wantWarn   bool
+	}{
+		{"24-bit source to 16-bit is dithered", OutputBitDepth16, 24, true, false},
+		{"16-bit source to 16-bit is dithered", OutputBitDepth16, 16, true, false},
+		{"16-bit from an unmeasured source is dithered", OutputBitDepth16, 0, true, false},
+		{"24-bit from a 16-bit source warns", OutputBitDepth24, 16, false, true},
+		{"unmeasured source depth", OutputBitDepth24, 0, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := DefaultFilterConfig()
+			if err := base.SetOutputBitDepth(tt.outputBits); err != nil {
+				t.Fatal(err)
+			}
+			m := &AudioMeasurements{Dynamics: DynamicsMetrics{BitDepth: tt.sourceBits}}
+			config, diag := AdaptConfig(base, m)
+			if config.Resample.Dither != tt.wantDither || diag.OutputDither != tt.wantDither {
+				t.Errorf("dither = %v (diagnostics %v), want %v", config.Resample.Dither, diag.OutputDither, tt.wantDither)
+			}
+			if got := len(diag.Warnings) > 0; got != tt.wantWarn {
+				t.Errorf("warned = %v, want %v (%q)", got, tt.wantWarn, diag.Warnings)
+			}
+		})
+	}
+}
+
+func TestTuneOutputFormatRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputRate string
+		sourceRate int
+		wantWarn   bool
+	}{
+		{"96 kHz from a 48 kHz source warns", "96000", 48000, true},
+		{"44.1 kHz from a 48 kHz source", "44100", 48000, false},
+		{"same rate", OutputRateSame, 44100, false},
+		{"unmeasured source rate", "96000", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := DefaultFilterConfig()
+			if err := base.SetOutputRate(tt.outputRate); err != nil {
+				t.Fatal(err)
+			}
+			m := &AudioMeasurements{SampleRate: tt.sourceRate, Dynamics: DynamicsMetrics{BitDepth: 16}}
+			_, diag := AdaptConfig(base, m)
+			if got := len(diag.Warnings) > 0; got != tt.wantWarn {
+				t.Errorf("warned = %v, want %v (%q)", got, tt.wantWarn, diag.Warnings)
+			}
+		})
+	}
+}
+
+func TestTuneComfortNoise(t *testing.T) {
+	t.Run("level and corner follow the room tone", func(t *testing.T) {
+		config := newTestConfig()
+		config.SpeechGate.Enabled = true
+		config.SpeechGate.Threshold = DbToLinear(-45)
+		diag := &AdaptiveDiagnostics{}
+		tuneComfortNoise(config, diag, &AudioMeasurements{Regions: RegionMetrics{
+			NoiseProfile: &NoiseProfile{
+				MeasuredNoiseFloor: -60,
+				Spectral:           SpectralMetrics{Centroid: 2500},
+			},
+		}})
+
+		if !config.SpeechGate.ComfortNoiseEnabled || !diag.ComfortNoise {
+			t.Fatalf("comfort noise not enabled (config %v, diagnostics %v)", config.SpeechGate.ComfortNoiseEnabled, diag.ComfortNoise)
+		}
+		// 20 dB under the -60 dB floor, which is also under the -45 dB gate minus 20.
+		if math.Abs(config.SpeechGate.ComfortNoiseLevelDB-(-80)) > 1e-9 {
+			t.Errorf("level = %.2f dB, want -80", config.SpeechGate.ComfortNoiseLevelDB)
+		}
+		if config.SpeechGate.ComfortNoiseCornerHz != 2500 {
+			t.Errorf("corner = %.0f Hz, want the 2500 Hz centroid", config.SpeechGate.ComfortNoiseCornerHz)
+		}
+	})
+
+	t.Run("level stays under a low gate threshold", func(t *testing.T) {
+		config := newTestConfig()
+		config.SpeechGate.Enabled = true
+		config.SpeechGate.Threshold = DbToLinear(-70)
+		diag := &AdaptiveDiagnostics{}
+		tuneComfortNoise(config, diag, &AudioMeasurements{Regions: RegionMetrics{
+			NoiseProfile: &NoiseProfile{
+				MeasuredNoiseFloor: -55,
+				Spectral:           SpectralMetrics{Centroid: 50},
+			},
+		}})
+
+		if math.Abs(config.SpeechGate.ComfortNoiseLevelDB-(-90)) > 1e-6 {
+			t.Errorf("level = %.2f dB, want -90 (gate -70 minus 20)", config.SpeechGate.ComfortNoiseLevelDB)
+		}
+		if config.SpeechGate.ComfortNoiseCornerHz != comfortNoiseMinCornerHz {
+			t.Errorf("corner = %.0f Hz, want clamped to %.0f", config.SpeechGate.ComfortNoiseCornerHz, comfortNoiseMinCornerHz)
+		}
+	})
+
+	t.Run("no room tone profile warns and skips", func(t *testing.T) {
+		config := newTestConfig()
+		config.SpeechGate.Enabled = true
+		diag := &AdaptiveDiagnostics{}
+		tuneComfortNoise(config, diag, &AudioMeasurements{})
+
+		if config.SpeechGate.ComfortNoiseEnabled || diag.ComfortNoise {
+			t.Error("comfort noise enabled without a noise profile")
+		}
+		if len(diag.Warnings) != 1 || !strings.Contains(diag.Warnings[0], "comfort noise") {
+			t.Errorf("warnings = %q, want one comfort noise warning", diag.Warnings)
+		}
+	})
+
+	t.Run("off unless requested", func(t *testing.T) {
+		m := &AudioMeasurements{Regions: RegionMetrics{NoiseProfile: &NoiseProfile{MeasuredNoiseFloor: -60}}}
+		config, diag := AdaptConfig(DefaultFilterConfig(), m)
+		if config.SpeechGate.ComfortNoiseEnabled || diag.ComfortNoise {
+			t.Error("comfort noise enabled without --comfort-noise")
+		}
+	})
+}
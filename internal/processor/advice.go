@@ -3,6 +3,7 @@ package processor
 import (
 	"fmt"
 	"math"
+	"strings"
 )
 
 // GainAdviceKind is the single outcome of input-gain advice. The advice keys off
@@ -109,3 +110,70 @@ func (r GainAdviceResult) Message() string {
 		return fmt.Sprintf("Level well set. Peaks at %+.1f ㏈TP. No action required.", r.InputTP)
 	}
 }
+
+// gainAdviceWidePLR is the input peak-to-loudness ratio (true peak minus
+// integrated loudness) above which the voice sits far back against its own
+// transients. Close-miked speech usually reads 12 to 18 dB; a distant mic
+// picks up more room and less voice, widening the gap. Moving closer lifts the
+// voice without lifting the peaks, which gain alone cannot do.
+const gainAdviceWidePLR = 20.0
+
+// gainAdviceKindNames are the run-record names of the GainAdviceKind values.
+var gainAdviceKindNames = map[GainAdviceKind]string{
+	GainFine:     "fine",
+	GainQuiet:    "quiet",
+	GainHot:      "hot",
+	GainClipping: "clipping",
+}
+
+// InputGainAdvice is the run-record form of GainAdvice for the Markdown report:
+// the peak-only gain verdict, the peak-to-loudness ratio beside it, and one
+// coaching sentence. The gain change still keys off the true peak alone; the
+// loudness only adds the mic-distance hint.
+type InputGainAdvice struct {
+	Kind         string  `json:"kind"`
+	InputTP      float64 `json:"input_true_peak_dbtp"`
+	InputI       float64 `json:"input_integrated_lufs"`
+	PLR          float64 `json:"peak_to_loudness_ratio_db"`
+	GainChangeDB float64 `json:"gain_change_db"` // signed: negative lowers, positive raises
+	SpeakCloser  bool    `json:"speak_closer"`
+	Advice       string  `json:"advice"`
+}
+
+// newInputGainAdvice derives the report advice from the Pass 1 input true peak
+// and integrated loudness. Returns nil when either was not measured (a zero
+// loudness is unmeasured, as in speechTargetShift).
+func newInputGainAdvice(m *AudioMeasurements) *InputGainAdvice {
+	if m == nil || !isFinite(m.Loudness.InputTP) || !isFinite(m.Loudness.InputI) ||
+		m.Loudness.InputI == 0 || m.Loudness.InputI <= loudnessAbsoluteGateLUFS {
+		return nil
+	}
+	gain := GainAdvice(m.Loudness.InputTP)
+	a := &InputGainAdvice{
+		Kind:         gainAdviceKindNames[gain.Kind],
+		InputTP:      m.Loudness.InputTP,
+		InputI:       m.Loudness.InputI,
+		PLR:          m.Loudness.InputTP - m.Loudness.InputI,
+		GainChangeDB: gain.DeltaDB,
+	}
+	a.SpeakCloser = a.PLR > gainAdviceWidePLR
+
+	var steps []string
+	switch {
+	case gain.DeltaDB < 0:
+		steps = append(steps, fmt.Sprintf("lowering your input gain about %.0f dB", -gain.DeltaDB))
+	case gain.DeltaDB > 0:
+		steps = append(steps, fmt.Sprintf("raising your input gain about %.0f dB", gain.DeltaDB))
+	}
+	if a.SpeakCloser {
+		steps = append(steps, "speaking closer to the mic")
+	}
+
+	levels := fmt.Sprintf("Your recording peaked at %.1f dBTP with %.1f LUFS integrated", a.InputTP, a.InputI)
+	if len(steps) == 0 {
+		a.Advice = levels + ": the input gain is well set."
+	} else {
+		a.Advice = levels + ": consider " + strings.Join(steps, " and ") + "."
+	}
+	return a
+}
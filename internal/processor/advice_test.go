@@ -180,3 +180,37 @@ func TestGainAdviceDeltaIsRounded(t *testing.T) {
 		}
 	}
 }
+
+func TestNewInputGainAdvice(t *testing.T) {
+	measure := func(tp, i float64) *AudioMeasurements {
+		m := &AudioMeasurements{}
+		m.Loudness.InputTP = tp
+		m.Loudness.InputI = i
+		return m
+	}
+
+	a := newInputGainAdvice(measure(-0.5, -28.5))
+	if a == nil {
+		t.Fatal("no advice for a hot, distant capture")
+	}
+	if a.Kind != "hot" || a.GainChangeDB != -6 || !a.SpeakCloser || a.PLR != 28 {
+		t.Errorf("advice = %+v, want hot, -6 dB, speak closer, PLR 28", *a)
+	}
+	want := "Your recording peaked at -0.5 dBTP with -28.5 LUFS integrated: consider lowering your input gain about 6 dB and speaking closer to the mic."
+	if a.Advice != want {
+		t.Errorf("Advice = %q, want %q", a.Advice, want)
+	}
+
+	// Healthy peaks and a close-miked ratio need nothing.
+	if a := newInputGainAdvice(measure(-6.0, -21.0)); a == nil || a.Kind != "fine" || !strings.HasSuffix(a.Advice, "the input gain is well set.") {
+		t.Errorf("well-set advice = %+v", a)
+	}
+	// A high-crest capture with healthy peaks is not told to turn up, only to move closer.
+	if a := newInputGainAdvice(measure(-6.0, -30.0)); a == nil || a.GainChangeDB != 0 || !a.SpeakCloser {
+		t.Errorf("high-crest advice = %+v, want no gain change and speak closer", a)
+	}
+
+	if newInputGainAdvice(nil) != nil || newInputGainAdvice(measure(-6.0, 0)) != nil {
+		t.Error("advice without a measured input loudness")
+	}
+}
@@ -70,10 +70,21 @@ type NoiseProfile struct {
 	BandNoise     []float64 `json:"band_noise_dbfs,omitempty"`     // Per-band RMS (dBFS) across the afftdn fixed bands
 	BandsMeasured bool      `json:"band_noise_measured,omitempty"` // True only when all afftdn bands measured successfully
 
+	// Mains hum reading (measureMainsHum): the power-summed narrow-band RMS
+	// (dBFS) at 50/100/150 Hz and at 60/120/180 Hz over the room-tone region.
+	// HumMeasured is true only when all six tones measured; --mains=auto reads
+	// them through MainsHumHz.
+	Hum50RMS    float64 `json:"hum_50hz_dbfs,omitempty"`
+	Hum60RMS    float64 `json:"hum_60hz_dbfs,omitempty"`
+	HumMeasured bool    `json:"hum_measured,omitempty"`
+
 	// Golden sub-region refinement info (populated when a long candidate is refined)
 	OriginalStart    time.Duration `json:"original_start,omitempty"`    // Original candidate start before refinement (time.Duration ns)
 	OriginalDuration time.Duration `json:"original_duration,omitempty"` // Original candidate duration before refinement (time.Duration ns)
 	WasRefined       bool          `json:"was_refined,omitempty"`       // True if region was refined from a longer candidate
+
+	Pinned bool `json:"pinned,omitempty"` // True if the region was pinned by --noise-region rather than elected
+	Loaded bool `json:"loaded,omitempty"` // True if the profile was loaded by --load-noise-profile; it has no region in this file
 }
 
 // RegionSample holds the bare per-region measurement subset shared by the room
@@ -125,6 +136,13 @@ type SpeechCandidateMetrics struct {
 	SibBandRMS    float64 `json:"speech_band_sib_rms_dbfs,omitempty"`  // dBFS, 6-9 kHz RMS over the speech region
 	BandsMeasured bool    `json:"speech_bands_measured,omitempty"`     // True only when both body and sibilant bands measured successfully
 
+	// Loudest 50 ms window RMS of each band over the speech region. A dark
+	// voice with occasional sharp "s" sounds reads quiet on the averages above
+	// but not here; the de-esser also engages on their excess.
+	BodyBandPeakRMS   float64 `json:"speech_band_body_peak_rms_dbfs,omitempty"` // dBFS, loudest 1-3 kHz window
+	SibBandPeakRMS    float64 `json:"speech_band_sib_peak_rms_dbfs,omitempty"`  // dBFS, loudest 6-9 kHz window
+	BandPeaksMeasured bool    `json:"speech_band_peaks_measured,omitempty"`     // True only when both band peaks measured
+
 	// Scoring
 	Score float64 `json:"score"` // Composite score for candidate ranking
 
@@ -149,11 +167,12 @@ type LoudnessMetrics struct {
 type InputLoudnessMetrics struct {
 	LoudnessMetrics // shared windowed loudness, promoted flat into this stage block
 
-	InputI       float64 `json:"integrated_lufs"`  // Integrated loudness (LUFS)
-	InputTP      float64 `json:"true_peak_dbtp"`   // True peak (dBTP)
-	InputLRA     float64 `json:"lra_lu"`           // Loudness range (LU)
-	InputThresh  float64 `json:"thresh_lufs"`      // Threshold level (LUFS)
-	TargetOffset float64 `json:"target_offset_db"` // Offset for normalization (config.TargetI - InputI)
+	InputI       float64 `json:"integrated_lufs"`        // Integrated loudness (LUFS)
+	InputTP      float64 `json:"true_peak_dbtp"`         // True peak (dBTP)
+	InputLRA     float64 `json:"lra_lu"`                 // Loudness range (LU)
+	InputThresh  float64 `json:"thresh_lufs"`            // Threshold level (LUFS)
+	TargetOffset float64 `json:"target_offset_db"`       // Offset for normalization (config.TargetI - InputI)
+	SpeechI      float64 `json:"speech_integrated_lufs"` // Integrated loudness over the speech regions only (LUFS); 0 when not measured
 }
 
 // DynamicsMetrics holds the astats time-domain measurements shared by the input
@@ -190,13 +209,21 @@ type DynamicsMetrics struct {
 // the noise-reduction headroom.
 type NoiseMetrics struct {
 	Floor               float64 `json:"floor_dbfs"`                  // Elected noise floor; under the VAD it is the momentary-LUFS p10 (vad_percentile source), so the value is on the momentary-LUFS axis
-	FloorSource         string  `json:"floor_source"`                // Source of Floor: "astats" / "rms_estimate" / "ebur128_estimate" / "vad_percentile"
+	FloorSource         string  `json:"floor_source"`                // Source of Floor: "astats" / "rms_estimate" / "ebur128_estimate" / "vad_percentile" / "agc_troughs" / "user" / "loaded"
 	FloorPrescan        float64 `json:"floor_prescan_dbfs"`          // Pre-scan noise floor seed estimated from interval data, on the momentary-LUFS axis (anchors the VAD split clamp)
 	FloorAstats         float64 `json:"floor_astats_dbfs"`           // FFmpeg astats noise floor estimate (dBFS)
 	RoomToneDetectLevel float64 `json:"room_tone_detect_level_dbfs"` // Adaptive room tone detection threshold, derived from the momentary-LUFS-axis seed
 	VoiceActivated      bool    `json:"voice_activated"`             // True when the floored (digital-silence) interval fraction is high (platform-gated capture signature)
 	FlooredFraction     float64 `json:"floored_fraction"`            // Fraction (0..1) of intervals at the digital-silence floor; the detection margin behind VoiceActivated (>= vadVoiceActivatedFraction)
 	ReductionHeadroom   float64 `json:"reduction_headroom_db"`       // dB gap between noise and quiet speech
+
+	RumbleBurstCount      int     `json:"rumble_burst_count"`       // Short low-frequency bursts (wind buffeting, handling thumps); see countRumbleBursts
+	RumbleBurstsPerMinute float64 `json:"rumble_bursts_per_minute"` // RumbleBurstCount per minute of audio
+
+	AGCRiseDB   float64 `json:"agc_rise_db"`  // Median level rise across long pauses (end minus start); see measureAGCRise
+	AGCDetected bool    `json:"agc_detected"` // True when the rise reads as source AGC pumping; Floor is then read from mid-speech troughs
+
+	SpeechDecayMS float64 `json:"speech_decay_ms"` // Median reverb tail after phrase ends (ms); see measureSpeechDecay. Zero when unmeasured
 }
 
 // RegionMetrics is the input-only regions domain block (8.1). It holds the
@@ -216,6 +243,14 @@ type RegionMetrics struct {
 	NoiseHighPercentile float64 `json:"noise_high_percentile_dbfs"` // Noise high percentile (p95) over below-split intervals (dBFS-relative momentary LUFS)
 	GateSeparationDB    float64 `json:"gate_separation_db"`         // Separation between VoicedLowPercentile and NoiseHighPercentile (dB)
 
+	// Pauses summarises the gaps between speech intervals across the whole file
+	// (speech rhythm, not room-tone profiling); nil when no gap exists.
+	Pauses *PauseStatistics `json:"pauses,omitempty"`
+
+	// Polarity is the speech polarity reading (analysePolarity); nil when the
+	// file has too little speech to judge.
+	Polarity *PolarityAnalysis `json:"polarity,omitempty"`
+
 	// ElectedRoomToneSample is the RegionSample measured from the elected room-tone
 	// (low-cluster) region. NoiseProfile is a slimmer struct without a RegionSample,
 	// so the record cannot reach the elected region's bare amplitude/spectral/loudness
@@ -243,9 +278,17 @@ type AudioMeasurements struct {
 	Noise    NoiseMetrics         `json:"noise"`
 	Regions  RegionMetrics        `json:"regions"`
 
+	// Clipping describes a clipped input (detectClipping); nil when the input
+	// is not clipped.
+	Clipping *ClippingAnalysis `json:"clipping,omitempty"`
+
 	// Duration is the total audio length in seconds, captured at file open. It is
 	// in-memory UI plumbing only and excluded from the report JSON contract.
 	Duration float64 `json:"-"`
+
+	// SampleRate is the source sample rate in Hz, captured at file open. Like
+	// Duration it is in-memory only; the record carries it as run provenance.
+	SampleRate int `json:"-"`
 }
 
 // OutputLoudnessMetrics is the Filtered/Final-stage loudness domain block: the
@@ -259,11 +302,12 @@ type AudioMeasurements struct {
 type OutputLoudnessMetrics struct {
 	LoudnessMetrics // shared windowed loudness, promoted flat into this stage block
 
-	OutputI      float64 `json:"integrated_lufs"`  // Integrated loudness (LUFS)
-	OutputTP     float64 `json:"true_peak_dbtp"`   // True peak (dBTP)
-	OutputLRA    float64 `json:"lra_lu"`           // Loudness range (LU)
-	OutputThresh float64 `json:"thresh_lufs"`      // Gating threshold (LUFS) - for loudnorm
-	TargetOffset float64 `json:"target_offset_db"` // Pre-limiter offset (dB) - from loudnorm measurement
+	OutputI      float64 `json:"integrated_lufs"`        // Integrated loudness (LUFS)
+	OutputTP     float64 `json:"true_peak_dbtp"`         // True peak (dBTP)
+	OutputLRA    float64 `json:"lra_lu"`                 // Loudness range (LU)
+	OutputThresh float64 `json:"thresh_lufs"`            // Gating threshold (LUFS) - for loudnorm
+	TargetOffset float64 `json:"target_offset_db"`       // Pre-limiter offset (dB) - from loudnorm measurement
+	SpeechI      float64 `json:"speech_integrated_lufs"` // Integrated loudness over the Pass 1 speech regions only (LUFS); 0 when not measured
 }
 
 // OutputLoudnormMeasurement groups the loudnorm first-pass (measurement mode)
@@ -334,16 +378,25 @@ func AnalyseAudio(ctx stdcontext.Context, filename string, config *BaseFilterCon
 	// anchors the split clamp; the hop and axis are the single configurable choices.
 	// It must finish before either band function runs, because it elects the
 	// speech and room-tone regions that both band functions go on to measure.
-	detectVoiceActivity(measurements, intervals, measurements.Noise.FloorPrescan, analysisIntervalHop, axisMomentaryLUFS, config.logger)
+	pinned, err := pinnedRoomToneRegion(config.NoiseRegion, intervals, time.Duration(measurements.Duration*float64(time.Second)))
+	if err != nil {
+		return nil, err
+	}
+	detectVoiceActivity(measurements, intervals, measurements.Noise.FloorPrescan, analysisIntervalHop, axisMomentaryLUFS, config.noiseWindow(), pinned, config.roomToneSelector, config.logger)
+	applyNoiseFloorOverride(measurements, intervals, config.NoiseFloorDB, analysisIntervalHop, axisMomentaryLUFS, config.logger)
+	applyLoadedNoiseProfile(measurements, intervals, config.NoiseProfileIn, analysisIntervalHop, axisMomentaryLUFS, config.logger)
+
+	// Speech-only integrated loudness, reported beside the gated figure.
+	measurements.Loudness.SpeechI, _ = speechOnlyLoudness(intervals, measurements.Regions.SpeechRegions)
 
 	// Post-loop band phase: the main decode loop is capped at BandPhaseProgressStart
-	// (0.95); the two band functions drive 0.95..1.0 by reporting each completed
+	// (0.95); the band functions drive 0.95..1.0 by reporting each completed
 	// band decode through one shared tracker (atomic counter, monotonic, clamped to
-	// 1.0). The total is the combined speech + noise band budget, so a band function
-	// that early-returns still drains its share via drainBandProgress and the phase
-	// reaches 1.0. The functions run sequentially (speech then noise) but each fans
+	// 1.0). The total is the combined speech + noise + hum band budget, so a band
+	// function that early-returns still drains its share via drainBandProgress and
+	// the phase reaches 1.0. The functions run sequentially (speech, noise, hum) but each fans
 	// its own bands across cores under the shared semaphore.
-	bandTotal := len(speechBandPlan) + len(afftdnBandCentresHz)
+	bandTotal := len(speechBandPlan) + len(afftdnBandCentresHz) + len(humTonePlan)
 	tracker := newBandProgressTracker(progressCallback, measurements.Duration, bandTotal)
 
 	// Measure body/sibilant band RMS over the elected speech region for the
@@ -356,6 +409,10 @@ func AnalyseAudio(ctx stdcontext.Context, filename string, config *BaseFilterCon
 	// white-noise afftdn path stands in when bands are unavailable).
 	measureNoiseBands(ctx, filename, measurements, tracker.report, config.logger)
 
+	// Read the 50 Hz and 60 Hz mains hum sets over the same room-tone region for
+	// --mains=auto. Measured on every run so the record always carries it.
+	measureMainsHum(ctx, filename, measurements, tracker.report, config.logger)
+
 	assignInputMeasurementSuggestions(measurements)
 
 	return measurements, nil
@@ -379,7 +436,8 @@ func buildInputMeasurements(filename string, collection *analysisFrameCollection
 	}
 
 	measurements := &AudioMeasurements{
-		Duration: collection.totalDuration,
+		Duration:   collection.totalDuration,
+		SampleRate: collection.sampleRate,
 	}
 	measurements.Noise.FloorPrescan = noiseFloorEstimate
 	measurements.Noise.RoomToneDetectLevel = silenceThreshold
@@ -401,6 +459,9 @@ func buildInputMeasurements(filename string, collection *analysisFrameCollection
 	measurements.Spectral = acc.finalizeSpectral()
 	assignAstatsMeasurements(measurements, acc)
 	assignInputNoiseFloor(measurements, acc)
+	if acc.astatsFound {
+		measurements.Clipping = detectClipping(measurements.Dynamics, acc.astatsPeakCount, collection.intervals, analysisIntervalHop)
+	}
 
 	return measurements, nil
 }
@@ -533,6 +594,7 @@ type analysisFrameCollection struct {
 	silenceIntervals []IntervalSample
 	silenceMedians   silenceMedians
 	totalDuration    float64 // total audio length, seconds (from input metadata)
+	sampleRate       int     // source sample rate, Hz (from input metadata)
 }
 
 func collectAnalysisFrames(ctx stdcontext.Context, filename string, config *BaseFilterConfig, pass PassNumber, progressCallback ProgressCallback) (*analysisFrameCollection, error) {
@@ -542,10 +604,14 @@ func collectAnalysisFrames(ctx stdcontext.Context, filename string, config *Base
 	}
 	defer reader.Close()
 
+	// Progress is the share of the input's samples read, so it holds for any
+	// codec's frame size.
 	totalDuration := metadata.Duration
-	sampleRate := float64(metadata.SampleRate)
-	samplesPerFrame := 4096.0
-	estimatedTotalFrames := (totalDuration * sampleRate) / samplesPerFrame
+	totalSamples := int64(totalDuration * float64(metadata.SampleRate))
+
+	if segments := planAnalysisSegments(time.Duration(totalDuration*float64(time.Second)), config.AnalysisSegments); len(segments) > 1 {
+		return collectSegmentedAnalysisFrames(ctx, filename, config, pass, segments, metadata, progressCallback)
+	}
 
 	filterGraph, bufferSrcCtx, bufferSinkCtx, err := createAnalysisFilterGraph(
 		reader.DecoderContext(),
@@ -568,9 +634,7 @@ func collectAnalysisFrames(ctx stdcontext.Context, filename string, config *Base
 
 	acc := &metadataAccumulators{}
 
-	var intervals []IntervalSample
-	var intervalAcc intervalAccumulator
-	var intervalStartTime time.Duration
+	var series intervalSeries
 
 	var inputSamplesProcessed int64
 	inputSampleRate := float64(reader.DecoderContext().SampleRate())
@@ -590,21 +654,14 @@ func collectAnalysisFrames(ctx stdcontext.Context, filename string, config *Base
 
 			inputFrameTime := time.Duration(float64(inputSamplesProcessed) / inputSampleRate * float64(time.Second))
 			inputSamplesProcessed += int64(inputFrame.NbSamples())
-			intervalAcc.addFrameRMSAndPeak(inputFrame)
+			series.addInputFrame(inputFrame, inputFrameTime)
 
-			if inputFrameTime-intervalStartTime >= analysisIntervalHop {
-				finalised := intervalAcc.finalize(intervalStartTime)
-				intervals = append(intervals, finalised)
-				intervalStartTime = inputFrameTime
-				intervalAcc.reset()
-			}
-
-			if frameCount%updateInterval == 0 && progressCallback != nil && estimatedTotalFrames > 0 {
+			if frameCount%updateInterval == 0 && progressCallback != nil && totalSamples > 0 {
 				// Cap the main-decode-loop progress at BandPhaseProgressStart;
 				// the post-loop band phase drives the remaining span to 1.0. Scale
-				// the frame ratio by the cap, still clamped, so the bar advances
+				// the sample ratio by the cap, still clamped, so the bar advances
 				// smoothly into the band phase instead of hitting 1.0 then freezing.
-				progress := (float64(frameCount) / estimatedTotalFrames) * BandPhaseProgressStart
+				progress := (float64(inputSamplesProcessed) / float64(totalSamples)) * BandPhaseProgressStart
 				if progress > BandPhaseProgressStart {
 					progress = BandPhaseProgressStart
 				}
@@ -624,7 +681,7 @@ func collectAnalysisFrames(ctx stdcontext.Context, filename string, config *Base
 			loudness := extractFrameLoudnessMetrics(metadata)
 
 			extractFrameMetadata(metadata, acc, spectral, loudness)
-			intervalAcc.add(extractIntervalFrameMetrics(spectral, loudness))
+			series.addFiltered(extractIntervalFrameMetrics(spectral, loudness))
 
 			return nil
 		},
@@ -632,21 +689,26 @@ func collectAnalysisFrames(ctx stdcontext.Context, filename string, config *Base
 		return nil, err
 	}
 
-	if intervalAcc.rawSampleCount > 0 {
-		finalised := intervalAcc.finalize(intervalStartTime)
-		intervals = append(intervals, finalised)
-	}
+	intervals := series.finish()
 
 	ffmpeg.AVFilterGraphFree(&filterGraph)
 	filterFreed = true
 
+	return newAnalysisFrameCollection(acc, intervals, totalDuration, metadata.SampleRate), nil
+}
+
+// newAnalysisFrameCollection wraps the accumulated Pass 1 frames with the
+// room-tone seed search over their intervals.
+func newAnalysisFrameCollection(acc *metadataAccumulators, intervals []IntervalSample, totalDuration float64, sampleRate int) *analysisFrameCollection {
+	silenceIntervals := seedSearchIntervals(intervals)
 	return &analysisFrameCollection{
 		accumulators:     acc,
 		intervals:        intervals,
-		silenceIntervals: intervals,
-		silenceMedians:   computeSilenceMedians(intervals),
+		silenceIntervals: silenceIntervals,
+		silenceMedians:   computeSilenceMedians(silenceIntervals),
 		totalDuration:    totalDuration,
-	}, nil
+		sampleRate:       sampleRate,
+	}
 }
 
 // createAnalysisFilterGraph creates an AVFilterGraph for Pass 1 analysis.
@@ -0,0 +1,111 @@
+package processor
+
+import (
+	"math"
+	"slices"
+)
+
+// Automatic gain control (AGC) baked into the source. Phones and some recorders
+// raise their gain when the talker stops and lower it when speech returns, so
+// the background noise swells through every pause and sinks under speech. A
+// gap-based noise floor then measures the pumped-up noise, not the noise the
+// listener hears under the voice. Pass 1 looks for the swell: across long
+// pauses the level climbs from the start of the gap to its end.
+const (
+	// agcMinGapIntervals is the shortest pause examined for a swell (2 s at the
+	// 250 ms hop). A shorter pause leaves no room between the edges below.
+	agcMinGapIntervals = 8
+
+	// agcEdgeIntervals is how many intervals each end of a gap is averaged over.
+	// One boundary interval is skipped before each edge: the first holds the
+	// speech decay, the last can hold a breath before the next phrase.
+	agcEdgeIntervals = 2
+
+	// agcMinGaps is the number of long pauses needed before a swell is trusted.
+	agcMinGaps = 3
+
+	// agcMinRiseDB is the median end-minus-start rise across long pauses that
+	// flags AGC. Steady room tone stays within a dB or two; a decaying reverb
+	// tail falls rather than rises.
+	agcMinRiseDB = 4.0
+
+	// agcTroughMaxIntervals is the longest pause treated as a mid-speech trough
+	// (1 s). AGC reacts over seconds, so the noise in a pause this short still
+	// sits at the under-speech gain.
+	agcTroughMaxIntervals = 4
+)
+
+// measureAGCRise returns the median level rise (dB) across the long interior
+// pauses and whether it reads as AGC pumping: at least agcMinGaps pauses of
+// agcMinGapIntervals or more, with a median rise of at least agcMinRiseDB.
+// flags are the per-interval speech flags; levels are read on the VAD axis.
+func measureAGCRise(intervals []IntervalSample, flags []bool, axis levelAxis) (riseDB float64, detected bool) {
+	var rises []float64
+	for _, g := range interiorGaps(flags) {
+		if g.length < agcMinGapIntervals {
+			continue
+		}
+		head := meanIntervalLevel(intervals[g.start+1:g.start+1+agcEdgeIntervals], axis)
+		tailEnd := g.start + g.length - 1
+		tail := meanIntervalLevel(intervals[tailEnd-agcEdgeIntervals:tailEnd], axis)
+		// A gated pause sits at digital silence; there is no noise to swell.
+		if isFlooredLevel(head) || isFlooredLevel(tail) {
+			continue
+		}
+		rises = append(rises, tail-head)
+	}
+	if len(rises) == 0 {
+		return 0, false
+	}
+
+	slices.Sort(rises)
+	riseDB = percentileOfSorted(rises, 50)
+	return riseDB, len(rises) >= agcMinGaps && riseDB >= agcMinRiseDB
+}
+
+// agcTroughFloor returns the noise floor read from the mid-speech troughs: the
+// median, across interior pauses of at most agcTroughMaxIntervals, of each
+// pause's quietest interval. ok is false when there are no such pauses.
+func agcTroughFloor(intervals []IntervalSample, flags []bool, axis levelAxis) (floor float64, ok bool) {
+	var troughs []float64
+	for _, g := range interiorGaps(flags) {
+		if g.length > agcTroughMaxIntervals {
+			continue
+		}
+		quietest := 0.0
+		found := false
+		for _, iv := range intervals[g.start : g.start+g.length] {
+			level := intervalLevel(iv, axis)
+			if isFlooredLevel(level) {
+				continue
+			}
+			if !found || level < quietest {
+				quietest = level
+				found = true
+			}
+		}
+		if found {
+			troughs = append(troughs, quietest)
+		}
+	}
+	if len(troughs) == 0 {
+		return 0, false
+	}
+
+	slices.Sort(troughs)
+	return percentileOfSorted(troughs, 50), true
+}
+
+// meanIntervalLevel averages the interval levels on axis. Any floored interval
+// makes the mean NaN, which isFlooredLevel reports.
+func meanIntervalLevel(intervals []IntervalSample, axis levelAxis) float64 {
+	var sum float64
+	for _, iv := range intervals {
+		level := intervalLevel(iv, axis)
+		if isFlooredLevel(level) {
+			return math.NaN()
+		}
+		sum += level
+	}
+	return sum / float64(len(intervals))
+}
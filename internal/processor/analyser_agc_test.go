@@ -0,0 +1,100 @@
+package processor
+
+import (
+	"math"
+	"testing"
+)
+
+// agcFixture builds a timeline of speech phrases separated by 3 s pauses whose
+// level climbs by swellDB from start to end, with a 0.5 s mid-speech trough at
+// -66 inside every phrase. Returns the intervals and their speech flags.
+func agcFixture(swellDB float64) ([]IntervalSample, []bool) {
+	var iv []IntervalSample
+	var flags []bool
+	add := func(s IntervalSample, speech bool) {
+		iv = append(iv, s)
+		flags = append(flags, speech)
+	}
+	phrase := func() {
+		for range 4 {
+			add(vadSpeechRich(len(iv)), true)
+		}
+		for range 2 {
+			add(vadInterval(len(iv), -66), false)
+		}
+		for range 4 {
+			add(vadSpeechRich(len(iv)), true)
+		}
+	}
+
+	const pause = 12
+	for range 4 {
+		phrase()
+		for i := range pause {
+			level := -60 + swellDB*float64(i)/float64(pause-1)
+			add(vadInterval(len(iv), level), false)
+		}
+	}
+	phrase()
+	return iv, flags
+}
+
+func TestMeasureAGCRise(t *testing.T) {
+	t.Run("swelling pauses read as AGC", func(t *testing.T) {
+		iv, flags := agcFixture(12)
+		rise, detected := measureAGCRise(iv, flags, axisMomentaryLUFS)
+		if !detected || rise < agcMinRiseDB {
+			t.Errorf("rise=%.1f detected=%v, want AGC detected", rise, detected)
+		}
+	})
+
+	t.Run("steady room tone is not AGC", func(t *testing.T) {
+		iv, flags := agcFixture(0)
+		rise, detected := measureAGCRise(iv, flags, axisMomentaryLUFS)
+		if detected || math.Abs(rise) > 0.01 {
+			t.Errorf("rise=%.1f detected=%v, want a flat floor", rise, detected)
+		}
+	})
+
+	t.Run("too few long pauses", func(t *testing.T) {
+		iv, flags := agcFixture(12)
+		// Fill all but two pauses with speech.
+		n := 0
+		for i := range flags {
+			if !flags[i] && iv[i].MomentaryLUFS != -66 {
+				n++
+				if n <= 2*12 {
+					flags[i] = true
+				}
+			}
+		}
+		if _, detected := measureAGCRise(iv, flags, axisMomentaryLUFS); detected {
+			t.Error("detected AGC on two pauses, want at least agcMinGaps")
+		}
+	})
+}
+
+func TestAGCTroughFloor(t *testing.T) {
+	iv, flags := agcFixture(12)
+	floor, ok := agcTroughFloor(iv, flags, axisMomentaryLUFS)
+	if !ok || floor != -66 {
+		t.Errorf("trough floor = %.1f (ok=%v), want -66 from the mid-speech troughs", floor, ok)
+	}
+
+	if _, ok := agcTroughFloor(iv, make([]bool, len(iv)), axisMomentaryLUFS); ok {
+		t.Error("trough floor found without speech, want ok=false")
+	}
+}
+
+func TestWarnAGCPumping(t *testing.T) {
+	diag := &AdaptiveDiagnostics{}
+	warnAGCPumping(diag, &AudioMeasurements{Noise: NoiseMetrics{AGCRiseDB: 6}})
+	if len(diag.Warnings) != 0 {
+		t.Errorf("warnings = %v, want none without AGC", diag.Warnings)
+	}
+
+	warnAGCPumping(diag, &AudioMeasurements{Noise: NoiseMetrics{AGCRiseDB: 6, AGCDetected: true}})
+	if len(diag.Warnings) != 1 {
+		t.Errorf("warnings = %v, want one AGC warning", diag.Warnings)
+	}
+}
@@ -41,11 +41,28 @@ const speechBandAnalysisFilterFormat = "aformat=channel_layouts=mono,atrim=start
 //
 // log sinks the non-fatal region-seek warning.
 func measureSpeechBandRMS(ctx context.Context, reader *audio.Reader, start, duration time.Duration, lowHz, highHz float64, log debugLogger) (float64, bool, error) {
+	levels, ok, err := measureSpeechBandLevels(ctx, reader, start, duration, lowHz, highHz, log)
+	return levels.rms, ok, err
+}
+
+// bandLevels is one band's levels over a region (dBFS): the Overall RMS, and
+// the RMS of its loudest astats window (RMS_peak, 50 ms), which catches short
+// bursts the region average dilutes. peakFound is false when astats reported
+// no RMS_peak.
+type bandLevels struct {
+	rms       float64
+	rmsPeak   float64
+	peakFound bool
+}
+
+// measureSpeechBandLevels is measureSpeechBandRMS that also keeps the band's
+// loudest-window RMS.
+func measureSpeechBandLevels(ctx context.Context, reader *audio.Reader, start, duration time.Duration, lowHz, highHz float64, log debugLogger) (bandLevels, bool, error) {
 	if start < 0 {
-		return 0, false, fmt.Errorf("invalid region: negative start time")
+		return bandLevels{}, false, fmt.Errorf("invalid region: negative start time")
 	}
 	if duration <= 0 {
-		return 0, false, fmt.Errorf("invalid region: non-positive duration")
+		return bandLevels{}, false, fmt.Errorf("invalid region: non-positive duration")
 	}
 
 	filterSpec := fmt.Sprintf(
@@ -55,7 +72,13 @@ func measureSpeechBandRMS(ctx context.Context, reader *audio.Reader, start, dura
 		lowHz,
 		highHz,
 	)
+	return measureRegionLevels(ctx, reader, start, filterSpec, log)
+}
 
+// measureRegionLevels runs one region-scoped band measurement graph (a spec
+// that trims to the region starting at start and ends in astats) and returns
+// the levels astats reported. ok is false when no RMS metadata was captured.
+func measureRegionLevels(ctx context.Context, reader *audio.Reader, start time.Duration, filterSpec string, log debugLogger) (bandLevels, bool, error) {
 	// Skip the pre-region span: seek the demuxer near the region before decoding
 	// rather than decoding from frame 0 and letting atrim discard everything
 	// ahead of start. The atrim window stays region-absolute, so the measured
@@ -64,19 +87,23 @@ func measureSpeechBandRMS(ctx context.Context, reader *audio.Reader, start, dura
 
 	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), filterSpec)
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to create band analysis filter graph: %w", err)
+		return bandLevels{}, false, fmt.Errorf("failed to create band analysis filter graph: %w", err)
 	}
 	defer ffmpeg.AVFilterGraphFree(&filterGraph)
 
-	var rmsLevel float64
+	var levels bandLevels
 	var rmsLevelFound bool
 
 	extract := func(_ *ffmpeg.AVFrame, filteredFrame *ffmpeg.AVFrame) error {
 		if metadata := filteredFrame.Metadata(); metadata != nil {
 			if value, ok := getFloatMetadata(metadata, metaKeyOverallRMSLevel); ok {
-				rmsLevel = value
+				levels.rms = value
 				rmsLevelFound = true
 			}
+			if value, ok := getFloatMetadata(metadata, metaKeyOverallRMSPeak); ok {
+				levels.rmsPeak = value
+				levels.peakFound = true
+			}
 		}
 		return nil
 	}
@@ -87,10 +114,10 @@ func measureSpeechBandRMS(ctx context.Context, reader *audio.Reader, start, dura
 		OnPullError: lenientHandler,
 		OnFrame:     extract,
 	}); err != nil {
-		return 0, false, err
+		return bandLevels{}, false, err
 	}
 
-	return rmsLevel, rmsLevelFound, nil
+	return levels, rmsLevelFound, nil
 }
 
 // speechBandPlan names the two speech-region bands measured in parallel. The
@@ -125,8 +152,8 @@ func measureSpeechBands(ctx context.Context, filename string, measurements *Audi
 	}
 
 	var results [len(speechBandPlan)]struct {
-		rms float64
-		ok  bool
+		levels bandLevels
+		ok     bool
 	}
 
 	runBandMeasurements(ctx, len(speechBandPlan), report, func(i int) {
@@ -138,17 +165,17 @@ func measureSpeechBands(ctx context.Context, filename string, measurements *Audi
 		defer reader.Close()
 
 		band := speechBandPlan[i]
-		rms, ok, err := measureSpeechBandRMS(ctx, reader, region.Start, region.Duration, band.lowHz, band.highHz, log)
+		levels, ok, err := measureSpeechBandLevels(ctx, reader, region.Start, region.Duration, band.lowHz, band.highHz, log)
 		if err != nil {
 			log.Logf("Warning: speech band %d RMS measurement failed: %v", i, err)
 			return
 		}
-		results[i].rms = rms
+		results[i].levels = levels
 		results[i].ok = ok
 	})
 
-	body, bodyOK := results[0].rms, results[0].ok
-	sib, sibOK := results[1].rms, results[1].ok
+	body, bodyOK := results[0].levels.rms, results[0].ok
+	sib, sibOK := results[1].levels.rms, results[1].ok
 	if bodyOK {
 		measurements.Regions.SpeechProfile.BodyBandRMS = body
 	}
@@ -161,8 +188,17 @@ func measureSpeechBands(ctx context.Context, filename string, measurements *Audi
 	// engages the de-esser at the cap (see adaptive_deesser.go).
 	measurements.Regions.SpeechProfile.BandsMeasured = bodyOK && sibOK
 
-	log.Logf("Speech band RMS: body=%.1f dBFS (found=%v), sib=%.1f dBFS (found=%v), excess=%.1f dB, measured=%v",
-		body, bodyOK, sib, sibOK, sib-body, measurements.Regions.SpeechProfile.BandsMeasured)
+	// The loudest-window levels back the intermittent-sibilance check; like the
+	// averages they only count when both bands have one.
+	if measurements.Regions.SpeechProfile.BandsMeasured && results[0].levels.peakFound && results[1].levels.peakFound {
+		measurements.Regions.SpeechProfile.BodyBandPeakRMS = results[0].levels.rmsPeak
+		measurements.Regions.SpeechProfile.SibBandPeakRMS = results[1].levels.rmsPeak
+		measurements.Regions.SpeechProfile.BandPeaksMeasured = true
+	}
+
+	log.Logf("Speech band RMS: body=%.1f dBFS (found=%v), sib=%.1f dBFS (found=%v), excess=%.1f dB, measured=%v, peak excess=%.1f dB (measured=%v)",
+		body, bodyOK, sib, sibOK, sib-body, measurements.Regions.SpeechProfile.BandsMeasured,
+		measurements.Regions.SpeechProfile.PeakSibilanceExcessDB(), measurements.Regions.SpeechProfile.BandPeaksMeasured)
 }
 
 // drainBandProgress fires report n times so an early-return band function still
@@ -157,19 +157,55 @@ func accumulateIntervalMetrics(regionIntervals []IntervalSample) intervalAccumul
 	return acc
 }
 
-// scoreIntervalWindow calculates a quality score for a contiguous window of intervals.
-// Returns average RMS level in dBFS (lower = better/quieter).
-// Could be extended to incorporate spectral stability (flux variance) if needed.
+// Room-tone window stability penalties, in dB added to the window's average
+// RMS. A chair creak or a distant voice barely moves a 10 s average but shows
+// as a flux burst and a centroid swing, so an unstable window must be this much
+// quieter to beat a steady one.
+const (
+	// roomToneFluxInstabilityDB is the penalty per unit of spectral-flux
+	// coefficient of variation (standard deviation over mean). Steady room
+	// tone sits well under 1; a single transient in the window lifts it past 1.
+	roomToneFluxInstabilityDB = 3.0
+
+	// roomToneCentroidExcursionDB is the penalty per unit of the largest
+	// centroid departure from the window's mean, relative to that mean. A
+	// creak or a voice pulls the centroid by half or more for an interval.
+	roomToneCentroidExcursionDB = 6.0
+)
+
+// scoreIntervalWindow calculates a quality score for a contiguous room-tone
+// window of intervals: the average RMS level in dBFS plus the spectral
+// instability penalties above (lower = better: quieter and steadier). A window
+// without spectral readings is scored on RMS alone.
 func scoreIntervalWindow(intervals []IntervalSample) float64 {
 	if len(intervals) == 0 {
 		return 0 // Should not happen in normal use
 	}
 
-	var sumRMS float64
+	n := float64(len(intervals))
+	var sumRMS, sumFlux, sumCentroid float64
 	for _, interval := range intervals {
 		sumRMS += interval.RMSLevel
+		sumFlux += interval.Spectral.Flux
+		sumCentroid += interval.Spectral.Centroid
+	}
+	meanFlux, meanCentroid := sumFlux/n, sumCentroid/n
+
+	var fluxSquares, centroidExcursion float64
+	for _, interval := range intervals {
+		d := interval.Spectral.Flux - meanFlux
+		fluxSquares += d * d
+		centroidExcursion = max(centroidExcursion, math.Abs(interval.Spectral.Centroid-meanCentroid))
+	}
+
+	score := sumRMS / n
+	if meanFlux > 0 {
+		score += roomToneFluxInstabilityDB * math.Sqrt(fluxSquares/n) / meanFlux
+	}
+	if meanCentroid > 0 {
+		score += roomToneCentroidExcursionDB * centroidExcursion / meanCentroid
 	}
-	return sumRMS / float64(len(intervals))
+	return score
 }
 
 // scoreSpeechIntervalWindow calculates a quality score for a contiguous window of speech intervals.
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Clipping detection. A clipped capture is flattened at full scale: the
+// waveform sits on the converter's ceiling for several samples at a time, and
+// the file's extreme level is reached over and over. Limiting it later only
+// hides the distortion, so Pass 1 flags it for a re-record instead.
+const (
+	// clipPeakDBFS is the sample level treated as full scale. Converters and
+	// recorders that clip often stop a hair short of 0 dBFS.
+	clipPeakDBFS = -0.1
+
+	// clipMinPeakSamples is how many samples must sit at the file's extreme
+	// levels (astats Peak_count) before a full-scale peak counts as clipping.
+	// A clean recording normalised to 0 dBFS touches its peak once or twice.
+	clipMinPeakSamples = 8
+
+	// clipMaxRegions caps the clipped stretches listed, worst first.
+	clipMaxRegions = 3
+)
+
+// ClippingAnalysis describes a clipped input. It is nil on a clean one.
+type ClippingAnalysis struct {
+	// PeakSamples is the number of samples at the file's extreme levels
+	// (astats Peak_count): the estimated clipped sample count.
+	PeakSamples float64 `json:"peak_samples"`
+
+	// FlatFactor is the astats flatness at the peak levels, in dB; the further
+	// below 0, the longer the runs of samples held at full scale.
+	FlatFactor float64 `json:"flat_factor"`
+
+	// ClippedIntervals counts the 250 ms analysis intervals peaking at full
+	// scale.
+	ClippedIntervals int `json:"clipped_intervals"`
+
+	// Regions are the longest runs of clipped intervals, up to clipMaxRegions,
+	// in timeline order.
+	Regions []ClippedRegion `json:"regions,omitempty"`
+}
+
+// ClippedRegion is one run of consecutive clipped intervals.
+type ClippedRegion struct {
+	Start     float64 `json:"start_s"`
+	End       float64 `json:"end_s"`
+	Intervals int     `json:"clipped_intervals"`
+}
+
+// detectClipping flags a clipped input: a peak at full scale reached by at
+// least clipMinPeakSamples samples. The clipped stretches come from the
+// interval peaks. Returns nil when the input is not clipped.
+func detectClipping(dynamics DynamicsMetrics, peakSamples float64, intervals []IntervalSample, hop time.Duration) *ClippingAnalysis {
+	if dynamics.PeakLevel < clipPeakDBFS || peakSamples < clipMinPeakSamples {
+		return nil
+	}
+
+	clipping := &ClippingAnalysis{PeakSamples: peakSamples, FlatFactor: dynamics.FlatFactor}
+	var regions []ClippedRegion
+	inRun := false
+	for _, iv := range intervals {
+		if iv.PeakLevel < clipPeakDBFS {
+			inRun = false
+			continue
+		}
+		clipping.ClippedIntervals++
+		if !inRun {
+			regions = append(regions, ClippedRegion{Start: iv.Timestamp.Seconds()})
+			inRun = true
+		}
+		run := &regions[len(regions)-1]
+		run.End = (iv.Timestamp + hop).Seconds()
+		run.Intervals++
+	}
+
+	slices.SortStableFunc(regions, func(a, b ClippedRegion) int {
+		return cmp.Compare(b.Intervals, a.Intervals)
+	})
+	if len(regions) > clipMaxRegions {
+		regions = regions[:clipMaxRegions]
+	}
+	slices.SortFunc(regions, func(a, b ClippedRegion) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+	clipping.Regions = regions
+	return clipping
+}
+
+// warnClipping reports a clipped input found in Pass 1. Nothing downstream can
+// restore the flattened peaks, so the warning asks for another take.
+func warnClipping(diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	if measurements == nil || measurements.Clipping == nil {
+		return
+	}
+	c := measurements.Clipping
+	msg := fmt.Sprintf("input appears clipped (%.0f samples at full scale, flat factor %.1f dB)", c.PeakSamples, c.FlatFactor)
+	if len(c.Regions) > 0 {
+		spans := make([]string, len(c.Regions))
+		for i, r := range c.Regions {
+			spans[i] = fmt.Sprintf("%.1f-%.1fs", r.Start, r.End)
+		}
+		msg += ", worst at " + strings.Join(spans, ", ")
+	}
+	diagnostics.Warnings = append(diagnostics.Warnings, msg+": limiting hides the distortion but cannot undo it; re-record with lower input gain")
+}
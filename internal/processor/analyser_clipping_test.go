@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// clippingIntervals builds a 20-interval timeline peaking at -6 dBFS, with
+// full-scale peaks at the given interval indices.
+func clippingIntervals(clipped ...int) []IntervalSample {
+	iv := make([]IntervalSample, 20)
+	for i := range iv {
+		iv[i] = IntervalSample{Timestamp: time.Duration(i) * analysisIntervalHop, PeakLevel: -6}
+	}
+	for _, i := range clipped {
+		iv[i].PeakLevel = 0
+	}
+	return iv
+}
+
+func TestDetectClipping(t *testing.T) {
+	full := DynamicsMetrics{PeakLevel: 0, FlatFactor: -14}
+
+	if c := detectClipping(DynamicsMetrics{PeakLevel: -1}, 500, clippingIntervals(), analysisIntervalHop); c != nil {
+		t.Errorf("peak below full scale = %+v, want nil", c)
+	}
+	if c := detectClipping(full, 2, clippingIntervals(3), analysisIntervalHop); c != nil {
+		t.Errorf("peak reached twice = %+v, want nil (a normalised clean file)", c)
+	}
+
+	// Four runs: one interval, three, two, and one. The three longest are kept,
+	// in timeline order.
+	c := detectClipping(full, 120, clippingIntervals(1, 5, 6, 7, 10, 11, 15), analysisIntervalHop)
+	if c == nil {
+		t.Fatal("clipped input not detected")
+	}
+	if c.PeakSamples != 120 || c.FlatFactor != -14 || c.ClippedIntervals != 7 {
+		t.Errorf("ClippingAnalysis = %+v, want 120 samples, flat -14, 7 intervals", c)
+	}
+	want := []ClippedRegion{{Start: 0.25, End: 0.5, Intervals: 1}, {Start: 1.25, End: 2, Intervals: 3}, {Start: 2.5, End: 3, Intervals: 2}}
+	if len(c.Regions) != len(want) {
+		t.Fatalf("Regions = %+v, want %+v", c.Regions, want)
+	}
+	for i := range want {
+		if c.Regions[i] != want[i] {
+			t.Errorf("Regions[%d] = %+v, want %+v", i, c.Regions[i], want[i])
+		}
+	}
+}
+
+func TestWarnClipping(t *testing.T) {
+	diagnostics := &AdaptiveDiagnostics{}
+	warnClipping(diagnostics, &AudioMeasurements{})
+	if len(diagnostics.Warnings) != 0 {
+		t.Errorf("unclipped input warned: %v", diagnostics.Warnings)
+	}
+
+	warnClipping(diagnostics, &AudioMeasurements{Clipping: &ClippingAnalysis{
+		PeakSamples: 120,
+		FlatFactor:  -14,
+		Regions:     []ClippedRegion{{Start: 1.5, End: 2.5, Intervals: 4}},
+	}})
+	if len(diagnostics.Warnings) != 1 || !strings.Contains(diagnostics.Warnings[0], "input appears clipped (120 samples at full scale, flat factor -14.0 dB), worst at 1.5-2.5s") {
+		t.Errorf("Warnings = %v", diagnostics.Warnings)
+	}
+}
@@ -0,0 +1,136 @@
+// Package processor handles audio analysis and processing
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Mains hum reading for --mains=auto. Hum is a steady tone at the mains
+// frequency and its harmonics, so it shows in the room tone, where no voice
+// competes. Each candidate mains frequency is read as narrow band-passes at its
+// fundamental and first harmonics; broadband noise fills the 50 Hz and 60 Hz
+// sets about equally, so a hum stands out as one set well above the other.
+const (
+	// humDetectHarmonics is how many tones per mains frequency are read,
+	// including the fundamental: 50/100/150 Hz against 60/120/180 Hz.
+	humDetectHarmonics = 3
+
+	// humDetectQ is the band-pass Q. Two cascaded 2-pole band-passes at Q 30
+	// pass about 2 Hz and hold the other mains set's tones 50 dB or more down.
+	humDetectQ = 30.0
+
+	// humDominanceDB is how far one mains set must stand above the other for
+	// auto to place the notch. Broadband noise alone differs by about 1 dB.
+	humDominanceDB = 6.0
+)
+
+// humToneAnalysisFilterFormat is the fmt.Sprintf format string for one hum
+// tone: the room-tone region start and duration in seconds, then the tone
+// frequency and Q for each of the two cascaded band-passes. The signal is
+// downmixed, trimmed to the region, and measured with astats like the band
+// measurements.
+const humToneAnalysisFilterFormat = "aformat=channel_layouts=mono,atrim=start=%f:duration=%f,asetpts=PTS-STARTPTS,bandpass=f=%f:width_type=q:w=%f,bandpass=f=%f:width_type=q:w=%f,astats=metadata=1:measure_perchannel=0"
+
+// humTonePlan lists the tones measureMainsHum reads, the 50 Hz set first.
+var humTonePlan = func() []float64 {
+	tones := make([]float64, 0, len(mainsFrequenciesHz)*humDetectHarmonics)
+	for _, f := range mainsFrequenciesHz {
+		for n := 1; n <= humDetectHarmonics; n++ {
+			tones = append(tones, f*float64(n))
+		}
+	}
+	return tones
+}()
+
+// humBandsUnavailable skips the hum reading when the linked FFmpeg lacks
+// bandpass. It is set once by FilterAvailability.Degrade, before any file is
+// processed.
+var humBandsUnavailable bool
+
+// measureMainsHum reads the 50 Hz and 60 Hz hum sets over the elected
+// room-tone region and writes their power sums onto the NoiseProfile. It is a
+// no-op without a NoiseProfile. Failures are non-fatal: HumMeasured stays false
+// and --mains=auto leaves the notch off. Each tone runs as its own bounded
+// decode (runBandMeasurements); report (when non-nil) advances the post-loop
+// progress span.
+func measureMainsHum(ctx context.Context, filename string, measurements *AudioMeasurements, report bandProgressReporter, log debugLogger) {
+	if humBandsUnavailable || measurements == nil || measurements.Regions.NoiseProfile == nil {
+		drainBandProgress(report, len(humTonePlan))
+		return
+	}
+	profile := measurements.Regions.NoiseProfile
+	if profile.Duration <= 0 {
+		drainBandProgress(report, len(humTonePlan))
+		return
+	}
+
+	levels := make([]float64, len(humTonePlan))
+	measured := make([]bool, len(humTonePlan))
+
+	runBandMeasurements(ctx, len(humTonePlan), report, func(i int) {
+		reader, _, err := audio.OpenAudioFile(filename)
+		if err != nil {
+			log.Logf("Warning: failed to open file for hum tone %.0f Hz measurement: %v", humTonePlan[i], err)
+			return
+		}
+		defer reader.Close()
+
+		tone := humTonePlan[i]
+		spec := fmt.Sprintf(humToneAnalysisFilterFormat,
+			profile.Start.Seconds(), profile.Duration.Seconds(), tone, humDetectQ, tone, humDetectQ)
+		l, ok, err := measureRegionLevels(ctx, reader, profile.Start, spec, log)
+		if err != nil {
+			log.Logf("Warning: hum tone %.0f Hz measurement failed: %v", tone, err)
+			return
+		}
+		levels[i] = l.rms
+		measured[i] = ok && isFinite(l.rms)
+	})
+
+	sums := make([]float64, len(mainsFrequenciesHz))
+	for set := range mainsFrequenciesHz {
+		tones := levels[set*humDetectHarmonics : (set+1)*humDetectHarmonics]
+		for j, ok := range measured[set*humDetectHarmonics : (set+1)*humDetectHarmonics] {
+			if !ok {
+				log.Logf("Hum reading incomplete: %.0f Hz tone not measured", humTonePlan[set*humDetectHarmonics+j])
+				return
+			}
+		}
+		sums[set] = powerSumDB(tones)
+	}
+
+	profile.Hum50RMS = sums[0]
+	profile.Hum60RMS = sums[1]
+	profile.HumMeasured = true
+	log.Logf("Mains hum: 50 Hz set %.1f dBFS, 60 Hz set %.1f dBFS (tones %v)", sums[0], sums[1], levels)
+}
+
+// powerSumDB adds dB levels as powers: 10*log10(sum(10^(L/10))).
+func powerSumDB(levels []float64) float64 {
+	var sum float64
+	for _, l := range levels {
+		sum += math.Pow(10, l/10)
+	}
+	return 10 * math.Log10(sum)
+}
+
+// MainsHumHz is the mains frequency whose hum set stands at least
+// humDominanceDB above the other's in the room tone, and by how much; 0 when
+// neither does or the reading is missing.
+func (p *NoiseProfile) MainsHumHz() (hz, marginDB float64) {
+	if p == nil || !p.HumMeasured {
+		return 0, 0
+	}
+	diff := p.Hum50RMS - p.Hum60RMS
+	switch {
+	case diff >= humDominanceDB:
+		return MainsHz50, diff
+	case -diff >= humDominanceDB:
+		return MainsHz60, -diff
+	}
+	return 0, math.Abs(diff)
+}
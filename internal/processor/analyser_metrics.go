@@ -29,6 +29,10 @@ type IntervalSample struct {
 	ShortTermLUFS float64 `json:"short_term_lufs"` // LUFS - 3s window loudness
 	TruePeak      float64 `json:"true_peak"`       // dBTP - true peak level (max tracked)
 	SamplePeak    float64 `json:"sample_peak"`     // dBFS - sample peak level (max tracked)
+
+	// polarity holds the interval's pre-emphasised moment sums, merged over the
+	// speech regions by analysePolarity. Not part of the JSON contract.
+	polarity polarityMoments
 }
 
 type intervalSampleJSON struct {
@@ -168,9 +172,10 @@ type intervalAccumulator struct {
 	// ─── Raw sample RMS accumulator (for accurate per-interval silence detection) ─
 	// These are calculated directly from frame samples, not from astats metadata,
 	// because astats with reset=0 provides cumulative stats, not per-interval.
-	rawSumSquares  float64 // Sum of squared sample values (normalized -1 to 1)
-	rawSampleCount int64   // Total sample count for this interval
-	rawPeakAbs     float64 // Maximum absolute sample value (linear, 0.0-1.0) for this interval
+	rawSumSquares  float64         // Sum of squared sample values (normalized -1 to 1)
+	rawSampleCount int64           // Total sample count for this interval
+	rawPeakAbs     float64         // Maximum absolute sample value (linear, 0.0-1.0) for this interval
+	polarity       polarityMoments // Pre-emphasised moment sums for polarity detection
 
 	// ─── aspectralstats accumulators (valid per-window from FFmpeg) ─────────────
 	spectralSum   SpectralMetrics
@@ -383,6 +388,7 @@ func (a *intervalAccumulator) finalize(timestamp time.Duration) IntervalSample {
 
 	sample := IntervalSample{
 		Timestamp: timestamp,
+		polarity:  a.polarity,
 
 		// Max values
 		PeakLevel:  peakLevelDB,
@@ -427,8 +433,46 @@ func (a *intervalAccumulator) reset() {
 	}
 }
 
+// intervalSeries cuts a run of Pass 1 frames into the per-interval samples.
+// Input frames carry the raw RMS, peak and polarity and close an interval once
+// it spans analysisIntervalHop; filtered frames add their windowed metrics to
+// whichever interval is open.
+type intervalSeries struct {
+	acc       intervalAccumulator
+	start     time.Duration
+	intervals []IntervalSample
+}
+
+// addInputFrame accumulates one input frame that starts at t.
+func (s *intervalSeries) addInputFrame(frame *ffmpeg.AVFrame, t time.Duration) {
+	s.acc.addFrameRMSAndPeak(frame)
+	s.acc.polarity.addFrame(frame)
+	if t-s.start >= analysisIntervalHop {
+		s.intervals = append(s.intervals, s.acc.finalize(s.start))
+		s.start = t
+		s.acc.reset()
+	}
+}
+
+// addFiltered adds one filtered frame's windowed metrics to the open interval.
+func (s *intervalSeries) addFiltered(m intervalFrameMetrics) {
+	s.acc.add(m)
+}
+
+// finish closes the last, partial interval and returns the series.
+func (s *intervalSeries) finish() []IntervalSample {
+	if s.acc.rawSampleCount > 0 {
+		s.intervals = append(s.intervals, s.acc.finalize(s.start))
+	}
+	return s.intervals
+}
+
 // Cached metadata keys for frame extraction - avoids per-frame C string allocations
-// These use GlobalCStr which maintains an internal cache, so identical strings share the same CStr
+// These use GlobalCStr which maintains an internal cache, so identical strings share the same CStr.
+// The cache is never evicted, so GlobalCStr only ever takes a string literal: the set
+// of interned strings is fixed at build time and a long-running process cannot grow it.
+// Anything formatted at run time (filter specs, paths, filter names) goes through
+// ToCStr and is freed. TestGlobalCStrLiteralsOnly enforces this.
 var (
 	// aspectralstats metadata keys (all measurements)
 	metaKeySpectralMean     = ffmpeg.GlobalCStr("lavfi.aspectralstats.1.mean")
@@ -453,6 +497,7 @@ var (
 	metaKeyRMSPeak           = ffmpeg.GlobalCStr("lavfi.astats.1.RMS_peak")
 	metaKeyDCOffset          = ffmpeg.GlobalCStr("lavfi.astats.1.DC_offset")
 	metaKeyFlatFactor        = ffmpeg.GlobalCStr("lavfi.astats.1.Flat_factor")
+	metaKeyPeakCount         = ffmpeg.GlobalCStr("lavfi.astats.1.Peak_count")
 	metaKeyCrestFactor       = ffmpeg.GlobalCStr("lavfi.astats.1.Crest_factor")
 	metaKeyZeroCrossingsRate = ffmpeg.GlobalCStr("lavfi.astats.1.Zero_crossings_rate")
 	metaKeyZeroCrossings     = ffmpeg.GlobalCStr("lavfi.astats.1.Zero_crossings")
@@ -472,6 +517,7 @@ var (
 	metaKeyOverallRMSLevel    = ffmpeg.GlobalCStr("lavfi.astats.Overall.RMS_level")
 	metaKeyOverallPeakLevel   = ffmpeg.GlobalCStr("lavfi.astats.Overall.Peak_level")
 	metaKeyOverallCrestFactor = ffmpeg.GlobalCStr("lavfi.astats.Overall.Crest_factor")
+	metaKeyOverallRMSPeak     = ffmpeg.GlobalCStr("lavfi.astats.Overall.RMS_peak")
 	// ebur128 metadata keys
 	metaKeyEbur128I            = ffmpeg.GlobalCStr("lavfi.r128.I")
 	metaKeyEbur128M            = ffmpeg.GlobalCStr("lavfi.r128.M")
@@ -497,6 +543,7 @@ type baseMetadataAccumulators struct {
 	astatsRMSPeak           float64
 	astatsDCOffset          float64
 	astatsFlatFactor        float64
+	astatsPeakCount         float64
 	astatsCrestFactor       float64
 	astatsZeroCrossingsRate float64
 	astatsZeroCrossings     float64
@@ -558,6 +605,9 @@ func (b *baseMetadataAccumulators) extractAstatsMetadata(metadata *ffmpeg.AVDict
 	if value, ok := getFloatMetadata(metadata, metaKeyFlatFactor); ok {
 		b.astatsFlatFactor = value
 	}
+	if value, ok := getFloatMetadata(metadata, metaKeyPeakCount); ok {
+		b.astatsPeakCount = value
+	}
 	// CrestFactor: FFmpeg reports as linear ratio (peak/RMS), convert to dB
 	if value, ok := getFloatMetadata(metadata, metaKeyCrestFactor); ok {
 		b.astatsCrestFactor = linearRatioToDB(value)
@@ -727,6 +777,13 @@ func (a *SpectralAccumulator) Add(spectral SpectralMetrics) {
 	a.count++
 }
 
+// merge folds another accumulator's frames into this one, as though they had
+// been added here.
+func (a *SpectralAccumulator) merge(other SpectralAccumulator) {
+	a.sum.add(other.sum)
+	a.count += other.count
+}
+
 // Average returns averaged spectral measurements, or the zero value when no
 // spectral metadata was accumulated.
 func (a SpectralAccumulator) Average() SpectralMetrics {
@@ -939,6 +996,10 @@ type outputMetadataAccumulators struct {
 	ebur128OutputLRA    float64
 	ebur128OutputThresh float64 // Gating threshold for loudnorm
 	ebur128Found        bool
+
+	// speech collects the speech-only momentary loudness; nil when Pass 1
+	// found no speech regions.
+	speech *speechLoudnessAccumulator
 }
 
 // extractOutputFrameMetadata extracts audio analysis metadata from a Pass 2 filtered frame.
@@ -1036,5 +1097,9 @@ func finalizeOutputMeasurements(acc *outputMetadataAccumulators) *OutputMeasurem
 		m.Loudness.OutputThresh = m.Loudness.OutputI - 10.0
 	}
 
+	if acc.speech != nil {
+		m.Loudness.SpeechI, _ = acc.speech.loudness()
+	}
+
 	return m
 }
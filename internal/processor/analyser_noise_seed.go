@@ -23,6 +23,16 @@ const (
 	goldenIntervalSize   = 250 * time.Millisecond // Must match interval sampling (analysisIntervalHop)
 )
 
+// roomToneWindow is the golden refinement's target and minimum window for a
+// room-tone run. defaultRoomToneWindow holds the constants above;
+// --noise-window replaces them (BaseFilterConfig.noiseWindow).
+type roomToneWindow struct {
+	Duration time.Duration
+	Minimum  time.Duration
+}
+
+var defaultRoomToneWindow = roomToneWindow{Duration: goldenWindowDuration, Minimum: goldenWindowMinimum}
+
 // Seed-estimator constants for the pre-scan noise floor.
 const (
 	// roomToneAmplitudeDecayDB is the dB range above median where amplitude score decays from 1.0 to 0.0.
@@ -45,8 +55,27 @@ const (
 	// floorSeedMinCount is the minimum number of intervals in the noise-floor seed set.
 	floorSeedMinCount = 8
 
+	// floorSeedConfidencePercentile is the share of the seed set's confidence
+	// weight that must lie at or below the noise floor. 0.8 sits just under the
+	// loudest candidates: on a clean set it lands within a decibel or two of the
+	// seed's max, yet one contaminated interval among the floorSeedMinCount
+	// minimum holds under a fifth of the weight and cannot set the floor alone.
+	floorSeedConfidencePercentile = 0.8
+
+	// floorSeedMinSpectralConfidence is the lowest spectral confidence a seed
+	// candidate is given. Tonal room tone (hum, fan whine) has low flatness and
+	// entropy yet is still room tone, so it is down-weighted, never dropped.
+	floorSeedMinSpectralConfidence = 0.1
+
 	// silenceThresholdHeadroomDB is additional dB added to the detected room tone level for headroom.
 	silenceThresholdHeadroomDB = 1.0
+
+	// leadInGuardIntervals is the number of opening 250 ms intervals kept out of
+	// the noise-floor seed and the room-tone runs. The first interval often holds
+	// a fade-up ramp or a partial frame, so its level is neither room tone nor
+	// speech. One interval is enough; the rest of the opening is left to the
+	// estimators.
+	leadInGuardIntervals = 1
 )
 
 // Threshold bounds for the fallback adaptive silence threshold.
@@ -133,6 +162,16 @@ func computeSilenceMedians(searchIntervals []IntervalSample) silenceMedians {
 	}
 }
 
+// seedSearchIntervals drops the lead-in guard (leadInGuardIntervals) from the
+// interval slice the noise-floor seed searches. A file too short to spare the
+// guard and still reach silenceThresholdMinIntervals keeps every interval.
+func seedSearchIntervals(intervals []IntervalSample) []IntervalSample {
+	if len(intervals)-leadInGuardIntervals < silenceThresholdMinIntervals {
+		return intervals
+	}
+	return intervals[leadInGuardIntervals:]
+}
+
 // estimateNoiseFloorAndThreshold analyses interval data to estimate noise floor and silence threshold.
 // Returns (noiseFloor, silenceThreshold, ok). If ok is false, fallback values should be used.
 //
@@ -143,11 +182,14 @@ func computeSilenceMedians(searchIntervals []IntervalSample) silenceMedians {
 //
 // The level is read on the momentary-LUFS axis (the axis the VAD split, floor,
 // and noise margin share), so the seeded floor and the detector measure one scale.
-// The noise floor is the max level of high-confidence room tone intervals; the
+// The noise floor is a confidence-weighted high percentile of the
+// high-confidence room tone intervals (floorSeedConfidencePercentile), each
+// weighted by its room-tone score and its spectral confidence, so the odd
+// interval with leaked speech cannot inflate it the way a hard max would. The
 // silence threshold adds headroom to it for detection margin.
 //
 // Floored intervals (momentary at or below vadLevelFloorDB, or non-finite) are
-// excluded before the max so true digital silence between phrases on
+// excluded before weighting so true digital silence between phrases on
 // voice-activated captures does not seed a phantom -120 dB floor. When no real
 // room-tone interval remains after exclusion, the estimator returns ok=false so
 // the caller falls back rather than fabricating a level.
@@ -195,30 +237,67 @@ func estimateNoiseFloorAndThreshold(intervals []IntervalSample, medians silenceM
 	candidateCount = max(candidateCount, floorSeedMinCount)
 	candidateCount = min(candidateCount, len(scored))
 
-	// Noise floor is the maximum level among high-confidence room tone intervals,
+	// Noise floor is the confidence-weighted high percentile of the seed set,
 	// excluding floored (digital-silence / unmeasurable) intervals so a
 	// voice-activated capture's true-silence gaps cannot seed a phantom floor.
-	maxRoomToneLevel := -120.0
-	seen := false
+	var levels, weights []float64
 	for i := 0; i < candidateCount; i++ {
-		level := scored[i].level
-		if isFlooredLevel(level) {
+		if isFlooredLevel(scored[i].level) {
 			continue
 		}
-		if !seen || level > maxRoomToneLevel {
-			maxRoomToneLevel = level
-			seen = true
-		}
+		levels = append(levels, scored[i].level)
+		weights = append(weights, scored[i].score*seedSpectralConfidence(intervals[scored[i].idx]))
 	}
 
 	// No real room-tone interval survived the floored-interval exclusion: do not
 	// fabricate a level. Return ok=false so the caller uses its low fallback and
 	// the downstream percentileFloor falls back to the momentary p10.
-	if !seen {
+	if len(levels) == 0 {
 		return 0, 0, false
 	}
 
-	return maxRoomToneLevel, maxRoomToneLevel + silenceThresholdHeadroomDB, true
+	floor := weightedPercentile(levels, weights, floorSeedConfidencePercentile)
+	return floor, floor + silenceThresholdHeadroomDB, true
+}
+
+// seedSpectralConfidence rates how noise-like an interval's spectrum is: the
+// mean of its flatness and entropy, both high for broadband room tone and low
+// for voiced speech. It never falls below floorSeedMinSpectralConfidence, and an
+// interval without spectral metrics is given full confidence so the room-tone
+// score alone weights it.
+func seedSpectralConfidence(interval IntervalSample) float64 {
+	if !interval.Spectral.Found {
+		return 1
+	}
+	confidence := (interval.Spectral.Flatness + interval.Spectral.Entropy) / 2
+	return max(confidence, floorSeedMinSpectralConfidence)
+}
+
+// weightedPercentile returns the lowest value at which the cumulative weight,
+// taken in ascending value order, reaches p of the total. With no positive
+// weight every value counts equally.
+func weightedPercentile(values, weights []float64, p float64) float64 {
+	order := make([]int, len(values))
+	total := 0.0
+	for i := range order {
+		order[i] = i
+		total += weights[i]
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(values[a], values[b])
+	})
+	if total <= 0 {
+		return values[order[int(p*float64(len(order)-1)+0.5)]]
+	}
+
+	cumulative := 0.0
+	for _, i := range order {
+		cumulative += weights[i]
+		if cumulative >= p*total {
+			return values[i]
+		}
+	}
+	return values[order[len(order)-1]]
 }
 
 // calculateAdaptiveSilenceThreshold computes a bounded room tone threshold from a noise floor estimate.
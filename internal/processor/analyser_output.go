@@ -12,10 +12,17 @@ import (
 
 // outputRegionAnalysisFilterFormat is the fmt.Sprintf format string for the
 // output-region analysis filter graph in measureOutputRegionFromReader. The
-// %f verbs take the region start and duration in seconds. Hoisted to a
+// %f verbs take the region start and duration in seconds, and the %s verb the
+// spectral stage (outputRegionSpectralSpec, or "" without aspectralstats). Hoisted to a
 // package-level constant so guard tests can assert the metadata flags against
-// live source without re-typing the filter string.
-const outputRegionAnalysisFilterFormat = "atrim=start=%f:duration=%f,asetpts=PTS-STARTPTS,astats=metadata=1:measure_perchannel=0,aspectralstats=measure=all,ebur128=metadata=1:peak=sample+true"
+// live source without re-typing the filter string. The aformat downmix folds a
+// dual-mono stereo output (--channels) back to the mono programme, so region
+// measurements do not depend on the delivered layout; on mono it is a no-op.
+const outputRegionAnalysisFilterFormat = "atrim=start=%f:duration=%f,asetpts=PTS-STARTPTS,aformat=channel_layouts=mono,astats=metadata=1:measure_perchannel=0,%sebur128=metadata=1:peak=sample+true"
+
+// outputRegionSpectralSpec is the aspectralstats stage of the output-region
+// graph, with its trailing separator.
+const outputRegionSpectralSpec = "aspectralstats=measure=all,"
 
 // regionSeekPreRoll is the head-start the demuxer seeks before a region's start
 // so decoding skips the pre-region span instead of running from frame 0.
@@ -104,6 +111,7 @@ func measureOutputRegionFromReader(ctx context.Context, reader *audio.Reader, st
 		outputRegionAnalysisFilterFormat,
 		start.Seconds(),
 		duration.Seconds(),
+		withSpectralStats(outputRegionSpectralSpec),
 	)
 
 	// Skip the pre-region span: seek the demuxer near the region before decoding
@@ -249,7 +257,7 @@ func measureOutputRoomToneRegionFromReader(ctx context.Context, reader *audio.Re
 func extractRegionPair(m *AudioMeasurements) (*RoomToneRegion, *SpeechRegion) {
 	var roomToneRegion *RoomToneRegion
 	var spRegion *SpeechRegion
-	if m.Regions.NoiseProfile != nil {
+	if m.Regions.NoiseProfile != nil && m.Regions.NoiseProfile.Duration > 0 {
 		roomToneRegion = &RoomToneRegion{
 			Start:    m.Regions.NoiseProfile.Start,
 			End:      m.Regions.NoiseProfile.Start + m.Regions.NoiseProfile.Duration,
@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"slices"
+	"time"
+)
+
+// longPauseThreshold is the gap length counted as a long pause in the pacing
+// summary. Two seconds is where a pause stops reading as phrasing and starts
+// reading as dead air on a podcast.
+const longPauseThreshold = 2 * time.Second
+
+// PauseStatistics is the §8.1 `pauses` block: the distribution of gaps between
+// speech across the whole file, for pacing feedback. A gap is a run of
+// non-speech 250 ms intervals (the VAD speech flag) bounded by speech on both
+// sides, so leading and trailing silence is excluded. Lengths are quantised to
+// the analysis hop. All times are seconds.
+type PauseStatistics struct {
+	Count   int     `json:"count"`
+	MeanS   float64 `json:"mean_s"`
+	MedianS float64 `json:"median_s"`
+
+	LongThresholdS float64 `json:"long_threshold_s"` // Gap length counted as a long pause
+	LongCount      int     `json:"long_count"`       // Gaps at or above LongThresholdS
+
+	LongestS      float64 `json:"longest_s"`
+	LongestStartS float64 `json:"longest_start_s"` // Timeline position of the longest gap
+}
+
+// newPauseStatistics derives the pause summary from the per-interval speech
+// flags. Returns nil when there is no gap between speech (no speech, or one
+// unbroken run), so omitempty drops the block.
+func newPauseStatistics(flags []bool, hop time.Duration) *PauseStatistics {
+	gaps := interiorGaps(flags)
+	if len(gaps) == 0 {
+		return nil
+	}
+
+	stats := &PauseStatistics{
+		Count:          len(gaps),
+		LongThresholdS: longPauseThreshold.Seconds(),
+	}
+
+	lengths := make([]float64, len(gaps))
+	var sum float64
+	var longest gapRun
+	for i, g := range gaps {
+		d := time.Duration(g.length) * hop
+		lengths[i] = d.Seconds()
+		sum += lengths[i]
+		if d >= longPauseThreshold {
+			stats.LongCount++
+		}
+		if g.length > longest.length {
+			longest = g
+		}
+	}
+
+	slices.Sort(lengths)
+	stats.MeanS = sum / float64(len(lengths))
+	stats.MedianS = percentileOfSorted(lengths, 50)
+	stats.LongestS = (time.Duration(longest.length) * hop).Seconds()
+	stats.LongestStartS = (time.Duration(longest.start) * hop).Seconds()
+
+	return stats
+}
@@ -0,0 +1,65 @@
+package processor
+
+import (
+	"testing"
+	"time"
+)
+
+// pauseFlags builds a speech-flag stream from alternating run lengths (in
+// intervals), starting with speech when speechFirst is set.
+func pauseFlags(speechFirst bool, runs ...int) []bool {
+	var flags []bool
+	speech := speechFirst
+	for _, n := range runs {
+		for range n {
+			flags = append(flags, speech)
+		}
+		speech = !speech
+	}
+	return flags
+}
+
+func TestNewPauseStatistics(t *testing.T) {
+	hop := analysisIntervalHop
+
+	t.Run("interior gaps only", func(t *testing.T) {
+		// Leading 8 and trailing 12 silent intervals are not pauses. Interior gaps:
+		// 1 (0.25 s), 2 (0.5 s), 12 (3 s), 8 (2 s).
+		flags := pauseFlags(false, 8, 10, 1, 6, 2, 4, 12, 20, 8, 5, 12)
+		stats := newPauseStatistics(flags, hop)
+		if stats == nil {
+			t.Fatal("newPauseStatistics returned nil with interior gaps")
+		}
+		if stats.Count != 4 {
+			t.Errorf("Count = %d, want 4", stats.Count)
+		}
+		if stats.MeanS != 1.4375 {
+			t.Errorf("MeanS = %v, want 1.4375", stats.MeanS)
+		}
+		if stats.MedianS != 0.5 {
+			t.Errorf("MedianS = %v, want 0.5 (nearest-rank)", stats.MedianS)
+		}
+		if stats.LongCount != 2 {
+			t.Errorf("LongCount = %d, want 2 (3 s and exactly 2 s)", stats.LongCount)
+		}
+		if stats.LongThresholdS != longPauseThreshold.Seconds() {
+			t.Errorf("LongThresholdS = %v, want %v", stats.LongThresholdS, longPauseThreshold.Seconds())
+		}
+		// Longest gap starts after 8+10+1+6+2+4 = 31 intervals.
+		if stats.LongestS != 3 || stats.LongestStartS != (31*hop).Seconds() {
+			t.Errorf("longest = %v s at %v s, want 3 s at %v s",
+				stats.LongestS, stats.LongestStartS, (31 * hop).Seconds())
+		}
+	})
+
+	for name, flags := range map[string][]bool{
+		"no speech":        pauseFlags(false, 20),
+		"one unbroken run": pauseFlags(false, 4, 20, 4),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := newPauseStatistics(flags, time.Second/4); got != nil {
+				t.Errorf("newPauseStatistics = %+v, want nil", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package processor
+
+import (
+	"slices"
+	"time"
+)
+
+// Speech decay at phrase ends. In a live room the voice does not stop when the
+// talker does: the reverb tail carries on above the room tone for a few hundred
+// milliseconds. A gate that closes faster than that tail cuts it off and the
+// word ends sound clipped against the room. Pass 1 times the tail at each
+// phrase end: how long the level stays clear of the pause's own floor after the
+// last speech interval. Levels are read on the raw per-interval RMS, not the
+// VAD's momentary LUFS, whose 400 ms window would smear the tail into a longer
+// decay than the room has.
+const (
+	// speechDecayMinGapIntervals is the shortest pause timed (1 s at the 250 ms
+	// hop). The tail needs room to fall and the pause a quiet end to read its
+	// floor from.
+	speechDecayMinGapIntervals = 4
+
+	// speechDecayFloorMarginDB is how far above the pause's quietest interval a
+	// gap interval must sit to count as tail. Room tone wobbles by a dB or two;
+	// a reverb tail clears it by more.
+	speechDecayFloorMarginDB = 6.0
+
+	// speechDecayMinOffsets is the number of timed phrase ends needed before the
+	// median decay is trusted.
+	speechDecayMinOffsets = 5
+)
+
+// measureSpeechDecay returns the median time the level stays more than
+// speechDecayFloorMarginDB above the pause floor after speech stops, over the
+// pauses of speechDecayMinGapIntervals or more. ok is false with fewer than
+// speechDecayMinOffsets timed pauses. Resolution is the interval hop: a dry room
+// reads zero, and any tail that outlasts a whole interval reads at least one hop.
+func measureSpeechDecay(intervals []IntervalSample, flags []bool, hop time.Duration) (decay time.Duration, ok bool) {
+	var decays []time.Duration
+	for _, g := range interiorGaps(flags) {
+		if g.length < speechDecayMinGapIntervals {
+			continue
+		}
+		gap := intervals[g.start : g.start+g.length]
+		floor, floored := quietestIntervalLevel(gap, axisRMS)
+		// A gated pause sits at digital silence; there is no tail to time.
+		if floored {
+			continue
+		}
+
+		tail := 0
+		// The last interval holds the next phrase's breath or onset; leave it out.
+		for _, iv := range gap[:len(gap)-1] {
+			if intervalLevel(iv, axisRMS)-floor <= speechDecayFloorMarginDB {
+				break
+			}
+			tail++
+		}
+		decays = append(decays, time.Duration(tail)*hop)
+	}
+	if len(decays) < speechDecayMinOffsets {
+		return 0, false
+	}
+
+	slices.Sort(decays)
+	return decays[len(decays)/2], true
+}
+
+// quietestIntervalLevel returns the lowest level on axis across intervals.
+// floored is true when any interval sits at the digital-silence floor.
+func quietestIntervalLevel(intervals []IntervalSample, axis levelAxis) (level float64, floored bool) {
+	for i, iv := range intervals {
+		l := intervalLevel(iv, axis)
+		if isFlooredLevel(l) {
+			return 0, true
+		}
+		if i == 0 || l < level {
+			level = l
+		}
+	}
+	return level, false
+}
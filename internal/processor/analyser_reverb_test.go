@@ -0,0 +1,84 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// decayFixture builds six phrases separated by 2 s pauses at -60 dBFS RMS. Each
+// pause opens with tail intervals falling 10 dB per interval from -30.
+func decayFixture(tail int) ([]IntervalSample, []bool) {
+	var iv []IntervalSample
+	var flags []bool
+	add := func(s IntervalSample, speech bool) {
+		iv = append(iv, s)
+		flags = append(flags, speech)
+	}
+	phrase := func() {
+		for range 8 {
+			add(vadSpeechRich(len(iv)), true)
+		}
+	}
+
+	for range 6 {
+		phrase()
+		for i := range 8 {
+			level := -60.0
+			if i < tail {
+				level = -30 - 10*float64(i)
+			}
+			add(vadInterval(len(iv), level), false)
+		}
+	}
+	phrase()
+	return iv, flags
+}
+
+func TestMeasureSpeechDecay(t *testing.T) {
+	hop := analysisIntervalHop
+
+	iv, flags := decayFixture(2)
+	decay, ok := measureSpeechDecay(iv, flags, hop)
+	if !ok || decay != 2*hop {
+		t.Errorf("live room decay = %v (ok=%v), want %v", decay, ok, 2*hop)
+	}
+
+	iv, flags = decayFixture(0)
+	if decay, ok := measureSpeechDecay(iv, flags, hop); !ok || decay != 0 {
+		t.Errorf("dry room decay = %v (ok=%v), want 0", decay, ok)
+	}
+
+	// Too few pauses to trust.
+	iv, flags = decayFixture(2)
+	for i := range flags[:len(flags)/2] {
+		flags[i] = true
+	}
+	if _, ok := measureSpeechDecay(iv, flags, hop); ok {
+		t.Error("timed decay over three pauses, want at least speechDecayMinOffsets")
+	}
+}
+
+func TestWarnGateTailTruncation(t *testing.T) {
+	config := deriveEffectiveFilterConfig(DefaultFilterConfig())
+	config.SpeechGate.Release = speechGateReleaseFixedMS
+
+	diag := &AdaptiveDiagnostics{}
+	warnGateTailTruncation(config, diag, &AudioMeasurements{Noise: NoiseMetrics{SpeechDecayMS: 0}})
+	if len(diag.Warnings) != 0 {
+		t.Errorf("warnings = %v, want none for a dry room", diag.Warnings)
+	}
+
+	decay := float64((2 * analysisIntervalHop) / time.Millisecond)
+	warnGateTailTruncation(config, diag, &AudioMeasurements{Noise: NoiseMetrics{SpeechDecayMS: decay}})
+	if len(diag.Warnings) != 1 || !strings.Contains(diag.Warnings[0], "reverb tail") {
+		t.Errorf("warnings = %v, want one reverb-tail warning", diag.Warnings)
+	}
+
+	config.SpeechGate.Enabled = false
+	diag = &AdaptiveDiagnostics{}
+	warnGateTailTruncation(config, diag, &AudioMeasurements{Noise: NoiseMetrics{SpeechDecayMS: decay}})
+	if len(diag.Warnings) != 0 {
+		t.Errorf("warnings = %v, want none with the gate off", diag.Warnings)
+	}
+}
@@ -0,0 +1,225 @@
+package processor
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// roomToneCandidateMinDuration is the shortest below-split run offered to the
+// interactive room-tone picker. Shorter runs are inter-word pauses, too brief to
+// profile the noise from.
+const roomToneCandidateMinDuration = 2 * time.Second
+
+// roomToneCandidateMax caps the number of runs offered to the picker. The
+// longest runs are kept, so the list stays short enough to choose from on a
+// terminal.
+const roomToneCandidateMax = 10
+
+// RoomToneCandidate is one quiet region offered to a RoomToneSelector. Bounds
+// are the golden-refined region the noise profile would be extracted from; the
+// levels are averaged over the region's 250 ms intervals.
+type RoomToneCandidate struct {
+	Start    time.Duration
+	End      time.Duration
+	Duration time.Duration
+
+	RMSLevel      float64 // astats RMS dBFS
+	MomentaryLUFS float64 // momentary LUFS (the VAD split axis)
+	Flux          float64 // spectral flux (lower = steadier)
+
+	// Automatic marks the region the detector would elect on its own.
+	Automatic bool
+}
+
+// RoomToneSelector lets the caller override the automatic room-tone election. It
+// receives the candidates in timeline order and returns the index of the region
+// to use, or -1 to keep the automatic choice. It runs inside Pass 1 on the
+// worker goroutine, so it may block (e.g. waiting on a TUI prompt).
+type RoomToneSelector func(candidates []RoomToneCandidate) int
+
+// SetRoomToneSelector installs a RoomToneSelector consulted during Pass 1. A nil
+// selector restores the automatic election.
+func (cfg *BaseFilterConfig) SetRoomToneSelector(selector RoomToneSelector) {
+	cfg.roomToneSelector = selector
+}
+
+// roomToneCandidates builds the picker list from the below-split runs: runs at
+// least roomToneCandidateMinDuration long, the longest roomToneCandidateMax of
+// them, golden-refined and returned in timeline order. automatic is the region
+// pickLowClusterRegion elected; the candidate refined to the same bounds is
+// flagged Automatic.
+func roomToneCandidates(intervals []IntervalSample, split float64, axis levelAxis, hop time.Duration, window roomToneWindow, automatic *RoomToneRegion) []RoomToneCandidate {
+	var runs []RoomToneRegion
+	for _, run := range lowClusterRuns(intervals, split, axis, hop) {
+		if run.Duration >= roomToneCandidateMinDuration {
+			runs = append(runs, run)
+		}
+	}
+
+	// Keep the longest runs, then restore timeline order for display.
+	slices.SortStableFunc(runs, func(a, b RoomToneRegion) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	if len(runs) > roomToneCandidateMax {
+		runs = runs[:roomToneCandidateMax]
+	}
+	slices.SortFunc(runs, func(a, b RoomToneRegion) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	candidates := make([]RoomToneCandidate, 0, len(runs))
+	for _, run := range runs {
+		region := refineRoomToneRegion(run, intervals, window)
+		regionIntervals := getIntervalsInRange(intervals, region.Start, region.End)
+		if len(regionIntervals) == 0 {
+			continue
+		}
+
+		acc := accumulateIntervalMetrics(regionIntervals)
+		n := float64(len(regionIntervals))
+		candidates = append(candidates, RoomToneCandidate{
+			Start:         region.Start,
+			End:           region.End,
+			Duration:      region.Duration,
+			RMSLevel:      acc.rmsSum / n,
+			MomentaryLUFS: acc.momentarySum / n,
+			Flux:          acc.spectralSum.average(n).Flux,
+			Automatic:     automatic != nil && region.Start == automatic.Start && region.End == automatic.End,
+		})
+	}
+	return candidates
+}
+
+// selectRoomToneRegion offers the candidate runs to selectRoomTone and returns
+// the chosen region. An out-of-range answer (including -1) or an empty list
+// keeps the automatic region.
+func selectRoomToneRegion(intervals []IntervalSample, split float64, axis levelAxis, hop time.Duration, window roomToneWindow, automatic *RoomToneRegion, selectRoomTone RoomToneSelector, log debugLogger) *RoomToneRegion {
+	candidates := roomToneCandidates(intervals, split, axis, hop, window, automatic)
+	if len(candidates) == 0 {
+		return automatic
+	}
+
+	idx := selectRoomTone(candidates)
+	if idx < 0 || idx >= len(candidates) {
+		log.Logf("VAD: room-tone selection kept the automatic region")
+		return automatic
+	}
+
+	c := candidates[idx]
+	log.Logf("VAD: room-tone region selected by user: %.2fs-%.2fs", c.Start.Seconds(), c.End.Seconds())
+	return &RoomToneRegion{Start: c.Start, End: c.End, Duration: c.Duration}
+}
+
+// NoiseRegionConfig is a room-tone region pinned by hand (--noise-region):
+// Duration from Start. The noise profile is read from exactly this stretch,
+// without the automatic election or its golden refinement.
+type NoiseRegionConfig struct {
+	Enabled  bool
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// End is where the region stops.
+func (r NoiseRegionConfig) End() time.Duration {
+	return r.Start + r.Duration
+}
+
+// SetNoiseRegion pins the room-tone region the noise profile is read from to
+// duration from start.
+func (cfg *BaseFilterConfig) SetNoiseRegion(start, duration time.Duration) error {
+	if start < 0 {
+		return fmt.Errorf("region start %v is negative", start)
+	}
+	if duration < roomToneCandidateMinDuration {
+		return fmt.Errorf("region duration %v is shorter than %v", duration, roomToneCandidateMinDuration)
+	}
+	cfg.NoiseRegion = NoiseRegionConfig{Enabled: true, Start: start, Duration: duration}
+	return nil
+}
+
+// SetNoiseWindow sets the length the elected room-tone run is refined to: its
+// quietest target-long stretch, accepting no shorter than minimum. A zero
+// target keeps goldenWindowDuration; a zero minimum keeps goldenWindowMinimum,
+// or the target when that is shorter. Both must be whole analysis intervals
+// and at least roomToneCandidateMinDuration, with minimum no longer than target.
+func (cfg *BaseFilterConfig) SetNoiseWindow(target, minimum time.Duration) error {
+	if target == 0 {
+		target = goldenWindowDuration
+	}
+	if minimum == 0 {
+		minimum = min(goldenWindowMinimum, target)
+	}
+	for _, d := range []time.Duration{target, minimum} {
+		if d < roomToneCandidateMinDuration {
+			return fmt.Errorf("window %v is shorter than %v", d, roomToneCandidateMinDuration)
+		}
+		if d%goldenIntervalSize != 0 {
+			return fmt.Errorf("window %v is not a whole number of %v intervals", d, goldenIntervalSize)
+		}
+	}
+	if minimum > target {
+		return fmt.Errorf("window minimum %v is longer than its target %v", minimum, target)
+	}
+	cfg.NoiseWindow, cfg.NoiseWindowMin = target, minimum
+	return nil
+}
+
+// noiseWindow is the room-tone refinement window in force: SetNoiseWindow's,
+// or defaultRoomToneWindow.
+func (cfg *BaseFilterConfig) noiseWindow() roomToneWindow {
+	if cfg.NoiseWindow == 0 {
+		return defaultRoomToneWindow
+	}
+	return roomToneWindow{Duration: cfg.NoiseWindow, Minimum: cfg.NoiseWindowMin}
+}
+
+// pinnedRoomToneRegion resolves the pinned region against the analysed file:
+// nil when none is pinned, an error when it runs past the end of the file or
+// holds no analysis intervals.
+func pinnedRoomToneRegion(region NoiseRegionConfig, intervals []IntervalSample, total time.Duration) (*RoomToneRegion, error) {
+	if !region.Enabled {
+		return nil, nil
+	}
+	if region.End() > total {
+		return nil, fmt.Errorf("noise region %.2fs-%.2fs runs past the end of the file (%.2fs)",
+			region.Start.Seconds(), region.End().Seconds(), total.Seconds())
+	}
+	if len(getIntervalsInRange(intervals, region.Start, region.End())) == 0 {
+		return nil, fmt.Errorf("noise region %.2fs-%.2fs holds no analysis intervals",
+			region.Start.Seconds(), region.End().Seconds())
+	}
+	return &RoomToneRegion{Start: region.Start, End: region.End(), Duration: region.Duration}, nil
+}
+
+// SetNoiseFloor sets the noise floor by hand (--noise-floor), for a recording
+// with no usable room tone: one with no pauses, or with music under every
+// gap. The bounds are those the estimated floor is clamped to.
+func (cfg *BaseFilterConfig) SetNoiseFloor(floorDB float64) error {
+	if !isFinite(floorDB) || floorDB < noiseFloorClampMinDB || floorDB > noiseFloorClampMaxDB {
+		return fmt.Errorf("noise floor %.1f dBFS is outside the range [%.0f, %.0f] dBFS",
+			floorDB, noiseFloorClampMinDB, noiseFloorClampMaxDB)
+	}
+	cfg.NoiseFloorDB = floorDB
+	return nil
+}
+
+// applyNoiseFloorOverride replaces the detected noise floor with floorDB and
+// drops the elected room tone with it: the user has said the recording has
+// none worth reading, so the tuners take their no-profile paths rather than
+// profile speech or music. The rumble bursts are recounted against the new
+// floor. A zero floorDB leaves the detection as it is.
+func applyNoiseFloorOverride(measurements *AudioMeasurements, intervals []IntervalSample, floorDB float64, hop time.Duration, axis levelAxis, log debugLogger) {
+	if floorDB == 0 {
+		return
+	}
+	log.Logf("Noise floor set by user: %.1f dBFS (detected %.1f dBFS, %s)",
+		floorDB, measurements.Noise.Floor, measurements.Noise.FloorSource)
+
+	measurements.Noise.Floor = floorDB
+	measurements.Noise.FloorSource = "user"
+	measurements.Regions.NoiseProfile = nil
+	measurements.Regions.ElectedRoomToneSample = nil
+	measurements.Noise.RumbleBurstCount, measurements.Noise.RumbleBurstsPerMinute = countRumbleBursts(intervals, floorDB, axis, hop)
+}
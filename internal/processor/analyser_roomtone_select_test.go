@@ -0,0 +1,224 @@
+package processor
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// roomToneSelectFixture builds a timeline of three quiet runs separated by
+// speech: a 1 s pause (too short to offer), a 4 s run at -58, and a 15 s run at
+// -62 (the automatic election). Returns the intervals and the two offered run
+// start times.
+func roomToneSelectFixture() (iv []IntervalSample, shortStart, longStart time.Duration) {
+	idx := 0
+	appendRun := func(n int, mk func(int) IntervalSample) {
+		for range n {
+			iv = append(iv, mk(idx))
+			idx++
+		}
+	}
+	quiet := func(level float64) func(int) IntervalSample {
+		return func(i int) IntervalSample { return vadInterval(i, level) }
+	}
+
+	appendRun(4, quiet(-60)) // 1 s pause, below roomToneCandidateMinDuration
+	appendRun(20, vadSpeechRich)
+	shortStart = time.Duration(idx) * analysisIntervalHop
+	appendRun(16, quiet(-58)) // 4 s
+	appendRun(20, vadSpeechRich)
+	longStart = time.Duration(idx) * analysisIntervalHop
+	appendRun(60, quiet(-62)) // 15 s
+	return iv, shortStart, longStart
+}
+
+func TestRoomToneCandidates(t *testing.T) {
+	hop := analysisIntervalHop
+	iv, shortStart, longStart := roomToneSelectFixture()
+	automatic := pickLowClusterRegion(iv, -30, axisMomentaryLUFS, hop, defaultRoomToneWindow)
+
+	candidates := roomToneCandidates(iv, -30, axisMomentaryLUFS, hop, defaultRoomToneWindow, automatic)
+	if len(candidates) != 2 {
+		t.Fatalf("got %d candidates, want 2 (the 1 s pause is too short)", len(candidates))
+	}
+	if candidates[0].Start != shortStart {
+		t.Errorf("candidates[0].Start = %v, want %v (timeline order)", candidates[0].Start, shortStart)
+	}
+	if candidates[0].Automatic {
+		t.Error("short run flagged Automatic, want only the elected region")
+	}
+	if !candidates[1].Automatic {
+		t.Error("elected region not flagged Automatic")
+	}
+	if candidates[1].Start < longStart || candidates[1].Duration != goldenWindowDuration {
+		t.Errorf("long run = %v+%v, want golden-refined window inside the run from %v",
+			candidates[1].Start, candidates[1].Duration, longStart)
+	}
+	if candidates[0].MomentaryLUFS != -58 || candidates[1].MomentaryLUFS != -62 {
+		t.Errorf("candidate levels = %.1f, %.1f; want -58, -62",
+			candidates[0].MomentaryLUFS, candidates[1].MomentaryLUFS)
+	}
+}
+
+func TestSelectRoomToneRegion(t *testing.T) {
+	hop := analysisIntervalHop
+	iv, shortStart, _ := roomToneSelectFixture()
+	automatic := pickLowClusterRegion(iv, -30, axisMomentaryLUFS, hop, defaultRoomToneWindow)
+
+	t.Run("user choice replaces the election", func(t *testing.T) {
+		region := selectRoomToneRegion(iv, -30, axisMomentaryLUFS, hop, defaultRoomToneWindow, automatic,
+			func([]RoomToneCandidate) int { return 0 }, nil)
+		if region.Start != shortStart {
+			t.Errorf("region.Start = %v, want chosen run start %v", region.Start, shortStart)
+		}
+	})
+
+	for _, idx := range []int{-1, 99} {
+		region := selectRoomToneRegion(iv, -30, axisMomentaryLUFS, hop, defaultRoomToneWindow, automatic,
+			func([]RoomToneCandidate) int { return idx }, nil)
+		if region != automatic {
+			t.Errorf("selector returned %d: region = %+v, want automatic %+v", idx, region, automatic)
+		}
+	}
+
+	t.Run("selector not consulted without candidates", func(t *testing.T) {
+		called := false
+		speech := []IntervalSample{vadSpeechRich(0), vadSpeechRich(1)}
+		region := selectRoomToneRegion(speech, -30, axisMomentaryLUFS, hop, defaultRoomToneWindow, nil,
+			func([]RoomToneCandidate) int { called = true; return 0 }, nil)
+		if called || region != nil {
+			t.Errorf("called=%v region=%+v, want selector skipped and nil region", called, region)
+		}
+	})
+}
+
+func TestSetNoiseRegion(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetNoiseRegion(30*time.Second, 15*time.Second); err != nil {
+		t.Fatalf("SetNoiseRegion: %v", err)
+	}
+	if !cfg.NoiseRegion.Enabled || cfg.NoiseRegion.End() != 45*time.Second {
+		t.Errorf("NoiseRegion = %+v, want 30 s to 45 s", cfg.NoiseRegion)
+	}
+	if err := cfg.SetNoiseRegion(-time.Second, 10*time.Second); err == nil {
+		t.Error("negative start accepted")
+	}
+	if err := cfg.SetNoiseRegion(0, time.Second); err == nil {
+		t.Error("1 s region accepted, want at least roomToneCandidateMinDuration")
+	}
+}
+
+func TestSetNoiseWindow(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if w := cfg.noiseWindow(); w != defaultRoomToneWindow {
+		t.Errorf("default window = %+v, want %+v", w, defaultRoomToneWindow)
+	}
+	if err := cfg.SetNoiseWindow(4*time.Second, 0); err != nil {
+		t.Fatalf("SetNoiseWindow: %v", err)
+	}
+	if w := cfg.noiseWindow(); w.Duration != 4*time.Second || w.Minimum != 4*time.Second {
+		t.Errorf("window = %+v, want 4 s with the minimum capped at the target", w)
+	}
+	if err := cfg.SetNoiseWindow(0, 6*time.Second); err != nil || cfg.NoiseWindow != goldenWindowDuration {
+		t.Errorf("SetNoiseWindow(0, 6s) = %v, target %v; want the default target", err, cfg.NoiseWindow)
+	}
+
+	for _, w := range []roomToneWindow{
+		{Duration: 15 * time.Second, Minimum: 20 * time.Second},
+		{Duration: 10100 * time.Millisecond},
+		{Duration: time.Second, Minimum: time.Second},
+		{Duration: -time.Second},
+	} {
+		if err := DefaultFilterConfig().SetNoiseWindow(w.Duration, w.Minimum); err == nil {
+			t.Errorf("SetNoiseWindow(%v, %v) accepted", w.Duration, w.Minimum)
+		}
+	}
+}
+
+func TestPickLowClusterRegionWindow(t *testing.T) {
+	iv, _, longStart := roomToneSelectFixture()
+	window := roomToneWindow{Duration: 4 * time.Second, Minimum: 4 * time.Second}
+	region := pickLowClusterRegion(iv, -30, axisMomentaryLUFS, analysisIntervalHop, window)
+	if region == nil || region.Start < longStart || region.Duration != 4*time.Second {
+		t.Errorf("region = %+v, want a 4 s window inside the 15 s run from %v", region, longStart)
+	}
+}
+
+func TestPinnedRoomToneRegion(t *testing.T) {
+	iv, shortStart, _ := roomToneSelectFixture()
+	total := time.Duration(len(iv)) * analysisIntervalHop
+
+	if region, err := pinnedRoomToneRegion(NoiseRegionConfig{}, iv, total); region != nil || err != nil {
+		t.Errorf("unpinned = %+v, %v; want nil, nil", region, err)
+	}
+
+	pin := NoiseRegionConfig{Enabled: true, Start: shortStart, Duration: 4 * time.Second}
+	region, err := pinnedRoomToneRegion(pin, iv, total)
+	if err != nil {
+		t.Fatalf("pinnedRoomToneRegion: %v", err)
+	}
+	if region.Start != shortStart || region.End != shortStart+4*time.Second {
+		t.Errorf("region = %+v, want the pinned bounds unrefined", region)
+	}
+
+	if _, err := pinnedRoomToneRegion(NoiseRegionConfig{Enabled: true, Start: total - time.Second, Duration: 4 * time.Second}, iv, total); err == nil {
+		t.Error("region past the end of the file accepted")
+	}
+	if _, err := pinnedRoomToneRegion(pin, nil, total); err == nil {
+		t.Error("region without intervals accepted")
+	}
+}
+
+func TestDetectVoiceActivityPinnedRegion(t *testing.T) {
+	iv, shortStart, _ := roomToneSelectFixture()
+	pinned := &RoomToneRegion{Start: shortStart, End: shortStart + 4*time.Second, Duration: 4 * time.Second}
+
+	m := &AudioMeasurements{}
+	detectVoiceActivity(m, iv, -70, analysisIntervalHop, axisMomentaryLUFS, defaultRoomToneWindow, pinned,
+		func([]RoomToneCandidate) int { t.Error("selector consulted despite a pinned region"); return -1 }, nil)
+
+	p := m.Regions.NoiseProfile
+	if p == nil {
+		t.Fatal("NoiseProfile nil, want the pinned region profiled")
+	}
+	if p.Start != shortStart || p.Duration != 4*time.Second || !p.Pinned || p.WasRefined {
+		t.Errorf("NoiseProfile = %v+%v pinned=%v refined=%v, want the pinned 4 s as given",
+			p.Start, p.Duration, p.Pinned, p.WasRefined)
+	}
+}
+
+func TestApplyNoiseFloorOverride(t *testing.T) {
+	iv, _, _ := roomToneSelectFixture()
+
+	m := &AudioMeasurements{}
+	detectVoiceActivity(m, iv, -70, analysisIntervalHop, axisMomentaryLUFS, defaultRoomToneWindow, nil, nil, nil)
+	if m.Regions.NoiseProfile == nil {
+		t.Fatal("fixture elected no room tone")
+	}
+	detected := m.Noise.Floor
+
+	applyNoiseFloorOverride(m, iv, 0, analysisIntervalHop, axisMomentaryLUFS, nil)
+	if m.Noise.Floor != detected || m.Regions.NoiseProfile == nil {
+		t.Errorf("zero floor changed the detection: floor %.1f, profile %v", m.Noise.Floor, m.Regions.NoiseProfile)
+	}
+
+	applyNoiseFloorOverride(m, iv, -55, analysisIntervalHop, axisMomentaryLUFS, nil)
+	if m.Noise.Floor != -55 || m.Noise.FloorSource != "user" {
+		t.Errorf("Floor = %.1f (%s), want -55 (user)", m.Noise.Floor, m.Noise.FloorSource)
+	}
+	if m.Regions.NoiseProfile != nil || m.Regions.ElectedRoomToneSample != nil {
+		t.Error("room tone kept, want it dropped with a user floor")
+	}
+}
+
+func TestSetNoiseFloor(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetNoiseFloor(-65); err != nil || cfg.NoiseFloorDB != -65 {
+		t.Errorf("SetNoiseFloor(-65) = %v, NoiseFloorDB %.1f", err, cfg.NoiseFloorDB)
+	}
+	for _, db := range []float64{-95, -25, math.NaN()} {
+		if err := cfg.SetNoiseFloor(db); err == nil {
+			t.Errorf("SetNoiseFloor(%v) accepted", db)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package processor
+
+import "time"
+
+// Wind buffeting and handling thumps are short, loud, low-frequency bursts. The
+// fixed rumble high-pass removes steady subsonic rumble, but a burst carries
+// enough energy just above its corner to punch through, so Pass 1 counts them and
+// tuneRumbleBursts steepens the high-pass when they are frequent.
+const (
+	// rumbleBurstMinExcessDB is how far above the noise floor an interval must
+	// sit to count towards a burst. Quiet room tone with a dark spectrum is not a
+	// burst; a thump or gust lifts the level well clear of the floor.
+	rumbleBurstMinExcessDB = 10.0
+
+	// rumbleBurstMaxDuration is the longest run of low-frequency intervals still
+	// counted as a burst. Longer runs are steady rumble (traffic, HVAC), which
+	// the fixed high-pass and the noise reduction already handle.
+	rumbleBurstMaxDuration = time.Second
+)
+
+// countRumbleBursts counts wind/handling bursts across the Pass 1 intervals: runs
+// of at most rumbleBurstMaxDuration whose spectral centroid sits below the
+// speech band (speechCentroidMin) and whose level is at least
+// rumbleBurstMinExcessDB above the noise floor on the VAD axis. The low
+// centroid separates a burst from speech of the same level; the run-length cap
+// separates it from steady rumble. Returns the count and the rate per minute of
+// audio.
+func countRumbleBursts(intervals []IntervalSample, floor float64, axis levelAxis, hop time.Duration) (int, float64) {
+	if len(intervals) == 0 || hop <= 0 {
+		return 0, 0
+	}
+
+	maxRun := int(rumbleBurstMaxDuration / hop)
+	count, run := 0, 0
+	endRun := func() {
+		if run > 0 && run <= maxRun {
+			count++
+		}
+		run = 0
+	}
+	for _, iv := range intervals {
+		level := intervalLevel(iv, axis)
+		burst := iv.Spectral.Centroid > 0 && iv.Spectral.Centroid < speechCentroidMin &&
+			!isFlooredLevel(level) && level >= floor+rumbleBurstMinExcessDB
+		if burst {
+			run++
+			continue
+		}
+		endRun()
+	}
+	endRun()
+
+	minutes := (time.Duration(len(intervals)) * hop).Minutes()
+	return count, float64(count) / minutes
+}
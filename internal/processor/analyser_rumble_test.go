@@ -0,0 +1,47 @@
+package processor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCountRumbleBursts(t *testing.T) {
+	hop := analysisIntervalHop
+	rumble := func(i int, level float64) IntervalSample {
+		s := vadInterval(i, level)
+		s.Spectral.Centroid = 90
+		return s
+	}
+
+	// One minute at 250 ms: room tone at -60 with speech, a 0.5 s thump, a 1 s
+	// gust, a quiet dark interval (room tone, not a burst), and 3 s of steady
+	// rumble (too long to be a burst).
+	var iv []IntervalSample
+	add := func(n int, mk func(int) IntervalSample) {
+		for range n {
+			iv = append(iv, mk(len(iv)))
+		}
+	}
+	quiet := func(i int) IntervalSample { return vadInterval(i, -60) }
+	add(20, vadSpeechRich)
+	add(2, func(i int) IntervalSample { return rumble(i, -30) })
+	add(20, quiet)
+	add(4, func(i int) IntervalSample { return rumble(i, -35) })
+	add(20, vadSpeechRich)
+	add(1, func(i int) IntervalSample { return rumble(i, -58) })
+	add(20, quiet)
+	add(12, func(i int) IntervalSample { return rumble(i, -30) })
+	add(141, quiet)
+
+	count, perMinute := countRumbleBursts(iv, -60, axisMomentaryLUFS, hop)
+	if count != 2 {
+		t.Errorf("count = %d, want 2 (thump + gust; quiet dark interval and steady rumble excluded)", count)
+	}
+	if math.Abs(perMinute-2) > 1e-9 {
+		t.Errorf("perMinute = %.3f, want 2 over one minute", perMinute)
+	}
+
+	if count, perMinute := countRumbleBursts(nil, -60, axisMomentaryLUFS, hop); count != 0 || perMinute != 0 {
+		t.Errorf("empty intervals = (%d, %.2f), want (0, 0)", count, perMinute)
+	}
+}
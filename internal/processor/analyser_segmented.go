@@ -0,0 +1,448 @@
+package processor
+
+import (
+	stdcontext "context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Segmented Pass 1 (--analysis-segments). A long input is cut into N time
+// segments, each decoded and measured by a filter graph of its own, and the
+// segments run concurrently. The interval series are laid end to end and the
+// spectral averages pool their frames, so both come out as they would from one
+// pass. The whole-file figures are merged the way they are defined:
+//
+//   - Integrated loudness applies the BS.1770 gates (gatedLoudness) to the
+//     momentary blocks of every segment, and the loudness range the EBU Tech
+//     3342 gates to the short-term blocks (loudnessRange). Neither can be
+//     averaged from per-segment figures, since each gate depends on the whole
+//     programme.
+//   - True and sample peak, and the astats extremes, take the maximum or
+//     minimum. RMS level, DC offset and the sample differences recombine by
+//     sample count; crest factor and dynamic range are rederived from them.
+//     Entropy is the sample-weighted mean of the segments'.
+//
+// ebur128 runs ahead of the segment's own trim, on analysisSegmentWarmUp of
+// the audio before it, so the momentary and short-term windows are full from
+// the segment's first sample. astats and aspectralstats sit after the trim and
+// measure the segment alone, in the sample format the single pass measures.
+const (
+	// maxAnalysisSegments caps --analysis-segments.
+	maxAnalysisSegments = 64
+
+	// analysisSegmentMin is the shortest segment worth a graph of its own;
+	// shorter inputs take fewer segments, down to a single pass.
+	analysisSegmentMin = 5 * time.Minute
+
+	// analysisSegmentWarmUp is the audio ebur128 reads ahead of a segment:
+	// its longest window, the 3 s short-term.
+	analysisSegmentWarmUp = 3 * time.Second
+
+	// loudnessAbsoluteGateLUFS is the BS.1770 absolute gate.
+	loudnessAbsoluteGateLUFS = -70.0
+
+	// loudnessRelativeGateLU is the BS.1770 relative gate below the
+	// absolute-gated mean.
+	loudnessRelativeGateLU = 10.0
+
+	// loudnessRangeRelativeGateLU is the EBU Tech 3342 relative gate below
+	// the absolute-gated short-term mean.
+	loudnessRangeRelativeGateLU = 20.0
+)
+
+// SetAnalysisSegments analyses inputs in n concurrent time segments
+// (--analysis-segments). Zero or one analyses every file in one pass.
+func (cfg *BaseFilterConfig) SetAnalysisSegments(n int) error {
+	if n < 0 || n > maxAnalysisSegments {
+		return fmt.Errorf("%d segments is outside [0, %d]", n, maxAnalysisSegments)
+	}
+	cfg.AnalysisSegments = n
+	return nil
+}
+
+// analysisSegment is the stretch of the input one segment measures: [start,
+// end), with a zero end on the last segment, which runs to the end of the file.
+type analysisSegment struct {
+	start, end time.Duration
+}
+
+// decodeStart is where the segment's graph starts reading: the warm-up
+// ahead of the segment, floored at the start of the file.
+func (s analysisSegment) decodeStart() time.Duration {
+	return max(s.start-analysisSegmentWarmUp, 0)
+}
+
+// planAnalysisSegments splits an input of length total into up to n equal
+// segments of at least analysisSegmentMin. A single segment means one pass.
+func planAnalysisSegments(total time.Duration, n int) []analysisSegment {
+	n = min(n, int(total/analysisSegmentMin))
+	if n < 2 {
+		return []analysisSegment{{}}
+	}
+	length := (total / time.Duration(n)).Truncate(time.Millisecond)
+	segments := make([]analysisSegment, n)
+	for i := range segments {
+		segments[i] = analysisSegment{start: length * time.Duration(i), end: length * time.Duration(i+1)}
+	}
+	segments[n-1].end = 0
+	return segments
+}
+
+// buildSegmentAnalysisSpec builds one segment's Pass 1 graph: the downmix and
+// ebur128 over the segment and its warm-up, then the trim to the segment and
+// astats and aspectralstats. sampleFmt is the format astats reads in the
+// single pass, the decoder's, restored after ebur128's double-precision
+// output; the pre-gain's volume stage already measures in double.
+func buildSegmentAnalysisSpec(cfg *EffectiveFilterConfig, seg analysisSegment, sampleFmt string) string {
+	in := fmt.Sprintf("atrim=start=%f", seg.decodeStart().Seconds())
+	if seg.end > 0 {
+		in += fmt.Sprintf(":end=%f", seg.end.Seconds())
+	}
+	specs := []string{in}
+	if downmix := cfg.buildDownmixFilter(); downmix != "" {
+		specs = append(specs, downmix)
+	}
+	if cfg.Downmix.PreGainDB != 0 {
+		specs = append(specs, quietPreGainSpec(-cfg.Downmix.PreGainDB))
+		sampleFmt = "dbl"
+	}
+	specs = append(specs,
+		fmt.Sprintf("%s:target=%.0f", ebur128AnalysisSpecPrefix, cfg.Loudnorm.TargetI),
+		fmt.Sprintf("atrim=start=%f", seg.start.Seconds()),
+		"aformat=sample_fmts="+sampleFmt,
+		astatsAnalysisSpec,
+	)
+	if spec := withSpectralStats(aspectralstatsAnalysisSpec); spec != "" {
+		specs = append(specs, spec)
+	}
+	return strings.Join(specs, ",")
+}
+
+// segmentFrames is what one segment's graph measured: the accumulators, the
+// interval series, and the momentary and short-term blocks for the merged
+// loudness gates.
+type segmentFrames struct {
+	acc       *metadataAccumulators
+	intervals []IntervalSample
+	momentary []float64
+	shortTerm []float64
+}
+
+// collectSegmentedAnalysisFrames is collectAnalysisFrames for an input cut
+// into segments: it measures them concurrently and merges the results in
+// order.
+func collectSegmentedAnalysisFrames(ctx stdcontext.Context, filename string, config *BaseFilterConfig, pass PassNumber,
+	segments []analysisSegment, metadata *audio.Metadata, progressCallback ProgressCallback,
+) (*analysisFrameCollection, error) {
+	config.logger.Logf("Pass 1 in %d segments with %v ebur128 warm-up", len(segments), analysisSegmentWarmUp)
+
+	analysisConfig := deriveEffectiveFilterConfig(config)
+	totalSamples := int64(metadata.Duration * float64(metadata.SampleRate))
+	var samplesRead, framesRead atomic.Int64
+	onRead := func(samples int64, level float64) {
+		done := samplesRead.Add(samples)
+		if framesRead.Add(1)%100 != 0 || progressCallback == nil || totalSamples <= 0 {
+			return
+		}
+		// Capped at BandPhaseProgressStart, as in the single pass; the band
+		// phase drives the rest.
+		progressCallback(ProgressUpdate{
+			Pass:     pass,
+			PassName: "Analysing",
+			Progress: min(float64(done)/float64(totalSamples)*BandPhaseProgressStart, BandPhaseProgressStart),
+			Level:    level,
+			Duration: metadata.Duration,
+		})
+	}
+
+	// The segments share the band decodes' semaphore, so a batch of files
+	// never runs more graphs than there are cores.
+	parts := make([]*segmentFrames, len(segments))
+	errs := make([]error, len(segments))
+	runBandMeasurements(ctx, len(segments), nil, func(i int) {
+		parts[i], errs[i] = collectSegmentFrames(ctx, filename, analysisConfig, segments[i], onRead, config.logger)
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("analysis segment %d of %d: %w", i+1, len(segments), err)
+		}
+	}
+
+	acc, intervals := mergeSegmentFrames(parts)
+	return newAnalysisFrameCollection(acc, intervals, metadata.Duration, metadata.SampleRate), nil
+}
+
+// collectSegmentFrames measures one segment on a reader of its own, seeked to
+// just before the warm-up. Input frames belong to the segment they start in;
+// reading stops at the first frame past its end.
+func collectSegmentFrames(ctx stdcontext.Context, filename string, config *EffectiveFilterConfig, seg analysisSegment,
+	onRead func(samples int64, level float64), log debugLogger,
+) (*segmentFrames, error) {
+	reader, _, err := audio.OpenAudioFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	defer reader.Close()
+	seekReaderBeforeRegion(reader, seg.decodeStart(), log)
+
+	decCtx := reader.DecoderContext()
+	spec := buildSegmentAnalysisSpec(config, seg, ffmpeg.AVGetSampleFmtName(decCtx.SampleFmt()).String())
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(decCtx, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filter graph: %w", err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	// Frame times come from the PTS, with the running sample count standing
+	// in for a frame that has none.
+	timeBase := decCtx.PktTimebase()
+	sampleRate := float64(decCtx.SampleRate())
+	var frameStart, next time.Duration
+	frameTime := func(frame *ffmpeg.AVFrame) time.Duration {
+		t := next
+		if pts := frame.Pts(); pts != ffmpeg.AVNoptsValue {
+			t = time.Duration(float64(pts) * float64(timeBase.Num()) / float64(timeBase.Den()) * float64(time.Second))
+		}
+		next = t + time.Duration(float64(frame.NbSamples())/sampleRate*float64(time.Second))
+		return t
+	}
+
+	part := &segmentFrames{acc: &metadataAccumulators{}}
+	series := intervalSeries{start: seg.start}
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnReadError: func(err error) error {
+			return fmt.Errorf("failed to read frame: %w", err)
+		},
+		OnPushError: func(err error) error {
+			return fmt.Errorf("failed to add frame to filter: %w", err)
+		},
+		OnPullError: func(err error) error {
+			return fmt.Errorf("failed to get filtered frame: %w", err)
+		},
+		StopReading: func(inputFrame *ffmpeg.AVFrame) bool {
+			frameStart = frameTime(inputFrame)
+			return seg.end > 0 && frameStart >= seg.end
+		},
+		OnInputFrame: func(inputFrame *ffmpeg.AVFrame) {
+			if frameStart < seg.start {
+				return
+			}
+			series.addInputFrame(inputFrame, frameStart)
+			onRead(int64(inputFrame.NbSamples()), calculateFrameLevel(inputFrame))
+		},
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			metadata := filteredFrame.Metadata()
+			spectral := extractSpectralMetrics(metadata)
+			loudness := extractFrameLoudnessMetrics(metadata)
+
+			extractFrameMetadata(metadata, part.acc, spectral, loudness)
+			series.addFiltered(extractIntervalFrameMetrics(spectral, loudness))
+			if loudness.momentaryFound {
+				part.momentary = append(part.momentary, loudness.momentary)
+			}
+			if loudness.shortTermFound {
+				part.shortTerm = append(part.shortTerm, loudness.shortTerm)
+			}
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	part.intervals = series.finish()
+	return part, nil
+}
+
+// mergeSegmentFrames combines the segments, in order, into the accumulators
+// and interval series one pass over the whole file would have produced.
+func mergeSegmentFrames(parts []*segmentFrames) (*metadataAccumulators, []IntervalSample) {
+	acc := &metadataAccumulators{baseMetadataAccumulators: mergeAstatsAccumulators(parts)}
+
+	var intervals []IntervalSample
+	var momentary, shortTerm []float64
+	for _, part := range parts {
+		intervals = append(intervals, part.intervals...)
+		momentary = append(momentary, part.momentary...)
+		shortTerm = append(shortTerm, part.shortTerm...)
+
+		// The peaks are cumulative within a segment, and a warm-up only
+		// rereads audio the file holds, so the maximum is the file's.
+		if !part.acc.ebur128Found {
+			continue
+		}
+		if !acc.ebur128Found || part.acc.ebur128InputTP > acc.ebur128InputTP {
+			acc.ebur128InputTP = part.acc.ebur128InputTP
+		}
+		if !acc.ebur128Found || part.acc.ebur128InputSP > acc.ebur128InputSP {
+			acc.ebur128InputSP = part.acc.ebur128InputSP
+		}
+		acc.ebur128Found = true
+	}
+	if !acc.ebur128Found {
+		return acc, intervals
+	}
+
+	// ebur128 reports -70 LUFS, its absolute gate, for a programme with no
+	// block above it.
+	acc.ebur128InputI = loudnessAbsoluteGateLUFS
+	if lufs, ok := gatedLoudness(momentary); ok {
+		acc.ebur128InputI = lufs
+	}
+	acc.ebur128InputLRA, _ = loudnessRange(shortTerm)
+
+	// The latest momentary and short-term readings are the last segment's.
+	last := parts[len(parts)-1].acc
+	acc.ebur128InputM = last.ebur128InputM
+	acc.ebur128InputS = last.ebur128InputS
+	return acc, intervals
+}
+
+// mergeAstatsAccumulators pools the segments' spectral frames and recombines
+// their whole-segment astats readings into whole-file ones.
+func mergeAstatsAccumulators(parts []*segmentFrames) baseMetadataAccumulators {
+	m := baseMetadataAccumulators{
+		astatsPeakLevel:     math.Inf(-1),
+		astatsRMSPeak:       math.Inf(-1),
+		astatsRMSTrough:     math.Inf(1),
+		astatsMaxDifference: math.Inf(-1),
+		astatsMinDifference: math.Inf(1),
+		astatsMinLevel:      math.Inf(-1),
+		astatsMaxLevel:      math.Inf(-1),
+		astatsNoiseFloor:    math.Inf(1),
+	}
+	var samples, sumSquares, dcOffset, meanDifference, differenceSquares, entropy float64
+	rangeOverPeak := math.Inf(-1)
+
+	for _, part := range parts {
+		b := &part.acc.baseMetadataAccumulators
+		m.spectral.merge(b.spectral)
+		if !b.astatsFound {
+			continue
+		}
+		m.astatsFound = true
+
+		n := b.astatsNumberOfSamples
+		samples += n
+		sumSquares += n * math.Pow(10, b.astatsRMSLevel/10)
+		dcOffset += n * b.astatsDCOffset
+		meanDifference += n * b.astatsMeanDifference
+		differenceSquares += n * b.astatsRMSDifference * b.astatsRMSDifference
+		entropy += n * b.astatsEntropy
+		m.astatsZeroCrossings += b.astatsZeroCrossings
+
+		// Flat factor and peak count describe the runs at the peak, so they
+		// come from the segments that reach it.
+		switch {
+		case b.astatsPeakLevel > m.astatsPeakLevel:
+			m.astatsPeakLevel = b.astatsPeakLevel
+			m.astatsPeakCount = b.astatsPeakCount
+			m.astatsFlatFactor = b.astatsFlatFactor
+		case b.astatsPeakLevel == m.astatsPeakLevel:
+			m.astatsPeakCount += b.astatsPeakCount
+			m.astatsFlatFactor = max(m.astatsFlatFactor, b.astatsFlatFactor)
+		}
+		switch {
+		case b.astatsNoiseFloor < m.astatsNoiseFloor:
+			m.astatsNoiseFloor = b.astatsNoiseFloor
+			m.astatsNoiseFloorCount = b.astatsNoiseFloorCount
+		case b.astatsNoiseFloor == m.astatsNoiseFloor:
+			m.astatsNoiseFloorCount += b.astatsNoiseFloorCount
+		}
+		m.astatsRMSPeak = max(m.astatsRMSPeak, b.astatsRMSPeak)
+		m.astatsRMSTrough = min(m.astatsRMSTrough, b.astatsRMSTrough)
+		m.astatsMaxDifference = max(m.astatsMaxDifference, b.astatsMaxDifference)
+		m.astatsMinDifference = min(m.astatsMinDifference, b.astatsMinDifference)
+		// MinLevel is the dBFS of the most negative sample, the largest in
+		// magnitude, so it merges by maximum too.
+		m.astatsMinLevel = max(m.astatsMinLevel, b.astatsMinLevel)
+		m.astatsMaxLevel = max(m.astatsMaxLevel, b.astatsMaxLevel)
+		m.astatsBitDepth = max(m.astatsBitDepth, b.astatsBitDepth)
+		// Dynamic range is the peak over the smallest non-zero sample, so its
+		// excess over the peak level carries that smallest sample across.
+		rangeOverPeak = max(rangeOverPeak, b.astatsDynamicRange-b.astatsPeakLevel)
+	}
+	if !m.astatsFound || samples <= 0 {
+		return baseMetadataAccumulators{spectral: m.spectral}
+	}
+
+	m.astatsNumberOfSamples = samples
+	m.astatsRMSLevel = 10 * math.Log10(sumSquares/samples)
+	m.astatsDCOffset = dcOffset / samples
+	m.astatsMeanDifference = meanDifference / samples
+	m.astatsRMSDifference = math.Sqrt(differenceSquares / samples)
+	m.astatsEntropy = entropy / samples
+	m.astatsZeroCrossingsRate = m.astatsZeroCrossings / samples
+	m.astatsDynamicRange = m.astatsPeakLevel + rangeOverPeak
+	// astats reports a crest factor of 1 (0 dB) for a silent signal.
+	if isFinite(m.astatsRMSLevel) {
+		m.astatsCrestFactor = m.astatsPeakLevel - m.astatsRMSLevel
+	}
+	return m
+}
+
+// gatedLoudness integrates momentary loudness blocks with the BS.1770 absolute
+// and relative gates: the energy mean of the blocks above -70 LUFS and within
+// 10 LU of their mean. ok is false when no block passes the absolute gate.
+func gatedLoudness(blocks []float64) (lufs float64, ok bool) {
+	mean := func(gate float64) (float64, bool) {
+		var energy float64
+		n := 0
+		for _, b := range blocks {
+			if b > gate && isFinite(b) {
+				energy += math.Pow(10, b/10)
+				n++
+			}
+		}
+		if n == 0 {
+			return 0, false
+		}
+		return 10 * math.Log10(energy/float64(n)), true
+	}
+
+	absolute, ok := mean(loudnessAbsoluteGateLUFS)
+	if !ok {
+		return 0, false
+	}
+	return mean(absolute - loudnessRelativeGateLU)
+}
+
+// loudnessRange is the EBU Tech 3342 loudness range of short-term loudness
+// blocks: the spread from the 10th to the 95th percentile of the blocks above
+// -70 LUFS and within 20 LU of their energy mean. ok is false when no block
+// passes the absolute gate.
+func loudnessRange(blocks []float64) (lu float64, ok bool) {
+	var gated []float64
+	var energy float64
+	for _, b := range blocks {
+		if b > loudnessAbsoluteGateLUFS && isFinite(b) {
+			gated = append(gated, b)
+			energy += math.Pow(10, b/10)
+		}
+	}
+	if len(gated) == 0 {
+		return 0, false
+	}
+	relative := 10*math.Log10(energy/float64(len(gated))) - loudnessRangeRelativeGateLU
+
+	kept := gated[:0]
+	for _, b := range gated {
+		if b > relative {
+			kept = append(kept, b)
+		}
+	}
+	slices.Sort(kept)
+	percentile := func(p float64) float64 {
+		return kept[int(math.Round(p*float64(len(kept)-1)))]
+	}
+	return percentile(0.95) - percentile(0.10), true
+}
@@ -0,0 +1,131 @@
+package processor
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanAnalysisSegments(t *testing.T) {
+	if got := planAnalysisSegments(3*time.Hour, 0); len(got) != 1 {
+		t.Errorf("segments off: %d segments, want 1", len(got))
+	}
+	if got := planAnalysisSegments(12*time.Minute, 8); len(got) != 2 {
+		t.Errorf("12m in 8: %d segments, want 2 of at least %v", len(got), analysisSegmentMin)
+	}
+
+	got := planAnalysisSegments(2*time.Hour, 4)
+	if len(got) != 4 {
+		t.Fatalf("2h in 4: %d segments, want 4", len(got))
+	}
+	for i, seg := range got {
+		if want := time.Duration(i) * 30 * time.Minute; seg.start != want {
+			t.Errorf("segment %d starts at %v, want %v", i, seg.start, want)
+		}
+		if i > 0 && got[i-1].end != seg.start {
+			t.Errorf("segment %d starts at %v, previous ends at %v", i, seg.start, got[i-1].end)
+		}
+	}
+	if got[3].end != 0 {
+		t.Errorf("last segment ends at %v, want open", got[3].end)
+	}
+	if got[0].decodeStart() != 0 || got[1].decodeStart() != 30*time.Minute-analysisSegmentWarmUp {
+		t.Errorf("decode starts %v, %v, want 0 and the warm-up ahead of 30m", got[0].decodeStart(), got[1].decodeStart())
+	}
+}
+
+func TestBuildSegmentAnalysisSpec(t *testing.T) {
+	cfg := deriveEffectiveFilterConfig(DefaultFilterConfig())
+	spec := buildSegmentAnalysisSpec(cfg, analysisSegment{start: 30 * time.Minute, end: time.Hour}, "s16")
+	want := []string{
+		"atrim=start=1797.000000:end=3600.000000,aformat=channel_layouts=mono,",
+		ebur128AnalysisSpecPrefix,
+		"atrim=start=1800.000000,aformat=sample_fmts=s16," + astatsAnalysisSpec,
+	}
+	last := -1
+	for _, w := range want {
+		i := strings.Index(spec, w)
+		if i <= last {
+			t.Fatalf("spec missing or misordered %q\n%s", w, spec)
+		}
+		last = i
+	}
+
+	cfg.Downmix.PreGainDB = 20
+	spec = buildSegmentAnalysisSpec(cfg, analysisSegment{start: 30 * time.Minute}, "s16")
+	if strings.Contains(spec, ":end=") || !strings.Contains(spec, "volume=-20.00dB:precision=double,ebur128") ||
+		!strings.Contains(spec, "aformat=sample_fmts=dbl,") {
+		t.Errorf("last segment with pre-gain\n%s", spec)
+	}
+}
+
+func TestLoudnessRange(t *testing.T) {
+	if _, ok := loudnessRange([]float64{-80, math.Inf(-1)}); ok {
+		t.Error("no block above the absolute gate, want ok=false")
+	}
+
+	// 21 blocks from -30 to -10 LUFS in 1 LU steps, plus silence below the
+	// relative gate: the range is the 95th less the 10th percentile.
+	var blocks []float64
+	for l := -30.0; l <= -10; l++ {
+		blocks = append(blocks, l)
+	}
+	blocks = append(blocks, -65, -120)
+	got, ok := loudnessRange(blocks)
+	if !ok || math.Abs(got-17) > 1e-9 {
+		t.Errorf("loudnessRange = %.2f (ok=%v), want 17", got, ok)
+	}
+}
+
+func TestMergeAstatsAccumulators(t *testing.T) {
+	part := func(n, rms, peak, dr, floor float64) *segmentFrames {
+		acc := &metadataAccumulators{}
+		acc.astatsFound = true
+		acc.astatsNumberOfSamples = n
+		acc.astatsRMSLevel = rms
+		acc.astatsPeakLevel = peak
+		acc.astatsPeakCount = 2
+		acc.astatsDynamicRange = dr
+		acc.astatsNoiseFloor = floor
+		acc.astatsNoiseFloorCount = 5
+		acc.astatsZeroCrossings = n / 10
+		acc.astatsDCOffset = 0.001
+		acc.spectral.Add(SpectralMetrics{Centroid: 1000, Found: true})
+		return &segmentFrames{acc: acc}
+	}
+	// Equal halves at -20 and -30 dBFS RMS: the mean square is the mean of
+	// the two.
+	m := mergeAstatsAccumulators([]*segmentFrames{
+		part(1000, -20, -3, 80, -70),
+		part(1000, -30, -1, 70, -70),
+	})
+
+	wantRMS := 10 * math.Log10((math.Pow(10, -2)+math.Pow(10, -3))/2)
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"RMSLevel", m.astatsRMSLevel, wantRMS},
+		{"PeakLevel", m.astatsPeakLevel, -1},
+		{"PeakCount", m.astatsPeakCount, 2},
+		{"CrestFactor", m.astatsCrestFactor, -1 - wantRMS},
+		{"DynamicRange", m.astatsDynamicRange, -1 + 83},
+		{"NoiseFloorCount", m.astatsNoiseFloorCount, 10},
+		{"NumberOfSamples", m.astatsNumberOfSamples, 2000},
+		{"ZeroCrossingsRate", m.astatsZeroCrossingsRate, 0.1},
+		{"DCOffset", m.astatsDCOffset, 0.001},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if avg := m.finalizeSpectral(); !avg.Found || avg.Centroid != 1000 {
+		t.Errorf("spectral = %+v, want the pooled frames", avg)
+	}
+
+	if got := mergeAstatsAccumulators([]*segmentFrames{{acc: &metadataAccumulators{}}}); got.astatsFound {
+		t.Error("no segment measured astats, want astatsFound=false")
+	}
+}
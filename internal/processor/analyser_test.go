@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"testing"
 	"time"
 
@@ -381,6 +382,23 @@ func TestScoreIntervalWindow(t *testing.T) {
 	}
 }
 
+func TestScoreIntervalWindowStability(t *testing.T) {
+	steady := makeTestIntervals(0, []float64{-66, -66, -66, -66, -66, -66, -66, -66})
+	creak := makeTestIntervals(0, []float64{-68, -68, -68, -68, -68, -68, -68, -68})
+	for i := range steady {
+		steady[i].Spectral.Flux, steady[i].Spectral.Centroid = 0.010, 1500
+		creak[i].Spectral.Flux, creak[i].Spectral.Centroid = 0.010, 1500
+	}
+	creak[4].Spectral.Flux, creak[4].Spectral.Centroid = 0.080, 3200
+
+	if got := scoreIntervalWindow(steady); math.Abs(got-(-66)) > 0.001 {
+		t.Errorf("steady window = %.2f, want its RMS -66 with no penalty", got)
+	}
+	if s, c := scoreIntervalWindow(steady), scoreIntervalWindow(creak); c <= s {
+		t.Errorf("creak window %.2f beats steady window %.2f, want the 2 dB quieter transient penalised past it", c, s)
+	}
+}
+
 // ============================================================================
 // Speech Detection Tests
 // ============================================================================
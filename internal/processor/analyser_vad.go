@@ -400,12 +400,38 @@ func hysteresisMargin(h histogram, split float64) float64 {
 // gapToleranceIntervals measures the inter-speech gaps in a first speech-flag
 // pass and returns clamp(p75(gaps), vadGapToleranceFloor, vadGapToleranceCeiling)
 // converted to interval counts against the hop. Only gaps bounded by speech on
-// both sides count: the trailing post-speech tail to EOF is excluded (it is not
-// a bridgeable gap). With no interior gap, the floor applies.
+// both sides count (interiorGaps): the trailing post-speech tail to EOF is
+// excluded (it is not a bridgeable gap). With no interior gap, the floor applies.
 func gapToleranceIntervals(flags []bool, hop time.Duration) int {
 	floor := intervalsForDuration(vadGapToleranceFloor, hop)
 	ceiling := intervalsForDuration(vadGapToleranceCeiling, hop)
 
+	runs := interiorGaps(flags)
+	if len(runs) == 0 {
+		return floor
+	}
+
+	gaps := make([]float64, len(runs))
+	for i, g := range runs {
+		gaps[i] = float64(g.length)
+	}
+	slices.Sort(gaps)
+	p75 := int(math.Round(percentileOfSorted(gaps, 75)))
+	return max(floor, min(ceiling, p75))
+}
+
+// gapRun is one run of non-speech intervals: the index of its first interval and
+// its length in intervals.
+type gapRun struct {
+	start  int
+	length int
+}
+
+// interiorGaps returns the runs of non-speech flags strictly between the first
+// and last speech flag, in timeline order. Leading silence before the first
+// speech and the tail after the last are not gaps between speech, so they are
+// excluded. Returns nil when there is no speech.
+func interiorGaps(flags []bool) []gapRun {
 	firstSpeech := -1
 	lastSpeech := -1
 	for i, f := range flags {
@@ -417,30 +443,22 @@ func gapToleranceIntervals(flags []bool, hop time.Duration) int {
 		}
 	}
 	if firstSpeech < 0 {
-		return floor
+		return nil
 	}
 
-	// Measure runs of non-speech strictly between the first and last speech flag.
-	var gaps []float64
+	var gaps []gapRun
 	gapLen := 0
 	for i := firstSpeech; i <= lastSpeech; i++ {
 		if flags[i] {
 			if gapLen > 0 {
-				gaps = append(gaps, float64(gapLen))
+				gaps = append(gaps, gapRun{start: i - gapLen, length: gapLen})
 			}
 			gapLen = 0
 			continue
 		}
 		gapLen++
 	}
-
-	if len(gaps) == 0 {
-		return floor
-	}
-
-	slices.Sort(gaps)
-	p75 := int(math.Round(percentileOfSorted(gaps, 75)))
-	return max(floor, min(ceiling, p75))
+	return gaps
 }
 
 // speechFlags returns the per-interval speech flag (isSpeechInterval) over the
@@ -627,10 +645,28 @@ func electSpeechProfile(runs []SpeechRegion, intervals []IntervalSample, noisePr
 // room-tone election: one split places every below-split interval in the noise
 // cluster, and the longest such run is the steadiest sample of it. Returns nil
 // when no below-split run exists.
-func pickLowClusterRegion(intervals []IntervalSample, split float64, axis levelAxis, hop time.Duration) *RoomToneRegion {
+func pickLowClusterRegion(intervals []IntervalSample, split float64, axis levelAxis, hop time.Duration, window roomToneWindow) *RoomToneRegion {
 	var best *RoomToneRegion
+	for _, run := range lowClusterRuns(intervals, split, axis, hop) {
+		if best == nil || run.Duration > best.Duration {
+			best = &run
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+	return refineRoomToneRegion(*best, intervals, window)
+}
+
+// lowClusterRuns returns every contiguous run of below-split intervals in
+// timeline order. pickLowClusterRegion elects the longest; the interactive
+// room-tone picker offers the longer runs to the user. The lead-in guard
+// intervals never open a run, so a run at the head of the file starts after the
+// fade-up.
+func lowClusterRuns(intervals []IntervalSample, split float64, axis levelAxis, hop time.Duration) []RoomToneRegion {
+	var runs []RoomToneRegion
 	var runStart time.Duration
-	var runLen int
 	inRun := false
 
 	closeRun := func(endIdx int) {
@@ -638,22 +674,17 @@ func pickLowClusterRegion(intervals []IntervalSample, split float64, axis levelA
 			return
 		}
 		endTime := intervals[endIdx].Timestamp + hop
-		region := &RoomToneRegion{Start: runStart, End: endTime, Duration: endTime - runStart}
-		if best == nil || region.Duration > best.Duration {
-			best = region
-		}
+		runs = append(runs, RoomToneRegion{Start: runStart, End: endTime, Duration: endTime - runStart})
 		inRun = false
-		runLen = 0
 	}
 
 	for i := range intervals {
-		below := intervalLevel(intervals[i], axis) < split
+		below := i >= leadInGuardIntervals && intervalLevel(intervals[i], axis) < split
 		if below {
 			if !inRun {
 				runStart = intervals[i].Timestamp
 				inRun = true
 			}
-			runLen++
 			continue
 		}
 		if inRun {
@@ -664,24 +695,25 @@ func pickLowClusterRegion(intervals []IntervalSample, split float64, axis levelA
 		closeRun(len(intervals) - 1)
 	}
 
-	if best == nil {
-		return nil
-	}
+	return runs
+}
 
-	// Golden refinement: trim a long quiet run to its cleanest (lowest-RMS) inner
-	// window, biasing the noise sample inward. Reuses the shared sliding-window
-	// refinement with the room-tone window bounds.
+// refineRoomToneRegion applies the golden refinement to a room-tone run: trim a
+// long quiet run to its cleanest (quietest, spectrally steadiest) inner window,
+// biasing the noise sample inward. Reuses the shared sliding-window refinement with the room-tone
+// window bounds; a run too short to refine is returned as-is.
+func refineRoomToneRegion(region RoomToneRegion, intervals []IntervalSample, window roomToneWindow) *RoomToneRegion {
 	refined, ok := refineToSubregion(
-		refineRegion{Start: best.Start, End: best.End, Duration: best.Duration},
+		refineRegion{Start: region.Start, End: region.End, Duration: region.Duration},
 		intervals,
-		goldenWindowDuration, goldenWindowMinimum,
+		window.Duration, window.Minimum,
 		scoreIntervalWindow,
 		func(candidate, current float64) bool { return candidate < current },
 	)
 	if ok {
 		return &RoomToneRegion{Start: refined.Start, End: refined.End, Duration: refined.Duration}
 	}
-	return best
+	return &region
 }
 
 // vadVoiceActivatedFraction is the floored (digital-silence) interval fraction
@@ -725,7 +757,12 @@ func flooredFraction(intervals []IntervalSample, axis levelAxis) float64 {
 // filters consume: the elected SpeechProfile and the NoiseProfile / Noise.Floor.
 // It replaces the selectNoiseProfile + selectSpeechProfile pair. The body only
 // wires the per-stage helpers; the maths lives in those helpers.
-func detectVoiceActivity(measurements *AudioMeasurements, intervals []IntervalSample, noiseFloorSeed float64, hop time.Duration, axis levelAxis, log debugLogger) {
+//
+// pinned is the room-tone region fixed by --noise-region, used as given; nil
+// elects one, refined to window. selectRoomTone is the optional user override
+// for the room-tone region (see RoomToneSelector); nil keeps the automatic
+// longest-run election.
+func detectVoiceActivity(measurements *AudioMeasurements, intervals []IntervalSample, noiseFloorSeed float64, hop time.Duration, axis levelAxis, window roomToneWindow, pinned *RoomToneRegion, selectRoomTone RoomToneSelector, log debugLogger) {
 	const histogramBinWidthDB = 1.0
 
 	histogram := buildLevelHistogram(intervals, axis, histogramBinWidthDB)
@@ -734,21 +771,50 @@ func detectVoiceActivity(measurements *AudioMeasurements, intervals []IntervalSa
 
 	split := clampSplit(otsuSplit(histogram), noiseFloorSeed, p75)
 	floor := percentileFloor(levels, noiseFloorSeed)
+	floorSource := "vad_percentile"
 
 	flags := speechFlags(intervals, split, axis)
+
+	// Source AGC swells the noise through every pause, so the gap-weighted
+	// percentile reads the pumped level. Read the floor from the mid-speech
+	// troughs instead, where the noise still sits at the under-speech gain.
+	agcRise, agcDetected := measureAGCRise(intervals, flags, axis)
+	measurements.Noise.AGCRiseDB = agcRise
+	measurements.Noise.AGCDetected = agcDetected
+	if agcDetected {
+		if trough, ok := agcTroughFloor(intervals, flags, axis); ok && trough < floor {
+			floor = trough
+			floorSource = "agc_troughs"
+		}
+	}
+	if decay, ok := measureSpeechDecay(intervals, flags, hop); ok {
+		measurements.Noise.SpeechDecayMS = float64(decay.Milliseconds())
+	}
 	margin := hysteresisMargin(histogram, split)
 	tol := gapToleranceIntervals(flags, hop)
+	measurements.Regions.Pauses = newPauseStatistics(flags, hop)
 
 	runs := buildSpeechRuns(intervals, split, margin, tol, axis, hop)
 	measurements.Regions.SpeechRegions = runs
-
-	noiseRegion := pickLowClusterRegion(intervals, split, axis, hop)
+	measurements.Regions.Polarity = analysePolarity(intervals, runs)
+
+	var noiseRegion *RoomToneRegion
+	switch {
+	case pinned != nil:
+		noiseRegion = pinned
+		log.Logf("VAD: room-tone region pinned: %.2fs-%.2fs", pinned.Start.Seconds(), pinned.End.Seconds())
+	case selectRoomTone != nil:
+		noiseRegion = selectRoomToneRegion(intervals, split, axis, hop, window, pickLowClusterRegion(intervals, split, axis, hop, window), selectRoomTone, log)
+	default:
+		noiseRegion = pickLowClusterRegion(intervals, split, axis, hop, window)
+	}
 	var noiseProfile *NoiseProfile
 	if noiseRegion != nil {
 		noiseProfile = extractNoiseProfileFromIntervals(noiseRegion, intervals)
 	}
 	if noiseProfile != nil {
 		noiseProfile.MeasuredNoiseFloor = floor
+		noiseProfile.Pinned = pinned != nil
 		measurements.Regions.NoiseProfile = noiseProfile
 		setVADRoomToneSample(measurements, noiseRegion, intervals)
 	}
@@ -773,13 +839,14 @@ func detectVoiceActivity(measurements *AudioMeasurements, intervals []IntervalSa
 	measurements.Regions.GateSeparationDB = gateStats.SeparationDB
 
 	measurements.Noise.Floor = floor
-	measurements.Noise.FloorSource = "vad_percentile"
+	measurements.Noise.FloorSource = floorSource
 	flooredFrac := flooredFraction(intervals, axis)
 	measurements.Noise.FlooredFraction = flooredFrac
 	measurements.Noise.VoiceActivated = flooredFrac >= vadVoiceActivatedFraction
+	measurements.Noise.RumbleBurstCount, measurements.Noise.RumbleBurstsPerMinute = countRumbleBursts(intervals, floor, axis, hop)
 
-	log.Logf("VAD: split=%.1f dB (axis=%d), floor=%.1f dB, margin=%.2f dB, gapTol=%d, runs=%d, speechElected=%v, noiseRegion=%v",
-		split, axis, floor, margin, tol, len(runs), profile != nil, noiseRegion != nil)
+	log.Logf("VAD: split=%.1f dB (axis=%d), floor=%.1f dB (%s), margin=%.2f dB, gapTol=%d, runs=%d, speechElected=%v, noiseRegion=%v, rumbleBursts=%d, agcRise=%.1f dB",
+		split, axis, floor, floorSource, margin, tol, len(runs), profile != nil, noiseRegion != nil, measurements.Noise.RumbleBurstCount, agcRise)
 }
 
 // setVADRoomToneSample measures the elected low-cluster region's RegionSample
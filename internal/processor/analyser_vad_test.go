@@ -398,8 +398,8 @@ func TestEstimateNoiseFloorAndThreshold_TruncationPicksLowestRMS(t *testing.T) {
 	// All quiet intervals tie at score 1.0; the louder ones score lower. The
 	// truncated seed set keeps floorSeedTopPercent of the scored set (len/5).
 	// The deterministic tie-break orders the tied run lowest-RMS first, so the
-	// seeded noise floor (max RMS over the truncation) must equal the highest RMS
-	// among only the kept lowest-RMS intervals, not any louder tied member.
+	// seeded noise floor (the confidence-weighted percentile over the truncation)
+	// must come from only the kept lowest-RMS intervals, not any louder tied member.
 	const total = 50
 	const tiedCount = 25
 	var intervals []IntervalSample
@@ -423,13 +423,54 @@ func TestEstimateNoiseFloorAndThreshold_TruncationPicksLowestRMS(t *testing.T) {
 	// candidateCount = len/floorSeedTopPercent, floored at floorSeedMinCount.
 	candidateCount := max(total/floorSeedTopPercent, floorSeedMinCount) // 10
 	// The kept tied intervals are the candidateCount lowest RMS values, starting
-	// at -80 dB in 1 dB steps; the highest kept RMS is the seeded floor.
-	wantFloor := -80.0 + float64(candidateCount-1)
+	// at -80 dB in 1 dB steps, all equally weighted; the floor is the first level
+	// whose cumulative weight reaches floorSeedConfidencePercentile.
+	wantFloor := -80.0 + math.Ceil(floorSeedConfidencePercentile*float64(candidateCount)) - 1
 	if math.Abs(floor-wantFloor) > 0.001 {
 		t.Errorf("seeded floor = %.3f, want %.3f (lowest-RMS tied intervals kept)", floor, wantFloor)
 	}
 }
 
+func TestEstimateNoiseFloorAndThreshold_ContaminatedIntervalOutweighed(t *testing.T) {
+	// One seed candidate carries leaked speech: it still scores as room tone but
+	// sits 20 dB above the rest and its spectrum is tonal. The weighted floor must
+	// stay with the clean room tone, where a hard max would jump to the leak.
+	noisy := func(level float64) IntervalSample {
+		iv := seedInterval(level, 0.01)
+		iv.Spectral.Flatness, iv.Spectral.Entropy = 0.6, 0.8
+		return iv
+	}
+	var intervals []IntervalSample
+	for range 20 {
+		intervals = append(intervals, noisy(-70))
+	}
+	leak := seedInterval(-50, 0.01)
+	leak.Spectral.Flatness, leak.Spectral.Entropy = 0.05, 0.2
+	intervals = append(intervals, leak)
+	for i := range 29 {
+		intervals = append(intervals, seedInterval(-30+float64(i%5), 0.50))
+	}
+
+	medians := computeSilenceMedians(intervals)
+	floor, _, ok := estimateNoiseFloorAndThreshold(intervals, medians)
+	if !ok {
+		t.Fatal("estimateNoiseFloorAndThreshold returned ok=false on a valid set")
+	}
+	if floor != -70 {
+		t.Errorf("seeded floor = %.3f, want -70 (contaminated interval outweighed)", floor)
+	}
+}
+
+func TestWeightedPercentile(t *testing.T) {
+	values := []float64{-60, -70, -65, -40}
+	if got := weightedPercentile(values, []float64{1, 1, 1, 0.1}, 0.8); got != -60 {
+		t.Errorf("weighted p80 = %v, want -60 (light outlier skipped)", got)
+	}
+	if got := weightedPercentile(values, []float64{0, 0, 0, 0}, 0.5); got != -60 {
+		t.Errorf("unweighted p50 = %v, want -60", got)
+	}
+}
+
 func TestEstimateNoiseFloorAndThreshold_ExcludesFlooredFromSeed(t *testing.T) {
 	// Voice-activated capture: the quietest, lowest-flux intervals are true digital
 	// silence (floored at -130, below vadLevelFloorDB) and must NOT seed the floor.
@@ -475,6 +516,46 @@ func TestEstimateNoiseFloorAndThreshold_AllFlooredReturnsNotOK(t *testing.T) {
 	}
 }
 
+// TestSeedSearchIntervalsLeadInGuard confirms the fade-up interval is kept out
+// of the seed set, and that a file too short to spare it keeps every interval.
+func TestSeedSearchIntervalsLeadInGuard(t *testing.T) {
+	var intervals []IntervalSample
+	intervals = append(intervals, seedInterval(-90, 0.01)) // fade-up ramp
+	for range silenceThresholdMinIntervals + 5 {
+		intervals = append(intervals, seedInterval(-60, 0.01))
+	}
+
+	got := seedSearchIntervals(intervals)
+	if len(got) != len(intervals)-leadInGuardIntervals || got[0].MomentaryLUFS != -60 {
+		t.Errorf("seed set = %d intervals starting at %.0f, want the fade-up dropped",
+			len(got), got[0].MomentaryLUFS)
+	}
+
+	short := intervals[:silenceThresholdMinIntervals]
+	if got := seedSearchIntervals(short); len(got) != len(short) {
+		t.Errorf("short seed set = %d intervals, want all %d kept", len(got), len(short))
+	}
+}
+
+// TestLowClusterRunsSkipLeadIn confirms a quiet run at the head of the file
+// starts after the lead-in guard interval.
+func TestLowClusterRunsSkipLeadIn(t *testing.T) {
+	hop := analysisIntervalHop
+	var iv []IntervalSample
+	for i := range 12 {
+		iv = append(iv, vadInterval(i, -60))
+	}
+	iv = append(iv, vadSpeechRich(12))
+
+	runs := lowClusterRuns(iv, -30, axisMomentaryLUFS, hop)
+	if len(runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(runs))
+	}
+	if want := time.Duration(leadInGuardIntervals) * hop; runs[0].Start != want {
+		t.Errorf("run start = %v, want %v (after the lead-in guard)", runs[0].Start, want)
+	}
+}
+
 func TestFlooredFraction_BoundaryAtThreshold(t *testing.T) {
 	// Guards the live >= test against vadVoiceActivatedFraction (0.20). A slice at
 	// exactly 0.20 floored must flag voice-activated; one just under must not.
@@ -852,7 +933,7 @@ func TestPickLowClusterRegion(t *testing.T) {
 		idx++
 	}
 
-	region := pickLowClusterRegion(iv, -30, axisMomentaryLUFS, hop)
+	region := pickLowClusterRegion(iv, -30, axisMomentaryLUFS, hop, defaultRoomToneWindow)
 	if region == nil {
 		t.Fatal("pickLowClusterRegion returned nil, want the long quiet run")
 	}
@@ -1173,7 +1254,7 @@ func TestDetectVoiceActivity(t *testing.T) {
 	}
 
 	m := &AudioMeasurements{}
-	detectVoiceActivity(m, iv, -70, hop, axisMomentaryLUFS, nil)
+	detectVoiceActivity(m, iv, -70, hop, axisMomentaryLUFS, defaultRoomToneWindow, nil, nil, nil)
 
 	if m.Regions.SpeechProfile == nil {
 		t.Error("SpeechProfile nil, want elected speech region")
@@ -1231,7 +1312,7 @@ func TestDetectVoiceActivity_NoProfileLeavesVoicedPercentileZero(t *testing.T) {
 	}
 
 	m := &AudioMeasurements{}
-	detectVoiceActivity(m, iv, -70, hop, axisMomentaryLUFS, nil)
+	detectVoiceActivity(m, iv, -70, hop, axisMomentaryLUFS, defaultRoomToneWindow, nil, nil, nil)
 
 	if m.Regions.SpeechProfile != nil {
 		t.Fatal("SpeechProfile elected, want none for a flat low-level stream")
@@ -0,0 +1,172 @@
+package processor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+)
+
+// Analysis cache (--cache-dir, --no-cache), off unless a directory is given.
+// Pass 1 depends only on the input's bytes, the analysis graph and the
+// analyser itself, so a re-run that changes only rendering options (the
+// loudness target, the output format) can reuse it. Entries are keyed by a
+// SHA-256 over the input file, the Pass 1 filter spec, the jivetalking version
+// and the running binary (analysisBuildID), so a new release, a rebuilt "dev"
+// binary or a changed analysis chain misses rather than reusing stale
+// measurements. Each entry holds the whole 250 ms interval series and nothing
+// is pruned, so the directory is the user's to clear.
+//
+// Entries are gob, not JSON: the measurements carry NaN and ±Inf sentinels
+// JSON cannot encode, and several fields are json:"-" in the run-record shape.
+const (
+	// analysisCacheFormat is mixed into every key; bump it when the entry
+	// layout or AudioMeasurements changes shape.
+	analysisCacheFormat = "jivetalking-analysis-v3"
+
+	analysisCacheExt = ".gob"
+)
+
+// analysisBuildID identifies the analyser for the cache key: the SHA-256 of
+// the running executable, so any rebuild misses, local builds that all report
+// "dev" included. When the executable cannot be read it falls back to the VCS
+// revision of a clean build, then to RunVersion alone. Computed once per
+// process.
+var analysisBuildID = sync.OnceValue(func() string {
+	if exe, err := os.Executable(); err == nil {
+		h := sha256.New()
+		if err := hashFileInto(h, exe); err == nil {
+			return "exe:" + hex.EncodeToString(h.Sum(nil))
+		}
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		var revision, modified string
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+			case "vcs.modified":
+				modified = s.Value
+			}
+		}
+		if revision != "" && modified != "true" {
+			return "vcs:" + revision
+		}
+	}
+	return "version:" + RunVersion
+})
+
+// analysisCacheKey hashes the input file with everything else Pass 1 reads:
+// the analysis filter spec, the pinned noise region and floor, the segment
+// count, any loaded noise profile, the version, and the analyser build.
+func analysisCacheKey(inputPath string, config *BaseFilterConfig) (string, error) {
+	analysisConfig := deriveEffectiveFilterConfig(config)
+	analysisConfig.FilterOrder = cloneFilterOrder(Pass1FilterOrder)
+	// ebur128's target only sets the zero of its meter display, never the
+	// measured values, and TargetOffset is recomputed on a hit, so the render
+	// target stays out of the key.
+	analysisConfig.Loudnorm.TargetI = 0
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00", analysisCacheFormat, RunVersion, analysisBuildID(), analysisConfig.BuildFilterSpec())
+	if r := config.NoiseRegion; r.Enabled {
+		fmt.Fprintf(h, "noise_region=%d:%d\x00", r.Start, r.Duration)
+	}
+	if config.NoiseWindow != 0 {
+		fmt.Fprintf(h, "noise_window=%d:%d\x00", config.NoiseWindow, config.NoiseWindowMin)
+	}
+	if config.NoiseFloorDB != 0 {
+		fmt.Fprintf(h, "noise_floor=%g\x00", config.NoiseFloorDB)
+	}
+	if config.AnalysisSegments > 1 {
+		fmt.Fprintf(h, "segments=%d\x00", config.AnalysisSegments)
+	}
+	if config.NoiseProfileIn != nil {
+		profile, err := json.Marshal(config.NoiseProfileIn)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "noise_profile=%s\x00", profile)
+	}
+	if err := hashFileInto(h, inputPath); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// loadCachedAnalysis reads the entry for key; ok is false on a miss or an
+// unreadable entry, which is then simply recomputed.
+func loadCachedAnalysis(dir, key string) (*AudioMeasurements, bool) {
+	f, err := os.Open(filepath.Join(dir, key+analysisCacheExt))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var m AudioMeasurements
+	if err := gob.NewDecoder(f).Decode(&m); err != nil {
+		return nil, false
+	}
+	return &m, true
+}
+
+// storeCachedAnalysis writes the entry for key through a temp file and a
+// rename, so a concurrent reader never sees a partial entry.
+func storeCachedAnalysis(dir, key string, m *AudioMeasurements) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".analysis-*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := f.Name()
+	defer func() { _ = os.Remove(tempPath) }()
+
+	if err := gob.NewEncoder(f).Encode(m); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, filepath.Join(dir, key+analysisCacheExt))
+}
+
+// analyseCached is AnalyseAudio through the analysis cache. cached reports a
+// hit. The cache is bypassed when it is off (no AnalysisCacheDir) or when the
+// room tone is picked interactively, since the pick is not part of the key. A
+// cache that cannot be read or written only costs the reuse.
+func analyseCached(ctx context.Context, inputPath string, config *BaseFilterConfig, progressCallback ProgressCallback) (measurements *AudioMeasurements, cached bool, err error) {
+	if config.AnalysisCacheDir == "" || config.roomToneSelector != nil {
+		measurements, err = AnalyseAudio(ctx, inputPath, config, progressCallback)
+		return measurements, false, err
+	}
+
+	key, err := analysisCacheKey(inputPath, config)
+	if err != nil {
+		config.logger.Logf("Warning: analysis cache key not computed: %v", err)
+		measurements, err = AnalyseAudio(ctx, inputPath, config, progressCallback)
+		return measurements, false, err
+	}
+	if m, ok := loadCachedAnalysis(config.AnalysisCacheDir, key); ok {
+		// The target offset is the one figure that follows the render target.
+		m.Loudness.TargetOffset = config.Loudnorm.TargetI - m.Loudness.InputI
+		return m, true, nil
+	}
+
+	measurements, err = AnalyseAudio(ctx, inputPath, config, progressCallback)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := storeCachedAnalysis(config.AnalysisCacheDir, key, measurements); err != nil {
+		config.logger.Logf("Warning: analysis not cached: %v", err)
+	}
+	return measurements, false, nil
+}
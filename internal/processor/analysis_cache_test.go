@@ -0,0 +1,102 @@
+package processor
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalysisCacheKey(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.wav")
+	b := filepath.Join(dir, "b.wav")
+	if err := os.WriteFile(a, []byte("one"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte("two"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultFilterConfig()
+
+	keyA, err := analysisCacheKey(a, config)
+	if err != nil {
+		t.Fatalf("analysisCacheKey: %v", err)
+	}
+	again, _ := analysisCacheKey(a, config)
+	if keyA != again {
+		t.Errorf("key not stable: %s then %s", keyA, again)
+	}
+	keyB, _ := analysisCacheKey(b, config)
+	if keyA == keyB {
+		t.Error("different inputs share a key")
+	}
+
+	// A render-only option leaves the key alone.
+	rendered := DefaultFilterConfig()
+	if err := rendered.SetOutputBitDepth(24); err != nil {
+		t.Fatal(err)
+	}
+	rendered.Loudnorm.TargetI = -23
+	if key, _ := analysisCacheKey(a, rendered); key != keyA {
+		t.Error("a render-only option changed the key")
+	}
+
+	oldVersion := RunVersion
+	RunVersion = "v-next"
+	defer func() { RunVersion = oldVersion }()
+	if key, _ := analysisCacheKey(a, config); key == keyA {
+		t.Error("a new version reused the key")
+	}
+}
+
+func TestAnalysisBuildID(t *testing.T) {
+	// The test binary is readable, so the key carries its hash.
+	if id := analysisBuildID(); !strings.HasPrefix(id, "exe:") || len(id) != len("exe:")+64 {
+		t.Errorf("analysisBuildID = %q, want the executable's SHA-256", id)
+	}
+}
+
+func TestAnalyseCachedHit(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "in.wav")
+	if err := os.WriteFile(input, []byte("audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultFilterConfig()
+	config.AnalysisCacheDir = filepath.Join(dir, "cache")
+
+	stored := &AudioMeasurements{Duration: 12.5, SampleRate: 48000}
+	stored.Loudness.InputI = -30
+	stored.Loudness.TargetOffset = 14
+	stored.Noise.Floor = math.Inf(-1)
+	stored.Regions.NoiseProfile = &NoiseProfile{BandNoise: []float64{-60, math.NaN()}}
+	key, err := analysisCacheKey(input, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := storeCachedAnalysis(config.AnalysisCacheDir, key, stored); err != nil {
+		t.Fatalf("storeCachedAnalysis: %v", err)
+	}
+
+	config.Loudnorm.TargetI = -23
+	got, cached, err := analyseCached(context.Background(), input, config, nil)
+	if err != nil || !cached {
+		t.Fatalf("analyseCached = cached %v, err %v; want a hit", cached, err)
+	}
+	if got.Duration != 12.5 || got.SampleRate != 48000 {
+		t.Errorf("in-memory fields lost: duration %v, rate %v", got.Duration, got.SampleRate)
+	}
+	if !math.IsInf(got.Noise.Floor, -1) || got.Regions.NoiseProfile == nil || !math.IsNaN(got.Regions.NoiseProfile.BandNoise[1]) {
+		t.Errorf("sentinels lost: floor %v, profile %+v", got.Noise.Floor, got.Regions.NoiseProfile)
+	}
+	if got.Loudness.TargetOffset != 7 {
+		t.Errorf("TargetOffset = %v, want 7 for the new -23 LUFS target", got.Loudness.TargetOffset)
+	}
+
+	if _, ok := loadCachedAnalysis(config.AnalysisCacheDir, "missing"); ok {
+		t.Error("loadCachedAnalysis hit on a missing key")
+	}
+}
@@ -0,0 +1,329 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Chunked rendering for very long inputs (--chunk-over). A multi-hour stream
+// runs Pass 2 a chunk at a time, each chunk in a filter graph of its own that
+// is freed before the next is built. Every chunk decodes chunkOverlap of audio
+// either side of the stretch it keeps and trims it away, so the stateful stages
+// (denoise, gate, compressor and de-esser envelopes) have settled by the first
+// kept sample and the FFT stages are not flushed at the last one. The kept
+// stretches meet exactly, and the chunks are joined sample for sample.
+//
+// The joined programme is measured in one streaming pass rather than by adding
+// up the chunks: the Pass 2 loudness is gated over the whole programme, so it
+// cannot be summed from per-chunk figures. Passes 3 and 4 then normalise the
+// joined file exactly as they would an unchunked one, so the loudness target is
+// met globally. Pass 1 has its own split (--analysis-segments), merged before
+// the voice activity split and the noise floor are taken, since those are
+// statistics of the whole recording.
+const (
+	// chunkOverlap is decoded and discarded either side of a chunk. It is
+	// well past the slowest envelope in the chain (the levelling compressor's
+	// release) and anlmdn's research window.
+	chunkOverlap = 10 * time.Second
+)
+
+// chunkLength is the stretch each Pass 2 chunk keeps; tests shorten it to
+// render several chunks from a short input.
+var chunkLength = 30 * time.Minute
+
+// SetChunkOver renders inputs longer than over in chunks (--chunk-over). Zero
+// turns chunking off; a threshold shorter than one chunk is rejected, since
+// such a file would be a single chunk anyway.
+func (cfg *BaseFilterConfig) SetChunkOver(over time.Duration) error {
+	if over < 0 {
+		return fmt.Errorf("chunk threshold %v is negative", over)
+	}
+	if over > 0 && over < chunkLength {
+		return fmt.Errorf("chunk threshold %v is shorter than one chunk (%v)", over, chunkLength)
+	}
+	cfg.ChunkOver = over
+	return nil
+}
+
+// chunked reports whether an input of length total is rendered in chunks.
+func (cfg *BaseFilterConfig) chunked(total time.Duration) bool {
+	return cfg.ChunkOver > 0 && total > cfg.ChunkOver
+}
+
+// chunkSpan is the stretch of the input one chunk keeps: [start, end), with a
+// zero end on the last chunk, which runs to the end of the file.
+type chunkSpan struct {
+	start, end time.Duration
+}
+
+// decodeStart and decodeEnd bound the audio the chunk's graph is fed: the span
+// plus the overlap either side. decodeEnd is zero on the last chunk.
+func (s chunkSpan) decodeStart() time.Duration { return max(s.start-chunkOverlap, 0) }
+func (s chunkSpan) decodeEnd() time.Duration {
+	if s.end == 0 {
+		return 0
+	}
+	return s.end + chunkOverlap
+}
+
+// planChunks splits an input of length total into spans of length. A
+// remainder shorter than the overlap is folded into the last full span rather
+// than rendered as a sliver of its own.
+func planChunks(total, length time.Duration) []chunkSpan {
+	var spans []chunkSpan
+	for start := time.Duration(0); ; start += length {
+		if total-start-length < chunkOverlap {
+			return append(spans, chunkSpan{start: start})
+		}
+		spans = append(spans, chunkSpan{start: start, end: start + length})
+	}
+}
+
+// buildChunkSpec wraps the Pass 2 chain for one chunk: trim the input to the
+// span and its overlap, run the chain, then keep the span alone. The trims
+// work on the input timeline, which the chain carries through, and adjacent
+// spans share their boundary, so no sample is dropped or repeated at a join.
+// The chain's own framing pads the chunk's last frame; the second trim drops
+// that padding and the chunk is reframed without it.
+func buildChunkSpec(pass2Spec string, span chunkSpan, frameSize int) string {
+	in := fmt.Sprintf("atrim=start=%f", span.decodeStart().Seconds())
+	keep := fmt.Sprintf("atrim=start=%f", span.start.Seconds())
+	if span.end > 0 {
+		in += fmt.Sprintf(":end=%f", span.decodeEnd().Seconds())
+		keep += fmt.Sprintf(":end=%f", span.end.Seconds())
+	}
+	return fmt.Sprintf("%s,%s,%s,asetnsamples=n=%d:p=0", in, pass2Spec, keep, frameSize)
+}
+
+// buildChunkJoinSpec joins the rendered chunks end to end: the first arrives on
+// [in] and the rest through amovie, in order. analysis measures the joined
+// programme on the way through; the output keeps the chunks' sample format in
+// fixed-size frames.
+func buildChunkJoinSpec(paths []string, analysis, format string, frameSize int) string {
+	var b strings.Builder
+	for i, path := range paths[1:] {
+		fmt.Fprintf(&b, "amovie=filename=%s[chunk_%d];", escapeFilterGraphOptionValue(path), i+1)
+	}
+	b.WriteString("[in]")
+	for i := range paths[1:] {
+		fmt.Fprintf(&b, "[chunk_%d]", i+1)
+	}
+	fmt.Fprintf(&b, "concat=n=%d:v=0:a=1,", len(paths))
+	if analysis != "" {
+		b.WriteString(analysis + ",")
+	}
+	fmt.Fprintf(&b, "aformat=sample_fmts=%s,asetnsamples=n=%d", format, frameSize)
+	return b.String()
+}
+
+// processInChunks is processWithFilters for an input past --chunk-over: it
+// renders Pass 2 chunk by chunk (renderChunk), then joins the chunks into
+// outputPath and measures the joined programme (joinChunks).
+func processInChunks(ctx context.Context, inputPath, outputPath string, config *EffectiveFilterConfig, progressCallback ProgressCallback, measurements *AudioMeasurements, outputMeasurements **OutputMeasurements, log debugLogger) (InputMetadata, error) {
+	reader, metadata, err := audio.OpenAudioFile(inputPath)
+	if err != nil {
+		return InputMetadata{}, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer reader.Close()
+	inputMetadata := newInputMetadata(metadata)
+
+	spans := planChunks(time.Duration(metadata.Duration*float64(time.Second)), chunkLength)
+	log.Logf("Pass 2 in %d chunks of %v with %v overlap", len(spans), chunkLength, chunkOverlap)
+
+	var chunkPaths []string
+	defer func() { removeTempPaths(chunkPaths) }()
+
+	pass2Spec := config.BuildFilterSpec()
+	for i, span := range spans {
+		if err := ctx.Err(); err != nil {
+			return InputMetadata{}, err
+		}
+		progress := func(fraction, level float64) {
+			if progressCallback == nil {
+				return
+			}
+			progressCallback(ProgressUpdate{
+				Pass:         PassProcessing,
+				PassName:     "Processing",
+				Progress:     min((float64(i)+fraction)/float64(len(spans)), 1.0),
+				Level:        level,
+				Duration:     measurements.Duration,
+				Measurements: measurements,
+			})
+		}
+		path, err := renderChunk(ctx, reader, outputPath, buildChunkSpec(pass2Spec, span, config.Resample.FrameSize),
+			span, fmt.Sprintf("chunk-%d", i+1), metadata.Duration, progress, log)
+		if err != nil {
+			return InputMetadata{}, fmt.Errorf("chunk %d of %d: %w", i+1, len(spans), err)
+		}
+		chunkPaths = append(chunkPaths, path)
+	}
+
+	joined, err := joinChunks(ctx, chunkPaths, outputPath, config, measurements)
+	if err != nil {
+		return InputMetadata{}, fmt.Errorf("failed to join chunks: %w", err)
+	}
+	if outputMeasurements != nil {
+		*outputMeasurements = joined
+	}
+	return inputMetadata, nil
+}
+
+// renderChunk runs one chunk's graph over reader, seeked to just before the
+// chunk, and writes it to a temp FLAC beside outputPath named with marker. Reading stops
+// once the chunk's overlap has been fed. progress receives the fraction of the
+// chunk read and the last filtered level. The chain pads its last frame with
+// silence; the chunks before the last trim that away with their overlap, and
+// the last is cut where its input ran out, so the joined programme is no
+// longer than an unchunked render.
+func renderChunk(ctx context.Context, reader *audio.Reader, outputPath, spec string, span chunkSpan, marker string,
+	totalSecs float64, progress func(fraction, level float64), log debugLogger,
+) (string, error) {
+	seekReaderBeforeRegion(reader, span.decodeStart(), log)
+
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to create filter graph: %w", err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	tempPath, err := processorCreateSiblingTempPath(outputPath, marker)
+	if err != nil {
+		return "", err
+	}
+	published := false
+	defer func() {
+		if !published {
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	encoder, err := createOutputEncoder(tempPath, bufferSinkCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	from := span.decodeStart().Seconds()
+	to := totalSecs
+	if span.end > 0 {
+		to = span.decodeEnd().Seconds()
+	}
+	timeBase := reader.DecoderContext().PktTimebase()
+	frameSecs := func(frame *ffmpeg.AVFrame) float64 {
+		if frame.Pts() == ffmpeg.AVNoptsValue {
+			return from
+		}
+		return float64(frame.Pts()) * float64(timeBase.Num()) / float64(timeBase.Den())
+	}
+
+	frameCount := 0
+	currentLevel := 0.0
+	inputEnd := from
+	var kept int64
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnReadError: func(err error) error {
+			return fmt.Errorf("failed to read frame: %w", err)
+		},
+		StopReading: func(inputFrame *ffmpeg.AVFrame) bool {
+			return span.end > 0 && frameSecs(inputFrame) >= to
+		},
+		OnInputFrame: func(inputFrame *ffmpeg.AVFrame) {
+			frameCount++
+			if rate := inputFrame.SampleRate(); rate > 0 {
+				inputEnd = frameSecs(inputFrame) + float64(inputFrame.NbSamples())/float64(rate)
+			}
+			if frameCount%100 == 0 && to > from {
+				progress(min(max((frameSecs(inputFrame)-from)/(to-from), 0), 1), currentLevel)
+			}
+		},
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			if span.end == 0 {
+				remaining := int64(math.Round((inputEnd-span.start.Seconds())*float64(filteredFrame.SampleRate()))) - kept
+				if remaining <= 0 {
+					return nil
+				}
+				if remaining < int64(filteredFrame.NbSamples()) {
+					filteredFrame.SetNbSamples(int(remaining))
+				}
+				kept += int64(filteredFrame.NbSamples())
+			}
+			currentLevel = calculateFrameLevel(filteredFrame)
+			filteredFrame.SetTimeBase(ffmpeg.AVBuffersinkGetTimeBase(bufferSinkCtx))
+			if err := encoder.WriteFrame(filteredFrame); err != nil {
+				return fmt.Errorf("failed to write frame: %w", err)
+			}
+			return nil
+		},
+	}); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return "", fmt.Errorf("failed to flush encoder: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to close encoder: %w", err)
+	}
+	published = true
+	return tempPath, nil
+}
+
+// joinChunks writes the chunks end to end to outputPath and returns the Pass 2
+// output measurements of the joined programme. The chunks already carry any
+// quiet-input pre-gain back off, so the measuring analysis stage leaves the
+// level alone.
+func joinChunks(ctx context.Context, chunkPaths []string, outputPath string, config *EffectiveFilterConfig, measurements *AudioMeasurements) (*OutputMeasurements, error) {
+	reader, _, err := audio.OpenAudioFile(chunkPaths[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the first chunk: %w", err)
+	}
+	defer reader.Close()
+
+	measure := *config
+	measure.Downmix.PreGainDB = 0
+	spec := buildChunkJoinSpec(chunkPaths, measure.buildAnalysisFilter(), config.Resample.Format, config.Resample.FrameSize)
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filter graph: %w", err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	encoder, err := createOutputEncoder(outputPath, bufferSinkCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	acc := &outputMetadataAccumulators{speech: newSpeechLoudnessAccumulator(measurements)}
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnReadError: func(err error) error {
+			return fmt.Errorf("failed to read frame: %w", err)
+		},
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			extractOutputFrameMetadata(filteredFrame.Metadata(), acc)
+			if acc.speech != nil {
+				acc.speech.observeFrame(filteredFrame)
+			}
+			filteredFrame.SetTimeBase(ffmpeg.AVBuffersinkGetTimeBase(bufferSinkCtx))
+			if err := encoder.WriteFrame(filteredFrame); err != nil {
+				return fmt.Errorf("failed to write frame: %w", err)
+			}
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to flush encoder: %w", err)
+	}
+	return finalizeOutputMeasurements(acc), nil
+}
@@ -0,0 +1,194 @@
+package processor
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+func TestPlanChunks(t *testing.T) {
+	tests := []struct {
+		name  string
+		total time.Duration
+		want  []chunkSpan
+	}{
+		{"shorter than a chunk", 20 * time.Minute, []chunkSpan{{start: 0}}},
+		{"exact multiple", 90 * time.Minute, []chunkSpan{
+			{start: 0, end: 30 * time.Minute},
+			{start: 30 * time.Minute, end: time.Hour},
+			{start: time.Hour},
+		}},
+		{"remainder", 70 * time.Minute, []chunkSpan{
+			{start: 0, end: 30 * time.Minute},
+			{start: 30 * time.Minute, end: time.Hour},
+			{start: time.Hour},
+		}},
+		{"sliver folded in", 60*time.Minute + 4*time.Second, []chunkSpan{
+			{start: 0, end: 30 * time.Minute},
+			{start: 30 * time.Minute},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := planChunks(tt.total, chunkLength); !slices.Equal(got, tt.want) {
+				t.Errorf("planChunks(%v) = %+v, want %+v", tt.total, got, tt.want)
+			}
+		})
+	}
+
+	// Every chunk but the last ends where the next one starts.
+	spans := planChunks(6*time.Hour+17*time.Minute, chunkLength)
+	for i := 1; i < len(spans); i++ {
+		if spans[i-1].end != spans[i].start {
+			t.Errorf("chunk %d ends at %v, chunk %d starts at %v", i, spans[i-1].end, i+1, spans[i].start)
+		}
+	}
+	if last := spans[len(spans)-1]; last.end != 0 || last.decodeEnd() != 0 {
+		t.Errorf("last chunk %+v is bounded, want it to run to the end of the file", last)
+	}
+}
+
+func TestBuildChunkSpec(t *testing.T) {
+	first := buildChunkSpec("highpass=f=80", chunkSpan{start: 0, end: 30 * time.Minute}, 4096)
+	if want := "atrim=start=0.000000:end=1810.000000,highpass=f=80,atrim=start=0.000000:end=1800.000000,asetnsamples=n=4096:p=0"; first != want {
+		t.Errorf("first chunk spec = %s, want %s", first, want)
+	}
+	last := buildChunkSpec("highpass=f=80", chunkSpan{start: time.Hour}, 4096)
+	if want := "atrim=start=3590.000000,highpass=f=80,atrim=start=3600.000000,asetnsamples=n=4096:p=0"; last != want {
+		t.Errorf("last chunk spec = %s, want %s", last, want)
+	}
+}
+
+func TestBuildChunkJoinSpec(t *testing.T) {
+	spec := buildChunkJoinSpec([]string{"/rec/a.flac", "/rec/b.flac", "/rec/c.flac"}, "ebur128", "s16", 4096)
+	for _, want := range []string{
+		"amovie=filename=/rec/b.flac[chunk_1];",
+		"amovie=filename=/rec/c.flac[chunk_2];",
+		"[in][chunk_1][chunk_2]concat=n=3:v=0:a=1,ebur128,aformat=sample_fmts=s16,asetnsamples=n=4096",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("join spec missing %q\n%s", want, spec)
+		}
+	}
+	if strings.Contains(spec, "a.flac") {
+		t.Errorf("join spec reopens the first chunk, which arrives on [in]\n%s", spec)
+	}
+}
+
+func TestSetChunkOver(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if cfg.chunked(10 * time.Hour) {
+		t.Error("default config chunks, want chunking off")
+	}
+	if err := cfg.SetChunkOver(-time.Hour); err == nil {
+		t.Error("negative threshold accepted")
+	}
+	if err := cfg.SetChunkOver(10 * time.Minute); err == nil {
+		t.Error("threshold shorter than a chunk accepted")
+	}
+	if err := cfg.SetChunkOver(3 * time.Hour); err != nil {
+		t.Fatalf("SetChunkOver: %v", err)
+	}
+	if cfg.chunked(3*time.Hour) || !cfg.chunked(6*time.Hour) {
+		t.Errorf("ChunkOver %v: chunked(3h), chunked(6h) = %v, %v, want false, true",
+			cfg.ChunkOver, cfg.chunked(3*time.Hour), cfg.chunked(6*time.Hour))
+	}
+}
+
+// TestProcessAudioChunked renders an input three chunks long both in chunks and
+// in one graph. The chunked output must keep exactly the input's samples and
+// match the unchunked render across the chunk boundaries.
+func TestProcessAudioChunked(t *testing.T) {
+	defer func(length time.Duration) { chunkLength = length }(chunkLength)
+	chunkLength = 20 * time.Second
+
+	// A whole number of 4096-sample frames, so the unchunked render does not
+	// pad its last frame either.
+	const sampleRate = 44100
+	const inputSamples = 540 * 4096
+	testFile := generateTestAudio(t, TestAudioOptions{
+		DurationSecs: (inputSamples + 0.5) / sampleRate,
+		SampleRate:   sampleRate,
+		ToneFreq:     440.0,
+		ToneLevel:    -18.0,
+	})
+	defer cleanupTestAudio(t, testFile)
+
+	render := func(name string, chunkOver time.Duration) []float64 {
+		t.Helper()
+		config := newTestBaseConfig()
+		config.Downmix.Enabled = true
+		config.Analysis.Enabled = true
+		config.Resample.Enabled = true
+		config.RumbleHighPass.Enabled = true
+		if err := config.SetChunkOver(chunkOver); err != nil {
+			t.Fatalf("SetChunkOver: %v", err)
+		}
+		config.OutputFile = filepath.Join(t.TempDir(), name+".flac")
+		result, err := ProcessAudio(context.Background(), testFile, config, func(ProgressUpdate) {})
+		if err != nil {
+			t.Fatalf("ProcessAudio(%s): %v", name, err)
+		}
+		return decodeMonoSamples(t, result.OutputPath)
+	}
+	whole := render("whole", 0)
+	chunked := render("chunked", chunkLength)
+
+	if len(chunked) != inputSamples || len(whole) != inputSamples {
+		t.Fatalf("output samples: chunked %d, whole %d, want the input's %d", len(chunked), len(whole), inputSamples)
+	}
+	// The chain has settled well inside the overlap, so the renders differ by
+	// no more than dither. A sample dropped or repeated at a boundary shifts
+	// the 440 Hz tone by about a twentieth of its amplitude.
+	for _, boundary := range []time.Duration{chunkLength, 2 * chunkLength} {
+		at := int(boundary.Seconds() * sampleRate)
+		for i := at - 64; i < at+64; i++ {
+			if diff := math.Abs(chunked[i] - whole[i]); diff > 1e-3 {
+				t.Fatalf("sample %d by the %v boundary: chunked %.6f, whole %.6f", i, boundary, chunked[i], whole[i])
+			}
+		}
+	}
+}
+
+// decodeMonoSamples decodes a mono 16- or 24-bit output file to samples in
+// [-1, 1).
+func decodeMonoSamples(t *testing.T, path string) []float64 {
+	t.Helper()
+	reader, _, err := audio.OpenAudioFile(path)
+	if err != nil {
+		t.Fatalf("OpenAudioFile(%s): %v", path, err)
+	}
+	defer reader.Close()
+
+	var samples []float64
+	for {
+		frame, err := reader.ReadFrame()
+		if err != nil {
+			t.Fatalf("ReadFrame(%s): %v", path, err)
+		}
+		if frame == nil {
+			return samples
+		}
+		n := frame.NbSamples()
+		switch ffmpeg.AVSampleFormat(frame.Format()) { //nolint:gosec // AVSampleFormat values fit in int32
+		case ffmpeg.AVSampleFmtS16:
+			for _, s := range unsafe.Slice((*int16)(frame.Data().Get(0)), n) {
+				samples = append(samples, float64(s)/32768.0)
+			}
+		case ffmpeg.AVSampleFmtS32:
+			for _, s := range unsafe.Slice((*int32)(frame.Data().Get(0)), n) {
+				samples = append(samples, float64(s)/2147483648.0)
+			}
+		default:
+			t.Fatalf("%s decodes to sample format %d, want packed s16 or s32", path, frame.Format())
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Clarity is an intelligibility proxy for non-experts (--clarity): one 0-100
+// figure scored the same way on the input and on the final output, so the pair
+// reads as "Clarity: 62 → 81". It is a deterministic composite of three
+// speech-region measurements, each mapped to 0-1 by a linear ramp:
+//
+//	SNR        speech RMS minus room-tone RMS (dB)            weight 0.50
+//	Sibilance  6-9 kHz band RMS minus 1-3 kHz band RMS (dB)   weight 0.25
+//	Tilt       1-3 kHz band RMS minus 100-300 Hz band RMS (dB) weight 0.25
+//
+// SNR rises from 0 at clarityNoisySNRDB to full marks at clarityCleanSNRDB.
+// Sibilance and tilt score full marks inside a balanced window and fall to 0 at
+// either extreme: too little sibilance or presence sounds dull and muffled, too
+// much sounds harsh or thin. Score = 100 × the weighted sum.
+const (
+	clarityWeightSNR       = 0.50
+	clarityWeightSibilance = 0.25
+	clarityWeightTilt      = 0.25
+
+	clarityNoisySNRDB = 15.0 // Speech this close to the room tone scores 0
+	clarityCleanSNRDB = 45.0 // Speech this far above the room tone scores 1
+
+	// Sibilance window. Above -6 dB the de-esser starts to engage
+	// (deessExcessOffDB); below -18 dB the consonants start to blur.
+	claritySibilanceDullDB  = -30.0
+	claritySibilanceLowDB   = -18.0
+	claritySibilanceHighDB  = -6.0
+	claritySibilanceHarshDB = 0.0
+
+	// Tilt window: presence against the chest and room resonances.
+	clarityTiltMuddyDB = -26.0
+	clarityTiltLowDB   = -14.0
+	clarityTiltHighDB  = 0.0
+	clarityTiltThinDB  = 10.0
+
+	clarityLowBandLowHz  = 100.0
+	clarityLowBandHighHz = 300.0
+)
+
+// ClarityScore is one side of the clarity comparison: the composite and the
+// three measurements behind it.
+type ClarityScore struct {
+	Score       float64 `json:"score"`        // 0-100 composite
+	SNRDB       float64 `json:"snr_db"`       // Speech RMS minus room-tone RMS
+	SibilanceDB float64 `json:"sibilance_db"` // 6-9 kHz minus 1-3 kHz band RMS over the speech region
+	TiltDB      float64 `json:"tilt_db"`      // 1-3 kHz minus 100-300 Hz band RMS over the speech region
+}
+
+// ClarityComparison is the input and final clarity, scored on the same speech
+// and room-tone regions.
+type ClarityComparison struct {
+	Input ClarityScore `json:"input"`
+	Final ClarityScore `json:"final"`
+}
+
+// newClarityScore scores the three measurements.
+func newClarityScore(snrDB, sibilanceDB, tiltDB float64) ClarityScore {
+	snr := linearScore(snrDB, clarityCleanSNRDB, clarityNoisySNRDB)
+	sibilance := windowScore(sibilanceDB, claritySibilanceDullDB, claritySibilanceLowDB, claritySibilanceHighDB, claritySibilanceHarshDB)
+	tilt := windowScore(tiltDB, clarityTiltMuddyDB, clarityTiltLowDB, clarityTiltHighDB, clarityTiltThinDB)
+	return ClarityScore{
+		Score: 100 * (clarityWeightSNR*snr +
+			clarityWeightSibilance*sibilance +
+			clarityWeightTilt*tilt),
+		SNRDB:       snrDB,
+		SibilanceDB: sibilanceDB,
+		TiltDB:      tiltDB,
+	}
+}
+
+// windowScore is 1 between low and high, falling linearly to 0 at zeroLow and
+// zeroHigh.
+func windowScore(v, zeroLow, low, high, zeroHigh float64) float64 {
+	return min(linearScore(v, low, zeroLow), linearScore(v, high, zeroHigh))
+}
+
+// clarityBandPlan names the three speech-region bands clarity measures, in
+// slot order: low, body, sibilant.
+var clarityBandPlan = [3]struct {
+	lowHz, highHz float64
+}{
+	{clarityLowBandLowHz, clarityLowBandHighHz},
+	{bandBodyLowHz, bandBodyHighHz},
+	{bandSibLowHz, bandSibHighHz},
+}
+
+// measureClarityBands measures the three clarity bands over region of path,
+// in parallel as measureSpeechBands does. ok is false unless all three
+// measured.
+func measureClarityBands(ctx context.Context, path string, region SpeechRegion, log debugLogger) (low, body, sib float64, ok bool) {
+	var results [len(clarityBandPlan)]struct {
+		rms float64
+		ok  bool
+	}
+	runBandMeasurements(ctx, len(clarityBandPlan), nil, func(i int) {
+		reader, _, err := audio.OpenAudioFile(path)
+		if err != nil {
+			log.Logf("Warning: failed to open file for clarity band %d measurement: %v", i, err)
+			return
+		}
+		defer reader.Close()
+
+		band := clarityBandPlan[i]
+		rms, found, err := measureSpeechBandRMS(ctx, reader, region.Start, region.Duration, band.lowHz, band.highHz, log)
+		if err != nil {
+			log.Logf("Warning: clarity band %d RMS measurement failed: %v", i, err)
+			return
+		}
+		results[i].rms, results[i].ok = rms, found
+	})
+	return results[0].rms, results[1].rms, results[2].rms,
+		results[0].ok && results[1].ok && results[2].ok
+}
+
+// measureClarity scores the input at inputPath and the normalised output at
+// outputPath over the elected speech region, with SNR read from the region
+// samples each pass already measured. It errors when a region or band is
+// missing, so the caller can say why the comparison is absent.
+func measureClarity(ctx context.Context, inputPath, outputPath string, measurements *AudioMeasurements, final *OutputMeasurements, log debugLogger) (*ClarityComparison, error) {
+	speech := measurements.Regions.SpeechProfile
+	roomTone := measurements.Regions.ElectedRoomToneSample
+	if speech == nil || roomTone == nil || final == nil || final.SpeechSample == nil || final.RoomToneSample == nil {
+		return nil, fmt.Errorf("no speech and room-tone regions to compare")
+	}
+
+	score := func(path string, speechRMS, roomToneRMS float64) (ClarityScore, error) {
+		low, body, sib, ok := measureClarityBands(ctx, path, speech.Region, log)
+		if !ok {
+			return ClarityScore{}, fmt.Errorf("speech bands not measured in %s", path)
+		}
+		return newClarityScore(speechRMS-roomToneRMS, sib-body, body-low), nil
+	}
+
+	in, err := score(inputPath, speech.RMSLevel, roomTone.RMSLevel)
+	if err != nil {
+		return nil, err
+	}
+	out, err := score(outputPath, final.SpeechSample.RMSLevel, final.RoomToneSample.RMSLevel)
+	if err != nil {
+		return nil, err
+	}
+	return &ClarityComparison{Input: in, Final: out}, nil
+}
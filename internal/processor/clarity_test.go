@@ -0,0 +1,38 @@
+package processor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewClarityScore(t *testing.T) {
+	tests := []struct {
+		name                 string
+		snr, sibilance, tilt float64
+		want                 float64
+	}{
+		{"clean and balanced", 50, -12, -6, 100},
+		{"noisy but balanced", 15, -12, -6, 50},
+		{"half-way SNR", 30, -12, -6, 75},
+		{"dull", 45, -30, -6, 75},
+		{"harsh and thin", 45, 0, 10, 50},
+		{"half-way muddy", 45, -12, -20, 87.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newClarityScore(tt.snr, tt.sibilance, tt.tilt)
+			if math.Abs(got.Score-tt.want) > 1e-9 {
+				t.Errorf("score = %.3f, want %.3f", got.Score, tt.want)
+			}
+			if got.SNRDB != tt.snr || got.SibilanceDB != tt.sibilance || got.TiltDB != tt.tilt {
+				t.Errorf("measurements = %+v, want them carried through", got)
+			}
+		})
+	}
+}
+
+func TestMeasureClarityNeedsRegions(t *testing.T) {
+	if _, err := measureClarity(t.Context(), "in.wav", "out.flac", &AudioMeasurements{}, &OutputMeasurements{}, nil); err == nil {
+		t.Error("measureClarity without regions = nil error, want error")
+	}
+}
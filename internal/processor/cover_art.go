@@ -0,0 +1,26 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// keepCoverArt copies the input's cover art onto the published output
+// (--keep-cover-art). The filter-graph encoder writes audio only, so the
+// pictures are carried across as raw FLAC PICTURE blocks; only a FLAC input
+// has them in that form. Returns the number of pictures copied.
+func keepCoverArt(inputPath, outputPath string) (int, error) {
+	pictures, err := audio.ReadFLACPictures(inputPath)
+	if errors.Is(err, audio.ErrNotFLAC) {
+		return 0, fmt.Errorf("only FLAC inputs carry cover art across")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read input cover art: %w", err)
+	}
+	if err := audio.WriteFLACPictures(outputPath, pictures); err != nil {
+		return 0, fmt.Errorf("failed to write cover art: %w", err)
+	}
+	return len(pictures), nil
+}
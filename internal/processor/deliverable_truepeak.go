@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// True-peak oversampling. BS.1770 measures true peak at 4x the signal rate, but
+// FFmpeg's ebur128 always upsamples to a fixed 192 kHz. That is 4x only for 48
+// kHz: a 44.1 kHz deliverable is oversampled about 4.35x, and the Pass-4 meter
+// runs at the source rate, ahead of the final resample to the output rate. A
+// standards meter reading the delivered file can therefore disagree with the
+// Pass-4 figure.
+const (
+	// truePeakOversample is the BS.1770 oversampling factor.
+	truePeakOversample = 4
+
+	// ebur128TruePeakRateHz is the fixed rate ebur128's true-peak meter
+	// upsamples to.
+	ebur128TruePeakRateHz = 192000
+)
+
+// deliverableTruePeakFilterFormat oversamples the delivered file to 4x its own
+// rate (the %d verb) and reads ebur128's sample peak there. The sample peak of
+// the 4x signal is the BS.1770 true peak of the deliverable.
+const deliverableTruePeakFilterFormat = "aresample=%d,ebur128=metadata=1:peak=sample"
+
+// needsDeliverableTruePeak reports whether the Pass-4 ebur128 true peak can
+// differ from a 4x meter on the delivered file: the output was resampled after
+// the measurement, or the output rate is not the one ebur128's 192 kHz meter
+// oversamples by exactly 4x.
+func needsDeliverableTruePeak(measuredRate, outputRate int) bool {
+	if outputRate <= 0 {
+		return false
+	}
+	return outputRate != measuredRate || outputRate*truePeakOversample != ebur128TruePeakRateHz
+}
+
+// measureDeliverableTruePeak re-measures the true peak of the published output
+// at 4x its own sample rate. measuredRate is the rate the Pass-4 meter ran at;
+// when that meter is already a 4x meter on the delivered rate the file is not
+// decoded and ok is false, as it is on any failure: the caller keeps the Pass-4
+// figure. Returns the true peak in dBTP.
+func measureDeliverableTruePeak(ctx context.Context, path string, measuredRate int, log debugLogger) (truePeak float64, ok bool) {
+	reader, metadata, err := audio.OpenAudioFile(path)
+	if err != nil {
+		log.Logf("Warning: deliverable true peak: failed to open output: %v", err)
+		return 0, false
+	}
+	defer reader.Close()
+
+	if !needsDeliverableTruePeak(measuredRate, metadata.SampleRate) {
+		return 0, false
+	}
+
+	filterSpec := fmt.Sprintf(deliverableTruePeakFilterFormat, metadata.SampleRate*truePeakOversample)
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), filterSpec)
+	if err != nil {
+		log.Logf("Warning: deliverable true peak: failed to create filter graph: %v", err)
+		return 0, false
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	var peak float64
+	found := false
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			if metadata := filteredFrame.Metadata(); metadata != nil {
+				if value, ok := getFloatMetadata(metadata, metaKeyEbur128SamplePeak); ok {
+					peak = max(peak, value)
+					found = true
+				}
+			}
+			return nil
+		},
+	}); err != nil {
+		log.Logf("Warning: deliverable true peak: %v", err)
+		return 0, false
+	}
+	if !found {
+		return 0, false
+	}
+
+	truePeak = linearRatioToDB(peak)
+	log.Logf("Deliverable true peak: %.2f dBTP (%dx oversampled at %d Hz)",
+		truePeak, truePeakOversample, metadata.SampleRate*truePeakOversample)
+	return truePeak, true
+}
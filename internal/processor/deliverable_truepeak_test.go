@@ -0,0 +1,42 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNeedsDeliverableTruePeak(t *testing.T) {
+	tests := []struct {
+		name                     string
+		measuredRate, outputRate int
+		want                     bool
+	}{
+		{"48k source to 48k output is already 4x", 48000, 48000, false},
+		{"44.1k source to 44.1k output", 44100, 44100, true},
+		{"48k source resampled to 44.1k", 48000, 44100, true},
+		{"96k source resampled to 48k", 96000, 48000, true},
+		{"unknown output rate", 48000, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsDeliverableTruePeak(tt.measuredRate, tt.outputRate); got != tt.want {
+				t.Errorf("needsDeliverableTruePeak(%d, %d) = %v, want %v",
+					tt.measuredRate, tt.outputRate, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDeliverableTruePeakFilterOversamples guards the filter: a 44.1 kHz
+// deliverable is oversampled to 176.4 kHz and read as a sample peak, since
+// ebur128's own true-peak meter would resample again to 192 kHz.
+func TestDeliverableTruePeakFilterOversamples(t *testing.T) {
+	spec := fmt.Sprintf(deliverableTruePeakFilterFormat, 44100*truePeakOversample)
+	if !strings.HasPrefix(spec, "aresample=176400,") {
+		t.Errorf("spec = %q, want a 176.4 kHz oversample first", spec)
+	}
+	if !strings.HasSuffix(spec, "peak=sample") {
+		t.Errorf("spec = %q, want ebur128 reading the sample peak only", spec)
+	}
+}
@@ -19,9 +19,15 @@ type Encoder struct {
 
 // createOutputEncoder creates an encoder for FLAC output
 func createOutputEncoder(outputPath string, bufferSinkCtx *ffmpeg.AVFilterContext) (*Encoder, error) {
+	return createFormatEncoder(outputPath, OutputFormatFLAC, bufferSinkCtx)
+}
+
+// createFormatEncoder creates an encoder for format output: OutputFormatFLAC
+// or OutputFormatWAV (PCM at the sink's bit depth).
+func createFormatEncoder(outputPath, format string, bufferSinkCtx *ffmpeg.AVFilterContext) (*Encoder, error) {
 	outputPathC := ffmpeg.ToCStr(outputPath)
 	defer outputPathC.Free()
-	fmtNameC := ffmpeg.ToCStr("flac")
+	fmtNameC := ffmpeg.ToCStr(format)
 	defer fmtNameC.Free()
 
 	var fmtCtx *ffmpeg.AVFormatContext
@@ -51,9 +57,22 @@ func createOutputEncoder(outputPath string, bufferSinkCtx *ffmpeg.AVFilterContex
 		}
 	}()
 
-	codec := ffmpeg.AVCodecFindEncoder(ffmpeg.AVCodecIdFlac)
+	// Get audio parameters from filter output. The aformat filter pins the
+	// sample format to S16 or, for 24-bit output, S32.
+	sinkFormat, err := ffmpeg.AVBuffersinkGetFormat(bufferSinkCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sample format: %w", err)
+	}
+	sinkS32 := ffmpeg.AVSampleFormat(sinkFormat) == ffmpeg.AVSampleFmtS32 //nolint:gosec // AVSampleFormat values fit in int32
+
+	var codec *ffmpeg.AVCodec
+	if format == OutputFormatWAV {
+		codec = findPCMEncoder(sinkS32)
+	} else {
+		codec = ffmpeg.AVCodecFindEncoder(ffmpeg.AVCodecIdFlac)
+	}
 	if codec == nil {
-		return nil, fmt.Errorf("FLAC encoder not found for output: %s", outputPath)
+		return nil, fmt.Errorf("%s encoder not found for output: %s", format, outputPath)
 	}
 
 	stream := ffmpeg.AVFormatNewStream(fmtCtx, nil)
@@ -66,11 +85,6 @@ func createOutputEncoder(outputPath string, bufferSinkCtx *ffmpeg.AVFilterContex
 		return nil, fmt.Errorf("failed to allocate encoder context for output: %s", outputPath)
 	}
 
-	// Get audio parameters from filter output (we only need sample rate, format is set to S16 via aformat filter)
-	if _, err := ffmpeg.AVBuffersinkGetFormat(bufferSinkCtx); err != nil { // Verify filter output is configured
-		return nil, fmt.Errorf("failed to get sample format: %w", err)
-	}
-
 	sampleRate, err := ffmpeg.AVBuffersinkGetSampleRate(bufferSinkCtx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sample rate: %w", err)
@@ -78,8 +92,15 @@ func createOutputEncoder(outputPath string, bufferSinkCtx *ffmpeg.AVFilterContex
 
 	timeBase := ffmpeg.AVBuffersinkGetTimeBase(bufferSinkCtx)
 
-	// Configure encoder - FLAC supports S16 and S32, we use S16 which matches our aformat filter
-	encCtx.SetSampleFmt(ffmpeg.AVSampleFmtS16)
+	// Configure encoder - FLAC supports S16 and S32. S32 carries 24-bit output:
+	// FLAC's 32-bit mode is not widely decodable, so only 24 bits are coded.
+	// The WAV encoder is pcm_s24le for S32, which codes the same 24 bits.
+	if sinkS32 {
+		encCtx.SetSampleFmt(ffmpeg.AVSampleFmtS32)
+		encCtx.SetBitsPerRawSample(OutputBitDepth24)
+	} else {
+		encCtx.SetSampleFmt(ffmpeg.AVSampleFmtS16)
+	}
 	encCtx.SetSampleRate(sampleRate)
 
 	channels, err := ffmpeg.AVBuffersinkGetChannels(bufferSinkCtx)
@@ -141,6 +162,18 @@ func createOutputEncoder(outputPath string, bufferSinkCtx *ffmpeg.AVFilterContex
 	}, nil
 }
 
+// findPCMEncoder finds the little-endian PCM encoder for WAV output: 24-bit
+// for an S32 sink, else 16-bit.
+func findPCMEncoder(s32 bool) *ffmpeg.AVCodec {
+	name := "pcm_s16le"
+	if s32 {
+		name = "pcm_s24le"
+	}
+	nameC := ffmpeg.ToCStr(name)
+	defer nameC.Free()
+	return ffmpeg.AVCodecFindEncoderByName(nameC)
+}
+
 // WriteFrame encodes and writes a single audio frame
 func (e *Encoder) WriteFrame(frame *ffmpeg.AVFrame) error {
 	// Rescale PTS to encoder timebase if needed
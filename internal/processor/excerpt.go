@@ -0,0 +1,150 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Excerpt processing (--excerpt). Only a stretch of the input is cut out,
+// analysed, and run through the four passes, and the processed stretch is the
+// deliverable: a trailer clip, or a trial of the chain on a few minutes of a
+// long recording. Unlike --fix-region nothing is spliced back.
+
+// excerptMinDuration is the shortest excerpt accepted: Pass 1 needs a few
+// seconds to find speech and room tone to tune the chain from.
+const excerptMinDuration = fixRegionMinDuration
+
+// ExcerptConfig is the stretch of the input to process (--excerpt): Duration
+// from Start.
+type ExcerptConfig struct {
+	Enabled  bool
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// End is where the excerpt stops.
+func (e ExcerptConfig) End() time.Duration {
+	return e.Start + e.Duration
+}
+
+// SetExcerpt restricts processing to duration from start, delivered on its own.
+func (cfg *BaseFilterConfig) SetExcerpt(start, duration time.Duration) error {
+	if start < 0 {
+		return fmt.Errorf("excerpt start %v is negative", start)
+	}
+	if duration < excerptMinDuration {
+		return fmt.Errorf("excerpt duration %v is shorter than %v", duration, excerptMinDuration)
+	}
+	cfg.Excerpt = ExcerptConfig{Enabled: true, Start: start, Duration: duration}
+	return nil
+}
+
+// ExcerptRange records the stretch of the input an excerpt run processed.
+// Every time in the result is from the excerpt's start.
+type ExcerptRange struct {
+	StartS    float64 `json:"start_s"`
+	DurationS float64 `json:"duration_s"`
+}
+
+// planExcerpt resolves excerpt against a file of length total, returning the
+// start and end to cut. An excerpt running past the end is clamped to it; one
+// starting past the end, or left shorter than excerptMinDuration, is an error.
+func planExcerpt(excerpt ExcerptConfig, total time.Duration) (start, end time.Duration, err error) {
+	if excerpt.Start >= total {
+		return 0, 0, fmt.Errorf("excerpt starts at %v, past the end of the file (%v)", excerpt.Start, total)
+	}
+	start, end = excerpt.Start, min(excerpt.End(), total)
+	if end-start < excerptMinDuration {
+		return 0, 0, fmt.Errorf("excerpt is shorter than %v inside the file (%v)", excerptMinDuration, total)
+	}
+	return start, end, nil
+}
+
+// excerptNoiseRegion moves a --noise-region given on the input's timeline onto
+// the excerpt's, which starts at zero. The region must lie inside the excerpt.
+func excerptNoiseRegion(region NoiseRegionConfig, start, end time.Duration) (NoiseRegionConfig, error) {
+	if !region.Enabled {
+		return region, nil
+	}
+	if region.Start < start || region.End() > end {
+		return NoiseRegionConfig{}, fmt.Errorf("noise region %v-%v lies outside the excerpt %v-%v", region.Start, region.End(), start, end)
+	}
+	region.Start -= start
+	return region, nil
+}
+
+// excerptName is the cut excerpt's file name, which the processed output is
+// named after: /path/to/audio.wav → audio-excerpt.flac.
+func excerptName(inputPath string) string {
+	filename := filepath.Base(inputPath)
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + "-excerpt.flac"
+}
+
+// processExcerpt is ProcessAudio for --excerpt: it cuts the excerpt to a temp
+// copy named <name>-excerpt.flac and runs the four passes on that alone, so the
+// output is published under the usual naming as <name>-excerpt-LUFS-NN-processed
+// beside the input or under --output. The result describes the excerpt;
+// Excerpt records where it was cut.
+func processExcerpt(ctx context.Context, inputPath string, config *BaseFilterConfig, progressCallback ProgressCallback) (*ProcessingResult, error) {
+	reader, metadata, err := audio.OpenAudioFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %w", err)
+	}
+	reader.Close()
+	start, end, err := planExcerpt(config.Excerpt, time.Duration(metadata.Duration*float64(time.Second)))
+	if err != nil {
+		return nil, err
+	}
+	noiseRegion, err := excerptNoiseRegion(config.NoiseRegion, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cut goes in a private directory beside the output, so its name can
+	// carry the output's and a failed run leaves nothing behind.
+	anchor := config.outputAnchor(inputPath)
+	tempDir, err := os.MkdirTemp(filepath.Dir(anchor), ".excerpt-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create excerpt directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	excerptPath := filepath.Join(tempDir, excerptName(inputPath))
+	cutSpec := fmt.Sprintf("atrim=start=%f:duration=%f,asetpts=PTS-STARTPTS,aformat=sample_fmts=s32",
+		start.Seconds(), (end - start).Seconds())
+	cutPath, err := renderInputCopy(ctx, inputPath, excerptPath, cutSpec, "excerpt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to cut the excerpt: %w", err)
+	}
+	if err := os.Rename(cutPath, excerptPath); err != nil {
+		return nil, fmt.Errorf("failed to cut the excerpt: %w", err)
+	}
+
+	// The excerpt runs the ordinary pipeline, published where the input's
+	// output would have gone. Cover art is read from the input afterwards.
+	excerpt := *config
+	excerpt.Excerpt = ExcerptConfig{}
+	excerpt.NoiseRegion = noiseRegion
+	if excerpt.OutputFile == "" && excerpt.OutputDir == "" {
+		excerpt.OutputDir = filepath.Dir(inputPath)
+	}
+	excerpt.KeepCoverArt = false
+	result, err := ProcessAudio(ctx, excerptPath, &excerpt, progressCallback)
+	if err != nil {
+		return nil, err
+	}
+	result.Excerpt = &ExcerptRange{StartS: start.Seconds(), DurationS: (end - start).Seconds()}
+
+	if config.KeepCoverArt {
+		if _, err := keepCoverArt(inputPath, result.OutputPath); err != nil {
+			result.Diagnostics.Warnings = append(result.Diagnostics.Warnings, fmt.Sprintf("cover art not kept: %v", err))
+		}
+	}
+	return result, nil
+}
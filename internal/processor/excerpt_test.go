@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanExcerpt(t *testing.T) {
+	total := 60 * time.Second
+	tests := []struct {
+		name               string
+		start, dur         time.Duration
+		wantStart, wantEnd time.Duration
+		wantErr            bool
+	}{
+		{"interior", 20 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, false},
+		{"whole file", 0, 2 * time.Minute, 0, total, false},
+		{"clamped to the end", 50 * time.Second, 20 * time.Second, 50 * time.Second, total, false},
+		{"past the end", 70 * time.Second, 10 * time.Second, 0, 0, true},
+		{"too short once clamped", 57 * time.Second, 10 * time.Second, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := planExcerpt(ExcerptConfig{Enabled: true, Start: tt.start, Duration: tt.dur}, total)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planExcerpt error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("planExcerpt = %v-%v, want %v-%v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestSetExcerpt(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetExcerpt(-time.Second, 10*time.Second); err == nil {
+		t.Error("negative start accepted")
+	}
+	if err := cfg.SetExcerpt(0, time.Second); err == nil {
+		t.Error("1 s excerpt accepted")
+	}
+	if err := cfg.SetExcerpt(12*time.Minute, 3*time.Minute); err != nil {
+		t.Fatalf("SetExcerpt: %v", err)
+	}
+	if !cfg.Excerpt.Enabled || cfg.Excerpt.End() != 15*time.Minute {
+		t.Errorf("Excerpt = %+v, want enabled ending at 15m", cfg.Excerpt)
+	}
+}
+
+func TestExcerptNoiseRegion(t *testing.T) {
+	start, end := 10*time.Minute, 13*time.Minute
+	got, err := excerptNoiseRegion(NoiseRegionConfig{Enabled: true, Start: 11 * time.Minute, Duration: 10 * time.Second}, start, end)
+	if err != nil || got.Start != time.Minute || got.Duration != 10*time.Second {
+		t.Errorf("excerptNoiseRegion = %+v, %v; want 1m for 10s on the excerpt", got, err)
+	}
+	if _, err := excerptNoiseRegion(NoiseRegionConfig{Enabled: true, Start: 5 * time.Minute, Duration: 10 * time.Second}, start, end); err == nil {
+		t.Error("noise region before the excerpt accepted")
+	}
+	if got, err := excerptNoiseRegion(NoiseRegionConfig{}, start, end); err != nil || got.Enabled {
+		t.Errorf("unset noise region = %+v, %v; want it left unset", got, err)
+	}
+}
+
+func TestExcerptName(t *testing.T) {
+	if got := excerptName("/shows/ep12.wav"); got != "ep12-excerpt.flac" {
+		t.Errorf("excerptName = %q, want ep12-excerpt.flac", got)
+	}
+}
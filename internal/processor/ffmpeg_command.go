@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// generateFFmpegCommandPath names the reproduction script beside the input.
+// Example: /path/to/audio.wav → /path/to/audio-ffmpeg-command.sh
+func generateFFmpegCommandPath(inputPath string) string {
+	dir := filepath.Dir(inputPath)
+	filename := filepath.Base(inputPath)
+	nameWithoutExt := strings.TrimSuffix(filename, filepath.Ext(filename))
+	return filepath.Join(dir, nameWithoutExt+"-ffmpeg-command.sh")
+}
+
+// shellQuote single-quotes s for a POSIX shell. An embedded single quote closes
+// the quoting, adds an escaped quote, and reopens it.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// buildFFmpegCommand assembles a runnable ffmpeg command line that reproduces
+// the render (--emit-ffmpeg-command). The Pass 2 chain and the Pass 4 chain
+// run back to back as one -af graph. The Pass 4 loudnorm carries this run's
+// Pass 3 measurements, so no separate measuring pass is needed. The
+// measurement filters stay in the graph; they only attach metadata. The
+// encoder options mirror createFormatEncoder for format. Without -y, ffmpeg
+// asks before overwriting outputPath.
+func buildFFmpegCommand(inputPath, outputPath, pass2Spec, pass4Spec string, bitDepth int, format string) string {
+	chain := pass2Spec
+	if pass4Spec != "" {
+		chain += "," + pass4Spec
+	}
+
+	args := []string{
+		"ffmpeg", "-i", shellQuote(inputPath),
+		"-af", shellQuote(chain),
+	}
+	switch {
+	case format == OutputFormatWAV && bitDepth == OutputBitDepth24:
+		args = append(args, "-c:a", "pcm_s24le")
+	case format == OutputFormatWAV:
+		args = append(args, "-c:a", "pcm_s16le")
+	default:
+		args = append(args, "-c:a", "flac", "-compression_level", "5", "-frame_size", "4096")
+		if bitDepth == OutputBitDepth24 {
+			args = append(args, "-bits_per_raw_sample", "24")
+		}
+	}
+	args = append(args, shellQuote(outputPath))
+	return strings.Join(args, " ")
+}
+
+// writeFFmpegCommand writes the reproduction command as a shell script beside
+// the input and returns its path.
+func writeFFmpegCommand(inputPath, command string) (string, error) {
+	scriptPath := generateFFmpegCommandPath(inputPath)
+	script := "#!/bin/sh\n# Reproduces the jivetalking render of " + filepath.Base(inputPath) + "\n" + command + "\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil { //nolint:gosec // an executable script is the point
+		return "", fmt.Errorf("failed to write ffmpeg command: %w", err)
+	}
+	return scriptPath, nil
+}
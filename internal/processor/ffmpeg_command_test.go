@@ -0,0 +1,45 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildFFmpegCommand(t *testing.T) {
+	cmd := buildFFmpegCommand("/rec/Guest's mic.wav", "/rec/out.flac", "highpass=f=80", "loudnorm=I=-16", OutputBitDepth16, OutputFormatFLAC)
+
+	want := `ffmpeg -i '/rec/Guest'\''s mic.wav' -af 'highpass=f=80,loudnorm=I=-16' -c:a flac -compression_level 5 -frame_size 4096 '/rec/out.flac'`
+	if cmd != want {
+		t.Errorf("command =\n%s\nwant\n%s", cmd, want)
+	}
+
+	cmd24 := buildFFmpegCommand("in.wav", "out.flac", "anull", "", OutputBitDepth24, "")
+	if !strings.Contains(cmd24, "-af 'anull' ") || !strings.Contains(cmd24, "-bits_per_raw_sample 24") {
+		t.Errorf("24-bit command = %q, want the Pass 2 chain alone and 24-bit coding", cmd24)
+	}
+
+	wav24 := buildFFmpegCommand("in.wav", "out.wav", "anull", "", OutputBitDepth24, OutputFormatWAV)
+	if !strings.Contains(wav24, "-c:a pcm_s24le 'out.wav'") || strings.Contains(wav24, "flac") {
+		t.Errorf("24-bit WAV command = %q, want pcm_s24le and no FLAC options", wav24)
+	}
+}
+
+func TestWriteFFmpegCommand(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "presenter.wav")
+	path, err := writeFFmpegCommand(input, "ffmpeg -i 'presenter.wav'")
+	if err != nil {
+		t.Fatalf("writeFFmpegCommand() failed: %v", err)
+	}
+	if filepath.Base(path) != "presenter-ffmpeg-command.sh" {
+		t.Errorf("script path = %q, want presenter-ffmpeg-command.sh", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "#!/bin/sh\n") || !strings.HasSuffix(string(data), "ffmpeg -i 'presenter.wav'\n") {
+		t.Errorf("script = %q, want a shebang and the command", data)
+	}
+}
@@ -1,9 +1,12 @@
 package processor
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 var processorRename = os.Rename
@@ -41,6 +44,38 @@ func createSiblingTempPathSuffix(targetPath, marker, suffix string) (string, err
 	return tempPath, nil
 }
 
+// copySiblingTemp copies src to a new sibling temp path (createSiblingTempPath)
+// and returns it. The temp path is removed if the copy fails.
+func copySiblingTemp(src, marker string) (string, error) {
+	dstPath, err := createSiblingTempPath(src, marker)
+	if err != nil {
+		return "", err
+	}
+	if err := copyFileContents(src, dstPath); err != nil {
+		_ = os.Remove(dstPath)
+		return "", fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	return dstPath, nil
+}
+
+func copyFileContents(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
 // publishOutput moves a same-directory temp file to dst, atomically overwriting
 // any existing destination (os.Rename replaces dst on the same filesystem), so a
 // re-run replaces the prior output rather than failing.
@@ -51,3 +86,213 @@ func publishOutput(src, dst string) error {
 
 	return nil
 }
+
+// origBackupSuffix names the backup kept when --in-place replaces an input:
+// /path/to/audio.flac → /path/to/audio.flac.orig
+const origBackupSuffix = ".orig"
+
+// sameFile reports whether a and b name the same file: the same cleaned
+// absolute path, or two existing paths os.SameFile matches (a symlink or hard
+// link to the input).
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA == nil && errB == nil && absA == absB {
+		return true
+	}
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// publishProcessedOutput publishes a processed temp file to dst, guarding the
+// input. A dst that resolves to inputPath is refused unless inPlace is set;
+// with inPlace the input is first moved to <input>.orig, and moved back if the
+// publish then fails, so the original survives either way. Any other dst is
+// published as publishOutput does.
+func publishProcessedOutput(src, dst, inputPath string, inPlace bool) error {
+	if !sameFile(dst, inputPath) {
+		return publishOutput(src, dst)
+	}
+	if !inPlace {
+		return fmt.Errorf("refusing to overwrite input %s: in-place writing is not enabled", inputPath)
+	}
+
+	backupPath := inputPath + origBackupSuffix
+	if err := processorRename(inputPath, backupPath); err != nil {
+		return fmt.Errorf("failed to back up input to %s: %w", backupPath, err)
+	}
+	if err := publishOutput(src, dst); err != nil {
+		_ = processorRename(backupPath, inputPath)
+		return err
+	}
+	return nil
+}
+
+// Output-exists policies (--on-exists): what to do when the output path is
+// already taken by an earlier run's file.
+const (
+	OnExistsOverwrite = "overwrite" // Replace it, as publishOutput always has
+	OnExistsSkip      = "skip"      // Leave it and do not process the input
+	OnExistsRename    = "rename"    // Write beside it as "<name> (1).flac", " (2)", ...
+	OnExistsError     = "error"     // Fail the file
+)
+
+// ErrOutputExists is returned under OnExistsError, and ErrOutputSkipped under
+// OnExistsSkip, when the output path is already taken. Both wrap the path.
+var (
+	ErrOutputExists  = errors.New("output already exists")
+	ErrOutputSkipped = errors.New("output already exists, not processed")
+)
+
+// onExistsMaxRenames bounds the " (N)" search so a directory full of earlier
+// runs fails rather than spins.
+const onExistsMaxRenames = 999
+
+// SetOnExists selects the output-exists policy.
+func (cfg *BaseFilterConfig) SetOnExists(policy string) error {
+	switch policy {
+	case OnExistsOverwrite, OnExistsSkip, OnExistsRename, OnExistsError:
+		cfg.OnExists = policy
+	default:
+		return fmt.Errorf("policy %q is not %q, %q, %q or %q",
+			policy, OnExistsOverwrite, OnExistsSkip, OnExistsRename, OnExistsError)
+	}
+	return nil
+}
+
+// SetOutput directs the outputs to path (--output). A path ending in .flac or
+// .wav names the processed output itself, unless it is an existing directory;
+// any other path is a directory the outputs are written into under their
+// usual names. Missing directories are created when a file is processed.
+func (cfg *BaseFilterConfig) SetOutput(path string) error {
+	if path == "" {
+		return fmt.Errorf("output path is empty")
+	}
+	cfg.OutputDir, cfg.OutputFile = path, ""
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".flac" || ext == ".wav" {
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			cfg.OutputDir, cfg.OutputFile = "", path
+		}
+	}
+	return nil
+}
+
+// outputAnchor is the path every output name and temp file derives from: the
+// input itself, or the input's name inside OutputDir or beside OutputFile. It
+// is never read, only named from.
+func (cfg *BaseFilterConfig) outputAnchor(inputPath string) string {
+	switch {
+	case cfg.OutputFile != "":
+		return filepath.Join(filepath.Dir(cfg.OutputFile), filepath.Base(inputPath))
+	case cfg.OutputDir != "":
+		return filepath.Join(cfg.OutputDir, filepath.Base(inputPath))
+	}
+	return inputPath
+}
+
+// processedOutputPath is the processed output's path for a run landing at
+// lufsValue: OutputFile verbatim, or the usual LUFS name at the anchor.
+func (cfg *BaseFilterConfig) processedOutputPath(inputPath string, lufsValue int) string {
+	if cfg.OutputFile != "" {
+		return cfg.OutputFile
+	}
+	return generateLUFSOutputPath(cfg.outputAnchor(inputPath), lufsValue, cfg.outputExt())
+}
+
+// prepareOutput creates the directory --output points into, with any missing
+// parents, and refuses up front an OutputFile that is the input itself unless
+// InPlace allows it, rather than after every pass has run. Writing beside the
+// input needs nothing.
+func (cfg *BaseFilterConfig) prepareOutput(inputPath string) error {
+	if cfg.OutputFile != "" && !cfg.InPlace && sameFile(cfg.OutputFile, inputPath) {
+		return fmt.Errorf("refusing to overwrite input %s: in-place writing is not enabled", inputPath)
+	}
+	anchor := cfg.outputAnchor(inputPath)
+	if anchor == inputPath {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(anchor), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return nil
+}
+
+// resolveOutputPath applies the output-exists policy to path. A free path, or
+// any path under OnExistsOverwrite (the empty policy included), is returned
+// unchanged; OnExistsRename returns the first free "<name> (N)<ext>".
+func resolveOutputPath(path, policy string) (string, error) {
+	if _, err := os.Lstat(path); errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to check output %s: %w", path, err)
+	}
+
+	switch policy {
+	case OnExistsSkip:
+		return "", fmt.Errorf("%w: %s", ErrOutputSkipped, path)
+	case OnExistsError:
+		return "", fmt.Errorf("%w: %s", ErrOutputExists, path)
+	case OnExistsRename:
+		ext := filepath.Ext(path)
+		stem := strings.TrimSuffix(path, ext)
+		for n := 1; n <= onExistsMaxRenames; n++ {
+			candidate := fmt.Sprintf("%s (%d)%s", stem, n, ext)
+			if _, err := os.Lstat(candidate); errors.Is(err, os.ErrNotExist) {
+				return candidate, nil
+			}
+		}
+		return "", fmt.Errorf("%w: %s and %d renamed copies", ErrOutputExists, path, onExistsMaxRenames)
+	default:
+		return path, nil
+	}
+}
+
+// reserveOutputPath is resolveOutputPath for the publish itself: under every
+// policy but OnExistsOverwrite it claims the chosen name by creating it with
+// O_EXCL, so two jobs can never both find "<name> (1)" free and both take it.
+// The empty placeholder is replaced when the output is published over it;
+// release removes it when the publish never happens. Under OnExistsOverwrite
+// nothing is claimed and release is a no-op.
+func reserveOutputPath(path, policy string) (resolved string, release func(), err error) {
+	noop := func() {}
+	if policy == "" || policy == OnExistsOverwrite {
+		return path, noop, nil
+	}
+
+	candidates := []string{path}
+	if policy == OnExistsRename {
+		ext := filepath.Ext(path)
+		stem := strings.TrimSuffix(path, ext)
+		for n := 1; n <= onExistsMaxRenames; n++ {
+			candidates = append(candidates, fmt.Sprintf("%s (%d)%s", stem, n, ext))
+		}
+	}
+	for _, candidate := range candidates {
+		f, err := os.OpenFile(candidate, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		} else if err != nil {
+			return "", noop, fmt.Errorf("failed to reserve output %s: %w", candidate, err)
+		}
+		_ = f.Close()
+		return candidate, func() { _ = os.Remove(candidate) }, nil
+	}
+
+	switch policy {
+	case OnExistsSkip:
+		return "", noop, fmt.Errorf("%w: %s", ErrOutputSkipped, path)
+	case OnExistsRename:
+		return "", noop, fmt.Errorf("%w: %s and %d renamed copies", ErrOutputExists, path, onExistsMaxRenames)
+	default:
+		return "", noop, fmt.Errorf("%w: %s", ErrOutputExists, path)
+	}
+}
+
+// OutputStem is the path every output of inputPath is named from, without an
+// extension: /in/episode.wav under --output=/out → /out/episode. Two inputs
+// with the same stem would write the same outputs and reports.
+func (cfg *BaseFilterConfig) OutputStem(inputPath string) string {
+	anchor := cfg.outputAnchor(inputPath)
+	return strings.TrimSuffix(anchor, filepath.Ext(anchor))
+}
@@ -1,6 +1,7 @@
 package processor
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -46,3 +47,287 @@ func TestCreateSiblingStatsPathRejectsSeparatorMarker(t *testing.T) {
 		t.Fatal("createSiblingStatsPath() with separator marker = nil error, want error")
 	}
 }
+
+func TestPublishProcessedOutputGuardsInput(t *testing.T) {
+	write := func(t *testing.T, path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(t *testing.T, path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	t.Run("refuses the input path without in-place", func(t *testing.T) {
+		dir := t.TempDir()
+		input := filepath.Join(dir, "presenter.flac")
+		temp := filepath.Join(dir, ".processing.tmp.flac")
+		write(t, input, "original")
+		write(t, temp, "processed")
+
+		if err := publishProcessedOutput(temp, input, input, false); err == nil {
+			t.Fatal("publishProcessedOutput() = nil error, want refusal")
+		}
+		if got := read(t, input); got != "original" {
+			t.Errorf("input = %q, want it untouched", got)
+		}
+	})
+
+	t.Run("in-place keeps an .orig backup", func(t *testing.T) {
+		dir := t.TempDir()
+		input := filepath.Join(dir, "presenter.flac")
+		temp := filepath.Join(dir, ".processing.tmp.flac")
+		write(t, input, "original")
+		write(t, temp, "processed")
+
+		// A relative spelling of the input still resolves to it.
+		t.Chdir(dir)
+		if err := publishProcessedOutput(temp, "presenter.flac", input, true); err != nil {
+			t.Fatalf("publishProcessedOutput() failed: %v", err)
+		}
+		if got := read(t, input); got != "processed" {
+			t.Errorf("input = %q, want the processed output", got)
+		}
+		if got := read(t, input+origBackupSuffix); got != "original" {
+			t.Errorf("backup = %q, want the original", got)
+		}
+	})
+
+	t.Run("other paths publish normally", func(t *testing.T) {
+		dir := t.TempDir()
+		input := filepath.Join(dir, "presenter.flac")
+		output := filepath.Join(dir, "presenter-LUFS-16-processed.flac")
+		temp := filepath.Join(dir, ".processing.tmp.flac")
+		write(t, input, "original")
+		write(t, temp, "processed")
+
+		if err := publishProcessedOutput(temp, output, input, false); err != nil {
+			t.Fatalf("publishProcessedOutput() failed: %v", err)
+		}
+		if got := read(t, output); got != "processed" {
+			t.Errorf("output = %q, want the processed output", got)
+		}
+		if _, err := os.Stat(input + origBackupSuffix); !os.IsNotExist(err) {
+			t.Errorf("unexpected backup beside a separate output: %v", err)
+		}
+	})
+}
+
+func TestResolveOutputPath(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "presenter-LUFS-16-processed.flac")
+
+	// A free path is kept under every policy.
+	for _, policy := range []string{"", OnExistsOverwrite, OnExistsSkip, OnExistsRename, OnExistsError} {
+		if got, err := resolveOutputPath(output, policy); err != nil || got != output {
+			t.Errorf("%q on a free path = %q, %v; want it unchanged", policy, got, err)
+		}
+	}
+
+	for _, name := range []string{"presenter-LUFS-16-processed.flac", "presenter-LUFS-16-processed (1).flac"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, err := resolveOutputPath(output, OnExistsOverwrite); err != nil || got != output {
+		t.Errorf("overwrite = %q, %v; want the taken path", got, err)
+	}
+	if _, err := resolveOutputPath(output, OnExistsSkip); !errors.Is(err, ErrOutputSkipped) {
+		t.Errorf("skip error = %v, want ErrOutputSkipped", err)
+	}
+	if _, err := resolveOutputPath(output, OnExistsError); !errors.Is(err, ErrOutputExists) {
+		t.Errorf("error policy = %v, want ErrOutputExists", err)
+	}
+	want := filepath.Join(dir, "presenter-LUFS-16-processed (2).flac")
+	if got, err := resolveOutputPath(output, OnExistsRename); err != nil || got != want {
+		t.Errorf("rename = %q, %v; want %q", got, err, want)
+	}
+}
+
+func TestReserveOutputPath(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "presenter-LUFS-16-processed.flac")
+
+	// Two renaming jobs in turn each claim a name of their own.
+	first, releaseFirst, err := reserveOutputPath(output, OnExistsRename)
+	if err != nil || first != output {
+		t.Fatalf("first rename = %q, %v; want the free path", first, err)
+	}
+	second, _, err := reserveOutputPath(output, OnExistsRename)
+	if want := filepath.Join(dir, "presenter-LUFS-16-processed (1).flac"); err != nil || second != want {
+		t.Errorf("second rename = %q, %v; want %q, the first being claimed", second, err, want)
+	}
+	if _, _, err := reserveOutputPath(output, OnExistsError); !errors.Is(err, ErrOutputExists) {
+		t.Errorf("error policy on a claimed path = %v, want ErrOutputExists", err)
+	}
+	if _, _, err := reserveOutputPath(output, OnExistsSkip); !errors.Is(err, ErrOutputSkipped) {
+		t.Errorf("skip policy on a claimed path = %v, want ErrOutputSkipped", err)
+	}
+
+	releaseFirst()
+	if _, err := os.Stat(first); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("released placeholder still there: %v", err)
+	}
+	got, release, err := reserveOutputPath(output, OnExistsOverwrite)
+	release()
+	if err != nil || got != output {
+		t.Errorf("overwrite = %q, %v; want the path unclaimed", got, err)
+	}
+	if _, err := os.Stat(output); !errors.Is(err, os.ErrNotExist) {
+		t.Error("overwrite claimed the path")
+	}
+}
+
+func TestOutputStem(t *testing.T) {
+	config := DefaultFilterConfig()
+	if got := config.OutputStem("/in/episode.wav"); got != "/in/episode" {
+		t.Errorf("OutputStem = %q, want /in/episode", got)
+	}
+	if err := config.SetOutput(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if a, b := config.OutputStem("/a/episode.wav"), config.OutputStem("/b/episode.flac"); a != b {
+		t.Errorf("OutputStem under --output = %q and %q, want one stem", a, b)
+	}
+}
+
+func TestSetOnExists(t *testing.T) {
+	config := DefaultFilterConfig()
+	if err := config.SetOnExists(OnExistsRename); err != nil || config.OnExists != OnExistsRename {
+		t.Errorf("SetOnExists(rename) = %v, OnExists %q", err, config.OnExists)
+	}
+	if err := config.SetOnExists("append"); err == nil {
+		t.Error("SetOnExists(append) = nil error, want error")
+	}
+}
+
+func TestSetOutputFormat(t *testing.T) {
+	config := DefaultFilterConfig()
+	if config.outputExt() != ".flac" {
+		t.Errorf("default outputExt = %q, want .flac", config.outputExt())
+	}
+	if err := config.SetOutputFormat(OutputFormatWAV); err != nil {
+		t.Fatalf("SetOutputFormat(wav): %v", err)
+	}
+	if got := config.processedOutputPath("/in/presenter.flac", 16); got != "/in/presenter-LUFS-16-processed.wav" {
+		t.Errorf("processedOutputPath = %q, want presenter-LUFS-16-processed.wav", got)
+	}
+	if err := config.SetOutputFormat("mp3"); err == nil {
+		t.Error("SetOutputFormat(mp3) = nil error, want error")
+	}
+}
+
+func TestPredictedOutputPath(t *testing.T) {
+	config := DefaultFilterConfig()
+	config.Loudnorm.TargetI = -16
+	if got, ok := predictedOutputPath("/in/presenter.wav", config); !ok || got != "/in/presenter-LUFS-16-processed.flac" {
+		t.Errorf("predictedOutputPath = %q, %v", got, ok)
+	}
+	config.Loudnorm.SpeechOnly = true
+	if _, ok := predictedOutputPath("/in/presenter.wav", config); ok {
+		t.Error("speech-only loudness predicted a name, want none")
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	dir := t.TempDir()
+	flacDir := filepath.Join(dir, "takes.flac")
+	if err := os.Mkdir(flacDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path           string
+		wantDir, wantF string
+	}{
+		{filepath.Join(dir, "out"), filepath.Join(dir, "out"), ""},
+		{filepath.Join(dir, "episode.flac"), "", filepath.Join(dir, "episode.flac")},
+		{filepath.Join(dir, "EPISODE.FLAC"), "", filepath.Join(dir, "EPISODE.FLAC")},
+		{filepath.Join(dir, "episode.wav"), "", filepath.Join(dir, "episode.wav")},
+		{flacDir, flacDir, ""},
+	} {
+		config := DefaultFilterConfig()
+		if err := config.SetOutput(tc.path); err != nil {
+			t.Fatalf("SetOutput(%q): %v", tc.path, err)
+		}
+		if config.OutputDir != tc.wantDir || config.OutputFile != tc.wantF {
+			t.Errorf("SetOutput(%q) = dir %q file %q, want dir %q file %q",
+				tc.path, config.OutputDir, config.OutputFile, tc.wantDir, tc.wantF)
+		}
+	}
+
+	if err := DefaultFilterConfig().SetOutput(""); err == nil {
+		t.Error("SetOutput(\"\") = nil error, want error")
+	}
+}
+
+func TestOutputPathsFollowOutput(t *testing.T) {
+	input := filepath.Join("/ro", "presenter.wav")
+
+	config := DefaultFilterConfig()
+	if got := config.processedOutputPath(input, 16); got != "/ro/presenter-LUFS-16-processed.flac" {
+		t.Errorf("beside the input = %q", got)
+	}
+
+	config.OutputDir = "/out"
+	if got := config.outputAnchor(input); got != "/out/presenter.wav" {
+		t.Errorf("anchor = %q, want the input's name in the directory", got)
+	}
+	if got := config.processedOutputPath(input, 16); got != "/out/presenter-LUFS-16-processed.flac" {
+		t.Errorf("in the directory = %q", got)
+	}
+
+	config.OutputDir, config.OutputFile = "", "/out/final.flac"
+	if got := config.processedOutputPath(input, 16); got != "/out/final.flac" {
+		t.Errorf("verbatim file = %q", got)
+	}
+	if got := generateNoiseStemPath(config.outputAnchor(input)); got != "/out/presenter-noise-stem.flac" {
+		t.Errorf("noise stem = %q, want beside the output file", got)
+	}
+	config.Loudnorm.SpeechOnly = true
+	if got, ok := predictedOutputPath(input, config); !ok || got != "/out/final.flac" {
+		t.Errorf("predictedOutputPath = %q, %v; want the named file whatever the target", got, ok)
+	}
+}
+
+func TestPrepareOutput(t *testing.T) {
+	dir := t.TempDir()
+	config := DefaultFilterConfig()
+	config.OutputDir = filepath.Join(dir, "a", "b")
+	if err := config.prepareOutput(filepath.Join(dir, "in.wav")); err != nil {
+		t.Fatalf("prepareOutput: %v", err)
+	}
+	if info, err := os.Stat(config.OutputDir); err != nil || !info.IsDir() {
+		t.Errorf("output directory not created: %v", err)
+	}
+
+	// Beside the input there is nothing to create.
+	if err := DefaultFilterConfig().prepareOutput(filepath.Join(dir, "missing", "in.wav")); err != nil {
+		t.Errorf("prepareOutput beside the input = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("created the input's directory")
+	}
+
+	// An output file that is the input is refused before any pass runs.
+	input := filepath.Join(dir, "episode.flac")
+	if err := os.WriteFile(input, []byte("audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config = DefaultFilterConfig()
+	config.OutputFile = input
+	if err := config.prepareOutput(input); err == nil {
+		t.Error("prepareOutput onto the input = nil error, want refusal")
+	}
+	config.InPlace = true
+	if err := config.prepareOutput(input); err != nil {
+		t.Errorf("prepareOutput onto the input with InPlace = %v", err)
+	}
+}
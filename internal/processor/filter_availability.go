@@ -0,0 +1,115 @@
+package processor
+
+import (
+	"slices"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+)
+
+// requiredFilters are the FFmpeg filters the four passes cannot run without.
+// Every graph is built from a filter spec string, so a missing one fails the
+// graph for every file rather than degrading anything.
+var requiredFilters = []string{
+	"abuffer", "abuffersink", "aformat", "aresample", "asetnsamples", "pan",
+	"highpass", "lowpass", "anlmdn", "afftdn", "agate", "acompressor", "deesser",
+	"astats", "ebur128", "volume", "alimiter", "loudnorm", "adeclick",
+	"atrim", "asetpts",
+}
+
+// optionalFilter is a filter whose absence switches off the feature that uses
+// it; effect says what the user loses.
+type optionalFilter struct {
+	name   string
+	effect string
+}
+
+// optionalFilters lists the filters the pipeline can work around.
+var optionalFilters = []optionalFilter{
+	{"aspectralstats", "spectral measurements are skipped and the spectral-driven tuning falls back to its defaults"},
+	{"aeval", "--comfort-noise is disabled"},
+	{"showspectrumpic", "--diagnostics cannot render spectrogram PNGs"},
+	{"aevalsrc", "--slate is disabled"},
+	{"concat", "--slate is disabled and long files are rendered whole, not in chunks"},
+	{"amovie", "--fix-region is disabled, whole files are processed, and long files are rendered whole, not in chunks"},
+	{"acrossfade", "--fix-region is disabled and whole files are processed"},
+	{"bandpass", "--mains=auto cannot detect the mains frequency and leaves the hum notch off"},
+	{"bandreject", "--mains is disabled"},
+}
+
+// spectralStatsUnavailable drops aspectralstats from every analysis graph. It
+// is set once by FilterAvailability.Degrade, before any file is processed.
+var spectralStatsUnavailable bool
+
+// withSpectralStats returns spec, or "" when aspectralstats is unavailable.
+func withSpectralStats(spec string) string {
+	if spectralStatsUnavailable {
+		return ""
+	}
+	return spec
+}
+
+// FilterAvailability lists the pipeline filters missing from the linked FFmpeg.
+type FilterAvailability struct {
+	MissingRequired []string
+	MissingOptional []string
+}
+
+// CheckFilters looks up every filter the pipeline uses in the linked FFmpeg.
+// The names are freed after the lookup rather than interned with GlobalCStr,
+// which is kept for string literals (see TestGlobalCStrLiteralsOnly).
+func CheckFilters() FilterAvailability {
+	return checkFilters(func(name string) bool {
+		nameC := ffmpeg.ToCStr(name)
+		defer nameC.Free()
+		return ffmpeg.AVFilterGetByName(nameC) != nil
+	})
+}
+
+// checkFilters is CheckFilters over an injected lookup.
+func checkFilters(linked func(name string) bool) FilterAvailability {
+	var a FilterAvailability
+	for _, name := range requiredFilters {
+		if !linked(name) {
+			a.MissingRequired = append(a.MissingRequired, name)
+		}
+	}
+	for _, f := range optionalFilters {
+		if !linked(f.name) {
+			a.MissingOptional = append(a.MissingOptional, f.name)
+		}
+	}
+	return a
+}
+
+// Degrade switches off the features that depend on the missing optional
+// filters and returns one warning per filter saying what was lost.
+func (a FilterAvailability) Degrade(cfg *BaseFilterConfig) []string {
+	var warnings []string
+	for _, f := range optionalFilters {
+		if !slices.Contains(a.MissingOptional, f.name) {
+			continue
+		}
+		switch f.name {
+		case "aspectralstats":
+			spectralStatsUnavailable = true
+		case "aeval":
+			cfg.ComfortNoise = false
+		case "aevalsrc":
+			cfg.Slate.Enabled = false
+		case "concat":
+			cfg.Slate.Enabled = false
+			cfg.ChunkOver = 0
+		case "amovie":
+			cfg.FixRegion.Enabled = false
+			cfg.ChunkOver = 0
+		case "acrossfade":
+			cfg.FixRegion.Enabled = false
+		case "bandpass":
+			humBandsUnavailable = true
+		case "bandreject":
+			cfg.Mains = ""
+		}
+		warnings = append(warnings, "FFmpeg filter "+f.name+" is not available: "+f.effect)
+	}
+	return warnings
+}
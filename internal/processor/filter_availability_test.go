@@ -0,0 +1,72 @@
+package processor
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckFilters(t *testing.T) {
+	all := checkFilters(func(string) bool { return true })
+	if len(all.MissingRequired) != 0 || len(all.MissingOptional) != 0 {
+		t.Errorf("complete build reported missing filters: %+v", all)
+	}
+
+	a := checkFilters(func(name string) bool { return name != "loudnorm" && name != "aspectralstats" })
+	if !slices.Equal(a.MissingRequired, []string{"loudnorm"}) {
+		t.Errorf("MissingRequired = %v, want [loudnorm]", a.MissingRequired)
+	}
+	if !slices.Equal(a.MissingOptional, []string{"aspectralstats"}) {
+		t.Errorf("MissingOptional = %v, want [aspectralstats]", a.MissingOptional)
+	}
+}
+
+func TestFilterAvailabilityDegrade(t *testing.T) {
+	t.Cleanup(func() { spectralStatsUnavailable = false })
+
+	cfg := DefaultFilterConfig()
+	cfg.ComfortNoise = true
+	warnings := FilterAvailability{MissingOptional: []string{"aspectralstats", "aeval"}}.Degrade(cfg)
+	if len(warnings) != 2 {
+		t.Fatalf("warnings = %q, want one per missing filter", warnings)
+	}
+	if cfg.ComfortNoise {
+		t.Error("comfort noise left on without aeval")
+	}
+
+	effective := deriveEffectiveFilterConfig(cfg)
+	if spec := effective.buildAnalysisFilter(); strings.Contains(spec, "aspectralstats") {
+		t.Errorf("analysis filter kept aspectralstats: %s", spec)
+	}
+	if !strings.HasPrefix(effective.buildAnalysisFilter(), astatsAnalysisSpec+","+ebur128AnalysisSpecPrefix) {
+		t.Errorf("analysis filter = %s, want astats then ebur128", effective.buildAnalysisFilter())
+	}
+}
+
+func TestFilterAvailabilityDegradeChunking(t *testing.T) {
+	for _, missing := range []string{"amovie", "concat"} {
+		cfg := DefaultFilterConfig()
+		cfg.ChunkOver = 3 * time.Hour
+		FilterAvailability{MissingOptional: []string{missing}}.Degrade(cfg)
+		if cfg.ChunkOver != 0 {
+			t.Errorf("chunking left on without %s", missing)
+		}
+	}
+}
+
+func TestFilterAvailabilityDegradeMains(t *testing.T) {
+	t.Cleanup(func() { humBandsUnavailable = false })
+
+	cfg := DefaultFilterConfig()
+	cfg.Mains = MainsAuto
+	FilterAvailability{MissingOptional: []string{"bandpass"}}.Degrade(cfg)
+	if !humBandsUnavailable || cfg.Mains != MainsAuto {
+		t.Errorf("without bandpass: hum reading skipped %v, Mains %q; want skipped and auto kept", humBandsUnavailable, cfg.Mains)
+	}
+
+	FilterAvailability{MissingOptional: []string{"bandreject"}}.Degrade(cfg)
+	if cfg.Mains != "" {
+		t.Errorf("Mains = %q without bandreject, want off", cfg.Mains)
+	}
+}
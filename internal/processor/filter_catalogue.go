@@ -0,0 +1,221 @@
+package processor
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// FilterParam is one tunable parameter of a stage and its default value.
+type FilterParam struct {
+	Name    string
+	Default string
+}
+
+// FilterStage describes one Pass 2 stage for --list-filters: what it does, its
+// parameters with their defaults, the AdaptConfig tuners that adjust it, and
+// the Pass 1 measurements those tuners read.
+type FilterStage struct {
+	ID           FilterID
+	Description  string
+	Params       []FilterParam
+	Tuners       []string
+	Measurements []string
+}
+
+// filterStageInfo is the part of the catalogue that cannot be read off the
+// config: a one-line description, the filterConfigDefaults field holding the
+// stage's parameters, the tuner functions, and the AudioMeasurements fields
+// they read (dotted Go field or method paths, checked by the tests).
+type filterStageInfo struct {
+	description  string
+	configField  string
+	tuners       []any
+	measurements []string
+}
+
+// filterStageCatalogue covers every stage in Pass2FilterOrder.
+var filterStageCatalogue = map[FilterID]filterStageInfo{
+	FilterDownmix: {
+		description: "Folds the input to mono; every later stage processes one channel; inverts polarity under --fix-polarity",
+		configField: "Downmix",
+		tuners:      []any{tunePolarity},
+		measurements: []string{
+			"Regions.Polarity.Verdict",
+		},
+	},
+	FilterRumbleHighPass: {
+		description: "Fixed 80 Hz high-pass removing subsonic rumble; cascaded to 24 dB/oct on frequent wind or handling bursts",
+		configField: "RumbleHighPass",
+		tuners:      []any{tuneRumbleBursts},
+		measurements: []string{
+			"Noise.RumbleBurstCount",
+			"Noise.RumbleBurstsPerMinute",
+		},
+	},
+	FilterHumNotch: {
+		description: "Narrow notches at the mains hum fundamental and harmonics under --mains; 50 or 60 Hz, or detected from the room tone",
+		configField: "HumNotch",
+		tuners:      []any{tuneHumNotch},
+		measurements: []string{
+			"Regions.NoiseProfile.Hum50RMS",
+			"Regions.NoiseProfile.Hum60RMS",
+			"Regions.NoiseProfile.MainsHumHz",
+		},
+	},
+	FilterBandlimitLowPass: {
+		description: "Unconditional 20.5 kHz band-limit removing inaudible ultrasonics; dropped on narrowband sources",
+		configField: "BandlimitLowPass",
+		tuners:      []any{tuneBandlimitLowPass, tuneNarrowbandSource},
+		measurements: []string{
+			"Regions.SpeechProfile.Spectral.Rolloff",
+			"Regions.SpeechProfile.SibilanceExcessDB",
+		},
+	},
+	FilterNoiseReduction: {
+		description: "anlmdn non-local-means denoise followed by the afftdn FFT denoise tail at the measured noise floor",
+		configField: "NoiseReduction",
+		tuners:      []any{tuneNoiseReduction, tuneNarrowbandSource},
+		measurements: []string{
+			"Noise.Floor",
+			"Noise.VoiceActivated",
+			"Regions.GateSeparationDB",
+			"Regions.NoiseProfile.Spectral.Flatness",
+		},
+	},
+	FilterSpeechGate: {
+		description: "Soft expander lowering the gaps between phrases once denoising has lowered the floor; optionally followed by a room-tone comfort-noise bed",
+		configField: "SpeechGate",
+		tuners:      []any{tuneSpeechGate, tuneComfortNoise},
+		measurements: []string{
+			"Noise.Floor",
+			"Regions.VoicedLowPercentile",
+			"Regions.GateSeparationDB",
+			"Regions.NoiseProfile.PeakLevel",
+			"Regions.NoiseProfile.CrestFactor",
+			"Regions.NoiseProfile.MeasuredNoiseFloor",
+			"Regions.NoiseProfile.Spectral.Centroid",
+			"Loudness.InputI",
+			"Loudness.InputLRA",
+		},
+	},
+	FilterLevellingCompressor: {
+		description: "Gentle levelling compressor evening the dynamics before normalisation, with a slow rider on wide loudness range",
+		configField: "LevellingCompressor",
+		tuners:      []any{tuneLevellingCompressor},
+		measurements: []string{
+			"Loudness.InputLRA",
+			"Dynamics.PeakLevel",
+			"Dynamics.RMSLevel",
+			"Regions.SpeechProfile.RMSLevel",
+		},
+	},
+	FilterDeesser: {
+		description: "Split-band de-esser taming the sibilance the compressor emphasises",
+		configField: "Deesser",
+		tuners:      []any{tuneDeesser, tuneNarrowbandSource},
+		measurements: []string{
+			"Regions.SpeechProfile.SibilanceExcessDB",
+			"Regions.SpeechProfile.PeakSibilanceExcessDB",
+		},
+	},
+	FilterAnalysis: {
+		description: "ebur128, astats and aspectralstats measurements of the processed audio; changes nothing",
+		configField: "Analysis",
+	},
+	FilterResample: {
+		description: "Output format: sample rate, sample format and frame size for the encoder; must be last",
+		configField: "Resample",
+		tuners:      []any{tuneOutputFormat},
+		measurements: []string{
+			"Dynamics.BitDepth",
+		},
+	},
+}
+
+// FilterCatalogue describes every Pass 2 stage in chain order. Parameters and
+// defaults are read from DefaultFilterConfig, and tuner names from the tuner
+// functions themselves, so the listing follows the code.
+func FilterCatalogue() []FilterStage {
+	defaults := reflect.ValueOf(DefaultFilterConfig().filterConfigDefaults)
+
+	stages := make([]FilterStage, 0, len(Pass2FilterOrder))
+	for _, id := range Pass2FilterOrder {
+		info := filterStageCatalogue[id]
+		stage := FilterStage{
+			ID:           id,
+			Description:  info.description,
+			Measurements: info.measurements,
+		}
+		if field := defaults.FieldByName(info.configField); field.IsValid() {
+			stage.Params = filterParams(field)
+		}
+		for _, tuner := range info.tuners {
+			stage.Tuners = append(stage.Tuners, funcName(tuner))
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// filterParams lists the exported fields of a stage config. A field is named by
+// its JSON key when it has one (the run-record name), otherwise by its Go name.
+func filterParams(cfg reflect.Value) []FilterParam {
+	var params []FilterParam
+	t := cfg.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" && tag != "-" {
+			name = tag
+		}
+		params = append(params, FilterParam{Name: name, Default: formatParamDefault(cfg.Field(i))})
+	}
+	return params
+}
+
+// formatParamDefault renders a default value; an empty string reads as unset.
+func formatParamDefault(v reflect.Value) string {
+	if v.Kind() == reflect.String && v.String() == "" {
+		return "(unset)"
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// funcName returns the bare name of a package-level function.
+func funcName(fn any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// WriteFilterCatalogue prints FilterCatalogue as plain text (--list-filters).
+func WriteFilterCatalogue(w io.Writer) error {
+	var sb strings.Builder
+	for i, stage := range FilterCatalogue() {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%d. %s\n   %s\n", i+1, stage.ID, stage.Description)
+		if len(stage.Params) > 0 {
+			sb.WriteString("   Parameters (defaults):\n")
+			for _, p := range stage.Params {
+				fmt.Fprintf(&sb, "     %-26s %s\n", p.Name, p.Default)
+			}
+		}
+		if len(stage.Tuners) > 0 {
+			fmt.Fprintf(&sb, "   Tuned by: %s\n", strings.Join(stage.Tuners, ", "))
+		} else {
+			sb.WriteString("   Tuned by: fixed, no adaptation\n")
+		}
+		if len(stage.Measurements) > 0 {
+			fmt.Fprintf(&sb, "   Driven by: %s\n", strings.Join(stage.Measurements, ", "))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
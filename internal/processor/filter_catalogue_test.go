@@ -0,0 +1,102 @@
+package processor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestFilterCatalogueCoversPass2Order asserts every Pass 2 stage is described,
+// in chain order, with a config field the parameters can be read from.
+func TestFilterCatalogueCoversPass2Order(t *testing.T) {
+	stages := FilterCatalogue()
+	if len(stages) != len(Pass2FilterOrder) {
+		t.Fatalf("catalogue has %d stages, Pass2FilterOrder has %d", len(stages), len(Pass2FilterOrder))
+	}
+	defaults := reflect.TypeFor[filterConfigDefaults]()
+	for i, id := range Pass2FilterOrder {
+		if stages[i].ID != id {
+			t.Errorf("stage %d = %q, want %q", i, stages[i].ID, id)
+		}
+		info, ok := filterStageCatalogue[id]
+		if !ok || info.description == "" {
+			t.Errorf("%s: no catalogue description", id)
+			continue
+		}
+		if _, ok := defaults.FieldByName(info.configField); !ok {
+			t.Errorf("%s: config field %q does not exist", id, info.configField)
+		}
+		if len(stages[i].Params) == 0 {
+			t.Errorf("%s: no parameters listed", id)
+		}
+	}
+}
+
+// TestFilterCatalogueMeasurementsResolve asserts every listed measurement is a
+// real AudioMeasurements field or method path, so a rename breaks the test
+// rather than leaving the listing stale.
+func TestFilterCatalogueMeasurementsResolve(t *testing.T) {
+	for id, info := range filterStageCatalogue {
+		for _, path := range info.measurements {
+			if !measurementPathExists(reflect.TypeFor[AudioMeasurements](), path) {
+				t.Errorf("%s: measurement %q is not on AudioMeasurements", id, path)
+			}
+		}
+	}
+}
+
+// measurementPathExists walks a dotted path of fields, ending optionally in a
+// method, through pointers.
+func measurementPathExists(t reflect.Type, path string) bool {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if f, ok := t.FieldByName(part); ok {
+			t = f.Type
+			continue
+		}
+		_, ok := reflect.PointerTo(t).MethodByName(part)
+		return ok && i == len(parts)-1
+	}
+	return true
+}
+
+func TestFilterCatalogueReadsDefaultsAndTuners(t *testing.T) {
+	var gate FilterStage
+	for _, s := range FilterCatalogue() {
+		if s.ID == FilterSpeechGate {
+			gate = s
+		}
+	}
+	if !reflect.DeepEqual(gate.Tuners, []string{"tuneSpeechGate", "tuneComfortNoise"}) {
+		t.Errorf("speech gate tuners = %v, want [tuneSpeechGate tuneComfortNoise]", gate.Tuners)
+	}
+
+	want := defaultSpeechGateConfig()
+	for _, p := range gate.Params {
+		if p.Name == "detection" && p.Default != want.Detection {
+			t.Errorf("detection default = %q, want %q", p.Default, want.Detection)
+		}
+	}
+}
+
+func TestWriteFilterCatalogue(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteFilterCatalogue(&sb); err != nil {
+		t.Fatalf("WriteFilterCatalogue: %v", err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"1. downmix",
+		"Tuned by: fixed, no adaptation",
+		"Tuned by: tuneNoiseReduction, tuneNarrowbandSource",
+		"Driven by: Noise.Floor",
+		"frequency_hz",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("listing missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -4,7 +4,10 @@ package processor
 import (
 	"fmt"
 	"math"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
 )
@@ -23,6 +26,7 @@ const (
 	// HP/LP side-chain filtering removes frequency extremes before the gate.
 	// Applied to the audio path before the gate for equivalent effect.
 	FilterRumbleHighPass   FilterID = "rumble_highpass"   // fixed 80 Hz HP corner (rumble removal)
+	FilterHumNotch         FilterID = "hum_notch"         // mains hum notches at the fundamental and harmonics (--mains)
 	FilterBandlimitLowPass FilterID = "bandlimit_lowpass" // #nosec G101 -- FFmpeg filter id, not a credential. Unconditional 20.5 kHz band-limit (ultrasonic rejection).
 	FilterSpeechGate       FilterID = "speech_gate"       // soft expander for inter-speech gaps
 
@@ -48,6 +52,7 @@ var Pass1FilterOrder = []FilterID{
 // Order rationale:
 // - Downmix first: ensures all downstream filters work with mono
 // - RumbleHighPass: removes subsonic rumble before other filters
+// - HumNotch: mains hum notches (--mains), before the gate listens to the pauses
 // - BandlimitLowPass: unconditional 20.5 kHz band-limit (removes inaudible ultrasonics)
 // - NoiseReduction: primary noise reduction using anlmdn + afftdn
 // - SpeechGate: soft expander for inter-speech cleanup (after denoising lowers floor)
@@ -58,6 +63,7 @@ var Pass1FilterOrder = []FilterID{
 var Pass2FilterOrder = []FilterID{
 	FilterDownmix,
 	FilterRumbleHighPass,
+	FilterHumNotch,
 	FilterBandlimitLowPass,
 	FilterNoiseReduction,
 	FilterSpeechGate,
@@ -117,6 +123,7 @@ type filterConfigDefaults struct {
 	Resample ResampleConfig `json:"-"`
 
 	RumbleHighPass      RumbleHighPassConfig      `json:"rumble_highpass"`
+	HumNotch            HumNotchConfig            `json:"hum_notch"`
 	BandlimitLowPass    BandlimitLowPassConfig    `json:"bandlimit_lowpass"`
 	NoiseReduction      NoiseReductionConfig      `json:"noise_reduction"`
 	SpeechGate          SpeechGateConfig          `json:"speech_gate"`
@@ -133,6 +140,12 @@ type filterConfigDefaults struct {
 
 type DownmixConfig struct {
 	Enabled bool
+	// InvertPolarity flips the signal after the fold (tunePolarity, under
+	// --fix-polarity).
+	InvertPolarity bool
+	// PreGainDB lifts a very quiet input after the fold (--quiet-pre-gain);
+	// the analysis stage takes it back off. Zero leaves the level alone.
+	PreGainDB float64
 }
 
 type AnalysisConfig struct {
@@ -140,10 +153,69 @@ type AnalysisConfig struct {
 }
 
 type ResampleConfig struct {
-	Enabled    bool
+	Enabled bool
+
+	// SampleRate is the delivered rate (--output-rate), applied by the Pass 2
+	// resample so Passes 3 and 4 measure and limit at it. Zero keeps the source
+	// rate. Soxr converts the rate with the SoX resampler rather than aformat's
+	// default swr conversion; --output-rate sets it.
 	SampleRate int
-	Format     string
-	FrameSize  int
+	Soxr       bool
+
+	Format    string
+	FrameSize int
+
+	// Channels is the delivered layout (--channels). The chain always processes
+	// mono; only the final Pass 4 output format applies this. OutputChannelsSame
+	// is resolved against the input once Pass 2 has read it. OutputChannelsSplit
+	// runs the chain once per channel instead (processSplitChannels).
+	Channels string
+
+	// BitDepth is the delivered bit depth (--bit-depth), matching Format: 16 is
+	// s16, 24 is s32 with 24 significant bits (FLAC is integer-only). Dither adds
+	// TPDF dither to the final 16-bit conversion; tuneOutputFormat sets it for
+	// every 16-bit output, since the passes run in float or s32.
+	BitDepth int
+	Dither   bool
+}
+
+// Output bit depths (--bit-depth).
+const (
+	OutputBitDepth16 = 16
+	OutputBitDepth24 = 24
+)
+
+// Output sample rates (--output-rate). OutputRateSame keeps the source rate.
+const (
+	OutputRate44100 = 44100
+	OutputRate48000 = 48000
+	OutputRate96000 = 96000
+	OutputRateSame  = "same"
+)
+
+// Output channel layouts (--channels). Stereo is a dual-mono upmix of the
+// processed mono programme, not a restoration of the input's stereo image.
+// Split processes each channel of a two-channel input on its own and delivers
+// them as L/R.
+const (
+	OutputChannelsMono   = "mono"
+	OutputChannelsStereo = "stereo"
+	OutputChannelsSame   = "same" // Mono input stays mono; two or more channels deliver stereo
+	OutputChannelsSplit  = "split"
+)
+
+// resolveOutputChannels turns a --channels choice into a concrete layout for an
+// input with inputChannels channels.
+func resolveOutputChannels(choice string, inputChannels int) string {
+	switch choice {
+	case OutputChannelsStereo:
+		return OutputChannelsStereo
+	case OutputChannelsSame:
+		if inputChannels >= 2 {
+			return OutputChannelsStereo
+		}
+	}
+	return OutputChannelsMono
 }
 
 // BiquadFilterConfig holds the shared parameters for a single biquad pole/zero
@@ -153,6 +225,7 @@ type BiquadFilterConfig struct {
 	Enabled   bool    `json:"enabled"`
 	Frequency float64 `json:"frequency_hz"`
 	Poles     int     `json:"poles_count"`
+	Stages    int     `json:"stages_count"` // Cascaded copies of the biquad; 2 doubles the slope (0 treated as 1)
 	Width     float64 `json:"width"`
 	Mix       float64 `json:"mix"`
 	Transform string  `json:"transform"`
@@ -167,6 +240,16 @@ type (
 	BandlimitLowPassConfig = BiquadFilterConfig
 )
 
+// HumNotchConfig is the mains hum notch (--mains): one narrow band-reject at
+// the mains fundamental and at each harmonic up to Harmonics, all with the same
+// Q. Off unless --mains is set; tuneHumNotch sets the fundamental.
+type HumNotchConfig struct {
+	Enabled   bool    `json:"enabled"`
+	Frequency float64 `json:"frequency_hz"`    // Mains fundamental, 50 or 60 Hz
+	Harmonics int     `json:"harmonics_count"` // Notches including the fundamental
+	Q         float64 `json:"q"`               // Notch Q; 30 is about 2 Hz wide at 60 Hz
+}
+
 type NoiseReductionConfig struct {
 	Enabled     bool    `json:"enabled"`
 	Strength    float64 `json:"strength"`
@@ -214,6 +297,13 @@ type SpeechGateConfig struct {
 	Knee      float64 `json:"knee"`
 	Makeup    float64 `json:"makeup"`
 	Detection string  `json:"detection"`
+	// Comfort noise (--comfort-noise): a low bed shaped like the room tone,
+	// added after the gate so pauses are not dead silence. Set by
+	// tuneComfortNoise; LevelDB is the bed RMS in dBFS, CornerHz its one-pole
+	// low-pass corner.
+	ComfortNoiseEnabled  bool    `json:"comfort_noise_enabled"`
+	ComfortNoiseLevelDB  float64 `json:"comfort_noise_level_db"`
+	ComfortNoiseCornerHz float64 `json:"comfort_noise_corner_hz"`
 }
 
 type LevellingCompressorConfig struct {
@@ -225,6 +315,16 @@ type LevellingCompressorConfig struct {
 	Makeup    float64 `json:"makeup_db"`
 	Knee      float64 `json:"knee"`
 	Mix       float64 `json:"mix"`
+	// Slow levelling stage, engaged only on very wide loudness range (the
+	// high-LRA path in tuneLevellingCompressor). It runs as a second acompressor
+	// AHEAD of the main stage: a low-ratio, slow-release rider that pulls the
+	// whispered and shouted passages together before the main stage shapes the
+	// syllable-level dynamics.
+	SlowStageEnabled   bool    `json:"slow_stage_enabled"`
+	SlowStageThreshold float64 `json:"slow_stage_threshold_db"`
+	SlowStageRatio     float64 `json:"slow_stage_ratio"`
+	SlowStageAttack    float64 `json:"slow_stage_attack_ms"`
+	SlowStageRelease   float64 `json:"slow_stage_release_ms"`
 }
 
 type DeesserConfig struct {
@@ -243,8 +343,29 @@ type AdeclickConfig struct {
 	Window    float64
 	Overlap   float64
 	Method    string
+
+	// AROrder and Burst are percentages of the window: the autoregression
+	// order and how close two clicks must sit to be repaired as one. Zero
+	// leaves FFmpeg's default (2%) in place (--declick-order, --declick-burst).
+	AROrder float64
+	Burst   float64
 }
 
+// Declick interpolation methods (--declick-method).
+const (
+	DeclickMethodAutoregression = "a" // Overlap-add autoregression: slower, repairs longer damage
+	DeclickMethodSpline         = "s" // Spline (the default): fast, right for short clicks
+)
+
+// adeclick option ranges. Overlap below 50% leaves gaps between windows; the
+// FFmpeg limits bound the rest.
+const (
+	adeclickMaxAROrder = 25.0
+	adeclickMinOverlap = 50.0
+	adeclickMaxOverlap = 95.0
+	adeclickMaxBurst   = 10.0
+)
+
 type LoudnormConfig struct {
 	Enabled   bool
 	TargetI   float64
@@ -252,6 +373,63 @@ type LoudnormConfig struct {
 	TargetLRA float64
 	DualMono  bool
 	Linear    bool
+
+	// SpeechOnly (--speech-loudness) normalises the speech-only integrated
+	// loudness to TargetI instead of the gated programme loudness, so long
+	// pauses do not pull the speech level off target.
+	SpeechOnly bool
+
+	// EstimateMeasurement (--skip-output-analysis) skips the Pass 3 loudnorm
+	// measurement and plans Pass 4 from an estimate built on the Pass 2 output
+	// measurements, saving one full read of the file.
+	EstimateMeasurement bool
+
+	// TargetRMS (--target-rms) normalises the output RMS level to this value in
+	// dBFS instead of the integrated loudness to TargetI. 0 selects loudness
+	// normalisation (SetTargetRMS).
+	TargetRMS float64
+
+	// Spec names the delivery target selected with --spec (SetLoudnessSpec); the
+	// result is checked against its tolerances. Empty when the targets are the
+	// defaults.
+	Spec string
+
+	// LimiterNoiseGuardDB (--limiter-noise-guard) is the least margin the
+	// levelling limiter's ceiling keeps above the Pass 2 room-tone peak, so the
+	// limiter never rides amplified noise. 0 turns the guard off.
+	LimiterNoiseGuardDB float64
+}
+
+// Loudnorm Pass 4 modes (--loudnorm-mode). Linear applies one static gain and
+// leaves the dynamics untouched, but the target can be missed on hard material;
+// dynamic hits the target more reliably by riding the gain, at the cost of
+// altering the dynamics.
+const (
+	LoudnormModeLinear  = "linear"
+	LoudnormModeDynamic = "dynamic"
+)
+
+// Normalisation target modes: integrated loudness (the default) or RMS level
+// (--target-rms).
+const (
+	TargetModeLUFS = "lufs"
+	TargetModeRMS  = "rms"
+)
+
+// TargetMode names the quantity the config normalises.
+func (c LoudnormConfig) TargetMode() string {
+	if c.TargetRMS != 0 {
+		return TargetModeRMS
+	}
+	return TargetModeLUFS
+}
+
+// Mode names the Pass 4 mode the config selects.
+func (c LoudnormConfig) Mode() string {
+	if c.Linear {
+		return LoudnormModeLinear
+	}
+	return LoudnormModeDynamic
 }
 
 type Decibels float64
@@ -277,7 +455,153 @@ func (linear LinearAmplitude) Float64() float64 {
 // BaseFilterConfig holds caller-owned defaults and user-facing options only.
 type BaseFilterConfig struct {
 	filterConfigDefaults
-	logger debugLogger
+
+	// SpeechGateThresholdDB is a user-pinned gate threshold in dBFS
+	// (--gate-threshold). The zero value means unset and the threshold stays
+	// adaptive; a real threshold is always negative. Set via
+	// SetSpeechGateThreshold so the safety bounds are enforced.
+	SpeechGateThresholdDB float64
+
+	// AnalysisSegments (--analysis-segments) measures Pass 1 in this many
+	// concurrent time segments (collectSegmentedAnalysisFrames); zero or one
+	// measures in one pass. Set via SetAnalysisSegments.
+	AnalysisSegments int
+
+	// NoiseStem requests the noise-reduction residual (--noise-stem): the audio
+	// the denoiser removed, written beside the input as <name>-noise-stem.flac.
+	NoiseStem bool
+
+	// SafeMode (--safe-mode) keeps a file whose Pass 1 analysis fails in the
+	// batch: it is processed with the fixed loudnorm-only chain from
+	// safeModeConfig instead of being skipped.
+	SafeMode bool
+
+	// InPlace (--in-place) lets the processed output replace its input when the
+	// output path resolves to the input file. The input is kept as
+	// <input>.orig. Without it such a run fails rather than clobber the input.
+	InPlace bool
+
+	// OutputDir and OutputFile (--output) move the outputs off the input's
+	// directory. OutputDir keeps each output's usual name inside it;
+	// OutputFile names the processed output itself, for a single input, with
+	// the side artefacts beside it. Both empty writes beside the input. Set
+	// via SetOutput.
+	OutputDir  string
+	OutputFile string
+
+	// OutputFormat (--output-format) is the delivered file format:
+	// OutputFormatFLAC (the default, also when empty) or OutputFormatWAV. Set
+	// via SetOutputFormat.
+	OutputFormat string
+
+	// QuietPreGain (--quiet-pre-gain) lifts an input quieter than
+	// quietPreGainThresholdLUFS before the analysis that tunes the chain and
+	// through Pass 2, then takes the lift back off (analyseLifted).
+	QuietPreGain bool
+
+	// OnExists (--on-exists) is the policy for an output path that is already
+	// taken: OnExistsOverwrite (the default, also when empty), OnExistsSkip,
+	// OnExistsRename or OnExistsError. The --in-place input guard comes first.
+	OnExists string
+
+	// EmitFFmpegCommand (--emit-ffmpeg-command) writes a runnable ffmpeg
+	// command that reproduces the render beside the input, as
+	// <name>-ffmpeg-command.sh.
+	EmitFFmpegCommand bool
+
+	// Receipt (--receipt) builds the processing receipt (newReceipt) for the
+	// result; WriteReceipt (--receipt-file) also writes it beside the output
+	// as <output>.receipt.json.
+	Receipt      bool
+	WriteReceipt bool
+
+	// KeepCoverArt (--keep-cover-art) copies a FLAC input's cover art onto the
+	// output. By default the output carries audio only.
+	KeepCoverArt bool
+
+	// ComfortNoise (--comfort-noise) adds a low bed shaped like the measured
+	// room tone after the gate, so pauses do not fall to dead silence.
+	ComfortNoise bool
+
+	// FixPolarity (--fix-polarity) inverts the signal when the Pass 1 speech
+	// reads as polarity-inverted (tunePolarity).
+	FixPolarity bool
+
+	// Mains (--mains) notches out mains hum: MainsHum50 or MainsHum60 pins the
+	// fundamental, MainsAuto detects it from the room tone (tuneHumNotch), and
+	// empty leaves the notch off. Set via SetMains.
+	Mains string
+
+	// Slate (--slate) prepends a line-up tone at the delivery loudness and a
+	// silence to the output; set via SetSlate.
+	Slate SlateConfig
+
+	// Clarity (--clarity) scores the speech clarity of the input and the final
+	// output (measureClarity) for the report and the completion summary.
+	Clarity bool
+
+	// ExtraTargets (--targets) are the integrated loudness targets rendered
+	// beside the primary Loudnorm.TargetI, each from the same Pass 2 output;
+	// set via SetLoudnessTargets.
+	ExtraTargets []float64
+
+	// FixRegion (--fix-region) processes only a stretch of the input and
+	// splices it back into a copy of the original; set via SetFixRegion.
+	FixRegion FixRegionConfig
+
+	// Excerpt (--excerpt) processes only a stretch of the input and delivers
+	// it on its own; set via SetExcerpt.
+	Excerpt ExcerptConfig
+
+	// NoiseRegion (--noise-region) pins the room-tone region the noise
+	// profile is read from; set via SetNoiseRegion.
+	NoiseRegion NoiseRegionConfig
+
+	// NoiseWindow and NoiseWindowMin (--noise-window, --noise-window-min)
+	// replace the target and minimum length the elected room-tone run is
+	// refined to. Zero keeps goldenWindowDuration and goldenWindowMinimum;
+	// set via SetNoiseWindow.
+	NoiseWindow    time.Duration
+	NoiseWindowMin time.Duration
+
+	// NoiseFloorDB (--noise-floor) replaces the detected noise floor, in
+	// dBFS, and drops the room-tone profile. The zero value means unset; set
+	// via SetNoiseFloor.
+	NoiseFloorDB float64
+
+	// NoiseProfileIn (--load-noise-profile) replaces the elected room tone
+	// with a profile saved from another recording; nil detects one as usual.
+	// Read with LoadNoiseProfile.
+	NoiseProfileIn *SavedNoiseProfile
+
+	// NoiseProfileOut (--save-noise-profile) is where the elected noise
+	// profile is written (WriteNoiseProfile); empty writes none.
+	NoiseProfileOut string
+
+	// DisabledStages (--disable) names the stages dropped from the chain
+	// after adaptation; set via SetDisabledStages.
+	DisabledStages []string
+
+	// ChunkOver (--chunk-over) renders inputs longer than this through Pass 2
+	// in chunks (processInChunks); zero renders every file whole. Set via
+	// SetChunkOver.
+	ChunkOver time.Duration
+
+	// IntervalsCSV (--export-intervals) is where the Pass 1 interval series
+	// is written as CSV (WriteIntervalsCSV); empty writes none.
+	IntervalsCSV string
+
+	// IntermediateDir (--keep-intermediate) keeps each file's Pass 2 render
+	// and its measurements here (keepIntermediate); empty keeps none.
+	IntermediateDir string
+
+	// AnalysisCacheDir (--cache-dir) holds Pass 1 measurements keyed by the
+	// input's hash (analyseCached); empty, the default or with --no-cache,
+	// turns the cache off.
+	AnalysisCacheDir string
+
+	logger           debugLogger
+	roomToneSelector RoomToneSelector
 }
 
 // AdaptiveDiagnostics holds report-only adaptation explanations.
@@ -302,6 +626,41 @@ type AdaptiveDiagnostics struct {
 	// the depth step to back off rather than over-gate.
 	SpeechGateNarrowGap bool `json:"narrow_gap"`
 
+	// LevellingHighLRA is set when the input loudness range exceeded the high-LRA
+	// threshold and the slow levelling stage was engaged ahead of the main
+	// compressor.
+	LevellingHighLRA bool `json:"levelling_high_lra"`
+
+	// NarrowbandSource is set when the speech region carries the phone/VoIP
+	// bandwidth signature and tuneNarrowbandSource switched to the narrowband
+	// chain (no low-pass, no de-esser, no afftdn).
+	NarrowbandSource bool `json:"narrowband_source"`
+
+	// RumbleBurstHighPass is set when frequent wind/handling bursts cascaded the
+	// rumble high-pass to 24 dB/oct (tuneRumbleBursts).
+	RumbleBurstHighPass bool `json:"rumble_burst_highpass"`
+
+	// HumNotchReason says how tuneHumNotch placed the hum notch under --mains:
+	// pinned by the flag, detected in the room tone, or why auto left it off.
+	// Empty when --mains is not set.
+	HumNotchReason string `json:"hum_notch_reason,omitempty"`
+
+	// OutputDither is set when the output is dithered to 16 bits, which is every
+	// 16-bit output (tuneOutputFormat).
+	OutputDither bool `json:"output_dither"`
+
+	// ComfortNoise is set when --comfort-noise added the room-tone bed after
+	// the gate (tuneComfortNoise).
+	ComfortNoise bool `json:"comfort_noise"`
+
+	// PolarityInverted is set when --fix-polarity inverted a recording whose
+	// speech read inverted (tunePolarity).
+	PolarityInverted bool `json:"polarity_inverted"`
+
+	// SafeMode is set when Pass 1 analysis failed and --safe-mode processed the
+	// file with the fixed loudnorm-only chain; no other adaptation ran.
+	SafeMode bool `json:"safe_mode"`
+
 	// AfftdnEnabled records whether the afftdn FFT denoise tail stays in the chain.
 	// tuneNoiseReduction disables it on voice-activated captures.
 	AfftdnEnabled bool `json:"afftdn_enabled"`
@@ -314,6 +673,19 @@ type AdaptiveDiagnostics struct {
 	// AfftdnNoiseType records the elected afftdn noise model: "w" (white) or
 	// "custom" (measured room-tone spectral shape). Empty when afftdn is disabled.
 	AfftdnNoiseType string `json:"afftdn_noise_type"`
+	// AfftdnNoisyBands lists the room-tone bands the custom profile reduces
+	// hardest because the noise is concentrated there (noisyAfftdnBands);
+	// empty when the noise is spread evenly or the white path runs.
+	AfftdnNoisyBands string `json:"afftdn_noisy_bands,omitempty"`
+
+	// DisabledStages lists the stages --disable dropped after tuning
+	// (applyDisabledStages), in the order given.
+	DisabledStages []string `json:"disabled_stages,omitempty"`
+
+	// Warnings carries non-fatal adaptation warnings for the user, such as a
+	// user-pinned gate threshold that sits outside the measured noise/speech
+	// gap. Empty when the adaptation raised nothing.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // filterBuilderFunc is a function that builds a filter spec from effective config.
@@ -327,6 +699,7 @@ var filterBuilders = map[FilterID]filterBuilderFunc{
 	FilterAnalysis:            (*EffectiveFilterConfig).buildAnalysisFilter,
 	FilterResample:            (*EffectiveFilterConfig).buildResampleFilter,
 	FilterRumbleHighPass:      (*EffectiveFilterConfig).buildRumbleHighpassFilter,
+	FilterHumNotch:            (*EffectiveFilterConfig).buildHumNotchFilter,
 	FilterBandlimitLowPass:    (*EffectiveFilterConfig).buildBandlimitLowPassFilter,
 	FilterNoiseReduction:      (*EffectiveFilterConfig).buildNoiseReductionFilter,
 	FilterSpeechGate:          (*EffectiveFilterConfig).buildSpeechGateFilter,
@@ -361,13 +734,186 @@ func (cfg *BaseFilterConfig) SetLogger(l func(format string, args ...any)) {
 	cfg.logger = debugLogger(l)
 }
 
+// SetSpeechGateThreshold pins the speech gate threshold to thresholdDB (dBFS),
+// overriding the adaptive placement while ratio, attack, release, and depth stay
+// adaptive. It rejects values outside the gate's safety bounds.
+func (cfg *BaseFilterConfig) SetSpeechGateThreshold(thresholdDB float64) error {
+	if !isFinite(thresholdDB) || thresholdDB < speechGateThresholdMinDB || thresholdDB > speechGateThresholdMaxDB {
+		return fmt.Errorf("gate threshold %.1f dB is outside the safe range [%.0f, %.0f] dB",
+			thresholdDB, speechGateThresholdMinDB, speechGateThresholdMaxDB)
+	}
+	cfg.SpeechGateThresholdDB = thresholdDB
+	return nil
+}
+
+// SetLoudnormMode selects the Pass 4 loudnorm mode: LoudnormModeLinear (the
+// default) or LoudnormModeDynamic.
+func (cfg *BaseFilterConfig) SetLoudnormMode(mode string) error {
+	switch mode {
+	case LoudnormModeLinear:
+		cfg.Loudnorm.Linear = true
+	case LoudnormModeDynamic:
+		cfg.Loudnorm.Linear = false
+	default:
+		return fmt.Errorf("loudnorm mode %q is not %q or %q", mode, LoudnormModeLinear, LoudnormModeDynamic)
+	}
+	return nil
+}
+
+// Bounds on --target-lufs: from below ATSC A/85's -24 LUFS broadcast target to
+// above the loudest streaming targets. The wider loudnorm range stays
+// available through --targets.
+const (
+	targetIMinLUFS = -31.0
+	targetIMaxLUFS = -9.0
+)
+
+// SetTargetLUFS sets the integrated loudness (LUFS) the output is normalised to.
+// The adaptive tuners and Pass 1's target offset all read Loudnorm.TargetI, so
+// they follow it.
+func (cfg *BaseFilterConfig) SetTargetLUFS(lufs float64) error {
+	if !isFinite(lufs) || lufs < targetIMinLUFS || lufs > targetIMaxLUFS {
+		return fmt.Errorf("loudness target %.1f LUFS is outside [%.0f, %.0f] LUFS", lufs, targetIMinLUFS, targetIMaxLUFS)
+	}
+	cfg.Loudnorm.TargetI = lufs
+	return nil
+}
+
+// Bounds on --target-rms. Below -50 dBFS the output is barely above a typical
+// noise floor; above -6 dBFS speech cannot reach the target without heavy
+// limiting.
+const (
+	targetRMSMinDB = -50.0
+	targetRMSMaxDB = -6.0
+)
+
+// SetTargetRMS selects RMS normalisation: the final gain brings the output RMS
+// level to rmsDB (dBFS) instead of the integrated loudness to TargetI.
+func (cfg *BaseFilterConfig) SetTargetRMS(rmsDB float64) error {
+	if !isFinite(rmsDB) || rmsDB < targetRMSMinDB || rmsDB > targetRMSMaxDB {
+		return fmt.Errorf("target RMS %.1f dBFS is outside [%.0f, %.0f] dBFS", rmsDB, targetRMSMinDB, targetRMSMaxDB)
+	}
+	cfg.Loudnorm.TargetRMS = rmsDB
+	return nil
+}
+
+// SetTargetTP sets the delivered true-peak ceiling (dBTP) the final brickwall
+// enforces, within the range loudnorm accepts.
+func (cfg *BaseFilterConfig) SetTargetTP(tpDB float64) error {
+	if !isFinite(tpDB) || tpDB < loudnormTPMinDB || tpDB > loudnormTPMaxDB {
+		return fmt.Errorf("true-peak ceiling %.1f dBTP is outside [%.0f, %.0f] dBTP", tpDB, loudnormTPMinDB, loudnormTPMaxDB)
+	}
+	cfg.Loudnorm.TargetTP = tpDB
+	return nil
+}
+
+// SetLimiterNoiseGuard sets the margin (dB) the levelling limiter's ceiling
+// keeps above the room-tone peak; 0 turns the guard off.
+func (cfg *BaseFilterConfig) SetLimiterNoiseGuard(db float64) error {
+	if !isFinite(db) || db < 0 || db > limiterNoiseGuardMaxDB {
+		return fmt.Errorf("limiter noise guard %.1f dB is outside [0, %.0f] dB", db, limiterNoiseGuardMaxDB)
+	}
+	cfg.Loudnorm.LimiterNoiseGuardDB = db
+	return nil
+}
+
+// SetOutputChannels selects the delivered channel layout: OutputChannelsMono
+// (the default), OutputChannelsStereo, OutputChannelsSame, or
+// OutputChannelsSplit.
+func (cfg *BaseFilterConfig) SetOutputChannels(choice string) error {
+	switch choice {
+	case OutputChannelsMono, OutputChannelsStereo, OutputChannelsSame, OutputChannelsSplit:
+		cfg.Resample.Channels = choice
+	default:
+		return fmt.Errorf("channel layout %q is not %q, %q, %q or %q",
+			choice, OutputChannelsMono, OutputChannelsStereo, OutputChannelsSame, OutputChannelsSplit)
+	}
+	return nil
+}
+
+// SetOutputRate selects the delivered sample rate: "44100" (the default rate),
+// "48000", "96000", or OutputRateSame. Any choice converts with soxr.
+func (cfg *BaseFilterConfig) SetOutputRate(choice string) error {
+	switch choice {
+	case strconv.Itoa(OutputRate44100), strconv.Itoa(OutputRate48000), strconv.Itoa(OutputRate96000):
+		cfg.Resample.SampleRate, _ = strconv.Atoi(choice)
+	case OutputRateSame:
+		cfg.Resample.SampleRate = 0
+	default:
+		return fmt.Errorf("sample rate %q is not %d, %d, %d or %q",
+			choice, OutputRate44100, OutputRate48000, OutputRate96000, OutputRateSame)
+	}
+	cfg.Resample.Soxr = true
+	return nil
+}
+
+// SetOutputBitDepth selects the delivered bit depth: OutputBitDepth16 (the
+// default) or OutputBitDepth24.
+func (cfg *BaseFilterConfig) SetOutputBitDepth(bits int) error {
+	switch bits {
+	case OutputBitDepth16:
+		cfg.Resample.Format = "s16"
+	case OutputBitDepth24:
+		cfg.Resample.Format = "s32"
+	default:
+		return fmt.Errorf("bit depth %d is not %d or %d", bits, OutputBitDepth16, OutputBitDepth24)
+	}
+	cfg.Resample.BitDepth = bits
+	return nil
+}
+
+// SetDeclickMethod selects the Pass 4 adeclick interpolation:
+// DeclickMethodSpline (the default) or DeclickMethodAutoregression.
+func (cfg *BaseFilterConfig) SetDeclickMethod(method string) error {
+	switch method {
+	case DeclickMethodAutoregression, DeclickMethodSpline:
+		cfg.Adeclick.Method = method
+	default:
+		return fmt.Errorf("declick method %q is not %q or %q", method, DeclickMethodAutoregression, DeclickMethodSpline)
+	}
+	return nil
+}
+
+// SetDeclickAROrder sets the adeclick autoregression order, as a percentage of
+// the window (above 0, up to 25; FFmpeg's default is 2).
+func (cfg *BaseFilterConfig) SetDeclickAROrder(percent float64) error {
+	if !isFinite(percent) || percent <= 0 || percent > adeclickMaxAROrder {
+		return fmt.Errorf("declick order %.1f%% is outside (0, %.0f]%%", percent, adeclickMaxAROrder)
+	}
+	cfg.Adeclick.AROrder = percent
+	return nil
+}
+
+// SetDeclickOverlap sets the adeclick window overlap percentage (50 to 95).
+func (cfg *BaseFilterConfig) SetDeclickOverlap(percent float64) error {
+	if !isFinite(percent) || percent < adeclickMinOverlap || percent > adeclickMaxOverlap {
+		return fmt.Errorf("declick overlap %.1f%% is outside [%.0f, %.0f]%%", percent, adeclickMinOverlap, adeclickMaxOverlap)
+	}
+	cfg.Adeclick.Overlap = percent
+	return nil
+}
+
+// SetDeclickBurst sets the adeclick burst fusion, as a percentage of the window
+// (above 0, up to 10; FFmpeg's default is 2).
+func (cfg *BaseFilterConfig) SetDeclickBurst(percent float64) error {
+	if !isFinite(percent) || percent <= 0 || percent > adeclickMaxBurst {
+		return fmt.Errorf("declick burst %.1f%% is outside (0, %.0f]%%", percent, adeclickMaxBurst)
+	}
+	cfg.Adeclick.Burst = percent
+	return nil
+}
+
 // CloneForWorker returns a per-worker config that shares no mutable state with
-// cfg. It shallow-copies the value, deep-copies the sole reference field
-// FilterOrder, and installs the per-worker logger. Concurrent workers may each
-// own and process their clone without racing on the base.
+// cfg. It shallow-copies the value, deep-copies the slices FilterOrder,
+// ExtraTargets and DisabledStages, and installs the per-worker logger.
+// NoiseProfileIn stays shared: it is only read, and copied before any change.
+// Concurrent workers may each own and process their clone without racing on
+// the base.
 func (cfg *BaseFilterConfig) CloneForWorker(logger func(format string, args ...any)) *BaseFilterConfig {
 	wc := *cfg
 	wc.FilterOrder = cloneFilterOrder(cfg.FilterOrder)
+	wc.ExtraTargets = slices.Clone(cfg.ExtraTargets)
+	wc.DisabledStages = slices.Clone(cfg.DisabledStages)
 	wc.SetLogger(logger)
 	return &wc
 }
@@ -378,6 +924,7 @@ func defaultFilterConfigDefaults() filterConfigDefaults {
 		defaultAnalysisConfig(),
 		defaultResampleConfig(),
 		defaultRumbleHighPassConfig(),
+		defaultHumNotchConfig(),
 		defaultBandlimitLowPassConfig(),
 		defaultNoiseReductionConfig(),
 		defaultSpeechGateConfig(),
@@ -393,6 +940,7 @@ func assembleFilterDefaults(
 	analysis AnalysisConfig,
 	resample ResampleConfig,
 	rumbleHighPass RumbleHighPassConfig,
+	humNotch HumNotchConfig,
 	bandlimitLowPass BandlimitLowPassConfig,
 	noiseReduction NoiseReductionConfig,
 	speechGate SpeechGateConfig,
@@ -406,6 +954,7 @@ func assembleFilterDefaults(
 		Analysis:            analysis,
 		Resample:            resample,
 		RumbleHighPass:      rumbleHighPass,
+		HumNotch:            humNotch,
 		BandlimitLowPass:    bandlimitLowPass,
 		NoiseReduction:      noiseReduction,
 		SpeechGate:          speechGate,
@@ -432,6 +981,8 @@ func defaultResampleConfig() ResampleConfig {
 		SampleRate: 44100,
 		Format:     "s16",
 		FrameSize:  4096,
+		Channels:   OutputChannelsMono,
+		BitDepth:   OutputBitDepth16,
 	}
 }
 
@@ -443,6 +994,7 @@ func defaultBiquadConfig(frequency float64) BiquadFilterConfig {
 		Enabled:   true,
 		Frequency: frequency,
 		Poles:     2,
+		Stages:    1,
 		Width:     0.707,
 		Mix:       1.0,
 		Transform: "tdii",
@@ -453,6 +1005,14 @@ func defaultRumbleHighPassConfig() RumbleHighPassConfig {
 	return defaultBiquadConfig(rumbleHPDefaultFreq)
 }
 
+func defaultHumNotchConfig() HumNotchConfig {
+	return HumNotchConfig{
+		Enabled:   false,
+		Harmonics: humNotchHarmonics,
+		Q:         humNotchQ,
+	}
+}
+
 func defaultBandlimitLowPassConfig() BandlimitLowPassConfig {
 	return defaultBiquadConfig(20500.0)
 }
@@ -516,7 +1076,7 @@ func defaultAdeclickConfig() AdeclickConfig {
 		Threshold: 1.7,
 		Window:    55.0,
 		Overlap:   50.0,
-		Method:    "s",
+		Method:    DeclickMethodSpline,
 	}
 }
 
@@ -528,6 +1088,8 @@ func defaultLoudnormConfig() LoudnormConfig {
 		TargetLRA: 20.0,
 		DualMono:  true,
 		Linear:    true,
+
+		LimiterNoiseGuardDB: defaultLimiterNoiseGuardDB,
 	}
 }
 
@@ -611,7 +1173,14 @@ func (cfg *EffectiveFilterConfig) buildDownmixFilter() string {
 	}
 	// aformat with channel_layouts=mono uses FFmpeg's standard downmix matrix
 	// which handles stereo, mono, and single-channel recordings appropriately
-	return "aformat=channel_layouts=mono"
+	spec := "aformat=channel_layouts=mono"
+	if downmix.InvertPolarity {
+		spec += ",pan=mono|c0=-1*c0"
+	}
+	if downmix.PreGainDB != 0 {
+		spec += "," + quietPreGainSpec(downmix.PreGainDB)
+	}
+	return spec
 }
 
 // Shared analysis-filter segments. Pass 2 (buildAnalysisFilter) and the Pass-4
@@ -681,22 +1250,35 @@ func (cfg *EffectiveFilterConfig) buildAnalysisFilter() string {
 	// has no "measure only" mode. It always processes/normalises audio. Loudnorm
 	// measurement for Pass 3 is done separately via measureWithLoudnorm() which
 	// reads the file without encoding output.
-	return fmt.Sprintf(
-		"%s,%s,%s:target=%.0f",
-		astatsAnalysisSpec,
-		aspectralstatsAnalysisSpec,
-		ebur128AnalysisSpecPrefix,
-		cfg.Loudnorm.TargetI)
+	// aspectralstats is dropped when the linked FFmpeg lacks it (CheckFilters);
+	// Spectral.Found then stays false and the spectral tuners keep their defaults.
+	var specs []string
+	// The quiet-input pre-gain ends here, so the output is measured, and
+	// handed on, at the source level.
+	if cfg.Downmix.PreGainDB != 0 {
+		specs = append(specs, quietPreGainSpec(-cfg.Downmix.PreGainDB))
+	}
+	specs = append(specs, astatsAnalysisSpec)
+	if spec := withSpectralStats(aspectralstatsAnalysisSpec); spec != "" {
+		specs = append(specs, spec)
+	}
+	specs = append(specs, fmt.Sprintf("%s:target=%.0f", ebur128AnalysisSpecPrefix, cfg.Loudnorm.TargetI))
+	return strings.Join(specs, ",")
 }
 
 // buildResampleFilter builds the output format standardisation filter.
-// Ensures consistent output: 44.1kHz, 16-bit, mono, fixed frame size.
-// Pass 2 only - applied after all processing and analysis.
+// Ensures consistent output: 44.1kHz (or the --output-rate choice), 16-bit,
+// mono, fixed frame size. Pass 2 only - applied after all processing and
+// analysis. With Resample.Soxr an explicit soxr aresample does the rate change
+// ahead of aformat, which is then a no-op on the rate.
 func (cfg *EffectiveFilterConfig) buildResampleFilter() string {
 	resample := cfg.Resample
 	if !resample.Enabled {
 		return ""
 	}
+	if resample.Soxr && resample.SampleRate > 0 {
+		return fmt.Sprintf("aresample=resampler=soxr:osr=%d,", resample.SampleRate) + cfg.buildRequiredOutputFormatFilter()
+	}
 	return cfg.buildRequiredOutputFormatFilter()
 }
 
@@ -704,9 +1286,36 @@ func (cfg *EffectiveFilterConfig) buildResampleFilter() string {
 // Use this when a pass must restore encoder-compatible audio regardless of
 // Resample.Enabled.
 func (cfg *EffectiveFilterConfig) buildRequiredOutputFormatFilter() string {
+	return cfg.buildOutputFormatFilter(OutputChannelsMono)
+}
+
+// buildFinalOutputFormatFilter builds the delivered output format for the end of
+// Pass 4, in the resolved Resample.Channels layout. Stereo is an explicit
+// dual-mono pan: aformat's default upmix would place mono in the centre at
+// -3 dB per channel and shift the delivered loudness. With Resample.Dither the
+// sample format conversion runs through aresample with triangular (TPDF) dither
+// instead of the default truncation.
+func (cfg *EffectiveFilterConfig) buildFinalOutputFormatFilter() string {
+	var prefix string
+	if cfg.Resample.Dither {
+		prefix = fmt.Sprintf("aresample=osf=%s:dither_method=triangular,", cfg.Resample.Format)
+	}
+	if cfg.Resample.Channels == OutputChannelsStereo {
+		return prefix + "pan=stereo|c0=c0|c1=c0," + cfg.buildOutputFormatFilter(OutputChannelsStereo)
+	}
+	return prefix + cfg.buildRequiredOutputFormatFilter()
+}
+
+// buildOutputFormatFilter pins the sample rate, layout, sample format and frame
+// size. A zero Resample.SampleRate (--output-rate same) leaves the rate alone.
+func (cfg *EffectiveFilterConfig) buildOutputFormatFilter(layout string) string {
 	resample := cfg.Resample
-	return fmt.Sprintf("aformat=sample_rates=%d:channel_layouts=mono:sample_fmts=%s,asetnsamples=n=%d",
-		resample.SampleRate, resample.Format, resample.FrameSize)
+	var rate string
+	if resample.SampleRate > 0 {
+		rate = fmt.Sprintf("sample_rates=%d:", resample.SampleRate)
+	}
+	return fmt.Sprintf("aformat=%schannel_layouts=%s:sample_fmts=%s,asetnsamples=n=%d",
+		rate, layout, resample.Format, resample.FrameSize)
 }
 
 // buildRumbleHighpassFilter builds the rumble high-pass filter.
@@ -726,6 +1335,22 @@ func (cfg *EffectiveFilterConfig) buildRumbleHighpassFilter() string {
 	return buildBiquadFilter(cfg.RumbleHighPass, "highpass")
 }
 
+// buildHumNotchFilter builds the mains hum notches: a bandreject at the
+// fundamental and at each harmonic (50/100/150/200 Hz or 60/120/180/240 Hz),
+// sharing the notch Q. Returns empty string when the notch is disabled or has
+// no fundamental.
+func (cfg *EffectiveFilterConfig) buildHumNotchFilter() string {
+	hum := cfg.HumNotch
+	if !hum.Enabled || hum.Frequency <= 0 {
+		return ""
+	}
+	notches := make([]string, 0, hum.Harmonics)
+	for n := 1; n <= hum.Harmonics; n++ {
+		notches = append(notches, fmt.Sprintf("bandreject=f=%.0f:width_type=q:w=%.0f", hum.Frequency*float64(n), hum.Q))
+	}
+	return strings.Join(notches, ",")
+}
+
 // buildBiquadFilter renders the shared biquad highpass/lowpass filter spec. The
 // keyword ("highpass"/"lowpass") selects the ffmpeg filter; every other byte of
 // the emitted string is identical between the two filters, so they share one
@@ -734,6 +1359,7 @@ func (cfg *EffectiveFilterConfig) buildRumbleHighpassFilter() string {
 // Parameters:
 // - f: cutoff frequency in Hz
 // - poles: 1=6dB/oct (gentle), 2=12dB/oct (standard, default)
+// - stages: cascaded copies (2 turns a 12dB/oct biquad into 24dB/oct)
 // - width: Q factor (0.707=Butterworth, default)
 // - transform: filter algorithm (tdii=best floating-point accuracy)
 // - mix: wet/dry blend (1.0=full filter)
@@ -765,6 +1391,10 @@ func buildBiquadFilter(cfg BiquadFilterConfig, keyword string) string {
 		spec += fmt.Sprintf(":m=%.2f", cfg.Mix)
 	}
 
+	if cfg.Stages > 1 {
+		spec = strings.TrimSuffix(strings.Repeat(spec+",", cfg.Stages), ",")
+	}
+
 	return spec
 }
 
@@ -879,7 +1509,7 @@ func (cfg *EffectiveFilterConfig) buildSpeechGateFilter() string {
 		detection = "rms"
 	}
 	// Note: attack/release use %.2f to support sub-millisecond values (0.5ms minimum)
-	return fmt.Sprintf(
+	spec := fmt.Sprintf(
 		"agate=threshold=%.6f:ratio=%.1f:attack=%.2f:release=%.0f:"+
 			"range=%.4f:knee=%.1f:detection=%s:makeup=%.1f",
 		gate.Threshold,
@@ -891,18 +1521,36 @@ func (cfg *EffectiveFilterConfig) buildSpeechGateFilter() string {
 		detection,
 		gate.Makeup,
 	)
+	if gate.ComfortNoiseEnabled {
+		spec += "," + buildComfortNoiseFilter(gate.ComfortNoiseLevelDB, gate.ComfortNoiseCornerHz)
+	}
+	return spec
 }
 
 // buildLevellingCompressorFilter builds the levelling compressor filter specification.
 // Uses FFmpeg's acompressor with settings tuned for gentle, programme-dependent
-// levelling.
+// levelling. On the high-LRA path a slow levelling stage is prepended (see
+// LevellingCompressorConfig.SlowStageEnabled), so the spec holds two acompressors.
 // Converts dB values to linear for FFmpeg's format.
 func (cfg *EffectiveFilterConfig) buildLevellingCompressorFilter() string {
 	levellingCompressor := cfg.LevellingCompressor
 	if !levellingCompressor.Enabled {
 		return ""
 	}
-	return fmt.Sprintf(
+
+	filters := make([]string, 0, 2)
+	if levellingCompressor.SlowStageEnabled {
+		filters = append(filters, fmt.Sprintf(
+			"acompressor=threshold=%.6f:ratio=%.1f:attack=%.0f:release=%.0f:"+
+				"makeup=1.00:knee=%.1f:detection=rms:mix=1.00",
+			Decibels(levellingCompressor.SlowStageThreshold).LinearAmplitude().Float64(),
+			levellingCompressor.SlowStageRatio,
+			levellingCompressor.SlowStageAttack,
+			levellingCompressor.SlowStageRelease,
+			levellingCompressor.Knee,
+		))
+	}
+	filters = append(filters, fmt.Sprintf(
 		"acompressor=threshold=%.6f:ratio=%.1f:attack=%.0f:release=%.0f:"+
 			"makeup=%.2f:knee=%.1f:detection=rms:mix=%.2f",
 		Decibels(levellingCompressor.Threshold).LinearAmplitude().Float64(),
@@ -912,7 +1560,9 @@ func (cfg *EffectiveFilterConfig) buildLevellingCompressorFilter() string {
 		Decibels(levellingCompressor.Makeup).LinearAmplitude().Float64(),
 		levellingCompressor.Knee,
 		levellingCompressor.Mix,
-	)
+	))
+
+	return strings.Join(filters, ",")
 }
 
 // buildDeesserFilter builds the deesser filter specification.
@@ -944,6 +1594,11 @@ func (cfg *EffectiveFilterConfig) buildDeesserFilter() string {
 // - w (window): Analysis window in ms (10-100)
 // - o (overlap): Window overlap percentage (50-95)
 // - m (method): interpolation method (a=autoregression, s=spline)
+// - a (arorder): autoregression order, % of window (0-25), emitted when set
+// - b (burst): burst fusion, % of window (0-10), emitted when set
+//
+// --declick-method, --declick-order, --declick-overlap and --declick-burst
+// push these harder for badly damaged sources.
 func (cfg *EffectiveFilterConfig) buildAdeclickFilter() string {
 	adeclick := cfg.Adeclick
 	if !adeclick.Enabled {
@@ -958,6 +1613,12 @@ func (cfg *EffectiveFilterConfig) buildAdeclickFilter() string {
 	if adeclick.Method != "" {
 		spec += ":m=" + adeclick.Method
 	}
+	if adeclick.AROrder > 0 {
+		spec += fmt.Sprintf(":a=%g", adeclick.AROrder)
+	}
+	if adeclick.Burst > 0 {
+		spec += fmt.Sprintf(":b=%g", adeclick.Burst)
+	}
 	return spec
 }
 
@@ -24,6 +24,7 @@ func newTestBaseConfig() *BaseFilterConfig {
 			Mix:       1.0,
 			Transform: "tdii",
 		},
+		HumNotchConfig{Enabled: false, Harmonics: 4, Q: 30},
 		BandlimitLowPassConfig{
 			Enabled:   false,
 			Frequency: 16000.0,
@@ -92,6 +93,9 @@ func TestDefaultFilterConfigComposesTypedDefaults(t *testing.T) {
 	if config.RumbleHighPass != defaultRumbleHighPassConfig() {
 		t.Errorf("RumbleHighPass = %+v, want %+v", config.RumbleHighPass, defaultRumbleHighPassConfig())
 	}
+	if config.HumNotch != defaultHumNotchConfig() {
+		t.Errorf("HumNotch = %+v, want %+v", config.HumNotch, defaultHumNotchConfig())
+	}
 	if config.BandlimitLowPass != defaultBandlimitLowPassConfig() {
 		t.Errorf("BandlimitLowPass = %+v, want %+v", config.BandlimitLowPass, defaultBandlimitLowPassConfig())
 	}
@@ -369,6 +373,29 @@ func TestBuildFilterSpecBehaviourBaseline(t *testing.T) {
 			}(),
 			want: "acompressor=threshold=0.031623:ratio=4.0:attack=10:release=60:makeup=1.00:knee=6.0:detection=rms:mix=0.85",
 		},
+		{
+			name: "levelling compressor high-LRA slow stage",
+			config: func() *EffectiveFilterConfig {
+				config := newTestConfig()
+				config.LevellingCompressor.Enabled = true
+				config.LevellingCompressor.Threshold = -18.0
+				config.LevellingCompressor.Ratio = 3.0
+				config.LevellingCompressor.Attack = 10
+				config.LevellingCompressor.Release = 200
+				config.LevellingCompressor.Makeup = 0
+				config.LevellingCompressor.Knee = 4.0
+				config.LevellingCompressor.Mix = 1.0
+				config.LevellingCompressor.SlowStageEnabled = true
+				config.LevellingCompressor.SlowStageThreshold = -27.0
+				config.LevellingCompressor.SlowStageRatio = 2.0
+				config.LevellingCompressor.SlowStageAttack = 50
+				config.LevellingCompressor.SlowStageRelease = 1000
+				config.FilterOrder = []FilterID{FilterLevellingCompressor}
+				return config
+			}(),
+			want: "acompressor=threshold=0.044668:ratio=2.0:attack=50:release=1000:makeup=1.00:knee=4.0:detection=rms:mix=1.00," +
+				"acompressor=threshold=0.125893:ratio=3.0:attack=10:release=200:makeup=1.00:knee=4.0:detection=rms:mix=1.00",
+		},
 		{
 			name: "noise-remove afftdn disabled",
 			config: func() *EffectiveFilterConfig {
@@ -565,6 +592,27 @@ func TestBuildSpeechGateFilter(t *testing.T) {
 			t.Errorf("buildSpeechGateFilter() = %q, want empty when disabled", spec)
 		}
 	})
+
+	t.Run("comfort noise follows the gate", func(t *testing.T) {
+		config := newTestConfig()
+		config.SpeechGate.Enabled = true
+		config.SpeechGate.ComfortNoiseEnabled = true
+		config.SpeechGate.ComfortNoiseLevelDB = -80
+		config.SpeechGate.ComfortNoiseCornerHz = 2500
+
+		spec := config.buildSpeechGateFilter()
+		gate, bed, ok := strings.Cut(spec, ",aeval=exprs=")
+		if !ok || !strings.HasPrefix(gate, "agate=") {
+			t.Fatalf("buildSpeechGateFilter() = %q, want agate followed by aeval", spec)
+		}
+		// The expression's own commas are escaped, so the bed stays one filter.
+		if strings.Contains(strings.ReplaceAll(bed, `\,`, ""), ",") {
+			t.Errorf("aeval expression has an unescaped comma: %q", bed)
+		}
+		if !strings.HasSuffix(bed, ":channel_layout=same") {
+			t.Errorf("aeval = %q, want channel_layout=same", bed)
+		}
+	})
 }
 
 func TestBuildBandlimitLowPassFilter(t *testing.T) {
@@ -922,6 +970,25 @@ func TestBuildNoiseReductionFilter(t *testing.T) {
 	})
 }
 
+func TestSetDeclickRanges(t *testing.T) {
+	base := DefaultFilterConfig()
+	for name, err := range map[string]error{
+		"method x":    base.SetDeclickMethod("x"),
+		"order 0":     base.SetDeclickAROrder(0),
+		"order 30":    base.SetDeclickAROrder(30),
+		"overlap 40":  base.SetDeclickOverlap(40),
+		"overlap 100": base.SetDeclickOverlap(100),
+		"burst 11":    base.SetDeclickBurst(11),
+	} {
+		if err == nil {
+			t.Errorf("%s accepted, want an error", name)
+		}
+	}
+	if base.Adeclick != defaultAdeclickConfig() {
+		t.Errorf("rejected options changed Adeclick to %+v", base.Adeclick)
+	}
+}
+
 func TestBuildAdeclickFilter(t *testing.T) {
 	t.Run("default config emits production clause", func(t *testing.T) {
 		config := DefaultEffectiveFilterConfig()
@@ -958,6 +1025,30 @@ func TestBuildAdeclickFilter(t *testing.T) {
 		}
 	})
 
+	t.Run("user declick options", func(t *testing.T) {
+		base := DefaultFilterConfig()
+		if err := base.SetDeclickMethod(DeclickMethodAutoregression); err != nil {
+			t.Fatal(err)
+		}
+		if err := base.SetDeclickAROrder(8); err != nil {
+			t.Fatal(err)
+		}
+		if err := base.SetDeclickOverlap(75); err != nil {
+			t.Fatal(err)
+		}
+		if err := base.SetDeclickBurst(4.5); err != nil {
+			t.Fatal(err)
+		}
+		config := deriveEffectiveFilterConfig(base)
+
+		spec := config.buildAdeclickFilter()
+
+		const want = "adeclick=t=1.7:w=55:o=75:m=a:a=8:b=4.5"
+		if spec != want {
+			t.Errorf("buildAdeclickFilter() = %q, want %q", spec, want)
+		}
+	})
+
 	t.Run("empty method omits m segment", func(t *testing.T) {
 		config := newTestConfig()
 		config.Adeclick.Enabled = true
@@ -1292,6 +1383,17 @@ func TestCloneForWorkerIsolatesStateAcrossClones(t *testing.T) {
 	if sinkB[0] != "from B 2" {
 		t.Errorf("sink B = %q, want %q", sinkB[0], "from B 2")
 	}
+
+	// (c) The other slices are copied too.
+	base.ExtraTargets = []float64{-23}
+	base.DisabledStages = []string{"deesser"}
+	clone := base.CloneForWorker(nil)
+	clone.ExtraTargets[0] = -16
+	clone.DisabledStages[0] = "limiter"
+	if base.ExtraTargets[0] != -23 || base.DisabledStages[0] != "deesser" {
+		t.Errorf("clone slice mutation changed base: ExtraTargets %v, DisabledStages %v",
+			base.ExtraTargets, base.DisabledStages)
+	}
 }
 
 func TestDbToLinear(t *testing.T) {
@@ -1558,6 +1660,36 @@ func TestBuildResampleFilter(t *testing.T) {
 		}
 	})
 
+	t.Run("output rate converts with soxr", func(t *testing.T) {
+		base := DefaultFilterConfig()
+		if err := base.SetOutputRate("48000"); err != nil {
+			t.Fatal(err)
+		}
+		config := deriveEffectiveFilterConfig(base)
+
+		result := config.buildResampleFilter()
+
+		expected := "aresample=resampler=soxr:osr=48000,aformat=sample_rates=48000:channel_layouts=mono:sample_fmts=s16,asetnsamples=n=4096"
+		if result != expected {
+			t.Errorf("buildResampleFilter() = %q, want %q", result, expected)
+		}
+	})
+
+	t.Run("same output rate leaves the rate alone", func(t *testing.T) {
+		base := DefaultFilterConfig()
+		if err := base.SetOutputRate(OutputRateSame); err != nil {
+			t.Fatal(err)
+		}
+		config := deriveEffectiveFilterConfig(base)
+
+		result := config.buildResampleFilter()
+
+		expected := "aformat=channel_layouts=mono:sample_fmts=s16,asetnsamples=n=4096"
+		if result != expected {
+			t.Errorf("buildResampleFilter() = %q, want %q", result, expected)
+		}
+	})
+
 	t.Run("disabled returns empty string", func(t *testing.T) {
 		config := newTestConfig()
 		config.Resample.Enabled = false
@@ -1585,6 +1717,35 @@ func TestBuildRequiredOutputFormatFilter(t *testing.T) {
 	}
 }
 
+func TestBuildFinalOutputFormatFilter(t *testing.T) {
+	config := newTestConfig()
+	mono := config.buildRequiredOutputFormatFilter()
+
+	config.Resample.Channels = resolveOutputChannels(OutputChannelsSame, 1)
+	if got := config.buildFinalOutputFormatFilter(); got != mono {
+		t.Errorf("same with mono input = %q, want mono %q", got, mono)
+	}
+
+	config.Resample.Channels = resolveOutputChannels(OutputChannelsSame, 2)
+	got := config.buildFinalOutputFormatFilter()
+	if !strings.HasPrefix(got, "pan=stereo|c0=c0|c1=c0,") || !strings.Contains(got, "channel_layouts=stereo") {
+		t.Errorf("same with stereo input = %q, want dual-mono pan then stereo aformat", got)
+	}
+
+	config.Resample.Channels = OutputChannelsMono
+	config.Resample.Dither = true
+	if got := config.buildFinalOutputFormatFilter(); got != "aresample=osf=s16:dither_method=triangular,"+mono {
+		t.Errorf("dithered 16-bit = %q, want TPDF aresample ahead of %q", got, mono)
+	}
+
+	if resolveOutputChannels(OutputChannelsMono, 2) != OutputChannelsMono {
+		t.Error("mono choice with stereo input should stay mono")
+	}
+	if resolveOutputChannels(OutputChannelsStereo, 1) != OutputChannelsStereo {
+		t.Error("stereo choice with mono input should upmix to stereo")
+	}
+}
+
 func TestPass1FilterOrder(t *testing.T) {
 	t.Run("includes correct filters in order", func(t *testing.T) {
 		// Pass 1 now uses interval sampling for silence detection (no silencedetect filter)
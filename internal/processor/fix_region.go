@@ -0,0 +1,319 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Region repair (--fix-region). Only a stretch of the file is analysed and run
+// through the four passes; the result is crossfaded back into a copy of the
+// original, which is otherwise left untouched. The processed stretch is spliced
+// at the original's level, not the loudness target, so it sits in the file
+// without a step at either edge.
+const (
+	// fixRegionCrossfade is the margin processed either side of the region and
+	// crossfaded with the original, so the repair fades in and out rather than
+	// cutting. It shrinks where the region meets the start or end of the file.
+	fixRegionCrossfade = 500 * time.Millisecond
+
+	// fixRegionMinDuration is the shortest region accepted: Pass 1 needs a few
+	// seconds to find speech and room tone to tune the chain from.
+	fixRegionMinDuration = 5 * time.Second
+)
+
+// FixRegionConfig is the stretch of the input to repair (--fix-region):
+// Duration from Start.
+type FixRegionConfig struct {
+	Enabled  bool
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// End is where the region stops.
+func (r FixRegionConfig) End() time.Duration {
+	return r.Start + r.Duration
+}
+
+// SetFixRegion restricts processing to duration from start, spliced back into
+// the original.
+func (cfg *BaseFilterConfig) SetFixRegion(start, duration time.Duration) error {
+	if start < 0 {
+		return fmt.Errorf("region start %v is negative", start)
+	}
+	if duration < fixRegionMinDuration {
+		return fmt.Errorf("region duration %v is shorter than %v", duration, fixRegionMinDuration)
+	}
+	cfg.FixRegion = FixRegionConfig{Enabled: true, Start: start, Duration: duration}
+	return nil
+}
+
+// FixRegionSplice records how a repaired region went back into the original.
+type FixRegionSplice struct {
+	StartS    float64 `json:"start_s"`
+	DurationS float64 `json:"duration_s"`
+	// LeadCrossfadeS and TailCrossfadeS are the crossfades at the region's
+	// edges; zero where the region meets the start or end of the file.
+	LeadCrossfadeS float64 `json:"lead_crossfade_s"`
+	TailCrossfadeS float64 `json:"tail_crossfade_s"`
+	// GainDB is the level change that took the processed region from the
+	// loudness target back to the original's loudness.
+	GainDB float64 `json:"gain_db"`
+}
+
+// fixRegionPlan is the region resolved against the file length: the region
+// with its end clamped, and the crossfade margins either side.
+type fixRegionPlan struct {
+	start, end time.Duration
+	lead, tail time.Duration
+}
+
+// extractStart and extractDuration bound the stretch processed: the region
+// plus its margins.
+func (p fixRegionPlan) extractStart() time.Duration { return p.start - p.lead }
+func (p fixRegionPlan) extractDuration() time.Duration {
+	return p.end + p.tail - p.extractStart()
+}
+
+// planFixRegion resolves region against a file of length total. A region
+// running past the end is clamped to it; one starting past the end, or
+// covering the whole file, is an error.
+func planFixRegion(region FixRegionConfig, total time.Duration) (fixRegionPlan, error) {
+	if region.Start >= total {
+		return fixRegionPlan{}, fmt.Errorf("region starts at %v, past the end of the file (%v)", region.Start, total)
+	}
+	p := fixRegionPlan{start: region.Start, end: min(region.End(), total)}
+	if p.end-p.start < fixRegionMinDuration {
+		return fixRegionPlan{}, fmt.Errorf("region is shorter than %v inside the file (%v)", fixRegionMinDuration, total)
+	}
+	if p.start == 0 && p.end == total {
+		return fixRegionPlan{}, fmt.Errorf("region covers the whole file; process it without --fix-region")
+	}
+	p.lead = min(fixRegionCrossfade, p.start)
+	p.tail = min(fixRegionCrossfade, total-p.end)
+	return p, nil
+}
+
+// fixRegionOutputPath names the repaired copy in the delivered format's ext:
+// /path/to/audio.wav → /path/to/audio-fixed.flac.
+func fixRegionOutputPath(inputPath, ext string) string {
+	dir := filepath.Dir(inputPath)
+	filename := filepath.Base(inputPath)
+	return filepath.Join(dir, strings.TrimSuffix(filename, filepath.Ext(filename))+"-fixed"+ext)
+}
+
+// channelLayoutName is the FFmpeg layout for a mono or stereo channel count.
+func channelLayoutName(channels int) (string, error) {
+	switch channels {
+	case 1:
+		return OutputChannelsMono, nil
+	case 2:
+		return OutputChannelsStereo, nil
+	}
+	return "", fmt.Errorf("%d-channel input: region repair supports mono and stereo", channels)
+}
+
+// buildFixRegionSpliceSpec crossfades the processed stretch at processedPath,
+// level-matched by gainDB, into the original on [in]: the original up to the
+// region start, the processed stretch, then the original from the region end.
+// Each acrossfade overlaps the margin the stretch carries beyond the region,
+// so the original and processed audio line up sample for sample across the
+// fade. The whole graph runs in the original's rate and layout, in float, so a
+// 16-bit result is dithered on the way out like any other 16-bit output.
+func buildFixRegionSpliceSpec(processedPath string, plan fixRegionPlan, gainDB float64, rate int, layout, format string) string {
+	common := fmt.Sprintf("aformat=sample_fmts=fltp:sample_rates=%d:channel_layouts=%s", rate, layout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "amovie=filename=%s,volume=%.2fdB:precision=double,aresample=%d,%s[fix_region];",
+		escapeFilterGraphOptionValue(processedPath), gainDB, rate, common)
+
+	hasHead, hasTail := plan.lead > 0, plan.tail > 0
+	switch {
+	case hasHead && hasTail:
+		fmt.Fprintf(&b, "[in]%s,asplit=2[orig_head][orig_tail];", common)
+		fmt.Fprintf(&b, "[orig_head]atrim=end=%f,asetpts=PTS-STARTPTS[head];", plan.start.Seconds())
+		fmt.Fprintf(&b, "[orig_tail]atrim=start=%f,asetpts=PTS-STARTPTS[tail];", plan.end.Seconds())
+	case hasHead:
+		fmt.Fprintf(&b, "[in]%s,atrim=end=%f,asetpts=PTS-STARTPTS[head];", common, plan.start.Seconds())
+	case hasTail:
+		fmt.Fprintf(&b, "[in]%s,atrim=start=%f,asetpts=PTS-STARTPTS[tail];", common, plan.end.Seconds())
+	}
+
+	spliced := "[fix_region]"
+	if hasHead {
+		fmt.Fprintf(&b, "[head]%sacrossfade=d=%f:c1=tri:c2=tri[spliced_head];", spliced, plan.lead.Seconds())
+		spliced = "[spliced_head]"
+	}
+	if hasTail {
+		fmt.Fprintf(&b, "%s[tail]acrossfade=d=%f:c1=tri:c2=tri,", spliced, plan.tail.Seconds())
+	} else {
+		b.WriteString(spliced + "anull,")
+	}
+	if format == "s16" {
+		b.WriteString("aresample=osf=s16:dither_method=triangular,")
+	}
+	fmt.Fprintf(&b, "aformat=sample_fmts=%s,asetnsamples=n=%d", format, defaultResampleConfig().FrameSize)
+	return b.String()
+}
+
+// renderInputCopy writes inputPath through spec to a temp FLAC beside anchor
+// (outputAnchor) named with marker. The caller removes it.
+func renderInputCopy(ctx context.Context, inputPath, anchor, spec, marker string) (string, error) {
+	reader, _, err := audio.OpenAudioFile(inputPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open input: %w", err)
+	}
+	defer reader.Close()
+
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s filter graph: %w", marker, err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	tempPath, err := processorCreateSiblingTempPath(anchor, marker)
+	if err != nil {
+		return "", err
+	}
+	published := false
+	defer func() {
+		if !published {
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	encoder, err := createOutputEncoder(tempPath, bufferSinkCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			filteredFrame.SetTimeBase(ffmpeg.AVBuffersinkGetTimeBase(bufferSinkCtx))
+			if err := encoder.WriteFrame(filteredFrame); err != nil {
+				return fmt.Errorf("failed to write frame: %w", err)
+			}
+			return nil
+		},
+	}); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return "", fmt.Errorf("failed to flush encoder: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to close encoder: %w", err)
+	}
+	published = true
+	return tempPath, nil
+}
+
+// processFixRegion is ProcessAudio for --fix-region: it cuts the region and its
+// margins to a temp copy, runs the four passes on that alone, and splices the
+// processed stretch into a copy of the original published as
+// fixRegionOutputPath. The result's measurements and report describe the
+// region; FixRegion records the splice.
+func processFixRegion(ctx context.Context, inputPath string, config *BaseFilterConfig, progressCallback ProgressCallback) (*ProcessingResult, error) {
+	anchor := config.outputAnchor(inputPath)
+	finalPath := fixRegionOutputPath(anchor, config.outputExt())
+	if config.OutputFile != "" {
+		finalPath = config.OutputFile
+	}
+	if config.OnExists == OnExistsSkip || config.OnExists == OnExistsError {
+		if _, err := resolveOutputPath(finalPath, config.OnExists); err != nil {
+			return nil, err
+		}
+	}
+
+	reader, metadata, err := audio.OpenAudioFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %w", err)
+	}
+	reader.Close()
+	layout, err := channelLayoutName(metadata.Channels)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planFixRegion(config.FixRegion, time.Duration(metadata.Duration*float64(time.Second)))
+	if err != nil {
+		return nil, err
+	}
+
+	extractSpec := fmt.Sprintf("atrim=start=%f:duration=%f,asetpts=PTS-STARTPTS,aformat=sample_fmts=s32",
+		plan.extractStart().Seconds(), plan.extractDuration().Seconds())
+	extractPath, err := renderInputCopy(ctx, inputPath, anchor, extractSpec, "fix-region")
+	if err != nil {
+		return nil, fmt.Errorf("failed to cut the region: %w", err)
+	}
+	defer func() { _ = os.Remove(extractPath) }()
+
+	// The region runs the ordinary pipeline; the side artefacts that describe a
+	// whole deliverable stay off, and its own output is an intermediate.
+	region := *config
+	region.FixRegion = FixRegionConfig{}
+	region.OutputDir, region.OutputFile = "", ""
+	region.OnExists = OnExistsOverwrite
+	region.InPlace = false
+	region.KeepCoverArt = false
+	region.NoiseStem = false
+	region.EmitFFmpegCommand = false
+	region.Receipt = false
+	region.WriteReceipt = false
+	region.Slate = SlateConfig{}
+	region.ExtraTargets = nil
+	result, err := ProcessAudio(ctx, extractPath, &region, progressCallback)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(result.OutputPath) }()
+
+	gainDB := result.InputLUFS - result.OutputLUFS
+	if !isFinite(gainDB) {
+		return nil, fmt.Errorf("region loudness not measured, cannot match it to the original")
+	}
+
+	spec := buildFixRegionSpliceSpec(result.OutputPath, plan, gainDB, metadata.SampleRate, layout, result.Config.Resample.Format)
+	splicedPath, err := renderInputCopy(ctx, inputPath, anchor, spec, "fix-region-splice")
+	if err != nil {
+		return nil, fmt.Errorf("failed to splice the region: %w", err)
+	}
+	defer func() { _ = os.Remove(splicedPath) }()
+
+	release := func() {}
+	if !sameFile(finalPath, inputPath) {
+		if finalPath, release, err = reserveOutputPath(finalPath, config.OnExists); err != nil {
+			return nil, err
+		}
+	}
+	if err := encodeOutputFormat(ctx, splicedPath, config.OutputFormat); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to write %s output: %w", config.OutputFormat, err)
+	}
+	if err := publishProcessedOutput(splicedPath, finalPath, inputPath, config.InPlace); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to publish output: %w", err)
+	}
+	result.OutputPath = finalPath
+	result.OutputChannels = metadata.Channels
+	result.FixRegion = &FixRegionSplice{
+		StartS:         plan.start.Seconds(),
+		DurationS:      (plan.end - plan.start).Seconds(),
+		LeadCrossfadeS: plan.lead.Seconds(),
+		TailCrossfadeS: plan.tail.Seconds(),
+		GainDB:         gainDB,
+	}
+
+	if config.KeepCoverArt {
+		if _, err := keepCoverArt(inputPath, finalPath); err != nil {
+			result.Diagnostics.Warnings = append(result.Diagnostics.Warnings, fmt.Sprintf("cover art not kept: %v", err))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package processor
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanFixRegion(t *testing.T) {
+	total := 60 * time.Second
+	tests := []struct {
+		name       string
+		start, dur time.Duration
+		want       fixRegionPlan
+		wantErr    bool
+	}{
+		{"interior", 20 * time.Second, 10 * time.Second,
+			fixRegionPlan{start: 20 * time.Second, end: 30 * time.Second, lead: fixRegionCrossfade, tail: fixRegionCrossfade}, false},
+		{"at the start", 0, 10 * time.Second,
+			fixRegionPlan{start: 0, end: 10 * time.Second, lead: 0, tail: fixRegionCrossfade}, false},
+		{"margin cut short", 200 * time.Millisecond, 10 * time.Second,
+			fixRegionPlan{start: 200 * time.Millisecond, end: 10200 * time.Millisecond, lead: 200 * time.Millisecond, tail: fixRegionCrossfade}, false},
+		{"clamped to the end", 50 * time.Second, 20 * time.Second,
+			fixRegionPlan{start: 50 * time.Second, end: total, lead: fixRegionCrossfade, tail: 0}, false},
+		{"past the end", 70 * time.Second, 10 * time.Second, fixRegionPlan{}, true},
+		{"too short once clamped", 57 * time.Second, 10 * time.Second, fixRegionPlan{}, true},
+		{"whole file", 0, 2 * time.Minute, fixRegionPlan{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := planFixRegion(FixRegionConfig{Enabled: true, Start: tt.start, Duration: tt.dur}, total)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planFixRegion error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("planFixRegion = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	p := fixRegionPlan{start: 20 * time.Second, end: 30 * time.Second, lead: fixRegionCrossfade, tail: fixRegionCrossfade}
+	if p.extractStart() != 19500*time.Millisecond || p.extractDuration() != 11*time.Second {
+		t.Errorf("extract = %v for %v, want 19.5s for 11s", p.extractStart(), p.extractDuration())
+	}
+}
+
+func TestSetFixRegion(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetFixRegion(-time.Second, 10*time.Second); err == nil {
+		t.Error("negative start accepted")
+	}
+	if err := cfg.SetFixRegion(0, time.Second); err == nil {
+		t.Error("1 s region accepted")
+	}
+	if err := cfg.SetFixRegion(time.Minute, 10*time.Second); err != nil {
+		t.Fatalf("SetFixRegion: %v", err)
+	}
+	if !cfg.FixRegion.Enabled || cfg.FixRegion.End() != 70*time.Second {
+		t.Errorf("FixRegion = %+v, want enabled ending at 70s", cfg.FixRegion)
+	}
+}
+
+func TestBuildFixRegionSpliceSpec(t *testing.T) {
+	interior := fixRegionPlan{start: 20 * time.Second, end: 30 * time.Second, lead: fixRegionCrossfade, tail: fixRegionCrossfade}
+	spec := buildFixRegionSpliceSpec("/tmp/a:b.flac", interior, -7.5, 48000, OutputChannelsStereo, "s16")
+	for _, want := range []string{
+		`amovie=filename=/tmp/a\:b.flac,volume=-7.50dB`,
+		"asplit=2[orig_head][orig_tail]",
+		"[orig_head]atrim=end=20.000000",
+		"[orig_tail]atrim=start=30.000000",
+		"[head][fix_region]acrossfade=d=0.500000",
+		"[spliced_head][tail]acrossfade=d=0.500000",
+		"channel_layouts=stereo",
+		"aresample=osf=s16:dither_method=triangular,aformat=sample_fmts=s16",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("interior spec missing %q\n%s", want, spec)
+		}
+	}
+	if !strings.HasSuffix(spec, "aformat=sample_fmts=s16,asetnsamples=n=4096") {
+		t.Errorf("spec does not end in the output format\n%s", spec)
+	}
+
+	atStart := fixRegionPlan{start: 0, end: 10 * time.Second, tail: fixRegionCrossfade}
+	spec = buildFixRegionSpliceSpec("/tmp/r.flac", atStart, 0, 44100, OutputChannelsMono, "s32")
+	if strings.Contains(spec, "asplit") || strings.Contains(spec, "[head]") {
+		t.Errorf("region at the start must not splice a head\n%s", spec)
+	}
+	if !strings.Contains(spec, "[fix_region][tail]acrossfade") {
+		t.Errorf("region at the start must crossfade into the tail\n%s", spec)
+	}
+
+	atEnd := fixRegionPlan{start: 50 * time.Second, end: time.Minute, lead: fixRegionCrossfade}
+	spec = buildFixRegionSpliceSpec("/tmp/r.flac", atEnd, 0, 44100, OutputChannelsMono, "s32")
+	if strings.Contains(spec, "[tail]") || !strings.Contains(spec, "[spliced_head]anull") {
+		t.Errorf("region at the end must not splice a tail\n%s", spec)
+	}
+}
+
+func TestFixRegionOutputPath(t *testing.T) {
+	got := fixRegionOutputPath(filepath.Join("dir", "show.wav"), ".flac")
+	if want := filepath.Join("dir", "show-fixed.flac"); got != want {
+		t.Errorf("fixRegionOutputPath = %q, want %q", got, want)
+	}
+}
@@ -52,6 +52,12 @@ type FrameLoopConfig struct {
 	// OnInputFrame is called for each input frame before it is pushed into
 	// the filter graph. Use for pre-filter work (progress tracking, RMS accumulation).
 	OnInputFrame func(inputFrame *ffmpeg.AVFrame)
+
+	// StopReading is called for each input frame before OnInputFrame. Returning
+	// true ends the read loop as EOF would: the frame is not pushed and the
+	// graph is flushed. Use when only a leading stretch of the input is needed.
+	// nil = read to EOF.
+	StopReading func(inputFrame *ffmpeg.AVFrame) bool
 }
 
 // runFilterGraph runs the read-push-pull loop over a filter graph.
@@ -127,6 +133,9 @@ func runFilterGraph(
 		if frame == nil {
 			break // EOF
 		}
+		if config.StopReading != nil && config.StopReading(frame) {
+			break
+		}
 
 		if config.OnInputFrame != nil {
 			config.OnInputFrame(frame)
@@ -0,0 +1,59 @@
+package processor
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGlobalCStrLiteralsOnly guards the process-lifetime C string cache:
+// every ffmpeg.GlobalCStr call in the module's non-test code takes a string
+// literal, so the cache holds a fixed set of keys however long a process
+// embedding the processor runs.
+func TestGlobalCStrLiteralsOnly(t *testing.T) {
+	fset := token.NewFileSet()
+	calls := 0
+	for _, root := range []string{"..", "../../cmd"} {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "GlobalCStr" {
+					return true
+				}
+				calls++
+				if len(call.Args) != 1 {
+					return true
+				}
+				if lit, ok := call.Args[0].(*ast.BasicLit); !ok || lit.Kind != token.STRING {
+					t.Errorf("%s: GlobalCStr with a non-literal argument; use ToCStr and Free", fset.Position(call.Pos()))
+				}
+				return true
+			})
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls == 0 {
+		t.Fatal("found no GlobalCStr calls; the walk is not reaching the sources")
+	}
+}
@@ -0,0 +1,72 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// intermediateMeasurements is the JSON written beside a kept Pass 2 render:
+// OutputMeasurements with its spectral block, which the run record otherwise
+// carries under its spectral stages.
+type intermediateMeasurements struct {
+	Loudness       OutputLoudnessMetrics     `json:"loudness"`
+	Dynamics       DynamicsMetrics           `json:"dynamics"`
+	Spectral       SpectralMetrics           `json:"spectral"`
+	Loudnorm       OutputLoudnormMeasurement `json:"loudnorm"`
+	RoomToneSample *RegionSample             `json:"room_tone_sample,omitempty"`
+	SpeechSample   *RegionSample             `json:"speech_sample,omitempty"`
+}
+
+// intermediatePaths names the kept Pass 2 render and its measurements in dir
+// after the input: /in/episode.wav → dir/episode-pass2.flac and
+// dir/episode-pass2.json.
+func intermediatePaths(inputPath, dir string) (audioPath, jsonPath string) {
+	filename := filepath.Base(inputPath)
+	base := filepath.Join(dir, strings.TrimSuffix(filename, filepath.Ext(filename))+"-pass2")
+	return base + ".flac", base + ".json"
+}
+
+// keepIntermediate copies the Pass 2 render at pass2Path, filtered but not yet
+// normalised, into dir (--keep-intermediate) with its measurements as JSON.
+// Returns the kept audio path.
+func keepIntermediate(inputPath, pass2Path, dir string, filtered *OutputMeasurements) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	audioPath, jsonPath := intermediatePaths(inputPath, dir)
+
+	tempPath, err := createSiblingTempPath(audioPath, "intermediate")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(tempPath) }()
+	if err := copyFileContents(pass2Path, tempPath); err != nil {
+		return "", fmt.Errorf("failed to copy pass 2 output: %w", err)
+	}
+	if err := publishOutput(tempPath, audioPath); err != nil {
+		return "", err
+	}
+
+	if filtered != nil {
+		dump := intermediateMeasurements{
+			Loudness:       filtered.Loudness,
+			Dynamics:       filtered.Dynamics,
+			Spectral:       filtered.Spectral,
+			Loudnorm:       filtered.Loudnorm,
+			RoomToneSample: filtered.RoomToneSample,
+			SpeechSample:   filtered.SpeechSample,
+		}
+		if err := writeSidecarFile("intermediate measurements", jsonPath, func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(SanitiseJSON(dump))
+		}); err != nil {
+			return audioPath, err
+		}
+	}
+	return audioPath, nil
+}
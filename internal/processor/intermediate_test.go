@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIntermediatePaths(t *testing.T) {
+	audioPath, jsonPath := intermediatePaths("/shows/episode.wav", "/tmp/keep")
+	if audioPath != "/tmp/keep/episode-pass2.flac" || jsonPath != "/tmp/keep/episode-pass2.json" {
+		t.Errorf("intermediatePaths = %s, %s", audioPath, jsonPath)
+	}
+}
+
+func TestKeepIntermediate(t *testing.T) {
+	tmp := t.TempDir()
+	pass2 := filepath.Join(tmp, ".processing-1.tmp.flac")
+	if err := os.WriteFile(pass2, []byte("fLaC render"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	filtered := &OutputMeasurements{Spectral: SpectralMetrics{Centroid: 2100}}
+	filtered.Loudness.OutputI = -19
+	filtered.Dynamics.RMSTrough = math.Inf(-1)
+
+	dir := filepath.Join(tmp, "keep", "nested")
+	got, err := keepIntermediate("/shows/episode.wav", pass2, dir, filtered)
+	if err != nil {
+		t.Fatalf("keepIntermediate: %v", err)
+	}
+	if data, err := os.ReadFile(got); err != nil || string(data) != "fLaC render" {
+		t.Errorf("kept audio %s = %q, %v; want a copy of the Pass 2 render", got, data, err)
+	}
+	if _, err := os.Stat(pass2); err != nil {
+		t.Errorf("Pass 2 render moved: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "episode-pass2.json"))
+	if err != nil {
+		t.Fatalf("measurements not written: %v", err)
+	}
+	var dump map[string]map[string]any
+	if err := json.Unmarshal(data, &dump); err != nil {
+		t.Fatalf("measurements are not JSON: %v\n%s", err, data)
+	}
+	if dump["spectral"] == nil || dump["loudness"] == nil || dump["loudnorm"] == nil {
+		t.Errorf("measurements missing a block: %s", data)
+	}
+}
@@ -0,0 +1,108 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// LoudnessSpec is a named delivery target (--spec): the integrated loudness and
+// true-peak ceiling a platform or standard asks for, and the loudness tolerance
+// its QC allows.
+type LoudnessSpec struct {
+	Name        string  // CLI name, e.g. "aes-podcast"
+	Title       string  // Report name, e.g. "AES podcast"
+	TargetI     float64 // Integrated loudness target (LUFS)
+	TargetTP    float64 // True-peak ceiling (dBTP)
+	ToleranceLU float64 // Allowed integrated loudness deviation (± LU)
+}
+
+// loudnessSpecs lists the supported delivery targets in --spec order. The
+// tolerances are the published ones: EBU R128 allows ±0.5 LU for file-based
+// programmes; the streaming and podcast recommendations allow ±1 LU.
+var loudnessSpecs = []LoudnessSpec{
+	{Name: "spotify", Title: "Spotify", TargetI: -14.0, TargetTP: -1.0, ToleranceLU: 1.0},
+	{Name: "apple", Title: "Apple Podcasts", TargetI: -16.0, TargetTP: -1.0, ToleranceLU: 1.0},
+	{Name: "youtube", Title: "YouTube", TargetI: -14.0, TargetTP: -1.0, ToleranceLU: 1.0},
+	{Name: "ebu-r128", Title: "EBU R128", TargetI: -23.0, TargetTP: -1.0, ToleranceLU: 0.5},
+	{Name: "aes-podcast", Title: "AES podcast", TargetI: -16.0, TargetTP: -1.0, ToleranceLU: 1.0},
+}
+
+// LoudnessSpecNames returns the --spec names in table order.
+func LoudnessSpecNames() []string {
+	names := make([]string, len(loudnessSpecs))
+	for i, s := range loudnessSpecs {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// lookupLoudnessSpec finds a spec by its CLI name.
+func lookupLoudnessSpec(name string) (LoudnessSpec, bool) {
+	for _, s := range loudnessSpecs {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return LoudnessSpec{}, false
+}
+
+// SetLoudnessSpec selects a named delivery target: loudnorm aims at its
+// integrated loudness and true-peak ceiling, and the result is checked against
+// its tolerances after Pass 4.
+func (cfg *BaseFilterConfig) SetLoudnessSpec(name string) error {
+	spec, ok := lookupLoudnessSpec(name)
+	if !ok {
+		return fmt.Errorf("loudness spec %q is not one of %s", name, strings.Join(LoudnessSpecNames(), ", "))
+	}
+	cfg.Loudnorm.Spec = spec.Name
+	cfg.Loudnorm.TargetI = spec.TargetI
+	cfg.Loudnorm.TargetTP = spec.TargetTP
+	return nil
+}
+
+// SpecCompliance is the post-processing QC verdict against the selected
+// LoudnessSpec, read from the final (Pass 4) loudness and deliverable true peak.
+type SpecCompliance struct {
+	Spec         string  `json:"spec"`
+	Title        string  `json:"title"`
+	TargetI      float64 `json:"target_lufs"`
+	TargetTP     float64 `json:"target_dbtp"`
+	ToleranceLU  float64 `json:"tolerance_lu"`
+	MeasuredI    float64 `json:"measured_lufs"`
+	MeasuredTP   float64 `json:"measured_dbtp"`
+	LoudnessPass bool    `json:"loudness_pass"`
+	TruePeakPass bool    `json:"true_peak_pass"`
+	Pass         bool    `json:"pass"`
+}
+
+// Verdict is the one-line QC statement, e.g. "PASS: AES podcast spec".
+func (c *SpecCompliance) Verdict() string {
+	if c.Pass {
+		return "PASS: " + c.Title + " spec"
+	}
+	return "FAIL: " + c.Title + " spec"
+}
+
+// checkLoudnessSpec grades the delivered loudness and true peak against the
+// named spec. The loudness passes within ±ToleranceLU of the target; the true
+// peak passes at or under the ceiling. Returns nil when no spec was selected.
+func checkLoudnessSpec(name string, measuredI, measuredTP float64) *SpecCompliance {
+	spec, ok := lookupLoudnessSpec(name)
+	if !ok {
+		return nil
+	}
+	c := &SpecCompliance{
+		Spec:        spec.Name,
+		Title:       spec.Title,
+		TargetI:     spec.TargetI,
+		TargetTP:    spec.TargetTP,
+		ToleranceLU: spec.ToleranceLU,
+		MeasuredI:   measuredI,
+		MeasuredTP:  measuredTP,
+	}
+	c.LoudnessPass = isFinite(measuredI) && math.Abs(measuredI-spec.TargetI) <= spec.ToleranceLU
+	c.TruePeakPass = isFinite(measuredTP) && measuredTP <= spec.TargetTP
+	c.Pass = c.LoudnessPass && c.TruePeakPass
+	return c
+}
@@ -0,0 +1,59 @@
+package processor
+
+import "testing"
+
+func TestSetLoudnessSpec(t *testing.T) {
+	for _, spec := range loudnessSpecs {
+		cfg := DefaultFilterConfig()
+		if err := cfg.SetLoudnessSpec(spec.Name); err != nil {
+			t.Fatalf("SetLoudnessSpec(%q): %v", spec.Name, err)
+		}
+		if cfg.Loudnorm.Spec != spec.Name || cfg.Loudnorm.TargetI != spec.TargetI || cfg.Loudnorm.TargetTP != spec.TargetTP {
+			t.Errorf("%s: Loudnorm = %q %.1f LUFS %.1f dBTP, want %.1f LUFS %.1f dBTP",
+				spec.Name, cfg.Loudnorm.Spec, cfg.Loudnorm.TargetI, cfg.Loudnorm.TargetTP, spec.TargetI, spec.TargetTP)
+		}
+	}
+
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetLoudnessSpec("broadcast"); err == nil {
+		t.Error("SetLoudnessSpec(broadcast) = nil, want error")
+	}
+	if cfg.Loudnorm.TargetI != NormTargetLUFS || cfg.Loudnorm.Spec != "" {
+		t.Errorf("rejected spec changed the config: %+v", cfg.Loudnorm)
+	}
+}
+
+func TestCheckLoudnessSpec(t *testing.T) {
+	tests := []struct {
+		name             string
+		spec             string
+		measuredI        float64
+		measuredTP       float64
+		wantLoud, wantTP bool
+		wantVerdict      string
+	}{
+		{"on target", "aes-podcast", -16.2, -1.5, true, true, "PASS: AES podcast spec"},
+		{"at the tolerance edge", "aes-podcast", -17.0, -1.0, true, true, "PASS: AES podcast spec"},
+		{"too quiet", "aes-podcast", -17.3, -3.0, false, true, "FAIL: AES podcast spec"},
+		{"peak over the ceiling", "spotify", -14.0, -0.4, true, false, "FAIL: Spotify spec"},
+		{"EBU tolerance is tighter", "ebu-r128", -23.7, -2.0, false, true, "FAIL: EBU R128 spec"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := checkLoudnessSpec(tt.spec, tt.measuredI, tt.measuredTP)
+			if c == nil {
+				t.Fatal("checkLoudnessSpec returned nil")
+			}
+			if c.LoudnessPass != tt.wantLoud || c.TruePeakPass != tt.wantTP {
+				t.Errorf("loudness pass %v, true peak pass %v; want %v, %v", c.LoudnessPass, c.TruePeakPass, tt.wantLoud, tt.wantTP)
+			}
+			if got := c.Verdict(); got != tt.wantVerdict {
+				t.Errorf("Verdict() = %q, want %q", got, tt.wantVerdict)
+			}
+		})
+	}
+
+	if c := checkLoudnessSpec("", -16, -1); c != nil {
+		t.Errorf("no spec: got %+v, want nil", c)
+	}
+}
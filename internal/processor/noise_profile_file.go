@@ -0,0 +1,232 @@
+package processor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Saved noise profiles (--save-noise-profile, --load-noise-profile). A show
+// recorded in the same room on the same kit has the same room tone from one
+// episode to the next, so a profile read from a clean take can stand in for an
+// episode whose own room tone is too short or too busy to read.
+const (
+	noiseProfileFileFormat  = "jivetalking-noise-profile"
+	noiseProfileFileVersion = 1
+
+	// noiseProfileFloorMismatchDB is how far this file's own floor may sit
+	// from the loaded one before the load warns that the room has changed.
+	noiseProfileFloorMismatchDB = 6.0
+)
+
+// SavedNoiseProfile is a noise profile written to disk: the room-tone
+// measurements and the floor elected with them, and the recording they came
+// from. Load one with LoadNoiseProfile.
+type SavedNoiseProfile struct {
+	Format     string        `json:"format"`
+	Version    int           `json:"version"`
+	Source     string        `json:"source"`
+	SampleRate int           `json:"sample_rate_hz"`
+	Floor      float64       `json:"noise_floor_dbfs"`
+	Profile    *NoiseProfile `json:"noise_profile"`
+}
+
+// UnmarshalJSON reads the flat spectral_* JSON contract NoiseProfile.MarshalJSON
+// writes.
+func (p *NoiseProfile) UnmarshalJSON(data []byte) error {
+	var decoded noiseProfileJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*p = NoiseProfile{
+		Start:              decoded.Start,
+		Duration:           decoded.Duration,
+		MeasuredNoiseFloor: decoded.MeasuredNoiseFloor,
+		PeakLevel:          decoded.PeakLevel,
+		CrestFactor:        decoded.CrestFactor,
+		Entropy:            decoded.Entropy,
+		ExtractionWarning:  decoded.ExtractionWarning,
+
+		Spectral: SpectralMetrics{
+			Mean:     decoded.SpectralMean,
+			Variance: decoded.SpectralVariance,
+			Centroid: decoded.SpectralCentroid,
+			Spread:   decoded.SpectralSpread,
+			Skewness: decoded.SpectralSkewness,
+			Kurtosis: decoded.SpectralKurtosis,
+			Entropy:  decoded.SpectralEntropy,
+			Flatness: decoded.SpectralFlatness,
+			Crest:    decoded.SpectralCrest,
+			Flux:     decoded.SpectralFlux,
+			Slope:    decoded.SpectralSlope,
+			Decrease: decoded.SpectralDecrease,
+			Rolloff:  decoded.SpectralRolloff,
+		},
+
+		BandNoise:     decoded.BandNoise,
+		BandsMeasured: decoded.BandsMeasured,
+
+		Hum50RMS:    decoded.Hum50RMS,
+		Hum60RMS:    decoded.Hum60RMS,
+		HumMeasured: decoded.HumMeasured,
+
+		OriginalStart:    decoded.OriginalStart,
+		OriginalDuration: decoded.OriginalDuration,
+		WasRefined:       decoded.WasRefined,
+
+		Pinned: decoded.Pinned,
+		Loaded: decoded.Loaded,
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.Spectral.Found = hasSpectralKeys(raw)
+	return nil
+}
+
+// newSavedNoiseProfile captures the elected noise profile of measurements, or
+// returns an error when no room tone was profiled.
+func newSavedNoiseProfile(inputPath string, measurements *AudioMeasurements) (*SavedNoiseProfile, error) {
+	if measurements == nil || measurements.Regions.NoiseProfile == nil {
+		return nil, errors.New("no room tone was profiled")
+	}
+	profile := *measurements.Regions.NoiseProfile
+	profile.BandNoise = slices.Clone(profile.BandNoise)
+	return &SavedNoiseProfile{
+		Format:     noiseProfileFileFormat,
+		Version:    noiseProfileFileVersion,
+		Source:     filepath.Base(inputPath),
+		SampleRate: measurements.SampleRate,
+		Floor:      measurements.Noise.Floor,
+		Profile:    &profile,
+	}, nil
+}
+
+// WriteNoiseProfile writes the elected noise profile of measurements to path
+// as JSON.
+func WriteNoiseProfile(inputPath string, measurements *AudioMeasurements, path string) error {
+	saved, err := newSavedNoiseProfile(inputPath, measurements)
+	if err != nil {
+		return err
+	}
+	return writeSidecarFile("noise profile", path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(saved)
+	})
+}
+
+// LoadNoiseProfile reads a profile WriteNoiseProfile wrote, rejecting any
+// other file and a profile with no usable floor.
+func LoadNoiseProfile(path string) (*SavedNoiseProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var saved SavedNoiseProfile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("%s is not a noise profile: %w", path, err)
+	}
+	if saved.Format != noiseProfileFileFormat {
+		return nil, fmt.Errorf("%s is not a noise profile", path)
+	}
+	if saved.Version != noiseProfileFileVersion {
+		return nil, fmt.Errorf("%s is noise profile version %d, want %d", path, saved.Version, noiseProfileFileVersion)
+	}
+	if saved.Profile == nil || math.IsNaN(saved.Floor) || saved.Floor >= 0 {
+		return nil, fmt.Errorf("%s holds no usable noise floor", path)
+	}
+	return &saved, nil
+}
+
+// lifted returns a copy of the profile with every level raised by gainDB, for
+// the quiet-input pre-gain's lifted analysis.
+func (s *SavedNoiseProfile) lifted(gainDB float64) *SavedNoiseProfile {
+	out := *s
+	profile := *s.Profile
+	out.Profile = &profile
+	out.Floor += gainDB
+	profile.MeasuredNoiseFloor += gainDB
+	profile.PeakLevel += gainDB
+	profile.BandNoise = slices.Clone(s.Profile.BandNoise)
+	for i := range profile.BandNoise {
+		profile.BandNoise[i] += gainDB
+	}
+	if profile.HumMeasured {
+		profile.Hum50RMS += gainDB
+		profile.Hum60RMS += gainDB
+	}
+	return &out
+}
+
+// applyLoadedNoiseProfile replaces the elected room tone with a saved
+// profile. The profile's region belongs to another recording, so it is
+// cleared here: the band and hum readings travel with the profile rather than
+// being measured again, and no room tone of this file is measured after
+// processing. A sample rate or floor that differs from this recording is noted
+// on the profile for warnLoadedNoiseProfile. A nil saved profile leaves the
+// detection as it is.
+func applyLoadedNoiseProfile(measurements *AudioMeasurements, intervals []IntervalSample, saved *SavedNoiseProfile, hop time.Duration, axis levelAxis, log debugLogger) {
+	if saved == nil || saved.Profile == nil {
+		return
+	}
+	log.Logf("Noise profile loaded from %s: floor %.1f dBFS (detected %.1f dBFS, %s)",
+		saved.Source, saved.Floor, measurements.Noise.Floor, measurements.Noise.FloorSource)
+
+	var mismatches []string
+	if saved.SampleRate > 0 && measurements.SampleRate > 0 && saved.SampleRate != measurements.SampleRate {
+		mismatches = append(mismatches, fmt.Sprintf("it was read at %d Hz, this file is %d Hz", saved.SampleRate, measurements.SampleRate))
+	}
+	if detected := measurements.Noise.Floor; isFinite(detected) && math.Abs(detected-saved.Floor) > noiseProfileFloorMismatchDB {
+		mismatches = append(mismatches, fmt.Sprintf("its floor is %.1f dBFS, this file's is %.1f dBFS", saved.Floor, detected))
+	}
+
+	profile := *saved.Profile
+	profile.BandNoise = slices.Clone(saved.Profile.BandNoise)
+	profile.Start, profile.Duration = 0, 0
+	profile.OriginalStart, profile.OriginalDuration, profile.WasRefined = 0, 0, false
+	profile.Pinned = false
+	profile.Loaded = true
+	profile.ExtractionWarning = ""
+	if len(mismatches) > 0 {
+		profile.ExtractionWarning = fmt.Sprintf("noise profile from %s may not suit this recording: %s", saved.Source, strings.Join(mismatches, "; "))
+	}
+
+	measurements.Noise.Floor = saved.Floor
+	measurements.Noise.FloorSource = "loaded"
+	measurements.Regions.NoiseProfile = &profile
+	measurements.Regions.ElectedRoomToneSample = nil
+	measurements.Noise.RumbleBurstCount, measurements.Noise.RumbleBurstsPerMinute = countRumbleBursts(intervals, saved.Floor, axis, hop)
+}
+
+// warnLoadedNoiseProfile reports a loaded noise profile that does not match
+// the recording it was applied to.
+func warnLoadedNoiseProfile(diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements) {
+	if measurements == nil || measurements.Regions.NoiseProfile == nil {
+		return
+	}
+	if p := measurements.Regions.NoiseProfile; p.Loaded && p.ExtractionWarning != "" {
+		diagnostics.Warnings = append(diagnostics.Warnings, p.ExtractionWarning)
+	}
+}
+
+// exportNoiseProfile writes the elected noise profile to
+// --save-noise-profile's path, as a warning on failure.
+func exportNoiseProfile(inputPath string, config *BaseFilterConfig, measurements *AudioMeasurements, diagnostics *AdaptiveDiagnostics) {
+	if config.NoiseProfileOut == "" || measurements == nil {
+		return
+	}
+	if err := WriteNoiseProfile(inputPath, measurements, config.NoiseProfileOut); err != nil && diagnostics != nil {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("noise profile not saved: %v", err))
+	}
+}
@@ -0,0 +1,111 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNoiseProfileRoundTrip(t *testing.T) {
+	m := &AudioMeasurements{SampleRate: 48000}
+	m.Noise.Floor = -68
+	m.Regions.NoiseProfile = &NoiseProfile{
+		Start:              90 * time.Second,
+		Duration:           8 * time.Second,
+		MeasuredNoiseFloor: -68,
+		PeakLevel:          -52,
+		Spectral:           SpectralMetrics{Centroid: 1800, Flatness: 0.4, Found: true},
+		BandNoise:          []float64{-70, -72, -75},
+		BandsMeasured:      true,
+		Hum50RMS:           -80,
+		HumMeasured:        true,
+	}
+
+	path := filepath.Join(t.TempDir(), "room.json")
+	if err := WriteNoiseProfile("/shows/ep1.wav", m, path); err != nil {
+		t.Fatalf("WriteNoiseProfile: %v", err)
+	}
+	saved, err := LoadNoiseProfile(path)
+	if err != nil {
+		t.Fatalf("LoadNoiseProfile: %v", err)
+	}
+	if saved.Source != "ep1.wav" || saved.SampleRate != 48000 || saved.Floor != -68 {
+		t.Errorf("saved = %s at %d Hz, floor %.1f", saved.Source, saved.SampleRate, saved.Floor)
+	}
+	p := saved.Profile
+	if p.Spectral.Centroid != 1800 || !p.Spectral.Found || len(p.BandNoise) != 3 || !p.BandsMeasured || p.Hum50RMS != -80 {
+		t.Errorf("profile did not survive the round trip: %+v", p)
+	}
+
+	if err := WriteNoiseProfile("ep2.wav", &AudioMeasurements{}, path); err == nil {
+		t.Error("WriteNoiseProfile with no room tone = nil error")
+	}
+}
+
+func TestLoadNoiseProfileRejectsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"not json":      "room tone",
+		"other format":  `{"format":"jivetalking-analysis","version":1,"noise_floor_dbfs":-60,"noise_profile":{}}`,
+		"newer version": `{"format":"jivetalking-noise-profile","version":2,"noise_floor_dbfs":-60,"noise_profile":{}}`,
+		"no profile":    `{"format":"jivetalking-noise-profile","version":1,"noise_floor_dbfs":-60}`,
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".json")
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadNoiseProfile(path); err == nil {
+			t.Errorf("%s: LoadNoiseProfile = nil error", name)
+		}
+	}
+}
+
+func TestApplyLoadedNoiseProfile(t *testing.T) {
+	iv, _, _ := roomToneSelectFixture()
+	saved := &SavedNoiseProfile{
+		Source:     "ep1.wav",
+		SampleRate: 44100,
+		Floor:      -40,
+		Profile:    &NoiseProfile{Start: time.Minute, Duration: 8 * time.Second, MeasuredNoiseFloor: -40, BandNoise: []float64{-50}, Pinned: true},
+	}
+
+	m := &AudioMeasurements{SampleRate: 48000}
+	detectVoiceActivity(m, iv, -70, analysisIntervalHop, axisMomentaryLUFS, defaultRoomToneWindow, nil, nil, nil)
+	detected := m.Noise.Floor
+
+	applyLoadedNoiseProfile(m, iv, nil, analysisIntervalHop, axisMomentaryLUFS, nil)
+	if m.Noise.Floor != detected {
+		t.Errorf("nil profile changed the floor to %.1f", m.Noise.Floor)
+	}
+
+	applyLoadedNoiseProfile(m, iv, saved, analysisIntervalHop, axisMomentaryLUFS, nil)
+	p := m.Regions.NoiseProfile
+	if m.Noise.Floor != -40 || m.Noise.FloorSource != "loaded" || p == nil || !p.Loaded {
+		t.Fatalf("Floor = %.1f (%s), profile %+v; want the loaded profile", m.Noise.Floor, m.Noise.FloorSource, p)
+	}
+	if p.Duration != 0 || p.Pinned || m.Regions.ElectedRoomToneSample != nil {
+		t.Errorf("loaded profile kept a region of another recording: %+v", p)
+	}
+	if saved.Profile.Duration == 0 {
+		t.Error("applying the profile changed the saved copy")
+	}
+
+	d := &AdaptiveDiagnostics{}
+	warnLoadedNoiseProfile(d, m)
+	if len(d.Warnings) != 1 || !strings.Contains(d.Warnings[0], "44100 Hz") || !strings.Contains(d.Warnings[0], "floor") {
+		t.Errorf("Warnings = %q, want the sample rate and floor mismatch", d.Warnings)
+	}
+}
+
+func TestSavedNoiseProfileLifted(t *testing.T) {
+	saved := &SavedNoiseProfile{Floor: -80, Profile: &NoiseProfile{MeasuredNoiseFloor: -80, BandNoise: []float64{-85}}}
+	lifted := saved.lifted(20)
+	if lifted.Floor != -60 || lifted.Profile.MeasuredNoiseFloor != -60 || lifted.Profile.BandNoise[0] != -65 {
+		t.Errorf("lifted = %.1f, %.1f, %v; want every level 20 dB up", lifted.Floor, lifted.Profile.MeasuredNoiseFloor, lifted.Profile.BandNoise)
+	}
+	if saved.Floor != -80 || saved.Profile.BandNoise[0] != -85 {
+		t.Error("lifted changed the source profile")
+	}
+}
@@ -0,0 +1,125 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// noiseStemPreFilters are the stages ahead of noise reduction in
+// Pass2FilterOrder. The stem runs them on both branches so the residual holds
+// only what the denoiser removed, not the rumble or ultrasonics the earlier
+// filters cut.
+var noiseStemPreFilters = []FilterID{
+	FilterDownmix,
+	FilterRumbleHighPass,
+	FilterBandlimitLowPass,
+}
+
+// generateNoiseStemPath names the noise stem beside the input. Like the
+// processed output it is always FLAC.
+// Example: /path/to/audio.wav → /path/to/audio-noise-stem.flac
+func generateNoiseStemPath(inputPath string) string {
+	dir := filepath.Dir(inputPath)
+	filename := filepath.Base(inputPath)
+	nameWithoutExt := strings.TrimSuffix(filename, filepath.Ext(filename))
+	return filepath.Join(dir, nameWithoutExt+"-noise-stem.flac")
+}
+
+// buildNoiseStemSpec builds the residual graph: the pre-NR stages, then a split
+// into a dry branch and a denoised branch. The denoised branch is polarity
+// inverted (aeval) and summed with the dry branch (amix, normalize=0), leaving
+// input - denoised. amix aligns the branches by timestamp, and anlmdn/afftdn
+// preserve timestamps, so the subtraction lines up sample for sample. Returns
+// "" when noise reduction is disabled: there is no residual to isolate.
+func (cfg *EffectiveFilterConfig) buildNoiseStemSpec() string {
+	nr := cfg.buildNoiseReductionFilter()
+	if nr == "" {
+		return ""
+	}
+
+	var pre []string
+	for _, id := range noiseStemPreFilters {
+		if spec := filterBuilders[id](cfg); spec != "" {
+			pre = append(pre, spec)
+		}
+	}
+	pre = append(pre, "asplit=2[dry][wet]")
+
+	// The downmix carries any quiet-input pre-gain; take it back off the
+	// residual as Pass 2 does.
+	post := cfg.buildRequiredOutputFormatFilter()
+	if cfg.Downmix.PreGainDB != 0 {
+		post = quietPreGainSpec(-cfg.Downmix.PreGainDB) + "," + post
+	}
+
+	return strings.Join(pre, ",") +
+		";[wet]" + nr + ",aeval=exprs=-val(ch):channel_layout=same[denoised]" +
+		";[dry][denoised]amix=inputs=2:normalize=0," + post
+}
+
+// writeNoiseStem renders the noise-reduction residual (--noise-stem) for
+// inputPath with the adapted config and publishes it named from anchor
+// (outputAnchor), beside the input unless --output moved it. It is a
+// separate decode of the input, so it does not touch the Pass 2 output.
+// Returns the published path.
+func writeNoiseStem(ctx context.Context, inputPath, anchor string, config *EffectiveFilterConfig) (string, error) {
+	spec := config.buildNoiseStemSpec()
+	if spec == "" {
+		return "", fmt.Errorf("noise reduction is disabled, no residual to write")
+	}
+
+	reader, _, err := audio.OpenAudioFile(inputPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer reader.Close()
+
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to create noise stem filter graph: %w", err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	stemPath := generateNoiseStemPath(anchor)
+	tempPath, err := processorCreateSiblingTempPath(anchor, "noise-stem")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(tempPath) }()
+
+	encoder, err := createOutputEncoder(tempPath, bufferSinkCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			filteredFrame.SetTimeBase(ffmpeg.AVBuffersinkGetTimeBase(bufferSinkCtx))
+			if err := encoder.WriteFrame(filteredFrame); err != nil {
+				return fmt.Errorf("failed to write frame: %w", err)
+			}
+			return nil
+		},
+	}); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return "", fmt.Errorf("failed to flush encoder: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to close encoder: %w", err)
+	}
+
+	if err := publishOutput(tempPath, stemPath); err != nil {
+		return "", err
+	}
+	return stemPath, nil
+}
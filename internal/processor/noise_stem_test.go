@@ -0,0 +1,57 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateNoiseStemPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"/path/to/audio.flac": "/path/to/audio-noise-stem.flac",
+		"/path/to/audio.wav":  "/path/to/audio-noise-stem.flac",
+		"episode.v2.mp3":      "episode.v2-noise-stem.flac",
+	} {
+		if got := generateNoiseStemPath(in); got != want {
+			t.Errorf("generateNoiseStemPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBuildNoiseStemSpec(t *testing.T) {
+	cfg := DefaultEffectiveFilterConfig()
+
+	spec := cfg.buildNoiseStemSpec()
+	nr := cfg.buildNoiseReductionFilter()
+
+	// Pre-NR stages run once, ahead of the split, so both branches share them.
+	split := strings.Index(spec, "asplit=2[dry][wet]")
+	if split < 0 {
+		t.Fatalf("spec missing the dry/wet split:\n%s", spec)
+	}
+	for _, pre := range []string{cfg.buildDownmixFilter(), cfg.buildRumbleHighpassFilter(), cfg.buildBandlimitLowPassFilter()} {
+		if i := strings.Index(spec, pre); i < 0 || i > split {
+			t.Errorf("pre-NR stage %q missing or after the split:\n%s", pre, spec)
+		}
+	}
+
+	// Only the wet branch is denoised, then inverted and summed with the dry.
+	if !strings.Contains(spec, "[wet]"+nr+",aeval=exprs=-val(ch):channel_layout=same[denoised]") {
+		t.Errorf("wet branch is not denoise + invert:\n%s", spec)
+	}
+	if !strings.Contains(spec, "[dry][denoised]amix=inputs=2:normalize=0,") {
+		t.Errorf("branches are not summed without normalisation:\n%s", spec)
+	}
+	if !strings.HasSuffix(spec, cfg.buildRequiredOutputFormatFilter()) {
+		t.Errorf("spec does not end in the encoder output format:\n%s", spec)
+	}
+	for _, post := range []string{"agate", "acompressor", "deesser"} {
+		if strings.Contains(spec, post) {
+			t.Errorf("spec contains post-NR stage %q; the stem isolates noise reduction only", post)
+		}
+	}
+
+	cfg.NoiseReduction.Enabled = false
+	if got := cfg.buildNoiseStemSpec(); got != "" {
+		t.Errorf("spec with noise reduction disabled = %q, want empty", got)
+	}
+}
@@ -33,6 +33,13 @@ const (
 	// See docs/Normalisation-Tuning.md for the corpus derivation.
 	minLimiterCeilingDB = -24.0 // dBTP
 
+	// defaultLimiterNoiseGuardDB is the default margin the levelling limiter's
+	// ceiling keeps above the Pass 2 room-tone peak (guardLimiterAboveNoise).
+	// Noise peaks sit well below it, so the limiter's gain reduction never
+	// tracks the noise between phrases. limiterNoiseGuardMaxDB bounds the option.
+	defaultLimiterNoiseGuardDB = 6.0 // dB
+	limiterNoiseGuardMaxDB     = 24.0
+
 	// brickwallTruePeakHeadroomDB is the inter-sample allowance (dB) subtracted
 	// from loudnorm's TargetTP to set the brickwall's sample-peak ceiling.
 	// See docs/Normalisation-Tuning.md for the corpus derivation.
@@ -487,11 +494,14 @@ type limiterPlan struct {
 	gainDB      float64
 	pass3Prefix string
 	filteredTP  float64 // Pass-2 filtered true peak (dBTP) the limiter acts on
+
+	noisePeakDB  float64 // Pass-2 room-tone peak plus pre-gain (dBFS); 0 when unmeasured
+	noiseGuarded bool    // Ceiling raised to stay guardDB above noisePeakDB
 }
 
-// diagnostics projects the plan's six limiter values into the exported
+// diagnostics projects the plan's limiter values into the exported
 // LimiterDiagnostics carried by NormalisationResult, so the result assigns them
-// in one step instead of copying six fields by hand.
+// in one step instead of copying each field by hand.
 func (p limiterPlan) diagnostics() LimiterDiagnostics {
 	return LimiterDiagnostics{
 		LimiterEnabled:    p.needed,
@@ -500,6 +510,8 @@ func (p limiterPlan) diagnostics() LimiterDiagnostics {
 		LimiterFilteredTP: p.filteredTP,
 		PreGainDB:         p.preGainDB,
 		LimiterClamped:    p.clamped,
+		NoisePeakDB:       p.noisePeakDB,
+		NoiseGuardRaised:  p.noiseGuarded,
 	}
 }
 
@@ -519,6 +531,7 @@ type loudnormApplicationResult struct {
 	finalMeasurements     *OutputMeasurements
 	loudnormStats         *LoudnormStats
 	regionMeasurementTime time.Duration
+	filterSpec            string
 }
 
 type loudnormApplicationPreparation struct {
@@ -526,6 +539,7 @@ type loudnormApplicationPreparation struct {
 	metadata      *audio.Metadata
 	tempPath      string
 	statsPath     string
+	filterSpec    string // The applied chain without the stats file, for NormalisationResult.FilterSpec
 	filterGraph   *ffmpeg.AVFilterGraph
 	bufferSrcCtx  *ffmpeg.AVFilterContext
 	bufferSinkCtx *ffmpeg.AVFilterContext
@@ -548,16 +562,45 @@ func planLimiterForLoudnorm(output *OutputMeasurements, config *EffectiveFilterC
 	if clamped {
 		ceilingDB = reDerivedCeiling
 	}
+	var noisePeakDB float64
+	var noiseGuarded bool
+	if needed {
+		ceilingDB, noisePeakDB, noiseGuarded = guardLimiterAboveNoise(
+			ceilingDB, preGainDB, output.RoomToneSample, loudnorm.LimiterNoiseGuardDB)
+	}
 
 	return limiterPlan{
-		preGainDB:   preGainDB,
-		ceilingDB:   ceilingDB,
-		needed:      needed,
-		clamped:     clamped,
-		gainDB:      loudnorm.TargetI - output.Loudness.OutputI,
-		pass3Prefix: buildPreLimiterPrefix(preGainDB, ceilingDB, needed),
-		filteredTP:  output.Loudness.OutputTP,
+		preGainDB:    preGainDB,
+		ceilingDB:    ceilingDB,
+		needed:       needed,
+		clamped:      clamped,
+		gainDB:       loudnorm.TargetI - output.Loudness.OutputI,
+		pass3Prefix:  buildPreLimiterPrefix(preGainDB, ceilingDB, needed),
+		filteredTP:   output.Loudness.OutputTP,
+		noisePeakDB:  noisePeakDB,
+		noiseGuarded: noiseGuarded,
+	}
+}
+
+// guardLimiterAboveNoise keeps the levelling limiter's ceiling at least guardDB
+// above the room-tone peak as the limiter sees it: the Pass 2 room-tone sample
+// peak plus any pre-gain. On a noisy recording that needs a lot of makeup, the
+// derived ceiling can fall to where the noise peaks reach it, and the limiter
+// then pumps the noise up and down between phrases. The ceiling is raised to the
+// guard instead (never above 0 dBFS); the brickwall still owns the delivered
+// peak. It returns the ceiling, the pre-limiter noise peak, and whether the
+// ceiling was raised. Without a measured room-tone peak, or with guardDB 0, the
+// ceiling is returned unchanged.
+func guardLimiterAboveNoise(ceilingDB, preGainDB float64, roomTone *RegionSample, guardDB float64) (float64, float64, bool) {
+	if guardDB <= 0 || roomTone == nil || !isFinite(roomTone.PeakLevel) || roomTone.PeakLevel >= 0 {
+		return ceilingDB, 0, false
+	}
+	noisePeakDB := roomTone.PeakLevel + preGainDB
+	guarded := min(noisePeakDB+guardDB, 0)
+	if ceilingDB >= guarded {
+		return ceilingDB, noisePeakDB, false
 	}
+	return guarded, noisePeakDB, true
 }
 
 // loudnormInternalTargetTP returns loudnorm's INTERNAL true-peak target, derived
@@ -637,12 +680,14 @@ func calculateLinearModeTarget(measuredI, measuredTP, desiredI, targetTP float64
 // six fields marshal under the same keys as before. limiterPlan.diagnostics()
 // produces it so the result fills these in one assignment.
 type LimiterDiagnostics struct {
-	LimiterEnabled    bool    `json:"limiter_enabled"` // True if pre-limiting was applied
-	LimiterCeiling    float64 `json:"ceiling_dbtp"`    // Ceiling in dBTP (only valid if LimiterEnabled)
-	LimiterGain       float64 `json:"gain_db"`         // Gain required that triggered limiting (dB)
-	LimiterFilteredTP float64 `json:"filtered_dbtp"`   // Pass-2 filtered true peak (dBTP) the limiter acts on
-	PreGainDB         float64 `json:"pre_gain_db"`     // Pre-gain amount in dB (0.0 when no pre-gain applied)
-	LimiterClamped    bool    `json:"limiter_clamped"` // True when calculateLimiterCeiling clamped ceiling to minimum
+	LimiterEnabled    bool    `json:"limiter_enabled"`    // True if pre-limiting was applied
+	LimiterCeiling    float64 `json:"ceiling_dbtp"`       // Ceiling in dBTP (only valid if LimiterEnabled)
+	LimiterGain       float64 `json:"gain_db"`            // Gain required that triggered limiting (dB)
+	LimiterFilteredTP float64 `json:"filtered_dbtp"`      // Pass-2 filtered true peak (dBTP) the limiter acts on
+	PreGainDB         float64 `json:"pre_gain_db"`        // Pre-gain amount in dB (0.0 when no pre-gain applied)
+	LimiterClamped    bool    `json:"limiter_clamped"`    // True when calculateLimiterCeiling clamped ceiling to minimum
+	NoisePeakDB       float64 `json:"noise_peak_dbfs"`    // Room-tone peak the limiter sees, pre-gain included (dBFS; 0 when unmeasured)
+	NoiseGuardRaised  bool    `json:"noise_guard_raised"` // True when the ceiling was raised to stay clear of the noise
 }
 
 // NormalisationResult contains the outcome of the normalisation pass.
@@ -660,10 +705,25 @@ type NormalisationResult struct {
 	// NOT serialised (json:"-"): the JSON record keeps the string-keyed
 	// LoudnormStats above as its parse target, so the schema is unchanged.
 	LoudnormParsed    *LoudnormMeasured `json:"-"`
-	RequestedTargetI  float64           `json:"requested_target_lufs"` // The target I that was requested (from config)
-	EffectiveTargetI  float64           `json:"effective_target_lufs"` // The target I actually used (may be lower to ensure linear mode)
-	LinearModeForced  bool              `json:"linear_mode_forced"`    // True if target was adjusted to force linear mode
-	ActualNormDynamic bool              `json:"actual_norm_dynamic"`   // True if loudnorm's reported normalization_type was "dynamic" (detective)
+	RequestedTargetI  float64           `json:"requested_target_lufs"`  // The target I that was requested (from config)
+	EffectiveTargetI  float64           `json:"effective_target_lufs"`  // The target I actually used (may be lower to ensure linear mode)
+	LinearModeForced  bool              `json:"linear_mode_forced"`     // True if target was adjusted to force linear mode
+	ActualNormDynamic bool              `json:"actual_norm_dynamic"`    // True if loudnorm's reported normalization_type was "dynamic" (detective), or dynamic mode was requested
+	LoudnormMode      string            `json:"loudnorm_mode"`          // Requested Pass 4 mode (--loudnorm-mode): "linear" or "dynamic"
+	SpeechTargetShift float64           `json:"speech_target_shift_lu"` // How far --speech-loudness lowered the gated target (speech minus gated loudness); 0 when off
+	// MeasurementEstimated is true when --skip-output-analysis replaced the Pass 3
+	// measurement with an estimate from Pass 2, so InputLUFS and InputTP are
+	// estimated rather than measured.
+	MeasurementEstimated bool `json:"measurement_estimated"`
+	// TargetMode is the quantity normalised: TargetModeLUFS, or TargetModeRMS under
+	// --target-rms. In RMS mode RequestedTargetI is the integrated loudness that
+	// puts the RMS level on TargetRMS.
+	TargetMode string  `json:"target_mode"`
+	TargetRMS  float64 `json:"target_rms_dbfs"` // --target-rms target (dBFS); 0 in LUFS mode
+	OutputRMS  float64 `json:"output_rms_dbfs"` // Final astats RMS level (dBFS)
+	// SpecCompliance is the pass/fail verdict against the --spec delivery target,
+	// from the final loudness and deliverable true peak; nil when no spec is set.
+	SpecCompliance *SpecCompliance `json:"spec_compliance,omitempty"`
 
 	// Limiter diagnostics (Pass 4 pre-limiting). The six limiter values live in
 	// the embedded LimiterDiagnostics (flattened into this JSON object); the Pass 3
@@ -677,6 +737,10 @@ type NormalisationResult struct {
 	// the record's `stages` map at RunRecord (2.4). Excluded here to avoid a nested
 	// duplicate of the final stage under `normalisation`.
 	FinalMeasurements *OutputMeasurements `json:"-"`
+
+	// FilterSpec is the Pass 4 filter chain as applied, less loudnorm's per-run
+	// stats_file, for the --emit-ffmpeg-command reproduction.
+	FilterSpec string `json:"-"`
 }
 
 // loudnormFellBackToDynamic reports whether loudnorm's stats say it ran in
@@ -798,6 +862,7 @@ func buildNormalisationResult(
 		Pass3FilterPrefix:     limiter.pass3Prefix,
 		RegionMeasurementTime: application.regionMeasurementTime,
 		FinalMeasurements:     application.finalMeasurements,
+		FilterSpec:            application.filterSpec,
 	}
 }
 
@@ -818,6 +883,41 @@ func applyNormalisationWithDeps(
 		return &NormalisationResult{Skipped: true}, nil
 	}
 
+	// --speech-loudness: the pauses pull the gated loudness below the speech, so
+	// aim the gated loudness that gap below the target. Every later step (limiter
+	// plan, Pass 3, Pass 4) works to the shifted target.
+	requestedTargetI := loudnorm.TargetI
+	speechShift, speechTargeted := speechTargetShift(loudnorm, outputMeasurements)
+	if speechTargeted {
+		shifted := *config
+		shifted.Loudnorm.TargetI -= speechShift
+		config = &shifted
+		loudnorm = config.Loudnorm
+		log.Logf("Speech-only loudness: speech sits %+.2f LU from the gated loudness; gated target %.2f LUFS",
+			speechShift, loudnorm.TargetI)
+	} else if loudnorm.SpeechOnly {
+		log.Logf("Speech-only loudness unavailable; normalising the gated integrated loudness")
+	}
+
+	// --target-rms: loudnorm's linear gain moves the RMS level and the integrated
+	// loudness by the same amount, so the RMS target becomes the loudness target
+	// that carries the Pass 2 RMS onto it.
+	targetMode := TargetModeLUFS
+	if loudnorm.TargetRMS != 0 {
+		if targetI, ok := rmsTargetLoudness(loudnorm.TargetRMS, outputMeasurements); ok {
+			rms := *config
+			rms.Loudnorm.TargetI = targetI
+			config = &rms
+			loudnorm = config.Loudnorm
+			requestedTargetI = targetI
+			targetMode = TargetModeRMS
+			speechShift = 0 // the RMS target replaces any speech-only target
+			log.Logf("RMS target %.2f dBFS: integrated loudness target %.2f LUFS", loudnorm.TargetRMS, targetI)
+		} else {
+			log.Logf("Pass 2 RMS unavailable; normalising the integrated loudness to %.2f LUFS", loudnorm.TargetI)
+		}
+	}
+
 	progress := normProgressEmitter{callback: progressCallback, duration: normaliseDuration(inputMeasurements)}
 
 	// Signal pass start - first we measure, then we apply
@@ -825,15 +925,30 @@ func applyNormalisationWithDeps(
 
 	// Compute the limiter prefix from Pass 2 ebur128 measurements (before Pass 3).
 	// This allows Pass 3 to measure through the same volume+alimiter prefix
-	// that Pass 4 will apply, closing the measurement mismatch.
-	limiter := planLimiterForLoudnorm(outputMeasurements, config)
+	// that Pass 4 will apply, closing the measurement mismatch. The prefix only
+	// exists to keep loudnorm linear, so dynamic mode runs without it.
+	var limiter limiterPlan
+	if loudnorm.Linear {
+		limiter = planLimiterForLoudnorm(outputMeasurements, config)
+	}
 
 	// Pass 3: Run loudnorm measurement pass on Pass 2 output.
 	// When a prefix is active, loudnorm measures the post-limiter signal,
 	// so its InputI/InputTP already reflect pre-gain and limiting.
-	measurement, err := measureWithLoudnorm(ctx, inputPath, config, limiter.pass3Prefix, progressCallback, deps)
-	if err != nil {
-		return nil, fmt.Errorf("loudnorm measurement pass failed: %w", err)
+	// --skip-output-analysis estimates the same figures from Pass 2 instead.
+	measurement, estimated := estimateLoudnormMeasurement(loudnorm, outputMeasurements, limiter)
+	if estimated {
+		log.Logf("Pass 3 skipped: loudnorm input estimated from Pass 2 (I %.2f LUFS, TP %.2f dBTP)",
+			measurement.InputI, measurement.InputTP)
+	} else {
+		if loudnorm.EstimateMeasurement {
+			log.Logf("Pass 2 loudness unavailable; running the Pass 3 measurement")
+		}
+		var err error
+		measurement, err = measureWithLoudnorm(ctx, inputPath, config, limiter.pass3Prefix, progressCallback, deps)
+		if err != nil {
+			return nil, fmt.Errorf("loudnorm measurement pass failed: %w", err)
+		}
 	}
 
 	// Validate measurements are usable
@@ -848,35 +963,13 @@ func applyNormalisationWithDeps(
 	// carries.
 	progress.measuringDoneNormalisingStart(limiter)
 
-	// Calculate effective target I that ensures linear mode (no dynamic fallback)
-	// Pass 3 measured through the same prefix chain as Pass 4, so
-	// measurement.InputI and measurement.InputTP already reflect the
-	// post-limiter signal. No effectiveMeasuredI/effectiveTP adjustment needed.
-	// Guard loudnorm against ITS OWN per-file internal TP target, not the brickwall
-	// ceiling. The downstream brickwall (pinned to loudnorm.TargetTP) owns real
-	// true-peak delivery. loudnormInternalTargetTP derives the internal TP from this
-	// file's measured TP/I, so the measuredTP/measuredI terms cancel in the guard and
-	// maxLinearTargetI collapses to TargetI + measurementCushionDB: the cap is inert
-	// by construction and every file reaches full −16.0 LUFS in linear mode.
-	effectiveTargetI, _, linearPossible := calculateLinearModeTarget(
-		measurement.InputI,
-		measurement.InputTP,
-		loudnorm.TargetI,
-		loudnormInternalTargetTP(loudnorm, measurement.InputTP, measurement.InputI),
-	)
-
-	// Bind the gain cap: the realised linear scalar gain is the CAPPED makeup
-	// (effectiveTargetI - measured_I), not loudnorm's own target_offset. On a
-	// high-crest stem the cap lowers effectiveTargetI below targetI, so the
-	// matching offset pins the final true peak at targetTP by construction. On a
-	// safe stem effectiveTargetI == targetI and this equals the planned makeup.
-	offset := effectiveTargetI - measurement.InputI
+	effectiveTargetI, offset, linearPossible := planLoudnormTarget(loudnorm, measurement)
 
 	// Store the effective target in config for loudnorm filter construction
 	effectiveConfig := *config
 	effectiveConfig.Loudnorm.TargetI = effectiveTargetI
 
-	// Pass 4: Apply loudnorm with linear=true and the measurements
+	// Pass 4: Apply loudnorm (linear or dynamic, per config) with the measurements
 	application, err := applyLoudnormAndMeasure(ctx, loudnormApplicationRequest{
 		inputPath:         inputPath,
 		config:            &effectiveConfig,
@@ -900,13 +993,108 @@ func applyNormalisationWithDeps(
 	// Detective check: the linear-mode guarantee is preventive only. If loudnorm
 	// reports it actually ran in dynamic mode, the output is 192kHz-derived and
 	// not linearly normalised. Warn and record the actual result for the report.
-	actualNormDynamic := loudnormFellBackToDynamic(application.loudnormStats, inputPath, log)
+	// In dynamic mode that is the requested outcome, not a fallback.
+	actualNormDynamic := !loudnorm.Linear ||
+		loudnormFellBackToDynamic(application.loudnormStats, inputPath, log)
 
-	return buildNormalisationResult(
+	result := buildNormalisationResult(
 		measurement, application, limiter,
-		offset, loudnorm.TargetI, effectiveTargetI,
+		offset, requestedTargetI, effectiveTargetI,
 		withinTarget, linearPossible, actualNormDynamic,
-	), nil
+	)
+	result.LoudnormMode = loudnorm.Mode()
+	result.SpeechTargetShift = speechShift
+	result.MeasurementEstimated = estimated
+	result.SpecCompliance = checkLoudnessSpec(loudnorm.Spec, result.OutputLUFS, result.OutputTP)
+	result.TargetMode = targetMode
+	if targetMode == TargetModeRMS {
+		result.TargetRMS = loudnorm.TargetRMS
+	}
+	if application.finalMeasurements != nil {
+		result.OutputRMS = application.finalMeasurements.Dynamics.RMSLevel
+	}
+	return result, nil
+}
+
+// rmsTargetLoudness converts an RMS target into the integrated loudness target
+// that reaches it: the Pass 2 loudness moved by the gap between the target and
+// the Pass 2 RMS level. ok is false when Pass 2 has no loudness or RMS reading.
+func rmsTargetLoudness(targetRMS float64, output *OutputMeasurements) (targetI float64, ok bool) {
+	if output == nil {
+		return 0, false
+	}
+	rms := output.Dynamics.RMSLevel
+	loudness := output.Loudness.OutputI
+	if rms == 0 || loudness == 0 || !isFinite(rms) || !isFinite(loudness) {
+		return 0, false
+	}
+	return loudness + (targetRMS - rms), true
+}
+
+// estimateLoudnormMeasurement stands in for the Pass 3 measurement under
+// --skip-output-analysis. Pass 3 reads the Pass 2 output through the limiter
+// prefix, so the estimate is the Pass 2 ebur128 figures moved by the pre-gain,
+// with the true peak held to the limiter ceiling when the limiter runs. The
+// limiter only touches the peaks, so the integrated loudness it removes is
+// ignored, and loudnorm's own target_offset is taken as zero. ok is false when
+// the option is off or Pass 2 has no loudness to estimate from; the caller then
+// measures as usual.
+func estimateLoudnormMeasurement(loudnorm LoudnormConfig, output *OutputMeasurements, limiter limiterPlan) (measurement *LoudnormMeasurement, ok bool) {
+	if !loudnorm.EstimateMeasurement || output == nil {
+		return nil, false
+	}
+	l := output.Loudness
+	if l.OutputI == 0 || !isFinite(l.OutputI) || !isFinite(l.OutputTP) {
+		return nil, false
+	}
+
+	tp := l.OutputTP + limiter.preGainDB
+	if limiter.needed {
+		tp = min(tp, limiter.ceilingDB)
+	}
+	return &LoudnormMeasurement{
+		InputI:      l.OutputI + limiter.preGainDB,
+		InputTP:     tp,
+		InputLRA:    l.OutputLRA,
+		InputThresh: l.OutputThresh + limiter.preGainDB,
+	}, true
+}
+
+// planLoudnormTarget picks the Pass 4 target and offset for the configured mode.
+//
+// Linear mode calculates the effective target I that ensures linear mode (no
+// dynamic fallback). Pass 3 measured through the same prefix chain as Pass 4, so
+// measurement.InputI and measurement.InputTP already reflect the post-limiter
+// signal. No effectiveMeasuredI/effectiveTP adjustment needed. Guard loudnorm
+// against ITS OWN per-file internal TP target, not the brickwall ceiling. The
+// downstream brickwall (pinned to loudnorm.TargetTP) owns real true-peak
+// delivery. loudnormInternalTargetTP derives the internal TP from this file's
+// measured TP/I, so the measuredTP/measuredI terms cancel in the guard and
+// maxLinearTargetI collapses to TargetI + measurementCushionDB: the cap is inert
+// by construction and every file reaches full −16.0 LUFS in linear mode.
+//
+// It then binds the gain cap: the realised linear scalar gain is the CAPPED
+// makeup (effectiveTargetI - measured_I), not loudnorm's own target_offset. On a
+// high-crest stem the cap lowers effectiveTargetI below targetI, so the matching
+// offset pins the final true peak at targetTP by construction. On a safe stem
+// effectiveTargetI == targetI and this equals the planned makeup.
+//
+// Dynamic mode is loudnorm's standard two-pass use: the requested target and
+// Pass 3's own target_offset go straight into the second pass, and loudnorm's
+// dynamic gain does the rest. There is nothing to force, so linearPossible is
+// reported true.
+func planLoudnormTarget(loudnorm LoudnormConfig, measurement *LoudnormMeasurement) (effectiveTargetI, offset float64, linearPossible bool) {
+	if !loudnorm.Linear {
+		return loudnorm.TargetI, measurement.TargetOffset, true
+	}
+
+	effectiveTargetI, _, linearPossible = calculateLinearModeTarget(
+		measurement.InputI,
+		measurement.InputTP,
+		loudnorm.TargetI,
+		loudnormInternalTargetTP(loudnorm, measurement.InputTP, measurement.InputI),
+	)
+	return effectiveTargetI, effectiveTargetI - measurement.InputI, linearPossible
 }
 
 // applyLoudnormAndMeasure applies loudnorm's second pass to the audio file and measures the result.
@@ -953,7 +1141,9 @@ func applyLoudnormAndMeasure(ctx context.Context, request loudnormApplicationReq
 
 	stats := freeGraphAndReadStats()
 
-	return finalizeLoudnormApplicationResult(ctx, request, execution, stats, log), nil
+	result := finalizeLoudnormApplicationResult(ctx, request, execution, stats, prep.metadata.SampleRate, log)
+	result.filterSpec = prep.filterSpec
+	return result, nil
 }
 
 func prepareLoudnormApplication(ctx context.Context, request loudnormApplicationRequest, deps loudnormDeps) (*loudnormApplicationPreparation, error) {
@@ -1005,10 +1195,18 @@ func prepareLoudnormApplication(ctx context.Context, request loudnormApplication
 	}
 
 	return &loudnormApplicationPreparation{
-		reader:        reader,
-		metadata:      metadata,
-		tempPath:      tempPath,
-		statsPath:     statsPath,
+		reader:    reader,
+		metadata:  metadata,
+		tempPath:  tempPath,
+		statsPath: statsPath,
+		filterSpec: buildLoudnormFilterSpec(
+			request.config,
+			request.measurement,
+			request.offset,
+			request.limiter,
+			metadata.SampleRate,
+			"",
+		),
 		filterGraph:   filterGraph,
 		bufferSrcCtx:  bufferSrcCtx,
 		bufferSinkCtx: bufferSinkCtx,
@@ -1024,6 +1222,7 @@ func executeAndPublishLoudnormApplication(
 	deps loudnormDeps,
 ) (*loudnormApplicationExecutionResult, error) {
 	result := &loudnormApplicationExecutionResult{}
+	result.acc.speech = newSpeechLoudnessAccumulator(request.inputMeasurements)
 
 	// failPublish captures the in-graph loudnorm stats and removes the temp file on
 	// any publish-failure path, returning result + the wrapped error. encoderClosed
@@ -1123,6 +1322,9 @@ func newLoudnormApplicationFrameLoop(
 
 			// Extract validation measurements using Pass 2's function
 			extractOutputFrameMetadata(filteredFrame.Metadata(), acc)
+			if acc.speech != nil {
+				acc.speech.observeFrame(filteredFrame)
+			}
 
 			// Encode frame
 			if err := encoder.WriteFrame(filteredFrame); err != nil {
@@ -1139,6 +1341,7 @@ func finalizeLoudnormApplicationResult(
 	request loudnormApplicationRequest,
 	execution *loudnormApplicationExecutionResult,
 	stats *LoudnormStats,
+	measuredRate int,
 	log debugLogger,
 ) *loudnormApplicationResult {
 	finalMeasurements, regionMeasurementTime := finalizeLoudnormOutputMeasurements(
@@ -1149,9 +1352,17 @@ func finalizeLoudnormApplicationResult(
 		log,
 	)
 
+	// The Pass-4 meter runs at the source rate through ebur128's fixed 192 kHz
+	// oversampler. Where that is not a 4x meter on the delivered rate, the
+	// reported true peak is re-measured on the published file.
+	finalTP := execution.acc.ebur128OutputTP
+	if tp, ok := measureDeliverableTruePeak(ctx, request.inputPath, measuredRate, log); ok {
+		finalTP = tp
+	}
+
 	return &loudnormApplicationResult{
 		finalLUFS:             execution.acc.ebur128OutputI,
-		finalTP:               execution.acc.ebur128OutputTP,
+		finalTP:               finalTP,
 		finalMeasurements:     finalMeasurements,
 		loudnormStats:         stats,
 		regionMeasurementTime: regionMeasurementTime,
@@ -1322,13 +1533,16 @@ func buildLoudnormFilterSpec(config *EffectiveFilterConfig, measurement *Loudnor
 	// (the brickwall already owns delivered loudness), so it uses the shared prefix
 	// without the target suffix Pass 2 appends.
 	filters = append(filters, astatsAnalysisSpec)
-	filters = append(filters, aspectralstatsAnalysisSpec)
+	if spec := withSpectralStats(aspectralstatsAnalysisSpec); spec != "" {
+		filters = append(filters, spec)
+	}
 	filters = append(filters, ebur128AnalysisSpecPrefix)
 
-	// 8. Resample back to output format (44.1kHz/s16/mono)
+	// 8. Resample back to output format (44.1kHz/s16, mono or dual-mono stereo)
 	// Required for the f64->s16 conversion ebur128 forces (output format f64, not a
-	// rate change); encoder expects s16 at 44.1kHz
-	filters = append(filters, config.buildRequiredOutputFormatFilter())
+	// rate change); encoder expects s16 at 44.1kHz. The layout change sits after
+	// the measurement filters so the final measurements stay on the mono programme.
+	filters = append(filters, config.buildFinalOutputFormatFilter())
 
 	return strings.Join(filters, ",")
 }
@@ -1019,6 +1019,129 @@ func TestApplyNormalisationProgressCadenceGuard(t *testing.T) {
 	requireNoLoudnormStatsFiles(t, testFile)
 }
 
+// TestApplyNormalisationEstimatedMeasurementSkipsPass3 asserts that
+// --skip-output-analysis runs only the Pass 4 graph, plans from the Pass 2
+// loudness, and marks the result as estimated.
+func TestApplyNormalisationEstimatedMeasurementSkipsPass3(t *testing.T) {
+	t.Parallel()
+
+	testFile := generateLoudnormApplicationTestAudio(t)
+	deps := defaultLoudnormDeps()
+	deps.setupFilterGraph = func(
+		*ffmpeg.AVCodecContext,
+		string,
+	) (*ffmpeg.AVFilterGraph, *ffmpeg.AVFilterContext, *ffmpeg.AVFilterContext, error) {
+		return nil, nil, nil, nil
+	}
+	deps.createEncoder = func(
+		string,
+		*ffmpeg.AVFilterContext,
+	) (loudnormOutputEncoder, error) {
+		return &loudnormTestEncoder{}, nil
+	}
+	deps.rename = func(oldPath, _ string) error {
+		return os.Remove(oldPath)
+	}
+
+	var runCalls int
+	deps.runFilterGraph = func(
+		_ context.Context,
+		_ *audio.Reader,
+		_, _ *ffmpeg.AVFilterContext,
+		config FrameLoopConfig,
+	) error {
+		runCalls++
+		frame := ffmpeg.AVFrameAlloc()
+		defer ffmpeg.AVFrameFree(&frame)
+		frame.SetNbSamples(44100)
+		if err := config.OnFrame(frame, frame); err != nil {
+			return err
+		}
+		writeLoudnormStatsForInput(t, testFile, loudnormCaptureTestJSON)
+		return nil
+	}
+
+	config := defaultNormalisationTestConfig()
+	config.Loudnorm.EstimateMeasurement = true
+	result, err := applyNormalisationWithDeps(
+		context.Background(),
+		testFile,
+		config,
+		&OutputMeasurements{Loudness: OutputLoudnessMetrics{OutputI: -20.0, OutputTP: -10.0, OutputThresh: -30.0}},
+		nil,
+		nil,
+		nil,
+		deps,
+	)
+	if err != nil {
+		t.Fatalf("ApplyNormalisation() error = %v", err)
+	}
+	if runCalls != 1 {
+		t.Fatalf("loudnorm run calls = %d, want 1 (Pass 4 only)", runCalls)
+	}
+	if !result.MeasurementEstimated {
+		t.Error("MeasurementEstimated = false, want true")
+	}
+	if result.InputLUFS != -20.0 {
+		t.Errorf("InputLUFS = %.2f, want the Pass 2 estimate -20.00", result.InputLUFS)
+	}
+	requireNoLoudnormTempFiles(t, testFile)
+	requireNoLoudnormStatsFiles(t, testFile)
+}
+
+func TestEstimateLoudnormMeasurement(t *testing.T) {
+	output := &OutputMeasurements{Loudness: OutputLoudnessMetrics{
+		OutputI: -30.0, OutputTP: -8.0, OutputLRA: 6.0, OutputThresh: -40.0,
+	}}
+	on := LoudnormConfig{EstimateMeasurement: true}
+
+	t.Run("off measures", func(t *testing.T) {
+		if _, ok := estimateLoudnormMeasurement(LoudnormConfig{}, output, limiterPlan{}); ok {
+			t.Error("estimated without --skip-output-analysis")
+		}
+	})
+	t.Run("no Pass 2 loudness measures", func(t *testing.T) {
+		if _, ok := estimateLoudnormMeasurement(on, &OutputMeasurements{}, limiterPlan{}); ok {
+			t.Error("estimated without Pass 2 loudness")
+		}
+		if _, ok := estimateLoudnormMeasurement(on, nil, limiterPlan{}); ok {
+			t.Error("estimated without Pass 2 measurements")
+		}
+	})
+	t.Run("pre-gain and ceiling", func(t *testing.T) {
+		m, ok := estimateLoudnormMeasurement(on, output, limiterPlan{preGainDB: 2.0, ceilingDB: -12.0, needed: true})
+		if !ok {
+			t.Fatal("no estimate")
+		}
+		want := LoudnormMeasurement{InputI: -28.0, InputTP: -12.0, InputLRA: 6.0, InputThresh: -38.0}
+		if *m != want {
+			t.Errorf("estimate = %+v, want %+v", *m, want)
+		}
+	})
+	t.Run("no limiter keeps the lifted peak", func(t *testing.T) {
+		m, _ := estimateLoudnormMeasurement(on, output, limiterPlan{})
+		if m.InputTP != -8.0 || m.InputI != -30.0 {
+			t.Errorf("estimate = %+v, want Pass 2 I -30 and TP -8 unchanged", *m)
+		}
+	})
+}
+
+func TestRmsTargetLoudness(t *testing.T) {
+	output := &OutputMeasurements{
+		Loudness: OutputLoudnessMetrics{OutputI: -24.0},
+		Dynamics: DynamicsMetrics{RMSLevel: -27.5},
+	}
+	if got, ok := rmsTargetLoudness(-20.0, output); !ok || got != -16.5 {
+		t.Errorf("rmsTargetLoudness(-20) = %v, %v; want -16.5, true", got, ok)
+	}
+	if _, ok := rmsTargetLoudness(-20.0, &OutputMeasurements{}); ok {
+		t.Error("converted without a Pass 2 RMS reading")
+	}
+	if _, ok := rmsTargetLoudness(-20.0, nil); ok {
+		t.Error("converted without Pass 2 measurements")
+	}
+}
+
 // TestLoudnormInternalTargetTPCancellation asserts the load-bearing invariant of
 // the per-file internal TP: feeding loudnormInternalTargetTP's output back into
 // calculateLinearModeTarget's maxLinearTargetI arithmetic, the measuredTP/measuredI
@@ -1469,6 +1592,32 @@ func TestBindingGainCapOnHighCrestInput(t *testing.T) {
 	}
 }
 
+func TestPlanLoudnormTarget(t *testing.T) {
+	measurement := &LoudnormMeasurement{InputI: -24.0, InputTP: -6.0, InputLRA: 7.0, InputThresh: -34.0, TargetOffset: 0.4}
+	loudnorm := DefaultFilterConfig().Loudnorm
+
+	t.Run("linear binds the capped makeup", func(t *testing.T) {
+		effectiveI, offset, _ := planLoudnormTarget(loudnorm, measurement)
+		wantI, _, _ := calculateLinearModeTarget(measurement.InputI, measurement.InputTP, loudnorm.TargetI,
+			loudnormInternalTargetTP(loudnorm, measurement.InputTP, measurement.InputI))
+		if effectiveI != wantI || offset != wantI-measurement.InputI {
+			t.Errorf("linear = (%.2f, %.2f), want (%.2f, %.2f)", effectiveI, offset, wantI, wantI-measurement.InputI)
+		}
+	})
+
+	t.Run("dynamic passes the requested target and loudnorm's offset", func(t *testing.T) {
+		loudnorm.Linear = false
+		effectiveI, offset, linearPossible := planLoudnormTarget(loudnorm, measurement)
+		if effectiveI != loudnorm.TargetI || offset != measurement.TargetOffset || !linearPossible {
+			t.Errorf("dynamic = (%.2f, %.2f, %v), want (%.2f, %.2f, true)",
+				effectiveI, offset, linearPossible, loudnorm.TargetI, measurement.TargetOffset)
+		}
+		if loudnorm.Mode() != LoudnormModeDynamic {
+			t.Errorf("Mode() = %q, want %q", loudnorm.Mode(), LoudnormModeDynamic)
+		}
+	})
+}
+
 func TestBuildLoudnormFilterSpec_PreGain(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -2303,3 +2452,34 @@ func TestPlanLimiterForLoudnormMatchesInlineCalculation(t *testing.T) {
 		})
 	}
 }
+
+// TestPlanLimiterNoiseGuard: a noisy file needing heavy makeup derives a
+// ceiling close to its room-tone peak; the guard lifts it clear, and a clean
+// file or a zero guard leaves the derived ceiling alone.
+func TestPlanLimiterNoiseGuard(t *testing.T) {
+	output := func(noisePeak float64) *OutputMeasurements {
+		return &OutputMeasurements{
+			Loudness:       OutputLoudnessMetrics{OutputI: -30, OutputTP: -6},
+			RoomToneSample: &RegionSample{PeakLevel: noisePeak},
+		}
+	}
+	config := defaultNormalisationTestConfig()
+
+	// Derived ceiling: -1 - (-16 - -30) = -15 dBTP.
+	plan := planLimiterForLoudnorm(output(-18), config)
+	if !plan.noiseGuarded || math.Abs(plan.ceilingDB-(-18+defaultLimiterNoiseGuardDB)) > 0.01 {
+		t.Errorf("noisy plan ceiling = %.2f (guarded %v), want %.2f", plan.ceilingDB, plan.noiseGuarded, -18+defaultLimiterNoiseGuardDB)
+	}
+	if d := plan.diagnostics(); !d.NoiseGuardRaised || d.NoisePeakDB != -18 {
+		t.Errorf("diagnostics = %+v, want the raise and the -18 dBFS noise peak", d)
+	}
+
+	if plan := planLimiterForLoudnorm(output(-50), config); plan.noiseGuarded || math.Abs(plan.ceilingDB+15) > 0.01 {
+		t.Errorf("clean plan ceiling = %.2f (guarded %v), want -15.00 unguarded", plan.ceilingDB, plan.noiseGuarded)
+	}
+
+	config.Loudnorm.LimiterNoiseGuardDB = 0
+	if plan := planLimiterForLoudnorm(output(-18), config); plan.noiseGuarded {
+		t.Error("guard 0 still raised the ceiling")
+	}
+}
@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Delivered file formats (--output-format). Both are lossless: the loudness
+// and true peak measured on the render hold for the delivered file. The
+// passes themselves always write FLAC; WAV is a final re-wrap of the same
+// samples (encodeOutputFormat).
+const (
+	OutputFormatFLAC = "flac"
+	OutputFormatWAV  = "wav"
+)
+
+// SetOutputFormat selects the delivered file format: OutputFormatFLAC (the
+// default) or OutputFormatWAV.
+func (cfg *BaseFilterConfig) SetOutputFormat(format string) error {
+	switch format {
+	case OutputFormatFLAC, OutputFormatWAV:
+		cfg.OutputFormat = format
+		return nil
+	}
+	return fmt.Errorf("output format %q is not %s or %s", format, OutputFormatFLAC, OutputFormatWAV)
+}
+
+// outputExt is the delivered file's extension, with its dot.
+func (cfg *BaseFilterConfig) outputExt() string {
+	if cfg.OutputFormat == OutputFormatWAV {
+		return ".wav"
+	}
+	return ".flac"
+}
+
+// encodeOutputFormat rewrites the finished FLAC at path in format, ahead of
+// publishing it. FLAC needs nothing; WAV copies the decoded samples, at their
+// bit depth, into PCM.
+func encodeOutputFormat(ctx context.Context, path, format string) error {
+	if format == "" || format == OutputFormatFLAC {
+		return nil
+	}
+
+	reader, _, err := audio.OpenAudioFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to open output: %w", err)
+	}
+	defer reader.Close()
+
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), "anull")
+	if err != nil {
+		return fmt.Errorf("failed to create %s filter graph: %w", format, err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	tempPath, err := createSiblingTempPathSuffix(path, format, ".tmp."+format)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tempPath) }()
+
+	encoder, err := createFormatEncoder(tempPath, format, bufferSinkCtx)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			filteredFrame.SetTimeBase(ffmpeg.AVBuffersinkGetTimeBase(bufferSinkCtx))
+			if err := encoder.WriteFrame(filteredFrame); err != nil {
+				return fmt.Errorf("failed to write frame: %w", err)
+			}
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return fmt.Errorf("failed to flush encoder: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to close encoder: %w", err)
+	}
+	return publishOutput(tempPath, path)
+}
@@ -0,0 +1,174 @@
+package processor
+
+import (
+	"math"
+	"unsafe"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+)
+
+// Polarity detection. An inverted mic or cable sounds the same on its own but
+// cancels against the other tracks of a multitrack mix. Voiced speech is driven
+// by the glottal closures, which a correctly wired chain records as sharp
+// negative-going excitation peaks. A pre-emphasis filter whitens the waveform
+// towards that excitation, so the skewness of the pre-emphasised speech is
+// negative on a normal recording and positive on an inverted one. The
+// heuristic only reads speech, and only calls a verdict on a clear asymmetry.
+const (
+	// polarityPreEmphasis is the first-order pre-emphasis coefficient, the
+	// usual speech value.
+	polarityPreEmphasis = 0.97
+
+	// polaritySkewThreshold is the smallest skewness magnitude that calls a
+	// verdict; weaker asymmetry reads as undetermined.
+	polaritySkewThreshold = 0.2
+
+	// polarityMinSpeechIntervals is the least speech (40 x 250 ms = 10 s)
+	// the skewness is trusted over.
+	polarityMinSpeechIntervals = 40
+)
+
+// Polarity verdicts.
+const (
+	PolarityNormal       = "normal"
+	PolarityInverted     = "inverted"
+	PolarityUndetermined = "undetermined"
+)
+
+// PolarityAnalysis is the Pass 1 polarity reading over the speech regions.
+type PolarityAnalysis struct {
+	Skewness float64 `json:"residual_skewness"` // Skewness of the pre-emphasised speech
+	Verdict  string  `json:"verdict"`           // PolarityNormal, PolarityInverted or PolarityUndetermined
+}
+
+// polarityMoments accumulates the raw moment sums of the pre-emphasised first
+// channel. The first sample after a reset only primes the filter.
+type polarityMoments struct {
+	n, s1, s2, s3 float64
+	prev          float64
+	primed        bool
+}
+
+// add feeds one sample through the pre-emphasis filter.
+func (m *polarityMoments) add(x float64) {
+	if m.primed {
+		d := x - polarityPreEmphasis*m.prev
+		m.n++
+		m.s1 += d
+		m.s2 += d * d
+		m.s3 += d * d * d
+	}
+	m.prev = x
+	m.primed = true
+}
+
+// merge adds another accumulator's sums.
+func (m *polarityMoments) merge(o polarityMoments) {
+	m.n += o.n
+	m.s1 += o.s1
+	m.s2 += o.s2
+	m.s3 += o.s3
+}
+
+// skewness is the sample skewness of the accumulated values. ok is false with
+// no samples or no variance.
+func (m *polarityMoments) skewness() (skew float64, ok bool) {
+	if m.n == 0 {
+		return 0, false
+	}
+	mean := m.s1 / m.n
+	variance := m.s2/m.n - mean*mean
+	if variance <= 0 {
+		return 0, false
+	}
+	third := m.s3/m.n - 3*mean*m.s2/m.n + 2*mean*mean*mean
+	return third / math.Pow(variance, 1.5), true
+}
+
+// addFrame feeds the first channel of a decoded input frame. The S16, FLT, S32
+// and DBL formats are read, interleaved or planar; others are skipped.
+func (m *polarityMoments) addFrame(frame *ffmpeg.AVFrame) {
+	if frame == nil || frame.NbSamples() == 0 {
+		return
+	}
+	sampleFmt := ffmpeg.AVSampleFormat(frame.Format()) //nolint:gosec // AVSampleFormat values fit in int32
+	stride := frame.ChLayout().NbChannels()
+	switch sampleFmt {
+	case ffmpeg.AVSampleFmtS16P, ffmpeg.AVSampleFmtFltp, ffmpeg.AVSampleFmtS32P, ffmpeg.AVSampleFmtDblp:
+		stride = 1
+	}
+	count := frame.NbSamples() * stride
+	dataPtr := frame.Data().Get(0)
+	if dataPtr == nil || stride < 1 {
+		return
+	}
+
+	switch sampleFmt {
+	case ffmpeg.AVSampleFmtS16, ffmpeg.AVSampleFmtS16P:
+		samples := unsafe.Slice((*int16)(dataPtr), count)
+		for i := 0; i < count; i += stride {
+			m.add(float64(samples[i]) / 32768.0)
+		}
+	case ffmpeg.AVSampleFmtFlt, ffmpeg.AVSampleFmtFltp:
+		samples := unsafe.Slice((*float32)(dataPtr), count)
+		for i := 0; i < count; i += stride {
+			m.add(float64(samples[i]))
+		}
+	case ffmpeg.AVSampleFmtS32, ffmpeg.AVSampleFmtS32P:
+		samples := unsafe.Slice((*int32)(dataPtr), count)
+		for i := 0; i < count; i += stride {
+			m.add(float64(samples[i]) / 2147483648.0)
+		}
+	case ffmpeg.AVSampleFmtDbl, ffmpeg.AVSampleFmtDblp:
+		samples := unsafe.Slice((*float64)(dataPtr), count)
+		for i := 0; i < count; i += stride {
+			m.add(samples[i])
+		}
+	}
+}
+
+// analysePolarity reads the polarity from the intervals that start inside a
+// speech region. Returns nil with less than polarityMinSpeechIntervals of
+// speech.
+func analysePolarity(intervals []IntervalSample, regions []SpeechRegion) *PolarityAnalysis {
+	var total polarityMoments
+	speech := 0
+	for _, iv := range intervals {
+		if inSpeechRegion(regions, iv.Timestamp) {
+			total.merge(iv.polarity)
+			speech++
+		}
+	}
+	if speech < polarityMinSpeechIntervals {
+		return nil
+	}
+	skew, ok := total.skewness()
+	if !ok {
+		return nil
+	}
+
+	verdict := PolarityUndetermined
+	switch {
+	case skew <= -polaritySkewThreshold:
+		verdict = PolarityNormal
+	case skew >= polaritySkewThreshold:
+		verdict = PolarityInverted
+	}
+	return &PolarityAnalysis{Skewness: skew, Verdict: verdict}
+}
+
+// tunePolarity inverts the chain under --fix-polarity when the speech reads
+// inverted. Without the option an inverted reading only adds a warning.
+func tunePolarity(config *EffectiveFilterConfig, diagnostics *AdaptiveDiagnostics, measurements *AudioMeasurements, fix bool) {
+	if measurements == nil || measurements.Regions.Polarity == nil ||
+		measurements.Regions.Polarity.Verdict != PolarityInverted {
+		return
+	}
+	if !fix {
+		diagnostics.Warnings = append(diagnostics.Warnings,
+			"speech polarity reads inverted; --fix-polarity would invert it")
+		return
+	}
+	config.Downmix.InvertPolarity = true
+	diagnostics.PolarityInverted = true
+}
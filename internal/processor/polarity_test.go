@@ -0,0 +1,94 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// glottalInterval returns a 250 ms interval whose samples pre-emphasise back to
+// an excitation of sharp pulses (-0.9 every 80 samples, a small positive level
+// between). sign -1 inverts the recording.
+func glottalInterval(start time.Duration, sign float64) IntervalSample {
+	iv := IntervalSample{Timestamp: start}
+	x := 0.0
+	for n := range 4000 {
+		e := 0.9 / 79
+		if n%80 == 0 {
+			e = -0.9
+		}
+		x = polarityPreEmphasis*x + sign*e
+		iv.polarity.add(x)
+	}
+	return iv
+}
+
+func TestAnalysePolarity(t *testing.T) {
+	regions := []SpeechRegion{{Start: 0, End: time.Minute}}
+	build := func(count int, sign float64) []IntervalSample {
+		intervals := make([]IntervalSample, count)
+		for i := range intervals {
+			intervals[i] = glottalInterval(time.Duration(i)*analysisIntervalHop, sign)
+		}
+		return intervals
+	}
+
+	normal := analysePolarity(build(polarityMinSpeechIntervals, 1), regions)
+	if normal == nil || normal.Verdict != PolarityNormal || normal.Skewness >= 0 {
+		t.Errorf("normal recording = %+v, want a negative skew read as normal", normal)
+	}
+	inverted := analysePolarity(build(polarityMinSpeechIntervals, -1), regions)
+	if inverted == nil || inverted.Verdict != PolarityInverted || inverted.Skewness <= 0 {
+		t.Errorf("inverted recording = %+v, want a positive skew read as inverted", inverted)
+	}
+
+	if got := analysePolarity(build(polarityMinSpeechIntervals-1, -1), regions); got != nil {
+		t.Errorf("too little speech = %+v, want nil", got)
+	}
+	if got := analysePolarity(build(polarityMinSpeechIntervals, -1), nil); got != nil {
+		t.Errorf("no speech regions = %+v, want nil", got)
+	}
+
+	// A symmetric signal has no skew to call.
+	flat := make([]IntervalSample, polarityMinSpeechIntervals)
+	for i := range flat {
+		flat[i].Timestamp = time.Duration(i) * analysisIntervalHop
+		for n := range 400 {
+			flat[i].polarity.add(float64(n%2*2 - 1))
+		}
+	}
+	if got := analysePolarity(flat, regions); got == nil || got.Verdict != PolarityUndetermined {
+		t.Errorf("symmetric signal = %+v, want undetermined", got)
+	}
+}
+
+func TestTunePolarity(t *testing.T) {
+	measurements := &AudioMeasurements{}
+	measurements.Regions.Polarity = &PolarityAnalysis{Skewness: 0.6, Verdict: PolarityInverted}
+
+	config := deriveEffectiveFilterConfig(DefaultFilterConfig())
+	diagnostics := &AdaptiveDiagnostics{}
+	tunePolarity(config, diagnostics, measurements, false)
+	if config.Downmix.InvertPolarity {
+		t.Error("inverted without --fix-polarity")
+	}
+	if len(diagnostics.Warnings) != 1 || !strings.Contains(diagnostics.Warnings[0], "--fix-polarity") {
+		t.Errorf("warnings = %q, want the --fix-polarity hint", diagnostics.Warnings)
+	}
+
+	diagnostics = &AdaptiveDiagnostics{}
+	tunePolarity(config, diagnostics, measurements, true)
+	if !config.Downmix.InvertPolarity || !diagnostics.PolarityInverted {
+		t.Error("--fix-polarity did not invert an inverted reading")
+	}
+	if spec := config.buildDownmixFilter(); spec != "aformat=channel_layouts=mono,pan=mono|c0=-1*c0" {
+		t.Errorf("downmix filter = %q, want the fold then the inversion", spec)
+	}
+
+	normal := deriveEffectiveFilterConfig(DefaultFilterConfig())
+	measurements.Regions.Polarity.Verdict = PolarityNormal
+	tunePolarity(normal, &AdaptiveDiagnostics{}, measurements, true)
+	if normal.Downmix.InvertPolarity {
+		t.Error("inverted a normal reading")
+	}
+}
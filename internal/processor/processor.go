@@ -23,6 +23,7 @@ type AnalysisResult struct {
 	Diagnostics        *AdaptiveDiagnostics
 	AnalysisDuration   time.Duration
 	AdaptationDuration time.Duration
+	AnalysisCached     bool // Pass 1 came from the analysis cache
 }
 
 // AnalyseOnlyDetailed performs Pass 1 analysis and returns stage timing details.
@@ -38,7 +39,7 @@ func AnalyseOnlyDetailed(ctx context.Context, inputPath string, config *BaseFilt
 	}
 
 	analysisStart := time.Now()
-	measurements, err := AnalyseAudio(ctx, inputPath, config, progressCallback)
+	measurements, cached, err := analyseCached(ctx, inputPath, config, progressCallback)
 	if err != nil {
 		return nil, fmt.Errorf("analysis failed: %w", err)
 	}
@@ -58,6 +59,8 @@ func AnalyseOnlyDetailed(ctx context.Context, inputPath string, config *BaseFilt
 	adaptationStart := time.Now()
 	effectiveConfig, diagnostics := AdaptConfig(config, measurements)
 	adaptationDuration := time.Since(adaptationStart)
+	exportIntervals(config, measurements, diagnostics)
+	exportNoiseProfile(inputPath, config, measurements, diagnostics)
 
 	return &AnalysisResult{
 		Measurements:       measurements,
@@ -65,17 +68,42 @@ func AnalyseOnlyDetailed(ctx context.Context, inputPath string, config *BaseFilt
 		Diagnostics:        diagnostics,
 		AnalysisDuration:   analysisDuration,
 		AdaptationDuration: adaptationDuration,
+		AnalysisCached:     cached,
 	}, nil
 }
 
 // ProcessAudio performs complete four-pass audio processing:
 //   - Pass 1: Analyse audio to get measurements and noise floor estimate
-//   - Pass 2: Process audio through filter chain (downmix → rumble_highpass → bandlimit_lowpass → noise_reduction[anlmdn+afftdn] → speech_gate → levelling_compressor → deesser → analysis → resample)
+//   - Pass 2: Process audio through filter chain (downmix → rumble_highpass → hum_notch → bandlimit_lowpass → noise_reduction[anlmdn+afftdn] → speech_gate → levelling_compressor → deesser → analysis → resample)
 //     (Pass 3 measures loudnorm; Pass 4 applies alimiter (levelling limiter) + loudnorm + brickwall)
 //
-// The output file will be named <basename>-LUFS-NN-processed.<ext> in the same directory as the input
+// The output file will be named <basename>-LUFS-NN-processed.<ext> in the same directory as the input,
+// or under --output in its directory or as its file (processedOutputPath)
 // If progressCallback is not nil, it will be called with progress updates
 func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfig, progressCallback ProgressCallback) (*ProcessingResult, error) {
+	if err := config.prepareOutput(inputPath); err != nil {
+		return nil, err
+	}
+	if config.FixRegion.Enabled {
+		return processFixRegion(ctx, inputPath, config, progressCallback)
+	}
+	if config.Excerpt.Enabled {
+		return processExcerpt(ctx, inputPath, config, progressCallback)
+	}
+
+	// Skip or fail before any work when the output the target would name is
+	// already there; publish checks again against the measured name.
+	if config.OnExists == OnExistsSkip || config.OnExists == OnExistsError {
+		if predicted, ok := predictedOutputPath(inputPath, config); ok {
+			if _, err := resolveOutputPath(predicted, config.OnExists); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if config.Resample.Channels == OutputChannelsSplit {
+		return processSplitChannels(ctx, inputPath, config, progressCallback)
+	}
+
 	// Pass 1: Analysis
 	if progressCallback != nil {
 		progressCallback(ProgressUpdate{
@@ -84,9 +112,24 @@ func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfi
 		})
 	}
 
-	measurements, err := AnalyseAudio(ctx, inputPath, config, progressCallback)
+	var effectiveConfig *EffectiveFilterConfig
+	var diagnostics *AdaptiveDiagnostics
+	analysisConfig := config
+	var pickedRoomTone *RoomToneCandidate
+	if config.QuietPreGain {
+		recording := *config
+		recording.roomToneSelector = recordRoomTonePick(config.roomToneSelector, &pickedRoomTone)
+		analysisConfig = &recording
+	}
+	measurements, analysisCached, err := analyseCached(ctx, inputPath, analysisConfig, progressCallback)
 	if err != nil {
-		return nil, fmt.Errorf("pass 1 failed: %w", err)
+		if !config.SafeMode || ctx.Err() != nil {
+			return nil, fmt.Errorf("pass 1 failed: %w", err)
+		}
+		// Safe mode: process with the fixed loudnorm-only chain rather than
+		// dropping the file. Pass 2 still measures its output for Pass 3.
+		measurements = &AudioMeasurements{}
+		effectiveConfig, diagnostics = safeModeConfig(config, err)
 	}
 
 	if progressCallback != nil {
@@ -99,11 +142,36 @@ func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfi
 		})
 	}
 
+	// A very quiet input is analysed again lifted, and the chain is tuned on
+	// that analysis. The source measurements stay the report's input figures.
+	adaptMeasurements := measurements
+	var quietPreGainDB float64
+	var quietPreGainWarning string
+	if config.QuietPreGain && effectiveConfig == nil {
+		lifted, gainDB, err := analyseLifted(ctx, inputPath, config, measurements, pickedRoomTone, progressCallback)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return nil, ctx.Err()
+		case err != nil:
+			quietPreGainWarning = fmt.Sprintf("quiet-input pre-gain not applied: %v", err)
+		case lifted != nil:
+			adaptMeasurements, quietPreGainDB = lifted, gainDB
+		}
+	}
+
 	// Adapt filter configuration based on Pass 1 measurements
-	effectiveConfig, diagnostics := AdaptConfig(config, measurements)
+	if effectiveConfig == nil {
+		effectiveConfig, diagnostics = AdaptConfig(config, adaptMeasurements)
+	}
 	if effectiveConfig == nil {
 		return nil, fmt.Errorf("adaptive config failed for %s: base filter config is nil or invalid", inputPath)
 	}
+	effectiveConfig.Downmix.PreGainDB = quietPreGainDB
+	if quietPreGainWarning != "" {
+		diagnostics.Warnings = append(diagnostics.Warnings, quietPreGainWarning)
+	}
+	exportIntervals(config, measurements, diagnostics)
+	exportNoiseProfile(inputPath, config, measurements, diagnostics)
 
 	// Pass 2: Processing. The start event also surfaces the just-derived effective
 	// config and diagnostics (read-only) so the TUI can light its filter-chain
@@ -123,7 +191,10 @@ func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfi
 		return nil, err
 	}
 
-	outputPath, err := processorCreateSiblingTempPath(inputPath, "processing")
+	// Temp files go where the output will, so a read-only input directory is
+	// never written and the publish is a same-filesystem rename.
+	anchor := config.outputAnchor(inputPath)
+	outputPath, err := processorCreateSiblingTempPath(anchor, "processing")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pass 2 temp output: %w", err)
 	}
@@ -136,16 +207,34 @@ func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfi
 
 	// Set Pass 2 filter chain order
 	effectiveConfig.FilterOrder = append([]FilterID(nil), Pass2FilterOrder...)
+	pass2Spec := effectiveConfig.BuildFilterSpec()
 
 	// Track output measurements from Pass 2 (filtered but not yet normalised)
 	var filteredMeasurements *OutputMeasurements
 	var regionTimings RegionMeasurementTimings
 
-	inputMetadata, err := processWithFilters(ctx, inputPath, outputPath, effectiveConfig, progressCallback, measurements, &filteredMeasurements)
+	// A very long input renders in chunks; the joined programme is measured
+	// whole, so Passes 3 and 4 run on it unchanged.
+	var inputMetadata InputMetadata
+	chunked := config.chunked(time.Duration(measurements.Duration * float64(time.Second)))
+	if chunked {
+		inputMetadata, err = processInChunks(ctx, inputPath, outputPath, effectiveConfig, progressCallback, measurements, &filteredMeasurements, config.logger)
+	} else {
+		inputMetadata, err = processWithFilters(ctx, inputPath, outputPath, effectiveConfig, progressCallback, measurements, &filteredMeasurements)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("pass 2 failed: %w", err)
 	}
 
+	if inputMetadata.AudioStreams > 1 {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+			"input has %d audio streams: processed stream #%d (%d channels, %d Hz)",
+			inputMetadata.AudioStreams, inputMetadata.StreamIndex, inputMetadata.Channels, inputMetadata.SampleRate))
+	}
+
+	// --channels same follows the input layout, known now Pass 2 has opened it.
+	effectiveConfig.Resample.Channels = resolveOutputChannels(effectiveConfig.Resample.Channels, inputMetadata.Channels)
+
 	if progressCallback != nil {
 		progressCallback(ProgressUpdate{
 			Pass:         PassProcessing,
@@ -168,6 +257,28 @@ func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfi
 		}
 	}
 
+	// --targets: copy the Pass 2 output for each extra loudness target before the
+	// primary normalisation renders over it.
+	var targetCopies []string
+	if filteredMeasurements != nil && len(config.ExtraTargets) > 0 {
+		targetCopies, err = copyPass2ForTargets(outputPath, len(config.ExtraTargets))
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy pass 2 output for the extra targets: %w", err)
+		}
+		defer removeTempPaths(targetCopies)
+	}
+
+	// --keep-intermediate: keep the Pass 2 render and its measurements before
+	// normalisation renders over it. It is a diagnostic artefact, so a failure
+	// is a warning.
+	var intermediatePath string
+	if filteredMeasurements != nil && config.IntermediateDir != "" {
+		intermediatePath, err = keepIntermediate(inputPath, outputPath, config.IntermediateDir, filteredMeasurements)
+		if err != nil {
+			diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("intermediate not kept: %v", err))
+		}
+	}
+
 	// Pass 3/4: Normalisation (measurement + loudnorm application)
 	// The FinalMeasurements in the result include region measurements captured in Pass 4
 	var normResult *NormalisationResult
@@ -182,6 +293,40 @@ func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfi
 		regionTimings.FinalOutput = normResult.RegionMeasurementTime
 	}
 
+	// Clarity is measured on the normalised programme, before any slate shifts
+	// the speech region. It is a report figure, so a failure is a warning.
+	var clarity *ClarityComparison
+	if config.Clarity && normResult != nil && !normResult.Skipped {
+		clarity, err = measureClarity(ctx, inputPath, outputPath, measurements, normResult.FinalMeasurements, config.logger)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("clarity not scored: %v", err))
+		}
+	}
+
+	// The slate goes on after every measurement, so the report describes the
+	// programme alone. It is part of the deliverable, so a failure fails the file.
+	if config.Slate.Enabled {
+		if err := applySlate(ctx, outputPath, config.Slate, effectiveConfig); err != nil {
+			return nil, fmt.Errorf("slate failed: %w", err)
+		}
+	}
+
+	// Optional noise-reduction residual. It is a diagnostic artefact, so a
+	// failure is reported as a warning rather than failing the processed output.
+	var noiseStemPath string
+	if config.NoiseStem {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		noiseStemPath, err = writeNoiseStem(ctx, inputPath, anchor, effectiveConfig)
+		if err != nil {
+			diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("noise stem not written: %v", err))
+		}
+	}
+
 	// Return the processing result with output measurements for comparison
 	result := &ProcessingResult{
 		OutputPath:           outputPath,
@@ -194,24 +339,91 @@ func ProcessAudio(ctx context.Context, inputPath string, config *BaseFilterConfi
 		RegionTimings:        regionTimings,
 		FilteredMeasurements: filteredMeasurements,
 		NormResult:           normResult,
+		NoiseStemPath:        noiseStemPath,
+		IntermediatePath:     intermediatePath,
+		Slate:                config.Slate,
+		Clarity:              clarity,
+		AnalysisCached:       analysisCached,
+		Pass2FilterSpec:      pass2Spec,
+	}
+	if chunked {
+		result.Pass2Chunks = len(planChunks(time.Duration(inputMetadata.DurationSecs*float64(time.Second)), chunkLength))
 	}
 
-	// Set OutputLUFS to final value (after normalisation if applied)
+	// Set OutputLUFS to final value (after normalisation if applied). The
+	// delivered layout is applied in Pass 4, so without it the output stays mono.
+	result.OutputChannels = 1
 	if normResult != nil && !normResult.Skipped {
 		result.OutputLUFS = normResult.OutputLUFS
+		if effectiveConfig.Resample.Channels == OutputChannelsStereo {
+			result.OutputChannels = 2
+		}
 	} else if filteredMeasurements != nil {
 		result.OutputLUFS = filteredMeasurements.Loudness.OutputI
 	}
 
 	// Rename output file to include LUFS value: <name>-processed.<ext> → <name>-LUFS-NN-processed.<ext>
 	lufsValue := lufsFilenameValue(result.OutputLUFS)
-	finalPath := generateLUFSOutputPath(inputPath, lufsValue)
-	if err := publishOutput(outputPath, finalPath); err != nil {
+	finalPath := config.processedOutputPath(inputPath, lufsValue)
+	release := func() {}
+	if !sameFile(finalPath, inputPath) {
+		if finalPath, release, err = reserveOutputPath(finalPath, config.OnExists); err != nil {
+			return nil, err
+		}
+	}
+	if err := encodeOutputFormat(ctx, outputPath, config.OutputFormat); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to write %s output: %w", config.OutputFormat, err)
+	}
+	if err := publishProcessedOutput(outputPath, finalPath, inputPath, config.InPlace); err != nil {
+		release()
 		return nil, fmt.Errorf("failed to publish output: %w", err)
 	}
 	cleanupTempOutput = false
 	result.OutputPath = finalPath
 
+	// Cover art is dropped unless asked for; a failure to keep it is a warning.
+	if config.KeepCoverArt {
+		if _, err := keepCoverArt(inputPath, finalPath); err != nil {
+			diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("cover art not kept: %v", err))
+		}
+	}
+
+	// Each extra target repeats only Pass 3/4 on its copy of the Pass 2 output,
+	// with the same adapted chain.
+	if len(targetCopies) > 0 {
+		result.TargetOutputs, err = renderExtraTargets(ctx, inputPath, targetCopies, config, effectiveConfig,
+			filteredMeasurements, measurements, []string{finalPath}, diagnostics)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Optional reproduction script. Like the noise stem it is a side artefact,
+	// so a failure is a warning.
+	var pass4Spec string
+	if normResult != nil {
+		pass4Spec = normResult.FilterSpec
+	}
+	if config.EmitFFmpegCommand {
+		command := buildFFmpegCommand(inputPath, finalPath, pass2Spec, pass4Spec, effectiveConfig.Resample.BitDepth, config.OutputFormat)
+		result.FFmpegCommandPath, err = writeFFmpegCommand(anchor, command)
+		if err != nil {
+			diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("ffmpeg command not written: %v", err))
+		}
+	}
+
+	// The receipt hashes the published output, so it comes last.
+	if config.Receipt || config.WriteReceipt {
+		if result.Receipt, err = newReceipt(inputPath, result, pass2Spec, pass4Spec); err != nil {
+			diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("receipt not built: %v", err))
+		} else if config.WriteReceipt {
+			if result.ReceiptPath, err = writeReceipt(result.Receipt); err != nil {
+				diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("receipt not written: %v", err))
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -220,6 +432,20 @@ type InputMetadata struct {
 	SampleRate   int
 	Channels     int
 	DurationSecs float64
+
+	StreamIndex  int // Container index of the processed audio stream
+	AudioStreams int // Audio streams in the input; above 1 the choice is reported as a warning
+}
+
+// newInputMetadata is the report-needed subset of the reader's metadata.
+func newInputMetadata(metadata *audio.Metadata) InputMetadata {
+	return InputMetadata{
+		SampleRate:   metadata.SampleRate,
+		Channels:     metadata.Channels,
+		DurationSecs: metadata.Duration,
+		StreamIndex:  metadata.StreamIndex,
+		AudioStreams: metadata.AudioStreams,
+	}
 }
 
 // RegionMeasurementTimings contains optional reportable region measurement durations.
@@ -237,8 +463,9 @@ type ProcessingResult struct {
 	Config       *EffectiveFilterConfig // Contains adaptive parameters used
 	Diagnostics  *AdaptiveDiagnostics
 
-	InputMetadata InputMetadata
-	RegionTimings RegionMeasurementTimings
+	InputMetadata  InputMetadata
+	OutputChannels int // Channel count of the published output (1 mono, 2 dual-mono stereo)
+	RegionTimings  RegionMeasurementTimings
 
 	// Pass 2 output analysis (populated when requested by the processing pass)
 	// Contains measurements after filter chain but before normalisation
@@ -247,6 +474,60 @@ type ProcessingResult struct {
 	// Normalisation result (Pass 3/4)
 	// NormResult.FinalMeasurements contains measurements after normalisation
 	NormResult *NormalisationResult // nil if normalisation disabled or skipped
+
+	// NoiseStemPath is the published noise-reduction residual (--noise-stem);
+	// empty when not requested or when writing it failed.
+	NoiseStemPath string
+
+	// IntermediatePath is the kept Pass 2 render (--keep-intermediate); empty
+	// when none was kept.
+	IntermediatePath string
+
+	// Slate is the lead-in prepended to the output (--slate); zero when off.
+	Slate SlateConfig
+
+	// Clarity is the input and final clarity score (--clarity); nil when not
+	// requested or not measurable.
+	Clarity *ClarityComparison
+
+	// AnalysisCached is set when Pass 1 was reused from the analysis cache
+	// (--cache-dir) rather than measured on this run.
+	AnalysisCached bool
+
+	// Pass2FilterSpec is the Pass 2 filter graph as built (BuildFilterSpec).
+	// The Pass 4 graph is NormResult.FilterSpec.
+	Pass2FilterSpec string
+
+	// Pass2Chunks is the number of chunks Pass 2 rendered a long input in
+	// (--chunk-over); zero when it was rendered whole.
+	Pass2Chunks int
+
+	// FixRegion records the splice when only a region was processed
+	// (--fix-region); nil otherwise. The measurements describe the region.
+	FixRegion *FixRegionSplice
+
+	// Excerpt records the stretch of the input processed (--excerpt); nil
+	// when the whole file was.
+	Excerpt *ExcerptRange
+
+	// Split holds each channel's own run when the channels were processed
+	// separately (--channels split); nil otherwise.
+	Split *SplitChannels
+
+	// TargetOutputs are the extra renderings at the other --targets loudness
+	// values, in the order given; OutputPath is the first target's.
+	TargetOutputs []TargetOutput
+
+	// FFmpegCommandPath is the written reproduction script
+	// (--emit-ffmpeg-command); empty when not requested or when writing it
+	// failed.
+	FFmpegCommandPath string
+
+	// Receipt is the processing receipt (--receipt); nil when not requested
+	// or when it could not be built. ReceiptPath is its written copy
+	// (--receipt-file), empty when not written.
+	Receipt     *Receipt
+	ReceiptPath string
 }
 
 // processWithFilters performs Pass 2 audio processing through the single-input
@@ -259,20 +540,11 @@ func processWithFilters(ctx context.Context, inputPath, outputPath string, confi
 		return InputMetadata{}, fmt.Errorf("failed to open input file: %w", err)
 	}
 	defer reader.Close()
-	inputMetadata := InputMetadata{
-		SampleRate:   metadata.SampleRate,
-		Channels:     metadata.Channels,
-		DurationSecs: metadata.Duration,
-	}
+	inputMetadata := newInputMetadata(metadata)
 
-	// Get total duration for progress calculation
-	totalDuration := metadata.Duration
-	sampleRate := float64(metadata.SampleRate)
-
-	// Calculate total frames estimate (duration * sample_rate / samples_per_frame)
-	// For FLAC, typical frame size is 4096 samples
-	samplesPerFrame := 4096.0
-	estimatedTotalFrames := (totalDuration * sampleRate) / samplesPerFrame
+	// Progress is the share of the input's samples read, so it holds for any
+	// codec's frame size.
+	totalSamples := int64(metadata.Duration * float64(metadata.SampleRate))
 
 	// Create filter graph with complete processing chain
 	// NOTE: loudnorm is NOT in the Pass 2 filter chain because it always processes audio
@@ -296,11 +568,12 @@ func processWithFilters(ctx context.Context, inputPath, outputPath string, confi
 	// Initialize output measurement accumulators if the caller requested output analysis.
 	var outputAcc *outputMetadataAccumulators
 	if outputMeasurements != nil {
-		outputAcc = &outputMetadataAccumulators{}
+		outputAcc = &outputMetadataAccumulators{speech: newSpeechLoudnessAccumulator(measurements)}
 	}
 
-	// Track frame count for periodic progress updates
+	// Track frame and sample counts for periodic progress updates
 	frameCount := 0
+	var samplesProcessed int64
 	currentLevel := 0.0
 
 	// Process all frames through the filter chain using runFilterGraph
@@ -316,11 +589,12 @@ func processWithFilters(ctx context.Context, inputPath, outputPath string, confi
 		},
 		OnInputFrame: func(inputFrame *ffmpeg.AVFrame) {
 			frameCount++
+			samplesProcessed += int64(inputFrame.NbSamples())
 
-			// Send periodic progress updates based on INPUT frame count
+			// Send periodic progress updates based on INPUT samples read
 			updateInterval := 100
-			if frameCount%updateInterval == 0 && progressCallback != nil && estimatedTotalFrames > 0 {
-				progress := float64(frameCount) / estimatedTotalFrames
+			if frameCount%updateInterval == 0 && progressCallback != nil && totalSamples > 0 {
+				progress := float64(samplesProcessed) / float64(totalSamples)
 				if progress > 1.0 {
 					progress = 1.0
 				}
@@ -341,6 +615,9 @@ func processWithFilters(ctx context.Context, inputPath, outputPath string, confi
 			// Extract output measurements from filtered frame metadata (if enabled)
 			if outputAcc != nil {
 				extractOutputFrameMetadata(filteredFrame.Metadata(), outputAcc)
+				if outputAcc.speech != nil {
+					outputAcc.speech.observeFrame(filteredFrame)
+				}
 			}
 
 			// Set timebase for the filtered frame
@@ -373,14 +650,27 @@ func processWithFilters(ctx context.Context, inputPath, outputPath string, confi
 }
 
 // generateLUFSOutputPath creates the final output filename with the measured LUFS value.
-// Output is always FLAC regardless of input extension.
+// The extension is the delivered format's, regardless of input extension.
 // Example: /path/to/audio.flac → /path/to/audio-LUFS-16-processed.flac
 // Example: /path/to/audio.wav  → /path/to/audio-LUFS-16-processed.flac
-func generateLUFSOutputPath(inputPath string, lufsValue int) string {
+func generateLUFSOutputPath(inputPath string, lufsValue int, ext string) string {
 	dir := filepath.Dir(inputPath)
 	filename := filepath.Base(inputPath)
 	nameWithoutExt := strings.TrimSuffix(filename, filepath.Ext(filename))
-	return filepath.Join(dir, fmt.Sprintf("%s-LUFS-%d-processed.flac", nameWithoutExt, lufsValue))
+	return filepath.Join(dir, fmt.Sprintf("%s-LUFS-%d-processed%s", nameWithoutExt, lufsValue, ext))
+}
+
+// predictedOutputPath is the output path a run reaching its loudness target
+// would publish. ok is false when the name cannot be known before measuring:
+// an RMS target or speech-only loudness lands at some other integrated LUFS.
+func predictedOutputPath(inputPath string, config *BaseFilterConfig) (string, bool) {
+	if config.OutputFile != "" {
+		return config.OutputFile, true
+	}
+	if config.Loudnorm.TargetMode() != TargetModeLUFS || config.Loudnorm.SpeechOnly {
+		return "", false
+	}
+	return config.processedOutputPath(inputPath, lufsFilenameValue(config.Loudnorm.TargetI)), true
 }
 
 func lufsFilenameValue(outputLUFS float64) int {
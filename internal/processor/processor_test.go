@@ -31,7 +31,7 @@ func TestGenerateLUFSOutputPath(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			got := generateLUFSOutputPath(tc.input, 16)
+			got := generateLUFSOutputPath(tc.input, 16, ".flac")
 			if got != tc.want {
 				t.Errorf("generateLUFSOutputPath(%q, 16) = %q, want %q", tc.input, got, tc.want)
 			}
@@ -72,6 +72,9 @@ func ComputeQualityScore(result *ProcessingResult) QualityScore {
 	target := NormTargetLUFS
 	if result.NormResult != nil && result.NormResult.RequestedTargetI != 0 {
 		target = result.NormResult.RequestedTargetI
+		// --speech-loudness puts the speech on target, leaving the gated
+		// loudness this far below it by design.
+		target -= result.NormResult.SpeechTargetShift
 	}
 
 	loudness := scoreLoudness(result.OutputLUFS, target)
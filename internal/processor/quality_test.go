@@ -299,3 +299,15 @@ func TestOutputLRA(t *testing.T) {
 		t.Errorf("OutputLRA = %.1f, want 7.5", lra)
 	}
 }
+
+func TestComputeQualityScoreSpeechTargetShift(t *testing.T) {
+	// --speech-loudness leaves the gated output 2 LU under the requested target
+	// by design; it scores as on target.
+	r := resultWith(-18.0, -2.0, -60.0, -82.0)
+	r.NormResult.SpeechTargetShift = 2.0
+	shifted := ComputeQualityScore(r)
+	onTarget := ComputeQualityScore(resultWith(-16.0, -2.0, -60.0, -82.0))
+	if math.Abs(shifted.Score-onTarget.Score) > 1e-9 {
+		t.Errorf("speech-targeted score %.1f, want the on-target %.1f", shifted.Score, onTarget.Score)
+	}
+}
@@ -0,0 +1,133 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Quiet-input pre-gain (--quiet-pre-gain). A very quiet recording sits so low
+// that the level-anchored analysis (the VAD floor clamps, the room-tone levels,
+// the noise bands) and the stages tuned from it work near their floors. Such an
+// input is lifted by a measured amount, analysed again, and processed lifted;
+// Pass 2 takes the lift back off ahead of its output analysis, so everything
+// downstream, and the report, sees the source level.
+const (
+	// quietPreGainThresholdLUFS is the integrated loudness below which an
+	// input is lifted.
+	quietPreGainThresholdLUFS = -35.0
+
+	// quietPreGainTargetLUFS is where the lift aims: the quiet end of the mid
+	// loudness tier, where the analysis is calibrated.
+	quietPreGainTargetLUFS = -24.0
+
+	// quietPreGainCeilingDBTP caps the lift so the lifted true peak stays clear
+	// of clipping, in the lifted copy and in Pass 2.
+	quietPreGainCeilingDBTP = -1.0
+
+	// quietPreGainMinDB is the smallest lift worth a second analysis, and
+	// quietPreGainMaxDB the largest, which a 24-bit copy of a 16-bit source
+	// holds without loss.
+	quietPreGainMinDB = 3.0
+	quietPreGainMaxDB = 40.0
+)
+
+// planQuietPreGain returns the lift (dB) for an input measuring inputI LUFS
+// with a true peak of inputTP dBTP: enough to reach quietPreGainTargetLUFS,
+// limited by the true-peak ceiling and quietPreGainMaxDB. Zero means no lift:
+// the input is loud enough, or the peaks leave too little room to be worth it.
+func planQuietPreGain(inputI, inputTP float64) float64 {
+	if !isFinite(inputI) || !isFinite(inputTP) || inputI >= quietPreGainThresholdLUFS {
+		return 0
+	}
+	gain := min(quietPreGainTargetLUFS-inputI, quietPreGainCeilingDBTP-inputTP, quietPreGainMaxDB)
+	if gain < quietPreGainMinDB {
+		return 0
+	}
+	return gain
+}
+
+// quietPreGainSpec lifts by gainDB; a negative gainDB takes the lift back.
+func quietPreGainSpec(gainDB float64) string {
+	return fmt.Sprintf("volume=%.2fdB:precision=double", gainDB)
+}
+
+// renderLiftedInput writes inputPath lifted by gainDB to a temp FLAC beside
+// anchor (outputAnchor) at 24 bits, for the second Pass 1. The caller removes
+// it.
+func renderLiftedInput(ctx context.Context, inputPath, anchor string, gainDB float64) (string, error) {
+	return renderInputCopy(ctx, inputPath, anchor, quietPreGainSpec(gainDB)+",aformat=sample_fmts=s32", "pre-gain")
+}
+
+// recordRoomTonePick wraps selector so the region it picks is kept in *picked,
+// for replayRoomTonePick to offer again on the lifted analysis. A nil selector
+// stays nil: the automatic election needs no replay.
+func recordRoomTonePick(selector RoomToneSelector, picked **RoomToneCandidate) RoomToneSelector {
+	if selector == nil {
+		return nil
+	}
+	return func(candidates []RoomToneCandidate) int {
+		idx := selector(candidates)
+		if idx >= 0 && idx < len(candidates) {
+			c := candidates[idx]
+			*picked = &c
+		}
+		return idx
+	}
+}
+
+// replayRoomTonePick selects the candidate overlapping picked the most, so the
+// lifted analysis profiles the region the user chose without asking twice. A
+// nil picked, or no overlap, keeps the automatic choice.
+func replayRoomTonePick(picked *RoomToneCandidate) RoomToneSelector {
+	if picked == nil {
+		return nil
+	}
+	return func(candidates []RoomToneCandidate) int {
+		best := -1
+		var bestOverlap time.Duration
+		for i, c := range candidates {
+			overlap := min(c.End, picked.End) - max(c.Start, picked.Start)
+			if overlap > bestOverlap {
+				best, bestOverlap = i, overlap
+			}
+		}
+		return best
+	}
+}
+
+// analyseLifted runs the quiet-input pre-gain when source measures quiet
+// enough: it renders the lifted copy and analyses it, replaying the room-tone
+// pick. The copy is written beside the output, never the input. It returns the
+// lifted measurements and the lift, or nil and 0 when no lift applies. The
+// source-only facts the copy cannot carry (the bit depth) are taken from
+// source.
+func analyseLifted(ctx context.Context, inputPath string, config *BaseFilterConfig, source *AudioMeasurements, picked *RoomToneCandidate, progressCallback ProgressCallback) (*AudioMeasurements, float64, error) {
+	gainDB := planQuietPreGain(source.Loudness.InputI, source.Loudness.InputTP)
+	if gainDB == 0 {
+		return nil, 0, nil
+	}
+
+	liftedPath, err := renderLiftedInput(ctx, inputPath, config.outputAnchor(inputPath), gainDB)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = os.Remove(liftedPath) }()
+
+	replay := *config
+	replay.roomToneSelector = replayRoomTonePick(picked)
+	if replay.NoiseFloorDB != 0 {
+		// The user's floor is on the source; the lift raises it by the gain.
+		replay.NoiseFloorDB += gainDB
+	}
+	if replay.NoiseProfileIn != nil {
+		replay.NoiseProfileIn = replay.NoiseProfileIn.lifted(gainDB)
+	}
+	lifted, err := AnalyseAudio(ctx, liftedPath, &replay, progressCallback)
+	if err != nil {
+		return nil, 0, err
+	}
+	lifted.Dynamics.BitDepth = source.Dynamics.BitDepth
+	return lifted, gainDB, nil
+}
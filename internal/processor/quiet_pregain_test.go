@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanQuietPreGain(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputI, inputTP float64
+		want            float64
+	}{
+		{"loud enough", -30, -6, 0},
+		{"lifted to the target", -42, -20, 18},
+		{"held under the true-peak ceiling", -42, -9, 8},
+		{"too little peak room to bother", -36, -2.5, 0},
+		{"capped", -80, -60, quietPreGainMaxDB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := planQuietPreGain(tt.inputI, tt.inputTP); got != tt.want {
+				t.Errorf("planQuietPreGain(%.0f, %.0f) = %.2f, want %.2f", tt.inputI, tt.inputTP, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietPreGainFilterSpec(t *testing.T) {
+	config := deriveEffectiveFilterConfig(DefaultFilterConfig())
+	config.FilterOrder = Pass2FilterOrder
+	if strings.Contains(config.BuildFilterSpec(), "volume=") {
+		t.Fatal("pass 2 carries a volume stage without a pre-gain")
+	}
+
+	config.Downmix.PreGainDB = 18
+	spec := config.BuildFilterSpec()
+	lift := strings.Index(spec, "aformat=channel_layouts=mono,volume=18.00dB")
+	undo := strings.Index(spec, "volume=-18.00dB:precision=double,astats")
+	if lift < 0 || undo < lift {
+		t.Errorf("pass 2 does not lift after the downmix and undo it before the analysis:\n%s", spec)
+	}
+	if stem := config.buildNoiseStemSpec(); !strings.Contains(stem, "volume=-18.00dB") {
+		t.Errorf("noise stem keeps the lift:\n%s", stem)
+	}
+}
+
+func TestReplayRoomTonePick(t *testing.T) {
+	if replayRoomTonePick(nil) != nil {
+		t.Error("replay without a pick must keep the automatic election")
+	}
+
+	picked := &RoomToneCandidate{Start: 10 * time.Second, End: 14 * time.Second}
+	replay := replayRoomTonePick(picked)
+	candidates := []RoomToneCandidate{
+		{Start: 2 * time.Second, End: 5 * time.Second},
+		{Start: 9750 * time.Millisecond, End: 13750 * time.Millisecond},
+		{Start: 13 * time.Second, End: 20 * time.Second},
+	}
+	if got := replay(candidates); got != 1 {
+		t.Errorf("replay = %d, want 1 (the most overlap)", got)
+	}
+	if got := replay(candidates[:1]); got != -1 {
+		t.Errorf("replay with no overlap = %d, want -1", got)
+	}
+}
@@ -0,0 +1,143 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Processing receipt (--receipt, --receipt-file). A compact provenance record
+// tying one output to what made it: the jivetalking version, the input's
+// SHA-256, a SHA-256 over the resolved render, and the output's SHA-256 and
+// headline figures. The render hash covers everything the output is rendered
+// from once the input is fixed: the adapted Pass 2 graph, the Pass 4 graph
+// (which carries this run's Pass 3 measurements), the output bit depth, the
+// slate, and the Pass 2 chunking of a long input. Two runs with equal input
+// and render hashes render the same audio; the output hash checks it.
+const receiptFormat = "jivetalking-receipt-v1"
+
+// Receipt is the processing receipt for one output.
+type Receipt struct {
+	Format       string  `json:"format"`
+	Version      string  `json:"version"`
+	InputPath    string  `json:"input_path"`
+	InputSHA256  string  `json:"input_sha256"`
+	RenderSHA256 string  `json:"render_sha256"`
+	OutputPath   string  `json:"output_path"`
+	OutputSHA256 string  `json:"output_sha256"`
+	OutputLUFS   float64 `json:"output_lufs"`
+
+	// The true peak and loudness range are nil when the output was not
+	// normalised, so no final measurement exists.
+	OutputTPDBTP *float64 `json:"output_true_peak_dbtp,omitempty"`
+	OutputLRALU  *float64 `json:"output_lra_lu,omitempty"`
+}
+
+// String is the receipt as printed after the run: one labelled line each.
+func (r *Receipt) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Receipt     %s\n", filepath.Base(r.OutputPath))
+	fmt.Fprintf(&b, "  version   %s\n", r.Version)
+	fmt.Fprintf(&b, "  input     sha256:%s\n", r.InputSHA256)
+	fmt.Fprintf(&b, "  render    sha256:%s\n", r.RenderSHA256)
+	fmt.Fprintf(&b, "  output    sha256:%s\n", r.OutputSHA256)
+	fmt.Fprintf(&b, "  loudness  %.1f LUFS", r.OutputLUFS)
+	if r.OutputTPDBTP != nil {
+		fmt.Fprintf(&b, ", %.1f dBTP", *r.OutputTPDBTP)
+	}
+	if r.OutputLRALU != nil {
+		fmt.Fprintf(&b, ", LRA %.1f LU", *r.OutputLRALU)
+	}
+	return b.String()
+}
+
+// hashFileInto feeds the contents of path to h.
+func hashFileInto(h hash.Hash, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// fileSHA256 is the hex SHA-256 of the contents of path.
+func fileSHA256(path string) (string, error) {
+	h := sha256.New()
+	if err := hashFileInto(h, path); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// renderSHA256 hashes the resolved render: the two filter graphs, the bit
+// depth, the slate and the Pass 2 chunk count, each NUL-terminated so no field
+// can run into the next.
+func renderSHA256(pass2Spec, pass4Spec string, bitDepth int, slate SlateConfig, chunks int) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d\x00", receiptFormat, pass2Spec, pass4Spec, bitDepth)
+	if slate.Enabled {
+		fmt.Fprintf(h, "slate:%d:%g:%d\x00", slate.Tone, slate.Frequency, slate.Silence)
+	}
+	if chunks > 0 {
+		fmt.Fprintf(h, "chunks:%d:%d:%d\x00", chunks, chunkLength, chunkOverlap)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newReceipt builds the receipt for a published result, hashing the input and
+// the output as they now stand on disk.
+func newReceipt(inputPath string, result *ProcessingResult, pass2Spec, pass4Spec string) (*Receipt, error) {
+	inputHash, err := fileSHA256(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash input: %w", err)
+	}
+	outputHash, err := fileSHA256(result.OutputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash output: %w", err)
+	}
+	r := &Receipt{
+		Format:       receiptFormat,
+		Version:      RunVersion,
+		InputPath:    inputPath,
+		InputSHA256:  inputHash,
+		RenderSHA256: renderSHA256(pass2Spec, pass4Spec, result.Config.Resample.BitDepth, result.Slate, result.Pass2Chunks),
+		OutputPath:   result.OutputPath,
+		OutputSHA256: outputHash,
+		OutputLUFS:   result.OutputLUFS,
+	}
+	if tp, ok := OutputTP(result); ok {
+		r.OutputTPDBTP = &tp
+	}
+	if lra, ok := OutputLRA(result); ok {
+		r.OutputLRALU = &lra
+	}
+	return r, nil
+}
+
+// generateReceiptPath names the receipt beside the output.
+// Example: /path/to/audio-LUFS-18-processed.flac → /path/to/audio-LUFS-18-processed.receipt.json
+func generateReceiptPath(outputPath string) string {
+	return strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + ".receipt.json"
+}
+
+// writeReceipt writes r as indented JSON beside its output and returns the
+// path.
+func writeReceipt(r *Receipt) (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode receipt: %w", err)
+	}
+	path := generateReceiptPath(r.OutputPath)
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec // a receipt is meant to be shared
+		return "", fmt.Errorf("failed to write receipt: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,106 @@
+package processor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderSHA256(t *testing.T) {
+	base := renderSHA256("highpass=f=80", "loudnorm=I=-16", OutputBitDepth16, SlateConfig{}, 0)
+	if base != renderSHA256("highpass=f=80", "loudnorm=I=-16", OutputBitDepth16, SlateConfig{}, 0) {
+		t.Fatal("render hash is not deterministic")
+	}
+
+	slate := SlateConfig{Enabled: true, Tone: 10 * time.Second, Frequency: 1000, Silence: 2 * time.Second}
+	for name, other := range map[string]string{
+		"pass 2":    renderSHA256("highpass=f=90", "loudnorm=I=-16", OutputBitDepth16, SlateConfig{}, 0),
+		"pass 4":    renderSHA256("highpass=f=80", "loudnorm=I=-18", OutputBitDepth16, SlateConfig{}, 0),
+		"bit depth": renderSHA256("highpass=f=80", "loudnorm=I=-16", OutputBitDepth24, SlateConfig{}, 0),
+		"slate":     renderSHA256("highpass=f=80", "loudnorm=I=-16", OutputBitDepth16, slate, 0),
+		"chunks":    renderSHA256("highpass=f=80", "loudnorm=I=-16", OutputBitDepth16, SlateConfig{}, 12),
+		"boundary":  renderSHA256("highpass=f=80loudnorm=I=-16", "", OutputBitDepth16, SlateConfig{}, 0),
+	} {
+		if other == base {
+			t.Errorf("changing the %s left the render hash unchanged", name)
+		}
+	}
+}
+
+func TestReceiptString(t *testing.T) {
+	tp, lra := -1.04, 6.2
+	r := &Receipt{
+		Version:      "1.2.3",
+		InputSHA256:  "aa",
+		RenderSHA256: "bb",
+		OutputPath:   "/rec/presenter-LUFS-16-processed.flac",
+		OutputSHA256: "cc",
+		OutputLUFS:   -16.02,
+		OutputTPDBTP: &tp,
+		OutputLRALU:  &lra,
+	}
+	got := r.String()
+	for _, want := range []string{
+		"presenter-LUFS-16-processed.flac",
+		"version   1.2.3",
+		"input     sha256:aa",
+		"render    sha256:bb",
+		"output    sha256:cc",
+		"-16.0 LUFS, -1.0 dBTP, LRA 6.2 LU",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("receipt missing %q:\n%s", want, got)
+		}
+	}
+
+	r.OutputTPDBTP, r.OutputLRALU = nil, nil
+	if got := r.String(); strings.Contains(got, "dBTP") || strings.Contains(got, "LRA") {
+		t.Errorf("receipt without final measurements shows them:\n%s", got)
+	}
+}
+
+func TestWriteReceipt(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "presenter-LUFS-16-processed.flac")
+	r := &Receipt{Format: receiptFormat, Version: "1.2.3", OutputPath: output, OutputLUFS: -16}
+
+	path, err := writeReceipt(r)
+	if err != nil {
+		t.Fatalf("writeReceipt() failed: %v", err)
+	}
+	if filepath.Base(path) != "presenter-LUFS-16-processed.receipt.json" {
+		t.Errorf("receipt path = %q, want presenter-LUFS-16-processed.receipt.json", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("receipt is not JSON: %v", err)
+	}
+	if got["format"] != receiptFormat || got["version"] != "1.2.3" {
+		t.Errorf("receipt = %v, want format and version", got)
+	}
+	if _, ok := got["output_true_peak_dbtp"]; ok {
+		t.Error("receipt carries a true peak that was never measured")
+	}
+}
+
+func TestFileSHA256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.wav")
+	if err := os.WriteFile(path, []byte("abc"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := fileSHA256(path)
+	if err != nil {
+		t.Fatalf("fileSHA256() failed: %v", err)
+	}
+	if want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"; got != want {
+		t.Errorf("fileSHA256 = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Recording-advice thresholds. Each finding is a capture problem the processing
+// can only partly repair, so the advice points at the next take.
+const (
+	// adviceNoiseFloorDB is the K-weighted momentary-LUFS noise floor above which
+	// the room is flagged. It sits two thirds of the way down the Recording
+	// score's floor ramp (recordingFloorFull -75 to recordingFloorZero -45):
+	// audible between phrases even after noise reduction.
+	adviceNoiseFloorDB = -55.0
+
+	// adviceMaxFindings caps the line at the two most important findings so it
+	// stays one readable line in the completion summary.
+	adviceMaxFindings = 2
+)
+
+// RecordingAdvice synthesises the key Pass-1 findings into one prioritised
+// advice line for the completion summary: clipping, a phone/VoIP bandwidth, a
+// high noise floor, a tonal background (hum or whine), source AGC, then wind
+// and handling bursts. Clipping leads because it is the one defect nothing downstream can
+// undo. Only the first adviceMaxFindings are kept. Returns "" when nothing is
+// worth mentioning, so a clean capture adds no line. diag may be nil.
+//
+// Each finding is "Problem, measurement: remedy." in the plain-prose register of
+// GainAdviceResult.Message.
+func RecordingAdvice(m *AudioMeasurements, diag *AdaptiveDiagnostics) string {
+	if m == nil {
+		return ""
+	}
+
+	var findings []string
+	if m.Loudness.InputTP >= 0 || m.Clipping != nil {
+		findings = append(findings, fmt.Sprintf(
+			"Clipped, peaks at %+.1f ㏈TP: lower the input gain.", m.Loudness.InputTP))
+	}
+	if diag != nil && diag.NarrowbandSource {
+		findings = append(findings,
+			"Phone-quality bandwidth: ask the guest to record locally.")
+	}
+	if m.Noise.Floor > adviceNoiseFloorDB && !m.Noise.VoiceActivated {
+		findings = append(findings, fmt.Sprintf(
+			"Noisy room, floor at %.0f ㏈: record somewhere quieter.", m.Noise.Floor))
+	}
+	if p := m.Regions.NoiseProfile; p != nil && p.Spectral.Found && p.Spectral.Flatness < afftdnCustomMinFlatness {
+		findings = append(findings,
+			"Tonal background (hum or whine): switch off fans and appliances.")
+	}
+	if m.Noise.AGCDetected {
+		findings = append(findings,
+			"Automatic gain control pumping the background: turn AGC off on the recorder.")
+	}
+	if m.Noise.RumbleBurstCount >= rumbleBurstMinCount && m.Noise.RumbleBurstsPerMinute >= rumbleBurstFrequentPerMinute {
+		findings = append(findings, fmt.Sprintf(
+			"Wind or handling bumps, %.1f a minute: use a windshield or shock mount.", m.Noise.RumbleBurstsPerMinute))
+	}
+
+	if len(findings) > adviceMaxFindings {
+		findings = findings[:adviceMaxFindings]
+	}
+	return strings.Join(findings, " ")
+}
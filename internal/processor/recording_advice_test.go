@@ -0,0 +1,96 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+// cleanAdviceMeasurements returns a capture with nothing worth advising on: a
+// quiet floor, headroom, and no wind bursts.
+func cleanAdviceMeasurements() *AudioMeasurements {
+	m := &AudioMeasurements{}
+	m.Loudness.InputTP = -3.0
+	m.Noise.Floor = -70.0
+	return m
+}
+
+func TestRecordingAdvice(t *testing.T) {
+	t.Run("clean capture adds no line", func(t *testing.T) {
+		if got := RecordingAdvice(cleanAdviceMeasurements(), &AdaptiveDiagnostics{}); got != "" {
+			t.Errorf("RecordingAdvice = %q, want empty", got)
+		}
+	})
+
+	t.Run("nil measurements", func(t *testing.T) {
+		if got := RecordingAdvice(nil, nil); got != "" {
+			t.Errorf("RecordingAdvice(nil) = %q, want empty", got)
+		}
+	})
+
+	t.Run("noisy room", func(t *testing.T) {
+		m := cleanAdviceMeasurements()
+		m.Noise.Floor = -48.0
+		got := RecordingAdvice(m, nil)
+		if !strings.Contains(got, "floor at -48 ㏈") || !strings.Contains(got, "quieter") {
+			t.Errorf("RecordingAdvice = %q, want the noise-floor finding", got)
+		}
+	})
+
+	t.Run("flat-topped peaks under 0 dBTP", func(t *testing.T) {
+		m := cleanAdviceMeasurements()
+		m.Loudness.InputTP = -0.1
+		m.Clipping = &ClippingAnalysis{PeakSamples: 40}
+		if got := RecordingAdvice(m, nil); !strings.HasPrefix(got, "Clipped, peaks at -0.1 ㏈TP") {
+			t.Errorf("RecordingAdvice = %q, want the clipping finding first", got)
+		}
+	})
+
+	t.Run("voice-activated floor is not a room", func(t *testing.T) {
+		m := cleanAdviceMeasurements()
+		m.Noise.Floor = -48.0
+		m.Noise.VoiceActivated = true
+		if got := RecordingAdvice(m, nil); got != "" {
+			t.Errorf("RecordingAdvice = %q, want empty for a voice-activated source", got)
+		}
+	})
+
+	t.Run("prioritised and capped", func(t *testing.T) {
+		m := cleanAdviceMeasurements()
+		m.Loudness.InputTP = 0.4
+		m.Noise.Floor = -48.0
+		m.Noise.RumbleBurstCount = 6
+		m.Noise.RumbleBurstsPerMinute = 4.0
+		got := RecordingAdvice(m, &AdaptiveDiagnostics{NarrowbandSource: true})
+
+		if !strings.HasPrefix(got, "Clipped, peaks at +0.4 ㏈TP") {
+			t.Errorf("RecordingAdvice = %q, want clipping first", got)
+		}
+		if !strings.Contains(got, "Phone-quality") {
+			t.Errorf("RecordingAdvice = %q, want narrowband second", got)
+		}
+		if strings.Contains(got, "Noisy room") || strings.Contains(got, "Wind") {
+			t.Errorf("RecordingAdvice = %q, want at most %d findings", got, adviceMaxFindings)
+		}
+	})
+
+	t.Run("source AGC", func(t *testing.T) {
+		m := cleanAdviceMeasurements()
+		m.Noise.AGCDetected = true
+		if got := RecordingAdvice(m, nil); !strings.Contains(got, "turn AGC off") {
+			t.Errorf("RecordingAdvice = %q, want the AGC finding", got)
+		}
+	})
+
+	t.Run("tonal background and wind", func(t *testing.T) {
+		m := cleanAdviceMeasurements()
+		m.Regions.NoiseProfile = &NoiseProfile{}
+		m.Regions.NoiseProfile.Spectral.Found = true
+		m.Regions.NoiseProfile.Spectral.Flatness = 0.2
+		m.Noise.RumbleBurstCount = rumbleBurstMinCount
+		m.Noise.RumbleBurstsPerMinute = rumbleBurstFrequentPerMinute
+		got := RecordingAdvice(m, nil)
+		if !strings.HasPrefix(got, "Tonal background") || !strings.Contains(got, "2.0 a minute") {
+			t.Errorf("RecordingAdvice = %q, want hum then wind", got)
+		}
+	})
+}
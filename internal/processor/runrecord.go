@@ -35,11 +35,43 @@ type RunRecord struct {
 	// (see normalisationRecord); the source struct is untouched.
 	Normalisation *normalisationRecord `json:"normalisation,omitempty"`
 
+	// ProcessingImpact summarises the net gain applied and the input-to-final
+	// change in crest factor, dynamic range, and loudness range. nil + omitempty
+	// drops it when the run has no final stage (analysis-only, normalisation off).
+	ProcessingImpact *ProcessingImpact `json:"processing_impact,omitempty"`
+
+	// SplitChannels is each channel of a --channels split run, whose stage
+	// blocks above describe the left channel. nil + omitempty drops it on any
+	// other run.
+	SplitChannels *SplitChannelsRecord `json:"split_channels,omitempty"`
+
+	// Clarity is the input and final speech-clarity score (--clarity), referenced
+	// off ProcessingResult. nil + omitempty drops it when not requested.
+	Clarity *ClarityComparison `json:"clarity,omitempty"`
+
 	// IntervalSummary holds the per-250ms RMS distribution and gap summary. The
 	// full per-interval series lives in the .intervals.jsonl sidecar; the summary
 	// stays inline. nil + omitempty drops it when no intervals exist.
 	IntervalSummary *IntervalSummary `json:"interval_summary,omitempty"`
 
+	// Pauses is the gaps-between-speech summary from Pass 1 (pacing feedback),
+	// referenced off RegionMetrics. nil + omitempty drops it when the file has no
+	// gap between speech.
+	Pauses *PauseStatistics `json:"pauses,omitempty"`
+
+	// Polarity is the Pass 1 speech polarity reading, referenced off
+	// RegionMetrics. nil + omitempty drops it when there was too little speech.
+	Polarity *PolarityAnalysis `json:"polarity,omitempty"`
+
+	// Clipping is the Pass 1 clipping reading, referenced off
+	// AudioMeasurements. nil + omitempty drops it on an unclipped input.
+	Clipping *ClippingAnalysis `json:"clipping,omitempty"`
+
+	// InputGainAdvice is the input-gain coaching derived from the Pass 1 true
+	// peak and integrated loudness (newInputGainAdvice). nil + omitempty drops
+	// it when the input loudness was not measured.
+	InputGainAdvice *InputGainAdvice `json:"input_gain_advice,omitempty"`
+
 	// Spectrograms is the deterministic before/after (processing) or input
 	// (analysis-only) spectrogram image list, attached synchronously by the
 	// --diagnostics write site via deriveSpectrogramImages before the background
@@ -58,6 +90,32 @@ type RunProvenance struct {
 	DurationS    float64 `json:"duration_s"`
 	SampleRateHz int     `json:"sample_rate_hz"`
 	Channels     int     `json:"channels"`
+	// OutputChannels is the published output's channel count (--channels); zero
+	// for analysis-only runs, which write no output.
+	OutputChannels int `json:"output_channels,omitempty"`
+	OutputBitDepth int `json:"output_bit_depth,omitempty"` // Published output bit depth (--bit-depth); zero for analysis-only
+	// OutputSampleRateHz is the published output's rate (--output-rate); zero
+	// for analysis-only runs.
+	OutputSampleRateHz int `json:"output_sample_rate_hz,omitempty"`
+	// SlateS is the lead-in (--slate) ahead of the programme in the published
+	// file; every measurement in the record is of the programme alone.
+	SlateS float64 `json:"slate_s,omitempty"`
+	// QuietPreGainDB is the lift a very quiet input was analysed and filtered
+	// at (--quiet-pre-gain). The input measurements are of the source, the
+	// adapted filter settings of the lifted signal.
+	QuietPreGainDB float64 `json:"quiet_pre_gain_db,omitempty"`
+	// FixRegion is the repaired region (--fix-region) and how it was spliced
+	// back; every measurement in the record is of the region alone.
+	FixRegion *FixRegionSplice `json:"fix_region,omitempty"`
+	// Excerpt is the stretch of the input processed (--excerpt); every
+	// measurement and time in the record is of the excerpt alone.
+	Excerpt *ExcerptRange `json:"excerpt,omitempty"`
+	// AnalysisCached is set when the Pass 1 measurements were reused from the
+	// analysis cache rather than measured on this run.
+	AnalysisCached bool `json:"analysis_cached,omitempty"`
+	// Pass2Chunks is the number of chunks a long input was rendered in
+	// (--chunk-over); the Pass 2 figures are of the joined programme.
+	Pass2Chunks int `json:"pass2_chunks,omitempty"`
 }
 
 // RunVersion is the jivetalking version string injected via ldflags at build
@@ -131,6 +189,30 @@ type SpectralStages struct {
 type FiltersBlock struct {
 	EffectiveFilterConfig
 	Diagnostics *AdaptiveDiagnostics `json:"diagnostics,omitempty"`
+
+	// Graphs are the FFmpeg filter graphs the run applied, for reproducing it
+	// in ffmpeg. nil + omitempty drops them when no graph was recorded.
+	Graphs *FilterGraphs `json:"graphs,omitempty"`
+}
+
+// FilterGraphs are the resolved filter graph strings per pass: Pass 2
+// processing and Pass 4 normalisation, less loudnorm's per-run stats file.
+// Normalisation is empty when Pass 4 did not run.
+type FilterGraphs struct {
+	Processing    string `json:"processing"`
+	Normalisation string `json:"normalisation,omitempty"`
+}
+
+// newFilterGraphs collects the graphs off result; nil when Pass 2 recorded none.
+func newFilterGraphs(result *ProcessingResult) *FilterGraphs {
+	if result.Pass2FilterSpec == "" {
+		return nil
+	}
+	graphs := &FilterGraphs{Processing: result.Pass2FilterSpec}
+	if result.NormResult != nil {
+		graphs.Normalisation = result.NormResult.FilterSpec
+	}
+	return graphs
 }
 
 // IntervalSummary is the §8.1 `interval_summary` block: the RMS distribution and
@@ -286,12 +368,37 @@ func NewRunRecord(result *ProcessingResult) *RunRecord {
 
 	if result.Config != nil {
 		rec.Filters = newFiltersBlock(result.Config, result.Diagnostics)
+		rec.Filters.Graphs = newFilterGraphs(result)
+	}
+	// A split run's stages pair the left channel's input with the joined
+	// pair's output, which is no before and after; each channel's own stages
+	// are in split_channels instead.
+	if result.Split == nil {
+		rec.ProcessingImpact = newProcessingImpact(result)
 	}
+	rec.Clarity = result.Clarity
+	rec.SplitChannels = newSplitChannelsRecord(result.Split)
 
 	// Provenance not carried by AudioMeasurements: source sample rate / channels.
 	rec.Run.InputFile = filepath.Base(result.OutputPath)
 	rec.Run.SampleRateHz = result.InputMetadata.SampleRate
 	rec.Run.Channels = result.InputMetadata.Channels
+	rec.Run.OutputChannels = result.OutputChannels
+	if result.Slate.Enabled {
+		rec.Run.SlateS = result.Slate.Duration().Seconds()
+	}
+	rec.Run.FixRegion = result.FixRegion
+	rec.Run.Excerpt = result.Excerpt
+	rec.Run.AnalysisCached = result.AnalysisCached
+	rec.Run.Pass2Chunks = result.Pass2Chunks
+	if result.Config != nil {
+		rec.Run.OutputBitDepth = result.Config.Resample.BitDepth
+		rec.Run.QuietPreGainDB = result.Config.Downmix.PreGainDB
+		rec.Run.OutputSampleRateHz = result.Config.Resample.SampleRate
+		if rec.Run.OutputSampleRateHz == 0 {
+			rec.Run.OutputSampleRateHz = result.InputMetadata.SampleRate
+		}
+	}
 	if result.InputMetadata.DurationSecs > 0 {
 		rec.Run.DurationS = result.InputMetadata.DurationSecs
 	}
@@ -332,6 +439,10 @@ func newPass1Record(m *AudioMeasurements) *RunRecord {
 	rec.Noise = &m.Noise
 	rec.Regions = newRegionsBlock(&m.Regions)
 	rec.IntervalSummary = newIntervalSummary(m.Regions.IntervalSamples)
+	rec.Pauses = m.Regions.Pauses
+	rec.Polarity = m.Regions.Polarity
+	rec.Clipping = m.Clipping
+	rec.InputGainAdvice = newInputGainAdvice(m)
 	rec.Run.DurationS = m.Duration
 
 	return rec
@@ -433,6 +544,13 @@ func MarshalRunRecord(r *RunRecord) ([]byte, error) {
 	return json.MarshalIndent(tree, "", "  ")
 }
 
+// SanitiseJSON returns v as the generic tree MarshalRunRecord serialises, with
+// non-finite float64 leaves replaced by nil, for callers that embed a run
+// record in a larger JSON document (--json) and marshal it themselves.
+func SanitiseJSON(v any) any {
+	return sanitiseValue(reflect.ValueOf(v))
+}
+
 // jsonMarshalerType is the reflect.Type of json.Marshaler, used to detect
 // custom-marshalled leaves during the sanitise walk.
 var jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
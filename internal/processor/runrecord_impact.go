@@ -0,0 +1,72 @@
+package processor
+
+// ProcessingImpact is the §8.1 `processing_impact` block: a summary of how hard
+// the run pushed the audio. The gain terms come from the applied filter config
+// and normalisation result; the deltas compare the final stage against the input
+// on the same measurement axis (astats for crest factor and dynamic range,
+// ebur128 for loudness, LRA and true peak, aspectralstats for the centroid, and
+// the same room-tone region for the noise floor), so no value mixes axes. A
+// negative delta means the output is narrower, quieter or darker than the input.
+type ProcessingImpact struct {
+	// MakeupGainDB sums the static makeup stages in the filter chain: the speech
+	// gate makeup and the levelling compressor makeup (the slow levelling stage
+	// runs at unity).
+	MakeupGainDB        float64 `json:"makeup_gain_db"`
+	NormalisationGainDB float64 `json:"normalisation_gain_db"` // Linear loudnorm offset (NormalisationResult.GainApplied)
+	NetGainDB           float64 `json:"net_gain_db"`           // MakeupGainDB + NormalisationGainDB
+
+	LoudnessChangeLU     float64 `json:"loudness_change_lu"`      // Final minus input integrated loudness
+	CrestFactorChangeDB  float64 `json:"crest_factor_change_db"`  // Final minus input astats crest factor
+	DynamicRangeChangeDB float64 `json:"dynamic_range_change_db"` // Final minus input astats dynamic range
+	LRAChangeLU          float64 `json:"lra_change_lu"`           // Final minus input loudness range
+
+	TruePeakChangeDB         float64 `json:"true_peak_change_db"`         // Delivered minus input true peak
+	SpectralCentroidChangeHz float64 `json:"spectral_centroid_change_hz"` // Final minus input whole-file spectral centroid
+
+	// NoiseFloorChangeDB is the time-aligned room-tone change: the RMS level of
+	// the elected room-tone region in the final output minus the same region in
+	// the input. Nil when either side was not measured.
+	NoiseFloorChangeDB *float64 `json:"noise_floor_change_db,omitempty"`
+}
+
+// newProcessingImpact derives the processing_impact block from a completed
+// result. It needs the input measurements, the applied config, and the final
+// (post-normalisation) measurements; without any of them there is no before and
+// after to compare, so it returns nil and omitempty drops the block.
+func newProcessingImpact(result *ProcessingResult) *ProcessingImpact {
+	if result.Measurements == nil || result.Config == nil ||
+		result.NormResult == nil || result.NormResult.FinalMeasurements == nil {
+		return nil
+	}
+
+	in := result.Measurements
+	final := result.NormResult.FinalMeasurements
+	cfg := result.Config
+
+	impact := &ProcessingImpact{
+		NormalisationGainDB:  result.NormResult.GainApplied,
+		LoudnessChangeLU:     final.Loudness.OutputI - in.Loudness.InputI,
+		CrestFactorChangeDB:  final.Dynamics.CrestFactor - in.Dynamics.CrestFactor,
+		DynamicRangeChangeDB: final.Dynamics.DynamicRange - in.Dynamics.DynamicRange,
+		LRAChangeLU:          final.Loudness.OutputLRA - in.Loudness.InputLRA,
+
+		TruePeakChangeDB:         result.NormResult.OutputTP - in.Loudness.InputTP,
+		SpectralCentroidChangeHz: final.Spectral.Centroid - in.Spectral.Centroid,
+	}
+	if rtIn, rtOut := in.Regions.ElectedRoomToneSample, final.RoomToneSample; rtIn != nil && rtOut != nil {
+		change := rtOut.RMSLevel - rtIn.RMSLevel
+		impact.NoiseFloorChangeDB = &change
+	}
+
+	// Gate makeup is a linear multiplier (1.0 = unity); a non-positive value is
+	// not a valid agate setting and contributes nothing.
+	if cfg.SpeechGate.Enabled && cfg.SpeechGate.Makeup > 0 {
+		impact.MakeupGainDB += LinearAmplitude(cfg.SpeechGate.Makeup).Decibels().Float64()
+	}
+	if cfg.LevellingCompressor.Enabled {
+		impact.MakeupGainDB += cfg.LevellingCompressor.Makeup
+	}
+	impact.NetGainDB = impact.MakeupGainDB + impact.NormalisationGainDB
+
+	return impact
+}
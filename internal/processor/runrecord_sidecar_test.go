@@ -156,6 +156,32 @@ func TestWriteIntervalsSidecar_OneLinePerSample(t *testing.T) {
 	}
 }
 
+// TestWriteIntervalsCSV_HeaderAndRows asserts the CSV export writes a header
+// and one row per sample, seconds timestamps, and empty cells for -Inf.
+func TestWriteIntervalsCSV_HeaderAndRows(t *testing.T) {
+	samples := syntheticIntervals(3)
+	samples[2].MomentaryLUFS = math.Inf(-1)
+	var buf bytes.Buffer
+	if err := streamIntervalsCSV(&buf, samples); err != nil {
+		t.Fatalf("write intervals CSV: %v", err)
+	}
+
+	lines := nonEmptyLines(buf.String())
+	if len(lines) != len(samples)+1 {
+		t.Fatalf("line count = %d, want header + %d rows", len(lines), len(samples))
+	}
+	if lines[0] != strings.Join(intervalsCSVHeader, ",") {
+		t.Errorf("header = %q", lines[0])
+	}
+	row := strings.Split(lines[2], ",")
+	if len(row) != len(intervalsCSVHeader) || row[0] != "0.25" || row[1] != "-59" {
+		t.Errorf("row 1 = %q, want 20 columns from 0.25 s at -59 dBFS", lines[2])
+	}
+	if got := strings.Split(lines[3], ",")[16]; got != "" {
+		t.Errorf("-Inf momentary_lufs = %q, want an empty cell", got)
+	}
+}
+
 // TestWriteCandidatesSidecar_TaggedLines asserts the candidates sidecar emits one
 // speech line per candidate, each tagged with kind, total M lines.
 func TestWriteCandidatesSidecar_TaggedLines(t *testing.T) {
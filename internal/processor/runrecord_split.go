@@ -0,0 +1,57 @@
+package processor
+
+// SplitChannelsRecord is the `split_channels` block of a --channels split run:
+// how the processed channels joined and were trimmed onto the target, then one
+// record per channel. The record's stage blocks describe the left channel, so
+// this block is where the right channel's analysis and adapted chain live.
+type SplitChannelsRecord struct {
+	JoinedILUFS  float64 `json:"joined_integrated_lufs"` // Stereo loudness of the joined pair before the trim
+	JoinedTPDBTP float64 `json:"joined_true_peak_dbtp"`  // Stereo true peak of the joined pair before the trim
+	TrimDB       float64 `json:"trim_db"`                // Gain that took the pair onto the target; never positive
+
+	Channels []SplitChannelRecord `json:"channels"`
+}
+
+// SplitChannelRecord is one channel of a split run: its loudness per stage,
+// its own noise analysis, and the filters adapted to it. Every block is
+// referenced off the channel's ProcessingResult, as in NewRunRecord.
+type SplitChannelRecord struct {
+	Channel  string         `json:"channel"`
+	Loudness LoudnessStages `json:"loudness"`
+	Noise    *NoiseMetrics  `json:"noise,omitempty"`
+	Filters  *FiltersBlock  `json:"filters,omitempty"`
+}
+
+// newSplitChannelsRecord builds the split_channels block; nil when the run
+// did not split its channels.
+func newSplitChannelsRecord(split *SplitChannels) *SplitChannelsRecord {
+	if split == nil {
+		return nil
+	}
+	rec := &SplitChannelsRecord{
+		JoinedILUFS:  split.JoinedLUFS,
+		JoinedTPDBTP: split.JoinedTP,
+		TrimDB:       split.TrimDB,
+	}
+	for i, result := range split.Channels {
+		if result == nil {
+			continue
+		}
+		channel := SplitChannelRecord{Channel: splitChannelNames[i]}
+		if m := result.Measurements; m != nil {
+			channel.Loudness.Input = &m.Loudness
+			channel.Noise = &m.Noise
+		}
+		if fm := result.FilteredMeasurements; fm != nil {
+			channel.Loudness.Filtered = &fm.Loudness
+		}
+		if result.NormResult != nil && result.NormResult.FinalMeasurements != nil {
+			channel.Loudness.Final = &result.NormResult.FinalMeasurements.Loudness
+		}
+		if result.Config != nil {
+			channel.Filters = newFiltersBlock(result.Config, result.Diagnostics)
+		}
+		rec.Channels = append(rec.Channels, channel)
+	}
+	return rec
+}
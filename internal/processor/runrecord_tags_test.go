@@ -38,7 +38,7 @@ func populatedAudioMeasurements() *AudioMeasurements {
 
 	m.Loudness = InputLoudnessMetrics{
 		LoudnessMetrics: LoudnessMetrics{MomentaryLoudness: -17, ShortTermLoudness: -16.5, SamplePeak: -1.2},
-		InputI:          -18, InputTP: -1, InputLRA: 7, InputThresh: -28, TargetOffset: -2,
+		InputI:          -18, InputTP: -1, InputLRA: 7, InputThresh: -28, TargetOffset: -2, SpeechI: -17.2,
 	}
 	m.Dynamics = DynamicsMetrics{
 		DynamicRange: 12, RMSLevel: -22, PeakLevel: -3, RMSTrough: -45, RMSPeak: -18,
@@ -102,7 +102,7 @@ func TestAudioMeasurementsJSON_HasCanonicalKeys(t *testing.T) {
 		"loudness", "dynamics", "noise", "regions",
 		// loudness (§8.4 suffixes)
 		"integrated_lufs", "true_peak_dbtp", "lra_lu", "thresh_lufs", "target_offset_db",
-		"momentary_lufs", "short_term_lufs", "sample_peak_dbfs",
+		"momentary_lufs", "short_term_lufs", "sample_peak_dbfs", "speech_integrated_lufs",
 		// dynamics
 		"rms_level_dbfs", "peak_level_dbfs", "dynamic_range_db", "crest_factor_astats_db",
 		"rms_trough_dbfs", "rms_peak_dbfs", "dc_offset", "flat_factor",
@@ -111,6 +111,8 @@ func TestAudioMeasurementsJSON_HasCanonicalKeys(t *testing.T) {
 		// noise
 		"floor_dbfs", "floor_source", "floor_prescan_dbfs", "floor_astats_dbfs",
 		"reduction_headroom_db", "room_tone_detect_level_dbfs", "voice_activated",
+		"rumble_burst_count", "rumble_bursts_per_minute", "agc_rise_db", "agc_detected",
+		"speech_decay_ms",
 		// spectral suffixes (region/profile spectral blocks)
 		"centroid_hz", "spread_hz", "rolloff_hz",
 		// regions
@@ -343,6 +345,7 @@ func jsonKeySet(t *testing.T, v any) map[string]bool {
 func TestEffectiveFilterConfigJSON_HasCanonicalKeys(t *testing.T) {
 	cfg := EffectiveFilterConfig{
 		RumbleHighPass:      RumbleHighPassConfig{Enabled: true, Frequency: 80, Poles: 2, Width: 0.707, Mix: 1.0, Transform: "tdii"},
+		HumNotch:            HumNotchConfig{Enabled: true, Frequency: 50, Harmonics: 4, Q: 30},
 		BandlimitLowPass:    BandlimitLowPassConfig{Enabled: true, Frequency: 20500, Poles: 2, Width: 0.707, Mix: 1.0, Transform: "tdii"},
 		NoiseReduction:      NoiseReductionConfig{Enabled: true, Strength: 0.002, PatchSec: 0.02, ResearchSec: 0.06, Smooth: 11, AfftdnEnabled: true, AfftdnNoiseReduction: 12, AfftdnNoiseType: "custom", AfftdnBandNoise: "0.0|1.0", AfftdnTrackNoise: true},
 		SpeechGate:          SpeechGateConfig{Enabled: true, Threshold: 0.01, Ratio: 2.0, Attack: 10, Release: 250, Range: 0.05, Knee: 3.0, Makeup: 1.0, Detection: "rms"},
@@ -364,7 +367,9 @@ func TestEffectiveFilterConfigJSON_HasCanonicalKeys(t *testing.T) {
 		// levelling_compressor
 		"makeup_db",
 		// hp/lp
-		"frequency_hz", "poles_count", "width", "mix", "transform",
+		"frequency_hz", "poles_count", "stages_count", "width", "mix", "transform",
+		// hum_notch
+		"hum_notch", "harmonics_count", "q",
 		// noise_reduction
 		"strength", "patch_s", "research_s", "smooth",
 		"afftdn_noise_reduction_db", "afftdn_noise_type", "afftdn_track_noise", "afftdn_band_noise",
@@ -453,7 +458,8 @@ func TestNormalisationResultJSON_HasCanonicalKeys(t *testing.T) {
 		"gain_applied_db", "within_target", "skipped", "loudnorm_measured",
 		"requested_target_lufs", "effective_target_lufs", "linear_mode_forced",
 		"actual_norm_dynamic", "limiter_enabled", "ceiling_dbtp", "gain_db",
-		"filtered_dbtp", "pre_gain_db", "limiter_clamped", "pass3_filter_prefix",
+		"filtered_dbtp", "pre_gain_db", "limiter_clamped", "noise_peak_dbfs",
+		"noise_guard_raised", "pass3_filter_prefix",
 		"region_measurement_ns",
 		// LoudnormStats nested keys (unchanged FFmpeg loudnorm JSON keys)
 		"input_i", "normalization_type", "target_offset",
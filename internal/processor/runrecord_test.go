@@ -154,6 +154,63 @@ func TestRunRecord_AnalysisOnlyDropsProcessingBlocks(t *testing.T) {
 	}
 }
 
+func TestRunRecord_ProcessingImpact(t *testing.T) {
+	result := populatedProcessingResult()
+	result.NormResult.GainApplied = 2.5
+	result.Config.LevellingCompressor.Enabled = true
+	result.Config.LevellingCompressor.Makeup = 1.5
+	result.Config.SpeechGate.Enabled = true
+	result.Config.SpeechGate.Makeup = 1.0 // unity, contributes 0 dB
+
+	impact := NewRunRecord(result).ProcessingImpact
+	if impact == nil {
+		t.Fatal("processing_impact missing on a full record")
+	}
+
+	// Input fixture: I -18, LRA 7, crest 14, DR 12. Final: I -16, LRA 6, crest 13, DR 11.
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"MakeupGainDB", impact.MakeupGainDB, 1.5},
+		{"NormalisationGainDB", impact.NormalisationGainDB, 2.5},
+		{"NetGainDB", impact.NetGainDB, 4.0},
+		{"LoudnessChangeLU", impact.LoudnessChangeLU, 2},
+		{"CrestFactorChangeDB", impact.CrestFactorChangeDB, -1},
+		{"DynamicRangeChangeDB", impact.DynamicRangeChangeDB, -1},
+		{"LRAChangeLU", impact.LRAChangeLU, -1},
+		{"TruePeakChangeDB", impact.TruePeakChangeDB, result.NormResult.OutputTP - result.Measurements.Loudness.InputTP},
+		{"SpectralCentroidChangeHz", impact.SpectralCentroidChangeHz,
+			result.NormResult.FinalMeasurements.Spectral.Centroid - result.Measurements.Spectral.Centroid},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	// Room tone is compared only on the same region at both ends.
+	if impact.NoiseFloorChangeDB != nil {
+		t.Errorf("NoiseFloorChangeDB = %v without a final room-tone sample, want nil", *impact.NoiseFloorChangeDB)
+	}
+	result.NormResult.FinalMeasurements.RoomToneSample = &RegionSample{
+		RMSLevel: result.Measurements.Regions.ElectedRoomToneSample.RMSLevel - 12,
+	}
+	impact = NewRunRecord(result).ProcessingImpact
+	if impact.NoiseFloorChangeDB == nil || math.Abs(*impact.NoiseFloorChangeDB-(-12)) > 1e-9 {
+		t.Errorf("NoiseFloorChangeDB = %v, want -12", impact.NoiseFloorChangeDB)
+	}
+
+	// No final stage: nothing to compare, the block drops.
+	result.NormResult = nil
+	if got := NewRunRecord(result).ProcessingImpact; got != nil {
+		t.Errorf("processing_impact = %+v without a final stage, want nil", got)
+	}
+	if rec := NewAnalysisRunRecord("/tmp/episode.flac", populatedAudioMeasurements()); rec.ProcessingImpact != nil {
+		t.Error("analysis-only record must drop processing_impact")
+	}
+}
+
 func TestRunRecord_NonFiniteFloatSerialisesAsNull(t *testing.T) {
 	m := populatedAudioMeasurements()
 	m.Dynamics.RMSLevel = math.NaN()
@@ -466,3 +523,66 @@ func TestRunRecord_GateThresholdIsDecibels(t *testing.T) {
 		t.Errorf("range_db = %v, want a dB value near -22, not a linear amplitude", rng)
 	}
 }
+
+func TestRunRecord_FilterGraphs(t *testing.T) {
+	result := populatedProcessingResult()
+	tree, _ := marshalRecordTree(t, NewRunRecord(result))
+	if _, ok := tree["filters"].(map[string]any)["graphs"]; ok {
+		t.Error("graphs present without a recorded Pass 2 graph")
+	}
+
+	result.Pass2FilterSpec = "highpass=f=80"
+	result.NormResult.FilterSpec = "loudnorm=I=-16"
+	tree, _ = marshalRecordTree(t, NewRunRecord(result))
+	graphs, ok := tree["filters"].(map[string]any)["graphs"].(map[string]any)
+	if !ok {
+		t.Fatal("filters.graphs missing with a Pass 2 graph recorded")
+	}
+	if graphs["processing"] != "highpass=f=80" || graphs["normalisation"] != "loudnorm=I=-16" {
+		t.Errorf("graphs = %v, want the Pass 2 and Pass 4 graphs", graphs)
+	}
+}
+
+func TestRunRecord_SplitChannels(t *testing.T) {
+	result := populatedProcessingResult()
+	tree, _ := marshalRecordTree(t, NewRunRecord(result))
+	if _, ok := tree["split_channels"]; ok {
+		t.Error("split_channels present on a run that did not split")
+	}
+
+	right := populatedProcessingResult()
+	right.Measurements.Noise.Floor = -52
+	result.Split = &SplitChannels{
+		Channels:   [2]*ProcessingResult{populatedProcessingResult(), right},
+		JoinedLUFS: -15.2, JoinedTP: -1.1, TrimDB: -0.8,
+	}
+	rec := NewRunRecord(result)
+	if rec.ProcessingImpact != nil {
+		t.Error("processing_impact present on a split run")
+	}
+	split := rec.SplitChannels
+	if split == nil || len(split.Channels) != 2 {
+		t.Fatalf("split_channels = %+v, want two channels", split)
+	}
+	if split.Channels[0].Channel != "left" || split.Channels[1].Channel != "right" {
+		t.Errorf("channels = %q, %q, want left, right", split.Channels[0].Channel, split.Channels[1].Channel)
+	}
+	if split.Channels[1].Noise != &right.Measurements.Noise {
+		t.Error("right channel noise is not referenced off its own measurements")
+	}
+	if split.Channels[1].Loudness.Final != &right.NormResult.FinalMeasurements.Loudness {
+		t.Error("right channel final loudness is not referenced off its own result")
+	}
+	if split.Channels[1].Filters == nil || math.Abs(split.Channels[1].Filters.SpeechGate.Threshold-(-45)) > 1e-9 {
+		t.Errorf("right channel filters = %+v, want the dB-converted gate", split.Channels[1].Filters)
+	}
+
+	tree, _ = marshalRecordTree(t, rec)
+	block, ok := tree["split_channels"].(map[string]any)
+	if !ok {
+		t.Fatal("split_channels missing from the JSON")
+	}
+	if block["joined_integrated_lufs"] != -15.2 || block["trim_db"] != -0.8 {
+		t.Errorf("split_channels = %v, want the joined loudness and trim", block)
+	}
+}
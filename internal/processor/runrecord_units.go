@@ -172,9 +172,16 @@ type noiseProfileJSON struct {
 	BandNoise     []float64 `json:"band_noise_dbfs,omitempty"`
 	BandsMeasured bool      `json:"band_noise_measured,omitempty"`
 
+	Hum50RMS    float64 `json:"hum_50hz_dbfs,omitempty"`
+	Hum60RMS    float64 `json:"hum_60hz_dbfs,omitempty"`
+	HumMeasured bool    `json:"hum_measured,omitempty"`
+
 	OriginalStart    time.Duration `json:"original_start,omitempty"`
 	OriginalDuration time.Duration `json:"original_duration,omitempty"`
 	WasRefined       bool          `json:"was_refined,omitempty"`
+
+	Pinned bool `json:"pinned,omitempty"`
+	Loaded bool `json:"loaded,omitempty"`
 }
 
 // MarshalJSON preserves the flat spectral_* JSON contract while the Go model
@@ -211,9 +218,16 @@ func (p NoiseProfile) MarshalJSON() ([]byte, error) {
 		BandNoise:     p.BandNoise,
 		BandsMeasured: p.BandsMeasured,
 
+		Hum50RMS:    p.Hum50RMS,
+		Hum60RMS:    p.Hum60RMS,
+		HumMeasured: p.HumMeasured,
+
 		OriginalStart:    p.OriginalStart,
 		OriginalDuration: p.OriginalDuration,
 		WasRefined:       p.WasRefined,
+
+		Pinned: p.Pinned,
+		Loaded: p.Loaded,
 	}
 	return json.Marshal(sanitiseValue(reflect.ValueOf(flat)))
 }
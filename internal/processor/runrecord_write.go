@@ -2,11 +2,13 @@ package processor
 
 import (
 	"bufio"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -171,3 +173,63 @@ func WriteRunRecordSidecars(measurements *AudioMeasurements, recordPath string)
 	}
 	return WriteCandidatesSidecar(speech, CandidatesSidecarPath(recordPath))
 }
+
+// intervalsCSVHeader names the --export-intervals columns: the .intervals.jsonl
+// keys, with the timestamp in seconds rather than nanoseconds for plotting.
+var intervalsCSVHeader = []string{
+	"timestamp_s", "rms_level", "peak_level",
+	"spectral_mean", "spectral_variance", "spectral_centroid", "spectral_spread",
+	"spectral_skewness", "spectral_kurtosis", "spectral_entropy", "spectral_flatness",
+	"spectral_crest", "spectral_flux", "spectral_slope", "spectral_decrease", "spectral_rolloff",
+	"momentary_lufs", "short_term_lufs", "true_peak", "sample_peak",
+}
+
+// WriteIntervalsCSV writes the per-250ms IntervalSamples series as CSV
+// (--export-intervals): a header row, then one row per interval in order. A
+// non-finite value is an empty cell, the CSV counterpart of the sidecar's null.
+func WriteIntervalsCSV(samples []IntervalSample, path string) error {
+	return writeSidecarFile("intervals CSV", path, func(w io.Writer) error {
+		return streamIntervalsCSV(w, samples)
+	})
+}
+
+// streamIntervalsCSV writes the interval series to w as CSV rows. Factored out
+// so the file writer and the unit tests share the same path.
+func streamIntervalsCSV(w io.Writer, samples []IntervalSample) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(intervalsCSVHeader); err != nil {
+		return err
+	}
+	row := make([]string, len(intervalsCSVHeader))
+	for i := range samples {
+		s := &samples[i]
+		for j, v := range []float64{
+			s.Timestamp.Seconds(), s.RMSLevel, s.PeakLevel,
+			s.Spectral.Mean, s.Spectral.Variance, s.Spectral.Centroid, s.Spectral.Spread,
+			s.Spectral.Skewness, s.Spectral.Kurtosis, s.Spectral.Entropy, s.Spectral.Flatness,
+			s.Spectral.Crest, s.Spectral.Flux, s.Spectral.Slope, s.Spectral.Decrease, s.Spectral.Rolloff,
+			s.MomentaryLUFS, s.ShortTermLUFS, s.TruePeak, s.SamplePeak,
+		} {
+			row[j] = ""
+			if isFinite(v) {
+				row[j] = strconv.FormatFloat(v, 'g', -1, 64)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportIntervals writes the --export-intervals CSV when config asks for it. A
+// failure is a warning on diagnostics: the CSV is a side artefact.
+func exportIntervals(config *BaseFilterConfig, measurements *AudioMeasurements, diagnostics *AdaptiveDiagnostics) {
+	if config.IntervalsCSV == "" || measurements == nil {
+		return
+	}
+	if err := WriteIntervalsCSV(measurements.Regions.IntervalSamples, config.IntervalsCSV); err != nil && diagnostics != nil {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("intervals not exported: %v", err))
+	}
+}
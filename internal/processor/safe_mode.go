@@ -0,0 +1,34 @@
+package processor
+
+import "fmt"
+
+// safeModeConfig builds the fixed fallback chain --safe-mode uses when Pass 1
+// analysis fails. Nothing is adapted: every stage that needs measurements (the
+// rumble high-pass and band-limit low-pass, noise reduction, speech gate,
+// levelling compressor, and de-esser) is dropped, leaving the downmix, the Pass 2
+// analysis tap, and the output format (dithered at 16 bits as usual). Loudness
+// is then set by the normal Pass 3/4 normalisation, which measures the Pass 2
+// output itself. The returned diagnostics record why the file took this path.
+func safeModeConfig(base *BaseFilterConfig, analysisErr error) (*EffectiveFilterConfig, *AdaptiveDiagnostics) {
+	config := deriveEffectiveFilterConfig(base)
+	if config == nil {
+		return nil, nil
+	}
+
+	config.RumbleHighPass.Enabled = false
+	config.BandlimitLowPass.Enabled = false
+	config.NoiseReduction.Enabled = false
+	config.NoiseReduction.AfftdnEnabled = false
+	config.SpeechGate.Enabled = false
+	config.LevellingCompressor.Enabled = false
+	config.Deesser.Enabled = false
+
+	diagnostics := &AdaptiveDiagnostics{
+		SafeMode:          true,
+		BandlimitLPReason: "disabled: safe mode",
+		Warnings: []string{fmt.Sprintf(
+			"safe mode: analysis failed (%v); processed with loudness normalisation only", analysisErr)},
+	}
+	tuneOutputFormat(config, diagnostics, nil) // 16-bit output is still dithered
+	return config, diagnostics
+}
@@ -0,0 +1,31 @@
+package processor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSafeModeConfig(t *testing.T) {
+	config, diag := safeModeConfig(DefaultFilterConfig(), errors.New("ebur128 measurements not found"))
+	if config == nil || diag == nil {
+		t.Fatal("safeModeConfig returned nil")
+	}
+
+	spec := config.BuildFilterSpec()
+	for _, filter := range []string{"highpass", "lowpass", "anlmdn", "afftdn", "agate", "acompressor", "deesser"} {
+		if strings.Contains(spec, filter+"=") {
+			t.Errorf("safe mode spec contains %s: %s", filter, spec)
+		}
+	}
+	if !strings.Contains(spec, "aformat=") {
+		t.Errorf("safe mode spec lost the output format stage: %s", spec)
+	}
+
+	if !diag.SafeMode {
+		t.Error("diagnostics.SafeMode = false, want true")
+	}
+	if len(diag.Warnings) != 1 || !strings.Contains(diag.Warnings[0], "ebur128 measurements not found") {
+		t.Errorf("warnings = %q, want one naming the analysis error", diag.Warnings)
+	}
+}
@@ -0,0 +1,205 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"os"
+	"time"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Slate defaults and bounds (--slate). The line-up tone is a sine at the
+// delivery loudness so an engineer downstream can calibrate against it; the
+// silence separates it from the programme.
+const (
+	SlateDefaultTone      = 10 * time.Second
+	SlateDefaultFrequency = 1000.0
+	SlateDefaultSilence   = 2 * time.Second
+
+	slateMaxDuration  = 5 * time.Minute
+	slateMinFrequency = 20.0
+	slateMaxFrequency = 20000.0
+)
+
+// SlateConfig is the lead-in prepended to the delivered file (--slate): Tone of
+// a sine at Frequency Hz, then Silence. Either part may be zero, not both.
+type SlateConfig struct {
+	Enabled   bool
+	Tone      time.Duration
+	Frequency float64
+	Silence   time.Duration
+}
+
+// Duration is the total lead-in length.
+func (s SlateConfig) Duration() time.Duration {
+	return s.Tone + s.Silence
+}
+
+// SetSlate enables the slate with a tone of the given length and frequency
+// followed by silence. Set it after the loudness targets: a tone that would
+// peak above the true-peak ceiling to measure the delivery loudness, as a low
+// tone does once K-weighting discounts it, is rejected.
+func (cfg *BaseFilterConfig) SetSlate(tone time.Duration, frequency float64, silence time.Duration) error {
+	if tone < 0 || tone > slateMaxDuration {
+		return fmt.Errorf("slate tone %v is outside [0, %v]", tone, slateMaxDuration)
+	}
+	if silence < 0 || silence > slateMaxDuration {
+		return fmt.Errorf("slate silence %v is outside [0, %v]", silence, slateMaxDuration)
+	}
+	if tone == 0 && silence == 0 {
+		return fmt.Errorf("slate needs a tone or a silence")
+	}
+	if !isFinite(frequency) || frequency < slateMinFrequency || frequency > slateMaxFrequency {
+		return fmt.Errorf("slate frequency %.0f Hz is outside [%.0f, %.0f] Hz", frequency, slateMinFrequency, slateMaxFrequency)
+	}
+	if peak := cfg.slatePeakDB(frequency); tone > 0 && peak > cfg.Loudnorm.TargetTP {
+		return fmt.Errorf("a %.0f Hz slate tone at the delivery loudness peaks at %+.1f dBFS, above the %.1f dBTP ceiling",
+			frequency, peak, cfg.Loudnorm.TargetTP)
+	}
+	cfg.Slate = SlateConfig{Enabled: true, Tone: tone, Frequency: frequency, Silence: silence}
+	return nil
+}
+
+// slatePeakDB is the highest peak the slate tone can reach under cfg: on a
+// mono output, which carries the most level per channel, at the loudest
+// delivery target. A sine's true peak is its amplitude.
+func (cfg *BaseFilterConfig) slatePeakDB(frequency float64) float64 {
+	if cfg.Loudnorm.TargetMode() == TargetModeRMS {
+		return cfg.Loudnorm.TargetRMS + 10*math.Log10(2)
+	}
+	loudest := cfg.Loudnorm.TargetI
+	for _, target := range cfg.ExtraTargets {
+		loudest = max(loudest, target)
+	}
+	return slateToneAmplitudeDB(loudest, frequency, 1)
+}
+
+// BS.1770 K-weighting at 48 kHz: the high-shelf pre-filter then the RLB
+// high-pass, as published in the recommendation. -0.691 is the loudness
+// constant that cancels the weighting's gain at 997 Hz.
+var (
+	kWeightingShelfB = [3]float64{1.53512485958697, -2.69169618940638, 1.19839281085285}
+	kWeightingShelfA = [3]float64{1, -1.69065929318241, 0.73248077421585}
+	kWeightingRLBB   = [3]float64{1, -2, 1}
+	kWeightingRLBA   = [3]float64{1, -1.99004745483398, 0.99007225036621}
+)
+
+const (
+	kWeightingRateHz       = 48000.0
+	bs1770LoudnessOffsetDB = -0.691
+)
+
+// kWeightingGainDB is the K-weighting magnitude response at frequency Hz.
+func kWeightingGainDB(frequency float64) float64 {
+	z := cmplx.Exp(complex(0, -2*math.Pi*frequency/kWeightingRateHz))
+	biquad := func(b, a [3]float64) complex128 {
+		num := complex(b[0], 0) + complex(b[1], 0)*z + complex(b[2], 0)*z*z
+		den := complex(a[0], 0) + complex(a[1], 0)*z + complex(a[2], 0)*z*z
+		return num / den
+	}
+	h := biquad(kWeightingShelfB, kWeightingShelfA) * biquad(kWeightingRLBB, kWeightingRLBA)
+	return 20 * math.Log10(cmplx.Abs(h))
+}
+
+// slateToneAmplitudeDB is the peak level (dBFS) of a sine at frequency Hz on
+// each of channels identical channels that measures targetLUFS integrated
+// loudness. A sine's mean square sits 3.01 dB below its peak squared.
+func slateToneAmplitudeDB(targetLUFS, frequency float64, channels int) float64 {
+	sineRMSOffset := 10 * math.Log10(2)
+	return targetLUFS - bs1770LoudnessOffsetDB + sineRMSOffset - kWeightingGainDB(frequency) - 10*math.Log10(float64(channels))
+}
+
+// slateToneLevelDB is the tone's peak level for the delivery target: the
+// integrated loudness target, or in RMS mode a sine whose RMS is the target.
+func slateToneLevelDB(config *EffectiveFilterConfig, frequency float64, channels int) float64 {
+	if config.Loudnorm.TargetMode() == TargetModeRMS {
+		return config.Loudnorm.TargetRMS + 10*math.Log10(2)
+	}
+	return slateToneAmplitudeDB(config.Loudnorm.TargetI, frequency, channels)
+}
+
+// buildSlateSpec prepends the slate to the graph input: a dithered sine and a
+// silence generated in the delivered layout, rate and sample format, joined
+// ahead of [in] by concat.
+func buildSlateSpec(slate SlateConfig, levelDB float64, layout string, rate int, format string) string {
+	var spec string
+	segments := 0
+	if slate.Tone > 0 {
+		spec += fmt.Sprintf("aevalsrc=exprs=%.6f*sin(2*PI*%g*t):s=%d:c=%s:d=%g,aresample=osf=%s:dither_method=triangular[slate_tone];",
+			DbToLinear(levelDB), slate.Frequency, rate, layout, slate.Tone.Seconds(), format)
+		segments++
+	}
+	if slate.Silence > 0 {
+		spec += fmt.Sprintf("aevalsrc=exprs=0:s=%d:c=%s:d=%g,aformat=sample_fmts=%s[slate_silence];",
+			rate, layout, slate.Silence.Seconds(), format)
+		segments++
+	}
+	if slate.Tone > 0 {
+		spec += "[slate_tone]"
+	}
+	if slate.Silence > 0 {
+		spec += "[slate_silence]"
+	}
+	return spec + fmt.Sprintf("[in]concat=n=%d:v=0:a=1,aformat=sample_fmts=%s,asetnsamples=n=%d",
+		segments+1, format, defaultResampleConfig().FrameSize)
+}
+
+// applySlate rewrites the Pass 4 output at path with the slate ahead of the
+// programme. It runs after every measurement, so the reported figures are the
+// programme's own.
+func applySlate(ctx context.Context, path string, slate SlateConfig, config *EffectiveFilterConfig) error {
+	reader, metadata, err := audio.OpenAudioFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to open output: %w", err)
+	}
+	defer reader.Close()
+
+	layout := OutputChannelsMono
+	if metadata.Channels >= 2 {
+		layout = OutputChannelsStereo
+	}
+	levelDB := slateToneLevelDB(config, slate.Frequency, metadata.Channels)
+	spec := buildSlateSpec(slate, levelDB, layout, metadata.SampleRate, config.Resample.Format)
+
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), spec)
+	if err != nil {
+		return fmt.Errorf("failed to create slate filter graph: %w", err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	tempPath, err := processorCreateSiblingTempPath(path, "slate")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tempPath) }()
+
+	encoder, err := createOutputEncoder(tempPath, bufferSinkCtx)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			filteredFrame.SetTimeBase(ffmpeg.AVBuffersinkGetTimeBase(bufferSinkCtx))
+			if err := encoder.WriteFrame(filteredFrame); err != nil {
+				return fmt.Errorf("failed to write frame: %w", err)
+			}
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return fmt.Errorf("failed to flush encoder: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to close encoder: %w", err)
+	}
+	return publishOutput(tempPath, path)
+}
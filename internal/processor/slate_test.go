@@ -0,0 +1,104 @@
+package processor
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlateToneAmplitude(t *testing.T) {
+	// The -0.691 constant exists to cancel the weighting at 997 Hz.
+	if g := kWeightingGainDB(997); math.Abs(g+bs1770LoudnessOffsetDB) > 0.01 {
+		t.Errorf("K-weighting at 997 Hz = %.3f dB, want %.3f", g, -bs1770LoudnessOffsetDB)
+	}
+	// A 0 dBFS 997 Hz sine on one channel reads -3.01 LUFS.
+	if a := slateToneAmplitudeDB(-16, 997, 1); math.Abs(a-(-16+3.01)) > 0.02 {
+		t.Errorf("mono amplitude = %.2f dBFS, want about -12.99", a)
+	}
+	// Dual-mono doubles the summed power.
+	if a := slateToneAmplitudeDB(-16, 997, 2); math.Abs(a-(-16)) > 0.02 {
+		t.Errorf("stereo amplitude = %.2f dBFS, want about -16", a)
+	}
+	// The shelf lifts high frequencies, so a 10 kHz tone needs less level.
+	if slateToneAmplitudeDB(-16, 10000, 1) >= slateToneAmplitudeDB(-16, 1000, 1)-3 {
+		t.Error("10 kHz tone not compensated for the K-weighting shelf")
+	}
+}
+
+func TestBuildSlateSpec(t *testing.T) {
+	slate := SlateConfig{Enabled: true, Tone: 10 * time.Second, Frequency: 1000, Silence: 2 * time.Second}
+	spec := buildSlateSpec(slate, -13, OutputChannelsStereo, 48000, "s16")
+	for _, want := range []string{
+		"aevalsrc=exprs=0.223872*sin(2*PI*1000*t):s=48000:c=stereo:d=10,aresample=osf=s16:dither_method=triangular[slate_tone]",
+		"aevalsrc=exprs=0:s=48000:c=stereo:d=2,",
+		"[slate_tone][slate_silence][in]concat=n=3:v=0:a=1,aformat=sample_fmts=s16",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("spec %q missing %q", spec, want)
+		}
+	}
+
+	silenceOnly := buildSlateSpec(SlateConfig{Enabled: true, Silence: time.Second}, 0, OutputChannelsMono, 44100, "s32")
+	if strings.Contains(silenceOnly, "slate_tone") || !strings.Contains(silenceOnly, "[slate_silence][in]concat=n=2") {
+		t.Errorf("silence-only spec = %q", silenceOnly)
+	}
+}
+
+func TestSetSlate(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetSlate(SlateDefaultTone, SlateDefaultFrequency, SlateDefaultSilence); err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Slate.Enabled || cfg.Slate.Duration() != 12*time.Second {
+		t.Errorf("Slate = %+v, want enabled with a 12 s lead-in", cfg.Slate)
+	}
+
+	for name, err := range map[string]error{
+		"no tone or silence": cfg.SetSlate(0, 1000, 0),
+		"negative tone":      cfg.SetSlate(-time.Second, 1000, 0),
+		"too long":           cfg.SetSlate(time.Hour, 1000, 0),
+		"inaudible":          cfg.SetSlate(time.Second, 10, 0),
+		"over the ceiling":   cfg.SetSlate(time.Second, 20, 0),
+	} {
+		if err == nil {
+			t.Errorf("%s accepted, want an error", name)
+		}
+	}
+	if err := cfg.SetSlate(0, 20, time.Second); err != nil {
+		t.Errorf("silence-only slate rejected for its unused tone frequency: %v", err)
+	}
+}
+
+// TestSetSlatePeakUnderCeiling pins every accepted slate tone at or below the
+// true-peak ceiling, whatever its frequency and the delivery target.
+func TestSetSlatePeakUnderCeiling(t *testing.T) {
+	for _, target := range []float64{targetIMinLUFS, -23, -16, targetIMaxLUFS} {
+		for _, tp := range []float64{loudnormTPMinDB, -1, loudnormTPMaxDB} {
+			for frequency := slateMinFrequency; frequency <= slateMaxFrequency; frequency *= 1.25 {
+				cfg := DefaultFilterConfig()
+				if err := cfg.SetTargetLUFS(target); err != nil {
+					t.Fatal(err)
+				}
+				if err := cfg.SetTargetTP(tp); err != nil {
+					t.Fatal(err)
+				}
+				if cfg.SetSlate(time.Second, frequency, 0) != nil {
+					continue
+				}
+				if peak := slateToneAmplitudeDB(target, frequency, 1); peak > tp {
+					t.Errorf("%.0f Hz tone at %.0f LUFS accepted with a %.2f dBFS peak, above %.0f dBTP", frequency, target, peak, tp)
+				}
+			}
+		}
+	}
+
+	// The 1 kHz default fits at every target under the default ceiling.
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetTargetLUFS(targetIMaxLUFS); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetSlate(SlateDefaultTone, SlateDefaultFrequency, SlateDefaultSilence); err != nil {
+		t.Errorf("default slate rejected at %.0f LUFS: %v", targetIMaxLUFS, err)
+	}
+}
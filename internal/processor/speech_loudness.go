@@ -0,0 +1,101 @@
+package processor
+
+import (
+	"time"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+)
+
+// Speech-only integrated loudness. BS.1770 gating keeps every block above -70
+// LUFS and within 10 LU of the mean, so on a recording with long pauses the
+// quieter pause blocks still pull the integrated figure below the speech level.
+// The speech-only figure applies the same two gates (gatedLoudness) to the
+// momentary blocks that fall inside the Pass 1 speech regions.
+// --speech-loudness normalises that figure, rather than the gated programme, to
+// the target.
+
+// inSpeechRegion reports whether t falls inside one of the regions.
+func inSpeechRegion(regions []SpeechRegion, t time.Duration) bool {
+	for _, r := range regions {
+		if t >= r.Start && t < r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// speechOnlyLoudness integrates the Pass 1 interval momentary loudness over the
+// intervals that start inside a speech region. ok is false when no region holds
+// a block above the absolute gate.
+func speechOnlyLoudness(intervals []IntervalSample, regions []SpeechRegion) (lufs float64, ok bool) {
+	var blocks []float64
+	for _, iv := range intervals {
+		if inSpeechRegion(regions, iv.Timestamp) {
+			blocks = append(blocks, iv.MomentaryLUFS)
+		}
+	}
+	return gatedLoudness(blocks)
+}
+
+// speechLoudnessAccumulator collects the momentary loudness of the output
+// frames that end inside a speech region. Pass 2 and Pass 4 preserve the input
+// timeline, so the Pass 1 regions locate the speech in their output.
+type speechLoudnessAccumulator struct {
+	regions []SpeechRegion
+	elapsed time.Duration
+	blocks  []float64
+}
+
+// add advances the timeline by one frame of length d and keeps its momentary
+// loudness when the frame ends inside speech. found is false for a frame
+// without a momentary reading; it still advances the timeline.
+func (a *speechLoudnessAccumulator) add(d time.Duration, momentary float64, found bool) {
+	a.elapsed += d
+	if found && inSpeechRegion(a.regions, a.elapsed) {
+		a.blocks = append(a.blocks, momentary)
+	}
+}
+
+// observeFrame feeds one filtered frame's length and ebur128 momentary reading.
+func (a *speechLoudnessAccumulator) observeFrame(frame *ffmpeg.AVFrame) {
+	rate := frame.SampleRate()
+	if rate <= 0 {
+		return
+	}
+	var momentary float64
+	found := false
+	if metadata := frame.Metadata(); metadata != nil {
+		momentary, found = getFloatMetadata(metadata, metaKeyEbur128M)
+	}
+	a.add(time.Duration(frame.NbSamples())*time.Second/time.Duration(rate), momentary, found)
+}
+
+// loudness is the speech-only integrated loudness of the frames seen so far.
+func (a *speechLoudnessAccumulator) loudness() (float64, bool) {
+	return gatedLoudness(a.blocks)
+}
+
+// newSpeechLoudnessAccumulator returns an accumulator over the Pass 1 speech
+// regions, or nil when there are none.
+func newSpeechLoudnessAccumulator(measurements *AudioMeasurements) *speechLoudnessAccumulator {
+	if measurements == nil || len(measurements.Regions.SpeechRegions) == 0 {
+		return nil
+	}
+	return &speechLoudnessAccumulator{regions: measurements.Regions.SpeechRegions}
+}
+
+// speechTargetShift is how far --speech-loudness lowers the gated target: the
+// Pass 2 speech-only loudness minus the Pass 2 gated loudness. Normalising the
+// gated loudness to the target minus this gap lands the speech on the target.
+// ok is false when the option is off or either figure is missing.
+func speechTargetShift(loudnorm LoudnormConfig, output *OutputMeasurements) (shift float64, ok bool) {
+	if !loudnorm.SpeechOnly || output == nil {
+		return 0, false
+	}
+	speech := output.Loudness.SpeechI
+	gated := output.Loudness.OutputI
+	if speech == 0 || !isFinite(speech) || !isFinite(gated) || gated <= loudnessAbsoluteGateLUFS {
+		return 0, false
+	}
+	return speech - gated, true
+}
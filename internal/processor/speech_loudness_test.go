@@ -0,0 +1,97 @@
+package processor
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGatedLoudness(t *testing.T) {
+	// Equal blocks integrate to themselves.
+	if got, ok := gatedLoudness([]float64{-20, -20, -20}); !ok || math.Abs(got+20) > 1e-9 {
+		t.Errorf("steady blocks = %.2f, %v; want -20", got, ok)
+	}
+
+	// A block below -70 fails the absolute gate; one more than 10 LU under the
+	// mean fails the relative gate. Neither moves the result.
+	if got, ok := gatedLoudness([]float64{-20, -20, -45, -90}); !ok || math.Abs(got+20) > 1e-9 {
+		t.Errorf("gated blocks = %.2f, %v; want -20", got, ok)
+	}
+
+	if _, ok := gatedLoudness([]float64{-90, math.Inf(-1)}); ok {
+		t.Error("all blocks below the absolute gate should report not ok")
+	}
+}
+
+func TestSpeechOnlyLoudness(t *testing.T) {
+	// Speech at -18 LUFS for 2 s, then pause noise at -26 LUFS for 2 s. The
+	// pause blocks pass the relative gate, so they pull the gated figure down;
+	// the speech-only figure stays at the speech level.
+	var intervals []IntervalSample
+	for i := range 16 {
+		level := -18.0
+		if i >= 8 {
+			level = -26.0
+		}
+		intervals = append(intervals, IntervalSample{
+			Timestamp:     time.Duration(i) * analysisIntervalHop,
+			MomentaryLUFS: level,
+		})
+	}
+	regions := []SpeechRegion{{Start: 0, End: 2 * time.Second, Duration: 2 * time.Second}}
+
+	speech, ok := speechOnlyLoudness(intervals, regions)
+	if !ok || math.Abs(speech+18) > 1e-9 {
+		t.Errorf("speech-only = %.2f, %v; want -18", speech, ok)
+	}
+
+	var all []float64
+	for _, iv := range intervals {
+		all = append(all, iv.MomentaryLUFS)
+	}
+	gated, _ := gatedLoudness(all)
+	if gated >= speech-1 {
+		t.Errorf("gated %.2f should sit well below speech-only %.2f", gated, speech)
+	}
+
+	if _, ok := speechOnlyLoudness(intervals, nil); ok {
+		t.Error("no speech regions should report not ok")
+	}
+}
+
+func TestSpeechLoudnessAccumulatorFollowsTimeline(t *testing.T) {
+	acc := &speechLoudnessAccumulator{
+		regions: []SpeechRegion{{Start: time.Second, End: 2 * time.Second}},
+	}
+	frame := 500 * time.Millisecond
+	acc.add(frame, -30, true) // ends at 0.5 s: outside
+	acc.add(frame, -30, true) // ends at 1.0 s: inside
+	acc.add(frame, -20, false)
+	acc.add(frame, -20, true) // ends at 2.0 s: outside (end exclusive)
+
+	if len(acc.blocks) != 1 || acc.blocks[0] != -30 {
+		t.Fatalf("blocks = %v, want [-30]", acc.blocks)
+	}
+	if acc.elapsed != 2*time.Second {
+		t.Errorf("elapsed = %v, want 2s", acc.elapsed)
+	}
+}
+
+func TestSpeechTargetShift(t *testing.T) {
+	output := &OutputMeasurements{Loudness: OutputLoudnessMetrics{OutputI: -22, SpeechI: -19.5}}
+
+	if _, ok := speechTargetShift(LoudnormConfig{}, output); ok {
+		t.Error("shift should be off without --speech-loudness")
+	}
+
+	on := LoudnormConfig{SpeechOnly: true}
+	shift, ok := speechTargetShift(on, output)
+	if !ok || math.Abs(shift-2.5) > 1e-9 {
+		t.Errorf("shift = %.2f, %v; want 2.5", shift, ok)
+	}
+
+	unmeasured := &OutputMeasurements{Loudness: OutputLoudnessMetrics{OutputI: -22}}
+	if _, ok := speechTargetShift(on, unmeasured); ok {
+		t.Error("an unmeasured speech loudness should report not ok")
+	}
+}
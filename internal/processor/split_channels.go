@@ -0,0 +1,286 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/audio"
+)
+
+// Split-channel processing (--channels split). A two-channel input with one
+// speaker on each side is processed as two mono programmes: each channel gets
+// its own Pass 1 analysis, noise profile and adaptive chain, and runs the four
+// passes alone. The processed channels are then joined back into L/R and
+// trimmed onto the loudness target as a stereo pair. The channels are not
+// linked, so this suits separate voices, not a stereo image to be preserved.
+
+// splitChannelOffsetLU is how far above the target each channel is normalised.
+// The chain measures a mono programme as dual-mono, 3 LU above what the same
+// channel reads as one side of a stereo pair. With the offset each channel on
+// its own reads the target as a side of the pair; speech on both sides at once
+// reads louder, so the joined pair is only ever trimmed down and the true-peak
+// ceiling each channel was limited to still holds.
+const splitChannelOffsetLU = 3.01
+
+// splitChannelNames are the channels of a split run, in input order.
+var splitChannelNames = [2]string{"left", "right"}
+
+// SplitChannels records a --channels split run: each channel's own result,
+// left then right, and how the joined pair was brought onto the target.
+type SplitChannels struct {
+	Channels [2]*ProcessingResult
+
+	// JoinedLUFS and JoinedTP are the stereo loudness and true peak of the
+	// joined channels before the trim.
+	JoinedLUFS float64
+	JoinedTP   float64
+
+	// TrimDB is the gain that took the joined pair onto the target; zero or
+	// negative, since the pair is never raised.
+	TrimDB float64
+}
+
+// splitChannelConfig is the base config one channel runs with: a mono output
+// at 24 bits, so the only requantisation to the delivered depth is the join's,
+// normalised splitChannelOffsetLU above the target. The side artefacts that
+// describe a whole deliverable stay off, and its own output is an intermediate.
+func splitChannelConfig(config *BaseFilterConfig) *BaseFilterConfig {
+	channel := *config
+	channel.Resample.Channels = OutputChannelsMono
+	channel.Resample.Format = "s32"
+	channel.Resample.BitDepth = OutputBitDepth24
+	channel.Loudnorm.TargetI += splitChannelOffsetLU
+	channel.Loudnorm.Spec = ""
+	channel.OutputFormat = OutputFormatFLAC
+	channel.OutputDir, channel.OutputFile = "", ""
+	channel.OnExists = OnExistsOverwrite
+	channel.InPlace = false
+	channel.KeepCoverArt = false
+	channel.NoiseStem = false
+	channel.EmitFFmpegCommand = false
+	channel.Receipt = false
+	channel.WriteReceipt = false
+	channel.Clarity = false
+	channel.Slate = SlateConfig{}
+	channel.ExtraTargets = nil
+	channel.IntermediateDir = ""
+	channel.IntervalsCSV = ""
+	channel.NoiseProfileOut = ""
+	return &channel
+}
+
+// buildSplitJoinSpec joins the processed left channel on [in] and the right at
+// rightPath into L/R.
+func buildSplitJoinSpec(rightPath string) string {
+	return fmt.Sprintf("amovie=filename=%s[right];[in][right]join=inputs=2:channel_layout=stereo:map=0.0-FL|1.0-FR",
+		escapeFilterGraphOptionValue(rightPath))
+}
+
+// buildSplitOutputSpec joins the channels, applies trimDB and converts to the
+// delivered sample format, with TPDF dither at 16 bits.
+func buildSplitOutputSpec(rightPath string, trimDB float64, resample ResampleConfig) string {
+	var b strings.Builder
+	b.WriteString(buildSplitJoinSpec(rightPath))
+	if trimDB != 0 {
+		fmt.Fprintf(&b, ",volume=%.2fdB:precision=double", trimDB)
+	}
+	if resample.BitDepth == OutputBitDepth16 {
+		fmt.Fprintf(&b, ",aresample=osf=%s:dither_method=triangular", resample.Format)
+	}
+	fmt.Fprintf(&b, ",aformat=sample_fmts=%s:channel_layouts=%s,asetnsamples=n=%d",
+		resample.Format, OutputChannelsStereo, resample.FrameSize)
+	return b.String()
+}
+
+// splitTrimDB is the gain that brings a joined pair measured at joinedLUFS onto
+// targetI. A pair already at or below the target is left alone: raising it
+// would push the channels past the ceiling they were limited to.
+func splitTrimDB(joinedLUFS, targetI float64) float64 {
+	return math.Min(0, targetI-joinedLUFS)
+}
+
+// measureStereoLoudness reads the integrated loudness and true peak of
+// inputPath through spec, without writing anything. A two-channel signal is
+// measured as a stereo pair.
+func measureStereoLoudness(ctx context.Context, inputPath, spec string) (lufs, truePeak float64, err error) {
+	reader, _, err := audio.OpenAudioFile(inputPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open input: %w", err)
+	}
+	defer reader.Close()
+
+	filterGraph, bufferSrcCtx, bufferSinkCtx, err := setupFilterGraph(reader.DecoderContext(), spec+",ebur128=metadata=1:peak=true")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create loudness filter graph: %w", err)
+	}
+	defer ffmpeg.AVFilterGraphFree(&filterGraph)
+
+	// ebur128's integrated loudness and true peak are cumulative, so the
+	// latest frame's values describe the whole programme.
+	var lufsFound, peakFound bool
+	var peak float64
+	if err := runFilterGraph(ctx, reader, bufferSrcCtx, bufferSinkCtx, FrameLoopConfig{
+		OnFrame: func(_, filteredFrame *ffmpeg.AVFrame) error {
+			if metadata := filteredFrame.Metadata(); metadata != nil {
+				if value, ok := getFloatMetadata(metadata, metaKeyEbur128I); ok {
+					lufs, lufsFound = value, true
+				}
+				if value, ok := getFloatMetadata(metadata, metaKeyEbur128TruePeak); ok {
+					peak, peakFound = value, true
+				}
+			}
+			return nil
+		},
+	}); err != nil {
+		return 0, 0, err
+	}
+	if !lufsFound || !peakFound || !isFinite(lufs) {
+		return 0, 0, fmt.Errorf("no loudness measured")
+	}
+	return lufs, linearRatioToDB(peak), nil
+}
+
+// processSplitChannels is ProcessAudio for --channels split: it cuts each
+// channel of a two-channel input to a mono temp copy, runs the four passes on
+// each, joins the processed channels into L/R, trims the pair onto the target
+// and publishes it under the usual naming. The result's stage measurements,
+// config and normalisation describe the left channel, with the loudness,
+// true peak and layout of the delivered pair, and the input loudness of the
+// stereo source; Split holds both channels.
+func processSplitChannels(ctx context.Context, inputPath string, config *BaseFilterConfig, progressCallback ProgressCallback) (*ProcessingResult, error) {
+	if config.Loudnorm.TargetMode() != TargetModeLUFS || config.Loudnorm.SpeechOnly || len(config.ExtraTargets) > 0 {
+		return nil, fmt.Errorf("split channels are normalised to a single integrated loudness target")
+	}
+	reader, metadata, err := audio.OpenAudioFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %w", err)
+	}
+	reader.Close()
+	if metadata.Channels != 2 {
+		return nil, fmt.Errorf("%d-channel input: split channels needs a two-channel input", metadata.Channels)
+	}
+
+	// The channel copies go in a private directory beside the output, so a
+	// failed run leaves nothing behind.
+	anchor := config.outputAnchor(inputPath)
+	tempDir, err := os.MkdirTemp(filepath.Dir(anchor), ".split-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create split-channel directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	filename := filepath.Base(inputPath)
+	stem := strings.TrimSuffix(filename, filepath.Ext(filename))
+	split := &SplitChannels{}
+	for i, name := range splitChannelNames {
+		channelPath := filepath.Join(tempDir, stem+"-"+name+".flac")
+		cutSpec := fmt.Sprintf("pan=mono|c0=c%d,aformat=sample_fmts=s32", i)
+		cutPath, err := renderInputCopy(ctx, inputPath, channelPath, cutSpec, "split-"+name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cut the %s channel: %w", name, err)
+		}
+		if err := os.Rename(cutPath, channelPath); err != nil {
+			return nil, fmt.Errorf("failed to cut the %s channel: %w", name, err)
+		}
+		result, err := ProcessAudio(ctx, channelPath, splitChannelConfig(config), progressCallback)
+		if err != nil {
+			return nil, fmt.Errorf("%s channel: %w", name, err)
+		}
+		split.Channels[i] = result
+	}
+	left, right := split.Channels[0], split.Channels[1]
+
+	targetI := config.Loudnorm.TargetI
+	split.JoinedLUFS, split.JoinedTP, err = measureStereoLoudness(ctx, left.OutputPath, buildSplitJoinSpec(right.OutputPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure the joined channels: %w", err)
+	}
+	split.TrimDB = splitTrimDB(split.JoinedLUFS, targetI)
+	outputLUFS := split.JoinedLUFS + split.TrimDB
+	outputTP := split.JoinedTP + split.TrimDB
+
+	resample := config.Resample
+	resample.Channels = OutputChannelsStereo
+	resample.Dither = resample.BitDepth == OutputBitDepth16
+	joinedPath, err := renderInputCopy(ctx, left.OutputPath, anchor,
+		buildSplitOutputSpec(right.OutputPath, split.TrimDB, resample), "split-join")
+	if err != nil {
+		return nil, fmt.Errorf("failed to join the channels: %w", err)
+	}
+	defer func() { _ = os.Remove(joinedPath) }()
+
+	finalPath := config.processedOutputPath(inputPath, lufsFilenameValue(outputLUFS))
+	release := func() {}
+	if !sameFile(finalPath, inputPath) {
+		if finalPath, release, err = reserveOutputPath(finalPath, config.OnExists); err != nil {
+			return nil, err
+		}
+	}
+	if err := encodeOutputFormat(ctx, joinedPath, config.OutputFormat); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to write %s output: %w", config.OutputFormat, err)
+	}
+	if err := publishProcessedOutput(joinedPath, finalPath, inputPath, config.InPlace); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to publish output: %w", err)
+	}
+
+	result := *left
+	if inputLUFS, _, err := measureStereoLoudness(ctx, inputPath, "anull"); err == nil {
+		result.InputLUFS = inputLUFS
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	result.OutputPath = finalPath
+	result.OutputLUFS = outputLUFS
+	result.OutputChannels = 2
+	result.InputMetadata.Channels = metadata.Channels
+	result.Split = split
+
+	effective := *left.Config
+	effective.Resample = resample
+	result.Config = &effective
+
+	diagnostics := *left.Diagnostics
+	diagnostics.Warnings = nil
+	for i, channel := range split.Channels {
+		for _, warning := range channel.Diagnostics.Warnings {
+			diagnostics.Warnings = append(diagnostics.Warnings, splitChannelNames[i]+" channel: "+warning)
+		}
+	}
+	if outputLUFS < targetI-NormToleranceLU {
+		diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+			"split channels: the joined pair reads %.1f LUFS, short of the %.1f LUFS target", outputLUFS, targetI))
+	}
+	result.Diagnostics = &diagnostics
+
+	if left.NormResult != nil {
+		norm := *left.NormResult
+		norm.OutputLUFS = outputLUFS
+		norm.OutputTP = outputTP
+		norm.RequestedTargetI = targetI
+		norm.EffectiveTargetI = targetI
+		norm.WithinTarget = math.Abs(outputLUFS-targetI) <= NormToleranceLU
+		norm.SpecCompliance = checkLoudnessSpec(config.Loudnorm.Spec, outputLUFS, outputTP)
+		norm.LoudnormParsed = nil
+		if norm.FinalMeasurements != nil {
+			final := *norm.FinalMeasurements
+			final.Loudness.OutputI = outputLUFS
+			final.Loudness.OutputTP = outputTP
+			norm.FinalMeasurements = &final
+		}
+		result.NormResult = &norm
+	}
+
+	if config.KeepCoverArt {
+		if _, err := keepCoverArt(inputPath, finalPath); err != nil {
+			result.Diagnostics.Warnings = append(result.Diagnostics.Warnings, fmt.Sprintf("cover art not kept: %v", err))
+		}
+	}
+	return &result, nil
+}
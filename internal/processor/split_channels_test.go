@@ -0,0 +1,75 @@
+package processor
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSplitChannelConfig(t *testing.T) {
+	base := DefaultFilterConfig()
+	if err := base.SetLoudnessSpec("apple"); err != nil {
+		t.Fatal(err)
+	}
+	base.Resample.Channels = OutputChannelsSplit
+	base.OutputFile = "/out/final.flac"
+	base.OutputFormat = OutputFormatWAV
+	base.NoiseStem = true
+	base.KeepCoverArt = true
+
+	channel := splitChannelConfig(base)
+	if channel.Resample.Channels != OutputChannelsMono || channel.Resample.BitDepth != OutputBitDepth24 || channel.Resample.Format != "s32" {
+		t.Errorf("channel resample = %+v, want 24-bit mono", channel.Resample)
+	}
+	if channel.Loudnorm.TargetI != base.Loudnorm.TargetI+splitChannelOffsetLU || channel.Loudnorm.Spec != "" {
+		t.Errorf("channel target = %.2f LUFS (spec %q), want %.2f with no spec",
+			channel.Loudnorm.TargetI, channel.Loudnorm.Spec, base.Loudnorm.TargetI+splitChannelOffsetLU)
+	}
+	if channel.OutputFile != "" || channel.OutputFormat != OutputFormatFLAC || channel.NoiseStem || channel.KeepCoverArt {
+		t.Errorf("channel keeps a deliverable option: %+v", channel)
+	}
+	if base.Resample.Channels != OutputChannelsSplit || base.Loudnorm.Spec != "apple" || base.OutputFile == "" {
+		t.Error("splitChannelConfig changed the base config")
+	}
+}
+
+func TestBuildSplitOutputSpec(t *testing.T) {
+	resample := defaultResampleConfig()
+	spec := buildSplitOutputSpec("/tmp/a:b-right.flac", -1.5, resample)
+	for _, want := range []string{
+		`amovie=filename=/tmp/a\:b-right.flac[right];`,
+		"[in][right]join=inputs=2:channel_layout=stereo:map=0.0-FL|1.0-FR",
+		"volume=-1.50dB:precision=double",
+		"aresample=osf=s16:dither_method=triangular",
+		"aformat=sample_fmts=s16:channel_layouts=stereo,asetnsamples=n=4096",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("spec missing %q\n%s", want, spec)
+		}
+	}
+
+	resample.Format, resample.BitDepth = "s32", OutputBitDepth24
+	spec = buildSplitOutputSpec("/tmp/right.flac", 0, resample)
+	if strings.Contains(spec, "volume=") || strings.Contains(spec, "dither") {
+		t.Errorf("untrimmed 24-bit spec has a gain or dither stage\n%s", spec)
+	}
+}
+
+func TestSplitTrimDB(t *testing.T) {
+	tests := []struct {
+		name   string
+		joined float64
+		want   float64
+	}{
+		{"alternating speakers land on the target", -16, 0},
+		{"overlapping speech is trimmed down", -14.2, -1.8},
+		{"a quiet pair is never raised", -17, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitTrimDB(tt.joined, -16); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("splitTrimDB(%.1f) = %.2f, want %.2f", tt.joined, got, tt.want)
+			}
+		})
+	}
+}
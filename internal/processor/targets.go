@@ -0,0 +1,156 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Bounds on a --targets loudness, loudnorm's own I= range.
+const (
+	targetLUFSMin = -70.0
+	targetLUFSMax = -5.0
+)
+
+// TargetOutput is one extra rendering of the programme (--targets): the
+// loudness it was normalised to and where it was published.
+type TargetOutput struct {
+	TargetI    float64
+	OutputLUFS float64
+	Path       string
+}
+
+// SetLoudnessTargets renders the programme at each integrated loudness in
+// targets. The first becomes the primary target; the rest are rendered from the
+// same Pass 1 analysis and Pass 2 output, so only the normalisation repeats.
+// Each target must name a distinct output file.
+func (cfg *BaseFilterConfig) SetLoudnessTargets(targets []float64) error {
+	if len(targets) == 0 {
+		return fmt.Errorf("no loudness targets given")
+	}
+	names := make(map[int]float64, len(targets))
+	for _, t := range targets {
+		if !isFinite(t) || t < targetLUFSMin || t > targetLUFSMax {
+			return fmt.Errorf("loudness target %.1f LUFS is outside [%.0f, %.0f] LUFS", t, targetLUFSMin, targetLUFSMax)
+		}
+		name := lufsFilenameValue(t)
+		if prev, ok := names[name]; ok {
+			return fmt.Errorf("loudness targets %.1f and %.1f LUFS would both be written as -LUFS-%d", prev, t, name)
+		}
+		names[name] = t
+	}
+	cfg.Loudnorm.TargetI = targets[0]
+	cfg.ExtraTargets = append([]float64(nil), targets[1:]...)
+	return nil
+}
+
+// copyPass2ForTargets copies the Pass 2 output once per extra target before the
+// primary normalisation renders over it. On error the copies made so far are
+// removed.
+func copyPass2ForTargets(pass2Path string, count int) ([]string, error) {
+	paths := make([]string, 0, count)
+	for range count {
+		path, err := copySiblingTemp(pass2Path, "target")
+		if err != nil {
+			removeTempPaths(paths)
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// removeTempPaths removes temp files that were not published.
+func removeTempPaths(paths []string) {
+	for _, path := range paths {
+		_ = os.Remove(path)
+	}
+}
+
+// renderExtraTargets normalises each Pass 2 copy to its extra target with the
+// primary's effective config, then publishes it under its measured loudness as
+// the primary is. A target whose output name is already taken by an earlier
+// output is reported and left unpublished rather than overwriting it.
+func renderExtraTargets(ctx context.Context, inputPath string, copies []string, config *BaseFilterConfig,
+	effective *EffectiveFilterConfig, filtered *OutputMeasurements, measurements *AudioMeasurements,
+	published []string, diagnostics *AdaptiveDiagnostics,
+) ([]TargetOutput, error) {
+	var outputs []TargetOutput
+	for i, target := range config.ExtraTargets {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		targeted := *effective
+		targeted.Loudnorm.TargetI = target
+		norm, err := ApplyNormalisation(ctx, copies[i], &targeted, filtered, measurements, nil, config.logger)
+		if err != nil {
+			return nil, fmt.Errorf("%.1f LUFS target failed: %w", target, err)
+		}
+		if config.Slate.Enabled {
+			if err := applySlate(ctx, copies[i], config.Slate, &targeted); err != nil {
+				return nil, fmt.Errorf("%.1f LUFS target slate failed: %w", target, err)
+			}
+		}
+
+		outputLUFS := norm.OutputLUFS
+		if norm.Skipped {
+			outputLUFS = filtered.Loudness.OutputI
+		}
+		finalPath := generateLUFSOutputPath(config.outputAnchor(inputPath), lufsFilenameValue(outputLUFS), config.outputExt())
+		if containsPath(published, finalPath) {
+			diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+				"%.1f LUFS target not written: it measured %.1f LUFS, the name of an earlier output", target, outputLUFS))
+			continue
+		}
+		release := func() {}
+		if !sameFile(finalPath, inputPath) {
+			resolved, releaseResolved, err := reserveOutputPath(finalPath, config.OnExists)
+			if errors.Is(err, ErrOutputSkipped) {
+				diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf(
+					"%.1f LUFS target not written: %v", target, err))
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			finalPath, release = resolved, releaseResolved
+		}
+		if err := encodeOutputFormat(ctx, copies[i], config.OutputFormat); err != nil {
+			release()
+			return nil, fmt.Errorf("%.1f LUFS target %s output failed: %w", target, config.OutputFormat, err)
+		}
+		if err := publishProcessedOutput(copies[i], finalPath, inputPath, config.InPlace); err != nil {
+			release()
+			return nil, fmt.Errorf("failed to publish %.1f LUFS target: %w", target, err)
+		}
+		published = append(published, finalPath)
+
+		if config.KeepCoverArt {
+			if _, err := keepCoverArt(inputPath, finalPath); err != nil {
+				diagnostics.Warnings = append(diagnostics.Warnings, fmt.Sprintf("cover art not kept on %.1f LUFS target: %v", target, err))
+			}
+		}
+		outputs = append(outputs, TargetOutput{TargetI: target, OutputLUFS: outputLUFS, Path: finalPath})
+	}
+	return outputs, nil
+}
+
+// containsPath reports whether paths holds a path naming the same file as path.
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if sameFile(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetPaths lists the published extra target outputs in --targets order.
+func (r *ProcessingResult) TargetPaths() []string {
+	paths := make([]string, len(r.TargetOutputs))
+	for i, o := range r.TargetOutputs {
+		paths[i] = o.Path
+	}
+	return paths
+}
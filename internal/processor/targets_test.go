@@ -0,0 +1,52 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetLoudnessTargets(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	if err := cfg.SetLoudnessTargets([]float64{-14, -16, -23}); err != nil {
+		t.Fatalf("SetLoudnessTargets: %v", err)
+	}
+	if cfg.Loudnorm.TargetI != -14 || len(cfg.ExtraTargets) != 2 || cfg.ExtraTargets[1] != -23 {
+		t.Errorf("targets = %.1f + %v, want -14 + [-16 -23]", cfg.Loudnorm.TargetI, cfg.ExtraTargets)
+	}
+
+	for _, bad := range [][]float64{nil, {-16, -80}, {-16, 0}, {-16, -15.8}} {
+		if err := DefaultFilterConfig().SetLoudnessTargets(bad); err == nil {
+			t.Errorf("SetLoudnessTargets(%v) = nil, want error", bad)
+		}
+	}
+}
+
+func TestCopyPass2ForTargets(t *testing.T) {
+	dir := t.TempDir()
+	pass2 := filepath.Join(dir, ".processing-1.tmp.flac")
+	if err := os.WriteFile(pass2, []byte("pass 2 audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	copies, err := copyPass2ForTargets(pass2, 2)
+	if err != nil {
+		t.Fatalf("copyPass2ForTargets: %v", err)
+	}
+	if len(copies) != 2 || copies[0] == copies[1] {
+		t.Fatalf("copies = %v, want two distinct paths", copies)
+	}
+	for _, path := range copies {
+		got, err := os.ReadFile(path)
+		if err != nil || string(got) != "pass 2 audio" {
+			t.Errorf("copy %s = %q (%v), want the Pass 2 contents", path, got, err)
+		}
+	}
+
+	removeTempPaths(copies)
+	for _, path := range copies {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("copy %s left behind", path)
+		}
+	}
+}
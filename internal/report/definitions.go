@@ -39,6 +39,11 @@ var Definitions = map[string]Definition{
 		Unit:  "LUFS",
 		Gloss: "Gated programme loudness over the whole input, BS.1770 K-weighted mean-square with two-stage gating.",
 	},
+	"speech_integrated_lufs": {
+		Label: "Speech-only loudness",
+		Unit:  "LUFS",
+		Gloss: "Integrated loudness over the detected speech regions only, with the same BS.1770 absolute and relative gates.",
+	},
 	"true_peak_dbtp": {
 		Label: "True peak",
 		Unit:  "dBTP",
@@ -255,6 +260,85 @@ var Definitions = map[string]Definition{
 		Unit:  "dB",
 		Gloss: "Gap in dB between the noise floor and quiet speech.",
 	},
+	"rumble_burst_count": {
+		Label: "Rumble bursts",
+		Unit:  "count",
+		Gloss: "Short (up to 1 s) low-frequency bursts well above the noise floor with a spectral centroid below the speech band: wind buffeting and handling thumps.",
+	},
+	"rumble_bursts_per_minute": {
+		Label: "Rumble burst rate",
+		Unit:  "per min",
+		Gloss: "Rumble bursts per minute of audio; at 2 or more (with at least 3 bursts) the rumble high-pass is cascaded to 24 dB/oct.",
+	},
+	"agc_rise_db": {
+		Label: "AGC swell",
+		Unit:  "dB",
+		Gloss: "Median level rise from the start to the end of pauses of 2 s or more. Steady room tone stays flat; a recorder's automatic gain control makes the noise swell through each pause.",
+	},
+	"agc_detected": {
+		Label: "Source AGC",
+		Unit:  "",
+		Gloss: "Automatic gain control detected: a swell of 4 dB or more across at least 3 long pauses. The noise floor is then read from the quietest point of pauses up to 1 s long (floor source agc_troughs).",
+	},
+	"speech_decay_ms": {
+		Label: "Speech decay",
+		Unit:  "ms",
+		Gloss: "Median time the level stays more than 6 dB above the pause floor after a phrase ends, over at least 5 pauses of 1 s or more: the room's reverb tail, in 250 ms steps. Longer than the gate release, the gate may cut the tail.",
+	},
+
+	// -------------------------------------------------------------------------
+	// Processing impact (input -> final deltas, net gain)
+	// -------------------------------------------------------------------------
+	"makeup_gain_db": {
+		Label: "Makeup gain",
+		Unit:  "dB",
+		Gloss: "Sum of the static makeup in the filter chain: speech gate makeup plus levelling compressor makeup.",
+	},
+	"normalisation_gain_db": {
+		Label: "Normalisation gain",
+		Unit:  "dB",
+		Gloss: "Linear loudnorm offset applied in Pass 4, effective target minus the measured filtered loudness.",
+	},
+	"net_gain_db": {
+		Label: "Net gain",
+		Unit:  "dB",
+		Gloss: "Makeup gain plus normalisation gain.",
+	},
+	"loudness_change_lu": {
+		Label: "Loudness change",
+		Unit:  "LU",
+		Gloss: "Final integrated loudness minus input integrated loudness.",
+	},
+	"crest_factor_change_db": {
+		Label: "Crest factor change",
+		Unit:  "dB",
+		Gloss: "Final astats crest factor minus input astats crest factor; negative when peaks moved closer to the RMS level.",
+	},
+	"dynamic_range_change_db": {
+		Label: "Dynamic range change",
+		Unit:  "dB",
+		Gloss: "Final astats dynamic range minus input astats dynamic range.",
+	},
+	"lra_change_lu": {
+		Label: "Loudness range change",
+		Unit:  "LU",
+		Gloss: "Final loudness range minus input loudness range.",
+	},
+	"true_peak_change_db": {
+		Label: "True peak change",
+		Unit:  "dB",
+		Gloss: "Delivered true peak minus input true peak.",
+	},
+	"spectral_centroid_change_hz": {
+		Label: "Spectral centroid change",
+		Unit:  "Hz",
+		Gloss: "Final whole-file spectral centroid minus input; negative when the balance moved darker.",
+	},
+	"noise_floor_change_db": {
+		Label: "Noise floor change",
+		Unit:  "dB",
+		Gloss: "RMS of the elected room-tone region in the final output minus the same region in the input; omitted when either was not measured.",
+	},
 
 	// -------------------------------------------------------------------------
 	// Regions: elected profile bounds and election-only fields
@@ -319,6 +403,16 @@ var Definitions = map[string]Definition{
 		Unit:  "dBFS",
 		Gloss: "RMS over the 6-9 kHz sibilant band of the elected speech region.",
 	},
+	"speech_band_body_peak_rms_dbfs": {
+		Label: "Body-band peak RMS",
+		Unit:  "dBFS",
+		Gloss: "RMS of the loudest 50 ms window in the 1-3 kHz band of the elected speech region.",
+	},
+	"speech_band_sib_peak_rms_dbfs": {
+		Label: "Sibilant-band peak RMS",
+		Unit:  "dBFS",
+		Gloss: "RMS of the loudest 50 ms window in the 6-9 kHz band of the elected speech region; catches sharp sibilance the average dilutes.",
+	},
 	"score": {
 		Label: "Score",
 		Unit:  "",
@@ -373,6 +467,64 @@ var Definitions = map[string]Definition{
 		Unit:  "dBFS",
 		Gloss: "Highest interval RMS above digital silence.",
 	},
+
+	// -------------------------------------------------------------------------
+	// Pauses (gaps between speech intervals)
+	// -------------------------------------------------------------------------
+	"pause_count": {
+		Label: "Pauses",
+		Unit:  "count",
+		Gloss: "Number of non-speech runs of 250 ms intervals bounded by speech on both sides.",
+	},
+	"pause_mean_s": {
+		Label: "Mean pause",
+		Unit:  "s",
+		Gloss: "Mean pause length, quantised to the 250 ms analysis hop.",
+	},
+	"pause_median_s": {
+		Label: "Median pause",
+		Unit:  "s",
+		Gloss: "Nearest-rank median pause length.",
+	},
+	"pause_long_threshold_s": {
+		Label: "Long-pause threshold",
+		Unit:  "s",
+		Gloss: "Pause length at or above which a pause is counted as long.",
+	},
+	"pause_long_count": {
+		Label: "Long pauses",
+		Unit:  "count",
+		Gloss: "Number of pauses at or above the long-pause threshold.",
+	},
+	"pause_longest_s": {
+		Label: "Longest pause",
+		Unit:  "s",
+		Gloss: "Length of the longest pause.",
+	},
+	"pause_longest_start_s": {
+		Label: "Longest pause start",
+		Unit:  "s",
+		Gloss: "Timeline position where the longest pause begins.",
+	},
+
+	// -------------------------------------------------------------------------
+	// Polarity (Pass 1 speech)
+	// -------------------------------------------------------------------------
+	"polarity_verdict": {
+		Label: "Polarity",
+		Unit:  "",
+		Gloss: "Speech polarity read from the residual skewness: normal, inverted, or undetermined when the asymmetry is too weak.",
+	},
+	"polarity_residual_skewness": {
+		Label: "Residual skewness",
+		Unit:  "",
+		Gloss: "Skewness of the pre-emphasised speech; negative on a normally wired chain, positive when inverted.",
+	},
+	"polarity_corrected": {
+		Label: "Corrected",
+		Unit:  "",
+		Gloss: "Whether --fix-polarity inverted the output.",
+	},
 }
 
 // requiredKeys is the set of RunRecord field names the loudness, dynamics, and
@@ -382,6 +534,7 @@ var Definitions = map[string]Definition{
 var requiredKeys = []string{
 	// Loudness
 	"integrated_lufs",
+	"speech_integrated_lufs",
 	"true_peak_dbtp",
 	"lra_lu",
 	"sample_peak_dbfs",
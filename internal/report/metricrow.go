@@ -68,6 +68,18 @@ func stageGetter[T any](s *T, f func(*T) float64) func() (float64, bool) {
 	return func() (float64, bool) { return f(s), true }
 }
 
+// measuredStageGetter is stageGetter for a metric whose zero value means "not
+// measured": a zero reading renders the placeholder rather than 0.
+func measuredStageGetter[T any](s *T, f func(*T) float64) func() (float64, bool) {
+	if s == nil {
+		return nil
+	}
+	return func() (float64, bool) {
+		v := f(s)
+		return v, v != 0
+	}
+}
+
 // formatCell formats one stage value through the row's metric rule, returning the
 // placeholder when the stage is absent (getter nil or reporting false).
 func formatCell(getter func() (float64, bool), format metricFormat) string {
@@ -12,16 +12,20 @@ import (
 //
 // Section order, with the Spectrograms slot after Regions:
 //
-//	Header -> Processing Summary -> Loudness -> Dynamics -> Spectral ->
-//	Noise Floor -> Regions -> Spectrograms (slot) -> Interval Summary ->
-//	Filter Chain -> Peak Limiter + Loudnorm (renderNormalisation).
+//	Header -> Processing Summary -> Delivery Spec -> Recording Advice ->
+//	Split Channels -> Loudness -> Dynamics ->
+//	Processing Impact -> Spectral -> Noise Floor -> Regions -> Spectrograms (slot) ->
+//	Interval Summary -> Pauses -> Polarity -> Filter Chain -> Peak Limiter + Loudnorm
+//	(renderNormalisation).
 //
 // A renderer that returns "" contributes nothing - no heading, no blank section.
 // This is how analysis-only / Pass-1-only records naturally drop the processing-
 // only blocks: renderProcessingSummary is empty for zero Timings,
 // renderSpectrograms is empty when the record carries no Spectrograms, and
-// renderFilters / renderNormalisation return "" when their record blocks are
-// absent. Non-empty sections are joined with one blank line between them.
+// renderSpecCompliance / renderSplitChannels / renderProcessingImpact /
+// renderClarity / renderFilters / renderNormalisation return "" when their
+// record blocks are absent. Non-empty sections are joined with one blank line
+// between them.
 func RenderMarkdown(rec *processor.RunRecord, timings Timings) string {
 	if rec == nil {
 		return ""
@@ -30,13 +34,20 @@ func RenderMarkdown(rec *processor.RunRecord, timings Timings) string {
 	sections := []string{
 		renderHeader(rec),
 		renderProcessingSummary(timings),
+		renderSpecCompliance(rec),
+		renderRecordingAdvice(rec),
+		renderSplitChannels(rec),
 		renderLoudness(rec),
 		renderDynamics(rec),
+		renderProcessingImpact(rec),
+		renderClarity(rec),
 		renderSpectral(rec),
 		renderNoiseFloor(rec),
 		renderRegions(rec),
 		renderSpectrograms(rec),
 		renderIntervalSummary(rec),
+		renderPauses(rec),
+		renderPolarity(rec),
 		renderFilters(rec),
 		renderNormalisation(rec),
 	}
@@ -29,6 +29,21 @@ func fullProcessingRecord() *processor.RunRecord {
 	rec.Noise = regions.Noise
 	rec.Regions = regions.Regions
 	rec.IntervalSummary = regions.IntervalSummary
+	rec.Pauses = regions.Pauses
+
+	// Input-to-final deltas consistent with the staged loudness fixture.
+	noiseFloorChange := -18.6
+	rec.ProcessingImpact = &processor.ProcessingImpact{
+		NormalisationGainDB:      9.05,
+		NetGainDB:                9.05,
+		LoudnessChangeLU:         19.17,
+		CrestFactorChangeDB:      -6.4,
+		DynamicRangeChangeDB:     -12.25,
+		LRAChangeLU:              -7.91,
+		TruePeakChangeDB:         -3.2,
+		SpectralCentroidChangeHz: -412,
+		NoiseFloorChangeDB:       &noiseFloorChange,
+	}
 	return rec
 }
 
@@ -54,10 +69,12 @@ func TestRenderMarkdownSectionOrder(t *testing.T) {
 		"## Processing Summary",
 		"## Loudness",
 		"## Dynamics",
+		"## Processing Impact",
 		"## Spectral",
 		"## Noise Floor",
 		"## Regions",
 		"## Interval Summary",
+		"## Pauses",
 		"## Filter Chain",
 		"## Peak Limiter",
 		"## Loudnorm",
@@ -83,6 +100,7 @@ func TestRenderMarkdownAnalysisOnlyOmitsProcessingSections(t *testing.T) {
 	// Processing-only sections must be ABSENT.
 	for _, banned := range []string{
 		"## Processing Summary",
+		"## Processing Impact",
 		"## Filter Chain",
 		"## Peak Limiter",
 		"## Loudnorm",
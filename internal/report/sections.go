@@ -9,11 +9,11 @@ import (
 )
 
 // This file holds the per-domain section renderers: Header, Processing Summary,
-// Loudness, Dynamics, Spectral, Noise Floor, Regions, and Interval Summary. Each
-// is a pure func(...) string reading ONLY the run record (and Timings for the
-// summary) - no AudioMeasurements, no .json re-read, no internal/logging. The
-// metric-table engine lives in metricrow.go; the filter/normalisation and
-// spectrogram renderers live in sections_filters.go and
+// Loudness, Dynamics, Processing Impact, Spectral, Noise Floor, Regions,
+// Interval Summary, and Pauses. Each is a pure func(...) string reading ONLY the run record
+// (and Timings for the summary) - no AudioMeasurements, no .json re-read, no
+// internal/logging. The metric-table engine lives in metricrow.go; the
+// filter/normalisation and spectrogram renderers live in sections_filters.go and
 // sections_spectrograms.go.
 
 // =============================================================================
@@ -37,6 +37,36 @@ func renderHeader(rec *processor.RunRecord) string {
 		{"Sample rate", formatSampleRate(rec.Run.SampleRateHz)},
 		{"Channels", channelName(rec.Run.Channels)},
 	}
+	if rec.Run.OutputChannels > 0 {
+		rows = append(rows, []string{"Output channels", channelName(rec.Run.OutputChannels)})
+	}
+	if rec.Run.OutputBitDepth > 0 {
+		rows = append(rows, []string{"Output bit depth", strconv.Itoa(rec.Run.OutputBitDepth) + "-bit"})
+	}
+	if rec.Run.OutputSampleRateHz > 0 {
+		rows = append(rows, []string{"Output sample rate", formatSampleRate(rec.Run.OutputSampleRateHz)})
+	}
+	if rec.Run.SlateS > 0 {
+		rows = append(rows, []string{"Slate", formatDuration(durationFromSeconds(rec.Run.SlateS)) + " ahead of the programme"})
+	}
+	if rec.Run.QuietPreGainDB != 0 {
+		rows = append(rows, []string{"Quiet-input pre-gain", formatMetricSigned(rec.Run.QuietPreGainDB, 1) + " dB, filters tuned on the lifted signal"})
+	}
+	if rec.Run.AnalysisCached {
+		rows = append(rows, []string{"Analysis", "Reused from the analysis cache"})
+	}
+	if rec.Run.Pass2Chunks > 0 {
+		rows = append(rows, []string{"Processing", "Rendered in " + strconv.Itoa(rec.Run.Pass2Chunks) + " chunks, joined and measured as one programme"})
+	}
+	if r := rec.Run.FixRegion; r != nil {
+		rows = append(rows, []string{"Fixed region", formatDuration(durationFromSeconds(r.DurationS)) +
+			" from " + formatDuration(durationFromSeconds(r.StartS)) +
+			", spliced back at " + formatMetricSigned(r.GainDB, 1) + " dB; the figures below describe the region"})
+	}
+	if e := rec.Run.Excerpt; e != nil {
+		rows = append(rows, []string{"Excerpt", formatDuration(durationFromSeconds(e.DurationS)) +
+			" from " + formatDuration(durationFromSeconds(e.StartS)) + "; the figures and times below are of the excerpt"})
+	}
 	b.WriteString(mdTable([]string{"Field", "Value"}, rows))
 	return b.String()
 }
@@ -98,6 +128,12 @@ func renderLoudness(rec *processor.RunRecord) string {
 			filt:  stageGetter(filt, func(m *processor.OutputLoudnessMetrics) float64 { return m.OutputI }),
 			final: stageGetter(final, func(m *processor.OutputLoudnessMetrics) float64 { return m.OutputI }),
 		},
+		{
+			key: "speech_integrated_lufs", format: fmtLUFS,
+			input: measuredStageGetter(in, func(m *processor.InputLoudnessMetrics) float64 { return m.SpeechI }),
+			filt:  measuredStageGetter(filt, func(m *processor.OutputLoudnessMetrics) float64 { return m.SpeechI }),
+			final: measuredStageGetter(final, func(m *processor.OutputLoudnessMetrics) float64 { return m.SpeechI }),
+		},
 		{
 			key: "true_peak_dbtp", format: fmtPeakDB,
 			input: stageGetter(in, func(m *processor.InputLoudnessMetrics) float64 { return m.InputTP }),
@@ -189,6 +225,153 @@ func renderDynamics(rec *processor.RunRecord) string {
 	return b.String()
 }
 
+// =============================================================================
+// Processing Impact
+// =============================================================================
+
+// renderProcessingImpact renders the net gain applied and the input-to-final
+// loudness, dynamics, spectral and room-tone deltas from rec.ProcessingImpact.
+// Every value is a signed change, so the table carries one Value column rather
+// than per-stage columns. Returns "" when the record has no impact block
+// (analysis-only or normalisation off).
+func renderProcessingImpact(rec *processor.RunRecord) string {
+	p := rec.ProcessingImpact
+	if p == nil {
+		return ""
+	}
+
+	signed := func(key string, value float64) []string {
+		return valueRow(key, formatByRule(value, fmtSigned, 2))
+	}
+	rows := [][]string{
+		signed("makeup_gain_db", p.MakeupGainDB),
+		signed("normalisation_gain_db", p.NormalisationGainDB),
+		signed("net_gain_db", p.NetGainDB),
+		signed("loudness_change_lu", p.LoudnessChangeLU),
+		signed("crest_factor_change_db", p.CrestFactorChangeDB),
+		signed("dynamic_range_change_db", p.DynamicRangeChangeDB),
+		signed("lra_change_lu", p.LRAChangeLU),
+		signed("true_peak_change_db", p.TruePeakChangeDB),
+		valueRow("spectral_centroid_change_hz", formatByRule(p.SpectralCentroidChangeHz, fmtSigned, 0)),
+	}
+	if p.NoiseFloorChangeDB != nil {
+		rows = append(rows, signed("noise_floor_change_db", *p.NoiseFloorChangeDB))
+	}
+
+	return renderValueTable("## Processing Impact\n\n", rows)
+}
+
+// =============================================================================
+// Clarity
+// =============================================================================
+
+// renderClarity renders the --clarity comparison: the headline score pair, then
+// the three measurements behind each score. Returns "" when the record carries
+// no clarity block.
+func renderClarity(rec *processor.RunRecord) string {
+	c := rec.Clarity
+	if c == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("## Clarity\n\n")
+	b.WriteString("Clarity: " + formatFloat(c.Input.Score, 0) + " → " + formatFloat(c.Final.Score, 0) + "\n\n")
+	b.WriteString("A 0-100 intelligibility proxy: half speech-to-room-tone SNR, a quarter sibilance balance, a quarter spectral tilt, each measured over the elected speech region.\n\n")
+	b.WriteString(mdTable([]string{"Measure", "Input", "Final"}, [][]string{
+		{"Score", formatFloat(c.Input.Score, 0), formatFloat(c.Final.Score, 0)},
+		{"Speech SNR (dB)", formatFloat(c.Input.SNRDB, 1), formatFloat(c.Final.SNRDB, 1)},
+		{"Sibilance, 6-9 kHz vs 1-3 kHz (dB)", formatMetricSigned(c.Input.SibilanceDB, 1), formatMetricSigned(c.Final.SibilanceDB, 1)},
+		{"Tilt, 1-3 kHz vs 100-300 Hz (dB)", formatMetricSigned(c.Input.TiltDB, 1), formatMetricSigned(c.Final.TiltDB, 1)},
+	}))
+	return b.String()
+}
+
+// =============================================================================
+// Split Channels
+// =============================================================================
+
+// renderSplitChannels renders a --channels split run's two channels side by
+// side: loudness per stage, noise floor, and the headline adapted filter
+// settings, then the joined pair's loudness and trim. Returns "" when the
+// record carries no split_channels block.
+func renderSplitChannels(rec *processor.RunRecord) string {
+	s := rec.SplitChannels
+	if s == nil || len(s.Channels) == 0 {
+		return ""
+	}
+
+	header := []string{"Measure"}
+	for _, c := range s.Channels {
+		header = append(header, strings.ToUpper(c.Channel[:1])+c.Channel[1:])
+	}
+	row := func(label string, cell func(processor.SplitChannelRecord) string) []string {
+		r := []string{label}
+		for _, c := range s.Channels {
+			r = append(r, cell(c))
+		}
+		return r
+	}
+	lufs := func(m *processor.OutputLoudnessMetrics) string {
+		if m == nil {
+			return placeholder
+		}
+		return formatMetricLUFS(m.OutputI, 2)
+	}
+	filter := func(c processor.SplitChannelRecord, value func(*processor.FiltersBlock) string) string {
+		if c.Filters == nil {
+			return placeholder
+		}
+		return value(c.Filters)
+	}
+
+	rows := [][]string{
+		row("Input integrated (LUFS)", func(c processor.SplitChannelRecord) string {
+			if c.Loudness.Input == nil {
+				return placeholder
+			}
+			return formatMetricLUFS(c.Loudness.Input.InputI, 2)
+		}),
+		row("Filtered integrated (LUFS)", func(c processor.SplitChannelRecord) string { return lufs(c.Loudness.Filtered) }),
+		row("Final integrated (LUFS)", func(c processor.SplitChannelRecord) string { return lufs(c.Loudness.Final) }),
+		row("Final true peak (dBTP)", func(c processor.SplitChannelRecord) string {
+			if c.Loudness.Final == nil {
+				return placeholder
+			}
+			return formatMetricDB(c.Loudness.Final.OutputTP, 2)
+		}),
+		row("Noise floor (dBFS)", func(c processor.SplitChannelRecord) string {
+			if c.Noise == nil {
+				return placeholder
+			}
+			return formatMetricDB(c.Noise.Floor, 2)
+		}),
+		row("Rumble high-pass (Hz)", func(c processor.SplitChannelRecord) string {
+			return filter(c, func(f *processor.FiltersBlock) string { return formatMetric(f.RumbleHighPass.Frequency, 0) })
+		}),
+		row("Noise removal strength", func(c processor.SplitChannelRecord) string {
+			return filter(c, func(f *processor.FiltersBlock) string { return formatMetric(f.NoiseReduction.Strength, 5) })
+		}),
+		row("Gate threshold (dB)", func(c processor.SplitChannelRecord) string {
+			return filter(c, func(f *processor.FiltersBlock) string { return formatMetric(f.SpeechGate.Threshold, 2) })
+		}),
+		row("Compressor threshold (dB)", func(c processor.SplitChannelRecord) string {
+			return filter(c, func(f *processor.FiltersBlock) string { return formatMetric(f.LevellingCompressor.Threshold, 2) })
+		}),
+		row("De-esser intensity", func(c processor.SplitChannelRecord) string {
+			return filter(c, func(f *processor.FiltersBlock) string { return formatMetric(f.Deesser.Intensity, 2) })
+		}),
+	}
+
+	var b strings.Builder
+	b.WriteString("## Split Channels\n\n")
+	b.WriteString("Each channel was analysed, filtered and normalised on its own, then the two were joined as L/R. The stage tables below describe the left channel.\n\n")
+	b.WriteString(mdTable(header, rows))
+	b.WriteString("\nJoined pair: " + formatMetricLUFS(s.JoinedILUFS, 2) + " LUFS, " +
+		formatMetricDB(s.JoinedTPDBTP, 2) + " dBTP; trimmed by " + formatMetricSigned(s.TrimDB, 2) + " dB onto the target.\n")
+	return b.String()
+}
+
 // =============================================================================
 // Spectral
 // =============================================================================
@@ -256,6 +439,11 @@ func renderNoiseFloor(rec *processor.RunRecord) string {
 		{metricLabel("voice_activated"), metricDefinition("voice_activated"), boolCell(n.VoiceActivated)},
 		metricValueRow("floored_fraction", n.FlooredFraction),
 		metricValueRow("reduction_headroom_db", n.ReductionHeadroom),
+		{metricLabel("rumble_burst_count"), metricDefinition("rumble_burst_count"), formatInt(n.RumbleBurstCount)},
+		valueRow("rumble_bursts_per_minute", formatByRule(n.RumbleBurstsPerMinute, fmtRaw, 2)),
+		metricValueRow("agc_rise_db", n.AGCRiseDB),
+		{metricLabel("agc_detected"), metricDefinition("agc_detected"), boolCell(n.AGCDetected)},
+		valueRow("speech_decay_ms", formatByRule(n.SpeechDecayMS, fmtRaw, 0)),
 	}
 
 	return renderValueTable("## Noise Floor\n\n", rows)
@@ -326,8 +514,11 @@ func renderGateStatistics(g *processor.GateStatistics) string {
 }
 
 // renderRoomToneElected renders the elected room-tone NoiseProfile metrics as a
-// Metric | Definition | Value table. Returns a short note when no profile was
-// elected. Reads the wrapped *NoiseProfile via the record's Profile() read seam.
+// Metric | Definition | Value table, headed as pinned when --noise-region chose
+// the region and as loaded, without the region it does not have in this file,
+// when --load-noise-profile supplied it. Returns a short note when no profile
+// was elected. Reads the wrapped *NoiseProfile via the record's Profile() read
+// seam.
 func renderRoomToneElected(p *processor.NoiseProfile) string {
 	if p == nil {
 		return "_No room-tone profile elected._\n\n"
@@ -345,6 +536,12 @@ func renderRoomToneElected(p *processor.NoiseProfile) string {
 		metricValueRow("spectral_kurtosis", p.Spectral.Kurtosis),
 	}
 
+	if p.Pinned {
+		return renderValueTable("**Pinned profile** (--noise-region)\n\n", rows)
+	}
+	if p.Loaded {
+		return renderValueTable("**Loaded profile** (--load-noise-profile)\n\n", rows[2:])
+	}
 	return renderValueTable("**Elected profile**\n\n", rows)
 }
 
@@ -367,9 +564,16 @@ func renderSpeechElected(p *processor.SpeechCandidateMetrics) string {
 		metricValueRow("sample_peak_dbfs", p.SamplePeak),
 		metricValueRow("speech_band_body_rms_dbfs", p.BodyBandRMS),
 		metricValueRow("speech_band_sib_rms_dbfs", p.SibBandRMS),
+	}
+	if p.BandPeaksMeasured {
+		rows = append(rows,
+			metricValueRow("speech_band_body_peak_rms_dbfs", p.BodyBandPeakRMS),
+			metricValueRow("speech_band_sib_peak_rms_dbfs", p.SibBandPeakRMS))
+	}
+	rows = append(rows,
 		metricValueRow("voicing_density", p.VoicingDensity),
 		metricValueRow("score", p.Score),
-	}
+	)
 
 	return renderValueTable("**Elected profile**\n\n", rows)
 }
@@ -484,6 +688,84 @@ func renderIntervalSummary(rec *processor.RunRecord) string {
 	return renderValueTable("## Interval Summary\n\n", rows)
 }
 
+// =============================================================================
+// Pauses
+// =============================================================================
+
+// renderPauses renders the gaps-between-speech summary from rec.Pauses: count,
+// mean/median length, long-pause count, and where the longest pause sits.
+// Returns "" when the record carries no pause block.
+func renderPauses(rec *processor.RunRecord) string {
+	p := rec.Pauses
+	if p == nil {
+		return ""
+	}
+
+	rows := [][]string{
+		{metricLabel("pause_count"), metricDefinition("pause_count"), formatInt(p.Count)},
+		metricValueRow("pause_mean_s", p.MeanS),
+		metricValueRow("pause_median_s", p.MedianS),
+		metricValueRow("pause_long_threshold_s", p.LongThresholdS),
+		{metricLabel("pause_long_count"), metricDefinition("pause_long_count"), formatInt(p.LongCount)},
+		metricValueRow("pause_longest_s", p.LongestS),
+		metricValueRow("pause_longest_start_s", p.LongestStartS),
+	}
+
+	return renderValueTable("## Pauses\n\n", rows)
+}
+
+// =============================================================================
+// Polarity
+// =============================================================================
+
+// renderPolarity renders the Pass 1 speech polarity reading from rec.Polarity
+// and whether --fix-polarity inverted the output. Returns "" when the record
+// carries no polarity block.
+func renderPolarity(rec *processor.RunRecord) string {
+	p := rec.Polarity
+	if p == nil {
+		return ""
+	}
+
+	rows := [][]string{
+		valueRow("polarity_verdict", stringCell(p.Verdict)),
+		valueRow("polarity_residual_skewness", formatMetric(p.Skewness, 3)),
+	}
+	if rec.Filters != nil {
+		rows = append(rows, valueRow("polarity_corrected", boolCell(rec.Filters.Downmix.InvertPolarity)))
+	}
+
+	return renderValueTable("## Polarity\n\n", rows)
+}
+
+// =============================================================================
+// Recording Advice
+// =============================================================================
+
+// renderRecordingAdvice renders the input-gain coaching from
+// rec.InputGainAdvice: the one-line advice, then the measurements behind it.
+// Returns "" when the record carries no advice (input loudness unmeasured).
+func renderRecordingAdvice(rec *processor.RunRecord) string {
+	a := rec.InputGainAdvice
+	if a == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("## Recording Advice\n\n")
+	b.WriteString(a.Advice + "\n\n")
+	b.WriteString(renderParamTable([]paramRow{
+		{"Input gain", stringCell(a.Kind)},
+		{"Suggested gain change (dB)", formatMetric(a.GainChangeDB, 0)},
+		{"Input true peak (dBTP)", formatMetricDB(a.InputTP, 1)},
+		{"Input integrated (LUFS)", formatMetricLUFS(a.InputI, 1)},
+		{"Peak-to-loudness ratio (dB)", formatMetric(a.PLR, 1)},
+		{"Speak closer", boolCell(a.SpeakCloser)},
+	}))
+	b.WriteString("\n")
+	return b.String()
+}
+
 // =============================================================================
 // Region/summary cell helpers
 // =============================================================================
@@ -55,19 +55,35 @@ func renderFilters(rec *processor.RunRecord) string {
 
 	b.WriteString("### Downmix\n\n")
 	b.WriteString("Stereo-to-mono downmix using FFmpeg's standard downmix matrix.\n\n")
+	if f.Downmix.InvertPolarity {
+		b.WriteString("Polarity inverted (--fix-polarity).\n\n")
+	}
 
 	b.WriteString("### Rumble high-pass\n\n")
-	b.WriteString("Removes subsonic rumble before the gate. Fixed corner, 2-pole Butterworth (12 dB/oct), non-adaptive.\n\n")
+	b.WriteString("Removes subsonic rumble before the gate. Fixed corner, 2-pole Butterworth (12 dB/oct); cascaded to 24 dB/oct when wind or handling bursts are frequent.\n\n")
 	b.WriteString(renderParamTable([]paramRow{
 		{"Enabled", boolCell(f.RumbleHighPass.Enabled)},
 		{"Frequency (Hz)", formatMetric(f.RumbleHighPass.Frequency, 0)},
 		{"Poles", formatInt(f.RumbleHighPass.Poles)},
+		{"Stages", formatInt(f.RumbleHighPass.Stages)},
 		{"Width (Q)", formatMetric(f.RumbleHighPass.Width, 3)},
 		{"Mix", formatMetric(f.RumbleHighPass.Mix, 2)},
 		{"Transform", stringCell(f.RumbleHighPass.Transform)},
 	}))
 	b.WriteString("\n")
 
+	if f.HumNotch.Enabled {
+		b.WriteString("### Hum notch\n\n")
+		b.WriteString("Narrow notches at the mains frequency and its harmonics (--mains), removing ground-loop hum the rumble high-pass leaves behind.\n\n")
+		b.WriteString(renderParamTable([]paramRow{
+			{"Enabled", boolCell(f.HumNotch.Enabled)},
+			{"Fundamental (Hz)", formatMetric(f.HumNotch.Frequency, 0)},
+			{"Harmonics", formatInt(f.HumNotch.Harmonics)},
+			{"Width (Q)", formatMetric(f.HumNotch.Q, 0)},
+		}))
+		b.WriteString("\n")
+	}
+
 	b.WriteString("### Band-limit low-pass\n\n")
 	b.WriteString("Unconditional 20.5 kHz band-limit (2-pole, 12 dB/oct), giving the encoder a consistent bandwidth. Non-adaptive.\n\n")
 	b.WriteString(renderParamTable([]paramRow{
@@ -99,7 +115,7 @@ func renderFilters(rec *processor.RunRecord) string {
 
 	b.WriteString("### Speech gate\n\n")
 	b.WriteString("Soft expander for inter-speech cleanup. Threshold and range are adapted per file; the threshold and range values below are in dB.\n\n")
-	b.WriteString(renderParamTable([]paramRow{
+	gateRows := []paramRow{
 		{"Enabled", boolCell(f.SpeechGate.Enabled)},
 		{"Threshold (dB)", formatMetric(f.SpeechGate.Threshold, 2)},
 		{"Ratio", formatMetric(f.SpeechGate.Ratio, 1)},
@@ -109,7 +125,15 @@ func renderFilters(rec *processor.RunRecord) string {
 		{"Knee", formatMetric(f.SpeechGate.Knee, 1)},
 		{"Makeup", formatMetric(f.SpeechGate.Makeup, 1)},
 		{"Detection", stringCell(f.SpeechGate.Detection)},
-	}))
+		{"Comfort noise", boolCell(f.SpeechGate.ComfortNoiseEnabled)},
+	}
+	if f.SpeechGate.ComfortNoiseEnabled {
+		gateRows = append(gateRows, []paramRow{
+			{"Comfort noise level (dBFS)", formatMetricDB(f.SpeechGate.ComfortNoiseLevelDB, 2)},
+			{"Comfort noise corner (Hz)", formatMetric(f.SpeechGate.ComfortNoiseCornerHz, 0)},
+		}...)
+	}
+	b.WriteString(renderParamTable(gateRows))
 	b.WriteString("\n")
 
 	b.WriteString("### Levelling compressor\n\n")
@@ -125,6 +149,16 @@ func renderFilters(rec *processor.RunRecord) string {
 		{"Mix", formatMetric(f.LevellingCompressor.Mix, 2)},
 	}))
 	b.WriteString("\n")
+	if lc := f.LevellingCompressor; lc.SlowStageEnabled {
+		b.WriteString("High loudness range: a slow levelling stage runs ahead of the main compressor.\n\n")
+		b.WriteString(renderParamTable([]paramRow{
+			{"Slow stage threshold (dB)", formatMetric(lc.SlowStageThreshold, 2)},
+			{"Slow stage ratio", formatMetric(lc.SlowStageRatio, 1)},
+			{"Slow stage attack (ms)", formatMetric(lc.SlowStageAttack, 0)},
+			{"Slow stage release (ms)", formatMetric(lc.SlowStageRelease, 0)},
+		}))
+		b.WriteString("\n")
+	}
 
 	b.WriteString("### De-esser\n\n")
 	b.WriteString("Sibilance reduction. Intensity is adapted from the speech-region sibilant-band excess; amount and frequency are fixed (FFmpeg deesser 0-1 normalised params).\n\n")
@@ -137,6 +171,7 @@ func renderFilters(rec *processor.RunRecord) string {
 	b.WriteString("\n")
 
 	b.WriteString(renderFilterDiagnostics(f.Diagnostics))
+	b.WriteString(renderFilterGraphs(f.Graphs))
 
 	return b.String()
 }
@@ -161,11 +196,43 @@ func renderFilterDiagnostics(d *processor.AdaptiveDiagnostics) string {
 		{"Gate threshold unclamped (dB)", formatMetric(d.SpeechGateThresholdUnclamped, 2)},
 		{"Clamp reason", stringCell(d.SpeechGateClampReason)},
 		{"Gate depth (dB)", formatMetric(d.SpeechGateDepthDB, 2)},
+		{"High-LRA levelling", boolCell(d.LevellingHighLRA)},
+		{"Narrowband (VoIP) source", boolCell(d.NarrowbandSource)},
+		{"Wind/handling high-pass", boolCell(d.RumbleBurstHighPass)},
+		{"Hum notch", stringCell(d.HumNotchReason)},
+		{"Output dither", boolCell(d.OutputDither)},
+		{"Comfort noise", boolCell(d.ComfortNoise)},
+		{"Polarity inverted", boolCell(d.PolarityInverted)},
+		{"Safe mode (analysis failed)", boolCell(d.SafeMode)},
 		{"afftdn enabled", boolCell(d.AfftdnEnabled)},
 		{"afftdn noise floor (dB)", afftdnNoiseFloorCell(d.AfftdnNoiseFloorDB)},
 		{"afftdn noise type", stringCell(d.AfftdnNoiseType)},
+		{"afftdn noisy bands", stringCell(d.AfftdnNoisyBands)},
 		{"afftdn disable reason", stringCell(d.AfftdnDisableReason)},
 	}))
+	if len(d.Warnings) > 0 {
+		b.WriteString("\n**Warnings**\n\n")
+		for _, w := range d.Warnings {
+			b.WriteString("- " + w + "\n")
+		}
+	}
+	return b.String()
+}
+
+// renderFilterGraphs renders the filter graph strings each pass applied, as
+// code blocks that paste straight into an ffmpeg -af. Returns the empty string
+// when the record carries no graphs.
+func renderFilterGraphs(g *processor.FilterGraphs) string {
+	if g == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n### Filter graphs\n\n")
+	b.WriteString("Pass 2 (processing):\n\n```text\n" + g.Processing + "\n```\n")
+	if g.Normalisation != "" {
+		b.WriteString("\nPass 4 (normalisation), less the loudnorm stats file:\n\n```text\n" + g.Normalisation + "\n```\n")
+	}
 	return b.String()
 }
 
@@ -204,28 +271,61 @@ func renderNormalisation(rec *processor.RunRecord) string {
 	var b strings.Builder
 	b.WriteString("## Peak Limiter\n\n")
 	b.WriteString("Transparent limiter that creates true-peak headroom so loudnorm reaches the target in linear mode. Pre-gain raises very quiet recordings before limiting.\n\n")
-	b.WriteString(renderParamTable([]paramRow{
+	limiterRows := []paramRow{
 		{"Enabled", boolCell(r.LimiterEnabled)},
 		{"Ceiling (dBTP)", formatMetricDB(r.LimiterCeiling, 2)},
 		{"Gain required (dB)", formatMetric(r.LimiterGain, 2)},
 		{"Filtered true peak (dBTP)", formatMetricDB(r.LimiterFilteredTP, 2)},
 		{"Pre-gain (dB)", formatMetric(r.PreGainDB, 2)},
 		{"Ceiling clamped", boolCell(r.LimiterClamped)},
-	}))
+	}
+	if r.NoiseGuardRaised {
+		// The derived ceiling sat too close to the noise; it was raised so the
+		// limiter does not ride the room tone.
+		limiterRows = append(limiterRows, []paramRow{
+			{"Room-tone peak (dBFS)", formatMetricDB(r.NoisePeakDB, 2)},
+			{"Ceiling raised above noise", boolCell(true)},
+		}...)
+	}
+	b.WriteString(renderParamTable(limiterRows))
 	b.WriteString("\n")
 
 	b.WriteString("## Loudnorm\n\n")
-	b.WriteString("EBU R128 loudness normalisation in linear mode using the Pass-3 measured input statistics.\n\n")
+	b.WriteString("EBU R128 loudness normalisation using the Pass-3 measured input statistics. Linear mode applies one static gain; dynamic mode rides the gain to hit the target.\n\n")
 	rows := []paramRow{
+		{"Mode", stringCell(r.LoudnormMode)},
+		{"Target mode", stringCell(r.TargetMode)},
+	}
+	if r.TargetMode == processor.TargetModeRMS {
+		// --target-rms: the requested loudness target below is derived from the
+		// RMS target; both measured output values are shown.
+		rows = append(rows, []paramRow{
+			{"Target RMS (dBFS)", formatMetricDB(r.TargetRMS, 2)},
+			{"Output RMS (dBFS)", formatMetricDB(r.OutputRMS, 2)},
+		}...)
+	}
+	rows = append(rows, []paramRow{
 		{"Requested target (LUFS)", formatMetricLUFS(r.RequestedTargetI, 2)},
 		{"Effective target (LUFS)", formatMetricLUFS(r.EffectiveTargetI, 2)},
+	}...)
+	if r.SpeechTargetShift != 0 {
+		// --speech-loudness: the effective target is the gated loudness that puts
+		// the speech on the requested target.
+		rows = append(rows, paramRow{"Speech target shift (LU)", formatMetricSigned(r.SpeechTargetShift, 2)})
+	}
+	if r.MeasurementEstimated {
+		// --skip-output-analysis: Pass 3 did not run, so the input loudness and
+		// true peak below are estimated from the Pass 2 analysis.
+		rows = append(rows, paramRow{"Input measurement", stringCell("estimated from Pass 2 (Pass 3 skipped)")})
+	}
+	rows = append(rows, []paramRow{
 		{"Gain applied (dB)", formatMetric(r.GainApplied, 2)},
 		{"Linear mode forced", boolCell(r.LinearModeForced)},
 		{"Input loudness (LUFS)", formatMetricLUFS(r.InputLUFS, 2)},
 		{"Input true peak (dBTP)", formatMetricDB(r.InputTP, 2)},
 		{"Output loudness (LUFS)", formatMetricLUFS(r.OutputLUFS, 2)},
 		{"Output true peak (dBTP)", formatMetricDB(r.OutputTP, 2)},
-	}
+	}...)
 	if m := r.LoudnormParsed; m != nil {
 		rows = append(rows,
 			paramRow{"Measured input integrated (LUFS)", loudnormValueCell(m.InputI, fmtLUFS)},
@@ -269,3 +369,41 @@ func loudnormValueCell(v processor.LoudnormValue, format metricFormat) string {
 	}
 	return formatByRule(v.Value, format, 2)
 }
+
+// renderSpecCompliance renders the --spec delivery QC: the verdict line, then
+// the target, tolerance and delivered figure for loudness and true peak, each
+// graded. Returns "" when no spec was selected or normalisation did not run.
+func renderSpecCompliance(rec *processor.RunRecord) string {
+	r := rec.Normalisation.Result()
+	if r == nil || r.SpecCompliance == nil {
+		return ""
+	}
+	c := r.SpecCompliance
+
+	var b strings.Builder
+	b.WriteString("## Delivery Spec\n\n")
+	b.WriteString("**" + c.Verdict() + "**\n\n")
+	b.WriteString(mdTable([]string{"Check", "Target", "Delivered", "Result"}, [][]string{
+		{
+			"Integrated loudness (LUFS)",
+			formatMetricLUFS(c.TargetI, 1) + " ±" + formatFloat(c.ToleranceLU, 1),
+			formatMetricLUFS(c.MeasuredI, 2),
+			passCell(c.LoudnessPass),
+		},
+		{
+			"True peak (dBTP)",
+			"≤ " + formatMetricDB(c.TargetTP, 1),
+			formatMetricDB(c.MeasuredTP, 2),
+			passCell(c.TruePeakPass),
+		},
+	}))
+	return b.String()
+}
+
+// passCell renders a QC check result.
+func passCell(pass bool) string {
+	if pass {
+		return "PASS"
+	}
+	return "FAIL"
+}
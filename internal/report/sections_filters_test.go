@@ -53,6 +53,8 @@ func processingRecord() *processor.RunRecord {
 		RequestedTargetI: -16.0,
 		EffectiveTargetI: -16.0,
 		LinearModeForced: false,
+		LoudnormMode:     processor.LoudnormModeLinear,
+		TargetMode:       processor.TargetModeLUFS,
 		LimiterDiagnostics: processor.LimiterDiagnostics{
 			LimiterEnabled:    true,
 			LimiterCeiling:    -24.0,
@@ -135,6 +137,28 @@ func TestRenderFiltersParams(t *testing.T) {
 	}
 }
 
+func TestRenderFilterGraphs(t *testing.T) {
+	if got := renderFilterGraphs(nil); got != "" {
+		t.Errorf("no graphs must render empty, got %q", got)
+	}
+	got := renderFilterGraphs(&processor.FilterGraphs{
+		Processing:    "highpass=f=80,lowpass=f=20500",
+		Normalisation: "loudnorm=I=-16",
+	})
+	for _, want := range []string{
+		"### Filter graphs",
+		"```text\nhighpass=f=80,lowpass=f=20500\n```",
+		"```text\nloudnorm=I=-16\n```",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("filter graphs missing %q\n%s", want, got)
+		}
+	}
+	if strings.Contains(renderFilterGraphs(&processor.FilterGraphs{Processing: "anull"}), "Pass 4") {
+		t.Error("Pass 4 graph rendered without normalisation")
+	}
+}
+
 func TestRenderFiltersAnalysisOnlyEmpty(t *testing.T) {
 	rec := pass1OnlyRecord()
 	rec.Filters = nil
@@ -205,6 +229,48 @@ func TestRenderNormalisationNumbers(t *testing.T) {
 	}
 }
 
+func TestRenderNormalisationTargetRMS(t *testing.T) {
+	if got := renderNormalisation(processingRecord()); strings.Contains(got, "Target RMS") {
+		t.Errorf("LUFS mode must not render RMS rows\n%s", got)
+	}
+
+	rec := processingRecord()
+	r := rec.Normalisation.Result()
+	r.TargetMode = processor.TargetModeRMS
+	r.TargetRMS = -20
+	r.OutputRMS = -20.04
+	got := renderNormalisation(rec)
+	for _, want := range []string{
+		"| Target mode | rms |",
+		"| Target RMS (dBFS) | -20.00 |",
+		"| Output RMS (dBFS) | -20.04 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("normalisation output missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderNormalisationNoiseGuard(t *testing.T) {
+	if got := renderNormalisation(processingRecord()); strings.Contains(got, "Room-tone peak") {
+		t.Errorf("unguarded limiter must not render the noise guard rows\n%s", got)
+	}
+
+	rec := processingRecord()
+	r := rec.Normalisation.Result()
+	r.NoiseGuardRaised = true
+	r.NoisePeakDB = -31.5
+	got := renderNormalisation(rec)
+	for _, want := range []string{
+		"| Room-tone peak (dBFS) | -31.50 |",
+		"| Ceiling raised above noise |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("normalisation output missing %q\n%s", want, got)
+		}
+	}
+}
+
 func TestRenderNormalisationAnalysisOnlyEmpty(t *testing.T) {
 	rec := pass1OnlyRecord()
 	rec.Normalisation = nil
@@ -213,6 +279,31 @@ func TestRenderNormalisationAnalysisOnlyEmpty(t *testing.T) {
 	}
 }
 
+func TestRenderSpecCompliance(t *testing.T) {
+	rec := processingRecord()
+	if got := renderSpecCompliance(rec); got != "" {
+		t.Errorf("no --spec must render empty, got %q", got)
+	}
+
+	rec.Normalisation.Result().SpecCompliance = &processor.SpecCompliance{
+		Spec: "aes-podcast", Title: "AES podcast",
+		TargetI: -16, TargetTP: -1, ToleranceLU: 1,
+		MeasuredI: -16.02, MeasuredTP: -2.37,
+		LoudnessPass: true, TruePeakPass: true, Pass: true,
+	}
+	got := renderSpecCompliance(rec)
+	for _, want := range []string{
+		"## Delivery Spec",
+		"**PASS: AES podcast spec**",
+		"| Integrated loudness (LUFS) | -16.0 ±1.0 | -16.02 | PASS |",
+		"| True peak (dBTP) | ≤ -1.0 | -2.37 | PASS |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("spec output missing %q\n%s", want, got)
+		}
+	}
+}
+
 func TestRenderSpectrogramsStubEmpty(t *testing.T) {
 	if got := renderSpectrograms(processingRecord()); got != "" {
 		t.Errorf("renderSpectrograms stub must return empty, got %q", got)
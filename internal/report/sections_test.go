@@ -15,13 +15,16 @@ import (
 func fullLoudnessRecord() *processor.RunRecord {
 	return &processor.RunRecord{
 		Run: processor.RunProvenance{
-			InputFile:    "EP83-mark.flac",
-			Version:      "0.6.0",
-			Executable:   "/usr/local/bin/jivetalking",
-			ProcessedAt:  "2026-06-11T17:20:55+01:00",
-			DurationS:    125.5,
-			SampleRateHz: 44100,
-			Channels:     1,
+			InputFile:          "EP83-mark.flac",
+			Version:            "0.6.0",
+			Executable:         "/usr/local/bin/jivetalking",
+			ProcessedAt:        "2026-06-11T17:20:55+01:00",
+			DurationS:          125.5,
+			SampleRateHz:       44100,
+			Channels:           1,
+			OutputChannels:     2,
+			OutputBitDepth:     24,
+			OutputSampleRateHz: 48000,
 		},
 		Loudness: processor.LoudnessDomain{
 			TargetILUFS: -16.0,
@@ -37,6 +40,7 @@ func fullLoudnessRecord() *processor.RunRecord {
 					InputLRA:     15.01,
 					InputThresh:  -45.22,
 					TargetOffset: 19.22,
+					SpeechI:      -33.4,
 				},
 				Filtered: &processor.OutputLoudnessMetrics{
 					OutputI:   -25.1,
@@ -47,6 +51,7 @@ func fullLoudnessRecord() *processor.RunRecord {
 					OutputI:   -16.05,
 					OutputTP:  -2.51,
 					OutputLRA: 7.1,
+					SpeechI:   -15.2,
 				},
 			},
 		},
@@ -109,12 +114,49 @@ func TestRenderHeader(t *testing.T) {
 		"EP83-mark.flac",
 		"2026-06-11T17:20:55+01:00",
 		"44.1 kHz",
-		"mono",
+		"| Channels | mono |",
+		"| Output channels | stereo |",
+		"| Output bit depth | 24-bit |",
+		"| Output sample rate | 48.0 kHz |",
 	} {
 		if !strings.Contains(got, want) {
 			t.Errorf("header missing %q\n%s", want, got)
 		}
 	}
+	if strings.Contains(got, "Slate") {
+		t.Errorf("header shows a slate the run did not add\n%s", got)
+	}
+
+	rec := fullLoudnessRecord()
+	rec.Run.SlateS = 12
+	if got := renderHeader(rec); !strings.Contains(got, "| Slate | 12.0s ahead of the programme |") {
+		t.Errorf("header missing the slate row\n%s", got)
+	}
+
+	rec.Run.QuietPreGainDB = 18.25
+	if got := renderHeader(rec); !strings.Contains(got, "| Quiet-input pre-gain | +18.2 dB, filters tuned on the lifted signal |") {
+		t.Errorf("header missing the pre-gain row\n%s", got)
+	}
+
+	rec.Run.AnalysisCached = true
+	if got := renderHeader(rec); !strings.Contains(got, "| Analysis | Reused from the analysis cache |") {
+		t.Errorf("header missing the analysis-cache row\n%s", got)
+	}
+
+	rec.Run.Pass2Chunks = 12
+	if got := renderHeader(rec); !strings.Contains(got, "| Processing | Rendered in 12 chunks, joined and measured as one programme |") {
+		t.Errorf("header missing the chunked-processing row\n%s", got)
+	}
+
+	rec.Run.FixRegion = &processor.FixRegionSplice{StartS: 83, DurationS: 20, LeadCrossfadeS: 0.5, TailCrossfadeS: 0.5, GainDB: -7.46}
+	if got := renderHeader(rec); !strings.Contains(got, "| Fixed region | 20.0s from 1m 23s, spliced back at -7.5 dB; the figures below describe the region |") {
+		t.Errorf("header missing the fixed-region row\n%s", got)
+	}
+
+	rec.Run.Excerpt = &processor.ExcerptRange{StartS: 720, DurationS: 180}
+	if got := renderHeader(rec); !strings.Contains(got, "| Excerpt | 3m 0s from 12m 0s; the figures and times below are of the excerpt |") {
+		t.Errorf("header missing the excerpt row\n%s", got)
+	}
 }
 
 func TestRenderProcessingSummaryZeroOmitted(t *testing.T) {
@@ -296,14 +338,18 @@ func regionsRecord() *processor.RunRecord {
 
 	m := &processor.AudioMeasurements{
 		Noise: processor.NoiseMetrics{
-			Floor:               -84.58,
-			FloorSource:         "vad_percentile",
-			FloorPrescan:        -83.60,
-			FloorAstats:         math.NaN(),
-			RoomToneDetectLevel: -82.60,
-			VoiceActivated:      false,
-			FlooredFraction:     0.1234,
-			ReductionHeadroom:   40.12,
+			Floor:                 -84.58,
+			FloorSource:           "vad_percentile",
+			FloorPrescan:          -83.60,
+			FloorAstats:           math.NaN(),
+			RoomToneDetectLevel:   -82.60,
+			VoiceActivated:        false,
+			FlooredFraction:       0.1234,
+			ReductionHeadroom:     40.12,
+			RumbleBurstCount:      9,
+			RumbleBurstsPerMinute: 0.19,
+			AGCRiseDB:             5.5,
+			AGCDetected:           true,
 		},
 		Regions: processor.RegionMetrics{
 			NoiseProfile:        &noise,
@@ -320,6 +366,10 @@ func regionsRecord() *processor.RunRecord {
 	// RegionSample); it backs regions.room_tone.samples.input.
 	electedRoomTone := processor.RegionSample{RMSLevel: -84.58, PeakLevel: -71.22}
 	m.Regions.ElectedRoomToneSample = &electedRoomTone
+	m.Regions.Pauses = &processor.PauseStatistics{
+		Count: 212, MeanS: 0.61, MedianS: 0.5, LongThresholdS: 2,
+		LongCount: 14, LongestS: 6.25, LongestStartS: 1841.75,
+	}
 
 	return processor.NewAnalysisRunRecord("LMP-83-mark.flac", m)
 }
@@ -509,6 +559,95 @@ func TestRenderIntervalSummaryNilOmitted(t *testing.T) {
 	}
 }
 
+func TestRenderProcessingImpact(t *testing.T) {
+	rec := fullLoudnessRecord()
+	noiseFloorChange := -18.6
+	rec.ProcessingImpact = &processor.ProcessingImpact{
+		MakeupGainDB:             0,
+		NormalisationGainDB:      9.05,
+		NetGainDB:                9.05,
+		LoudnessChangeLU:         19.17,
+		CrestFactorChangeDB:      -6.4,
+		DynamicRangeChangeDB:     -12.25,
+		LRAChangeLU:              -7.91,
+		TruePeakChangeDB:         -3.2,
+		SpectralCentroidChangeHz: -412,
+		NoiseFloorChangeDB:       &noiseFloorChange,
+	}
+	got := renderProcessingImpact(rec)
+	for _, want := range []string{
+		"## Processing Impact",
+		"| Metric | Definition | Value |",
+		"Net gain",
+		"+9.05",
+		"+19.17",
+		"-6.40",
+		"-12.25",
+		"-7.91",
+		"| True peak change |",
+		"-3.20",
+		"| Spectral centroid change |",
+		"-412",
+		"| Noise floor change |",
+		"-18.60",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("processing impact missing %q\n%s", want, got)
+		}
+	}
+
+	rec.ProcessingImpact = nil
+	if got := renderProcessingImpact(rec); got != "" {
+		t.Errorf("nil processing impact must render empty, got %q", got)
+	}
+}
+
+func TestRenderPauses(t *testing.T) {
+	got := renderPauses(regionsRecord())
+	for _, want := range []string{
+		"## Pauses",
+		"| 212 |", // pause count
+		"| 14 |",  // long pauses
+		"6.25",    // longest
+		"1841.75", // longest start
+		"Long-pause threshold",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("pauses missing %q\n%s", want, got)
+		}
+	}
+
+	rec := regionsRecord()
+	rec.Pauses = nil
+	if got := renderPauses(rec); got != "" {
+		t.Errorf("nil pauses must render empty, got %q", got)
+	}
+}
+
+func TestRenderPolarity(t *testing.T) {
+	rec := regionsRecord()
+	if got := renderPolarity(rec); got != "" {
+		t.Errorf("nil polarity must render empty, got %q", got)
+	}
+
+	rec.Polarity = &processor.PolarityAnalysis{Skewness: 0.412, Verdict: processor.PolarityInverted}
+	got := renderPolarity(rec)
+	for _, want := range []string{"## Polarity", "| inverted |", "| 0.412 |"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("polarity missing %q\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Corrected") {
+		t.Errorf("analysis-only record must not show the correction row\n%s", got)
+	}
+
+	rec.Filters = &processor.FiltersBlock{}
+	rec.Filters.Downmix.InvertPolarity = true
+	if got := renderPolarity(rec); !strings.Contains(got, "| Corrected | Whether --fix-polarity inverted the output. | yes |") {
+		t.Errorf("correction row missing\n%s", got)
+	}
+}
+
 // TestRenderSpectrogramsProcessing: a processing record (whole+roomtone+speech,
 // before/after) renders a ## Spectrograms section with image links and both
 // Before and After columns, using the record's relative basenames.
@@ -613,3 +752,90 @@ func TestRenderRegionsNoDroppedTokens(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderRecordingAdvice(t *testing.T) {
+	rec := regionsRecord()
+	if got := renderRecordingAdvice(rec); got != "" {
+		t.Errorf("no advice must render empty, got %q", got)
+	}
+
+	rec.InputGainAdvice = &processor.InputGainAdvice{
+		Kind: "hot", InputTP: -2, InputI: -28, PLR: 26, GainChangeDB: -4, SpeakCloser: true,
+		Advice: "Your recording peaked at -2.0 dBTP with -28.0 LUFS integrated: consider lowering your input gain about 4 dB and speaking closer to the mic.",
+	}
+	got := renderRecordingAdvice(rec)
+	for _, want := range []string{
+		"## Recording Advice",
+		"consider lowering your input gain about 4 dB and speaking closer to the mic.",
+		"| Suggested gain change (dB) | -4 |",
+		"| Speak closer | yes |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("recording advice missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderClarity(t *testing.T) {
+	rec := &processor.RunRecord{}
+	if got := renderClarity(rec); got != "" {
+		t.Errorf("no clarity block must render empty, got %q", got)
+	}
+
+	rec.Clarity = &processor.ClarityComparison{
+		Input: processor.ClarityScore{Score: 61.6, SNRDB: 24.2, SibilanceDB: -21.3, TiltDB: -16},
+		Final: processor.ClarityScore{Score: 81.2, SNRDB: 38.9, SibilanceDB: -14.1, TiltDB: -9.5},
+	}
+	got := renderClarity(rec)
+	for _, want := range []string{
+		"## Clarity",
+		"Clarity: 62 → 81",
+		"| Speech SNR (dB) | 24.2 | 38.9 |",
+		"| Sibilance, 6-9 kHz vs 1-3 kHz (dB) | -21.3 | -14.1 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("clarity section missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderSplitChannels(t *testing.T) {
+	rec := &processor.RunRecord{}
+	if got := renderSplitChannels(rec); got != "" {
+		t.Errorf("no split_channels block must render empty, got %q", got)
+	}
+
+	rec.SplitChannels = &processor.SplitChannelsRecord{
+		JoinedILUFS: -15.2, JoinedTPDBTP: -1.1, TrimDB: -0.8,
+		Channels: []processor.SplitChannelRecord{
+			{
+				Channel: "left",
+				Loudness: processor.LoudnessStages{
+					Input: &processor.InputLoudnessMetrics{InputI: -24.3},
+					Final: &processor.OutputLoudnessMetrics{OutputI: -12.99, OutputTP: -1.5},
+				},
+				Noise: &processor.NoiseMetrics{Floor: -61.2},
+			},
+			{
+				Channel: "right",
+				Loudness: processor.LoudnessStages{
+					Input: &processor.InputLoudnessMetrics{InputI: -30.1},
+				},
+			},
+		},
+	}
+	got := renderSplitChannels(rec)
+	for _, want := range []string{
+		"## Split Channels",
+		"| Measure | Left | Right |",
+		"| Input integrated (LUFS) | -24.30 | -30.10 |",
+		"| Final integrated (LUFS) | -12.99 | - |",
+		"| Noise floor (dBFS) | -61.20 | - |",
+		"| Gate threshold (dB) | - | - |",
+		"Joined pair: -15.20 LUFS, -1.10 dBTP; trimmed by -0.80 dB onto the target.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("split channels section missing %q\n%s", want, got)
+		}
+	}
+}
@@ -50,6 +50,15 @@ type CompletionResult struct {
 	// with Summary.InputLRA it drives the done-box Dynamics before→after row.
 	OutputLRA  float64
 	OutputPath string
+	// TargetPaths are the extra outputs rendered at the other --targets
+	// loudness values, one per line, listed under the heading; empty for a
+	// single target. A string keeps CompletionResult comparable.
+	TargetPaths string
+	// InputClarity and OutputClarity are the 0-100 clarity scores (--clarity)
+	// behind the done-box Clarity row, drawn only when HaveClarity is set.
+	InputClarity  float64
+	OutputClarity float64
+	HaveClarity   bool
 	// Quality is the OUTPUT quality score (Processed), graded against spec. It
 	// reliably saturates near 5 stars because the normaliser hits -16 LUFS.
 	Quality processor.QualityScore
@@ -57,11 +66,18 @@ type CompletionResult struct {
 	// Pass-1 measurements. It genuinely varies with source quality, so the pair
 	// Recording -> Processed tells the value story in the done box.
 	RecordingQuality processor.QualityScore
+	// Advice is the prioritised recording-advice line (processor.RecordingAdvice)
+	// shown under the quality rows. Empty for a clean capture, and then no line
+	// is drawn.
+	Advice string
 	// ProcessingTime is the total wall-clock time across all four passes; it drives
 	// the done-box Time row. FileProgress.ElapsedTime cannot be used because it
 	// resets per pass.
 	ProcessingTime time.Duration
 	Error          error
+	// Skipped marks a file left unprocessed because its output already exists
+	// (--on-exists=skip); Error carries the reason and path.
+	Skipped bool
 }
 
 // FileCompleteMsg indicates a file has finished processing
@@ -80,5 +96,15 @@ type AdaptedSummaryMsg struct {
 	Summary   AdaptedSummary
 }
 
+// RoomTonePickMsg asks the user to choose the room-tone region for a file
+// (--pick-room-tone). The pool worker sends it from inside Pass 1 and blocks on
+// Reply; the model answers with the chosen candidate index, or -1 to keep the
+// automatic choice. Reply must be buffered so the answer never blocks Update.
+type RoomTonePickMsg struct {
+	FileIndex  int
+	Candidates []processor.RoomToneCandidate
+	Reply      chan<- int
+}
+
 // AllCompleteMsg indicates all files have been processed
 type AllCompleteMsg struct{}
@@ -134,6 +134,7 @@ const (
 	StatusNormalising
 	StatusComplete
 	StatusError
+	StatusSkipped
 )
 
 // FileProgress tracks progress for a single audio file
@@ -223,6 +224,7 @@ type Model struct {
 	TotalFiles     int
 	CompletedFiles int
 	FailedFiles    int
+	SkippedFiles   int
 
 	// Global state
 	StartTime time.Time
@@ -248,11 +250,22 @@ type Model struct {
 	progressSpring harmonica.Spring // eases the progress bar fill
 	peakSpring     harmonica.Spring // eases the peak-hold marker
 
+	// pick is the pending room-tone selection prompt, nil when none is open.
+	// While set, the view shows the picker and the arrow/enter keys drive it
+	// instead of the file-queue viewport.
+	pick *roomTonePick
+
 	// Terminal dimensions
 	Width  int
 	Height int
 }
 
+// roomTonePick holds an open room-tone selection prompt and its cursor.
+type roomTonePick struct {
+	msg    RoomTonePickMsg
+	cursor int
+}
+
 // NewModel creates a new UI model with the given input files
 func NewModel(inputFiles []string) Model {
 	files := make([]FileProgress, len(inputFiles))
@@ -392,6 +405,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// An open room-tone prompt owns the keyboard until it is answered.
+	if key, ok := msg.(tea.KeyPressMsg); ok && m.pick != nil {
+		return m.updatePick(key), nil
+	}
+
 	// Forward scroll input (mouse wheel + pager keys) to the viewport so it can
 	// page the file queue. handleCommonMsg already consumed the quit keys and the
 	// resize, so they never reach here; everything else is safe to forward. Do NOT
@@ -437,12 +455,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.refreshViewportContent()
 		return m, nil
 
+	case RoomTonePickMsg:
+		m.pick = &roomTonePick{msg: msg, cursor: automaticCandidate(msg.Candidates)}
+		return m, nil
+
 	case FileCompleteMsg:
 		if msg.FileIndex >= 0 && msg.FileIndex < len(m.Files) {
 			m.Files[msg.FileIndex].Status = StatusComplete
 			m.Files[msg.FileIndex].CompletionResult = msg.CompletionResult
 
-			if msg.Error != nil {
+			if msg.Skipped {
+				m.Files[msg.FileIndex].Status = StatusSkipped
+				m.SkippedFiles++
+			} else if msg.Error != nil {
 				m.Files[msg.FileIndex].Status = StatusError
 				m.FailedFiles++
 			} else {
@@ -503,6 +528,8 @@ func (m Model) View() tea.View {
 		// tea.Quit), where the completion summary is reprinted with native
 		// scrollback. Render it whole here for the brief final frame.
 		view = tea.NewView(renderCompletionSummary(m))
+	} else if m.pick != nil {
+		view = tea.NewView(renderProcessingHeader(m) + "\n" + renderRoomTonePicker(m))
 	} else {
 		view = tea.NewView(m.renderScrollingView())
 	}
@@ -554,6 +581,40 @@ func (m Model) scrollbarStrip(overflow bool) string {
 	return renderScrollbar(vpHeight, m.vp.TotalLineCount(), vpHeight, m.vp.ScrollPercent())
 }
 
+// updatePick moves the room-tone prompt cursor or answers it. Enter replies
+// with the highlighted candidate; esc replies -1 to keep the automatic choice.
+// Either answer closes the prompt.
+func (m Model) updatePick(key tea.KeyPressMsg) Model {
+	switch key.String() {
+	case "up", "k":
+		if m.pick.cursor > 0 {
+			m.pick = &roomTonePick{msg: m.pick.msg, cursor: m.pick.cursor - 1}
+		}
+	case "down", "j":
+		if m.pick.cursor < len(m.pick.msg.Candidates)-1 {
+			m.pick = &roomTonePick{msg: m.pick.msg, cursor: m.pick.cursor + 1}
+		}
+	case "enter":
+		m.pick.msg.Reply <- m.pick.cursor
+		m.pick = nil
+	case "esc":
+		m.pick.msg.Reply <- -1
+		m.pick = nil
+	}
+	return m
+}
+
+// automaticCandidate returns the index of the candidate the detector would
+// elect on its own, so the prompt opens on the default. Falls back to 0.
+func automaticCandidate(candidates []processor.RoomToneCandidate) int {
+	for i, c := range candidates {
+		if c.Automatic {
+			return i
+		}
+	}
+	return 0
+}
+
 // updateFileProgress updates a FileProgress based on a ProgressMsg
 func updateFileProgress(fp FileProgress, msg ProgressMsg) FileProgress {
 	// Reset the start time when transitioning to a new pass
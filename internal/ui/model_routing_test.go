@@ -62,6 +62,24 @@ func TestFileCompleteMsgIndexRouting(t *testing.T) {
 	}
 }
 
+func TestFileCompleteMsgSkipped(t *testing.T) {
+	m := NewModel([]string{"a.wav", "b.wav"})
+
+	skip := errors.New("output already exists, not processed: a-LUFS-16-processed.flac")
+	updated, _ := m.Update(FileCompleteMsg{FileIndex: 0, CompletionResult: CompletionResult{Error: skip, Skipped: true}})
+	m = updated.(Model)
+
+	if m.Files[0].Status != StatusSkipped {
+		t.Errorf("Files[0].Status = %v, want StatusSkipped", m.Files[0].Status)
+	}
+	if m.SkippedFiles != 1 || m.FailedFiles != 0 || m.CompletedFiles != 0 {
+		t.Errorf("counts: completed=%d failed=%d skipped=%d, want 0/0/1", m.CompletedFiles, m.FailedFiles, m.SkippedFiles)
+	}
+	if got := renderOverallProgress(m); !strings.Contains(got, "1 skipped") {
+		t.Errorf("overall progress missing the skipped count:\n%s", got)
+	}
+}
+
 func TestUpdateOutOfRangeSafety(t *testing.T) {
 	m := NewModel([]string{"a.wav", "b.wav"})
 	want := append([]FileProgress(nil), m.Files...)
@@ -356,3 +374,56 @@ func TestMeterTickStopsAfterAllComplete(t *testing.T) {
 		t.Errorf("meters[0].pos = %v, want unchanged %v after Done", m.meters[0].pos, posBefore)
 	}
 }
+
+func TestRoomTonePickMsgPromptAndReply(t *testing.T) {
+	candidates := []processor.RoomToneCandidate{
+		{Duration: 4e9, MomentaryLUFS: -58},
+		{Duration: 10e9, MomentaryLUFS: -62, Automatic: true},
+		{Duration: 6e9, MomentaryLUFS: -60},
+	}
+
+	open := func(t *testing.T) (Model, chan int) {
+		t.Helper()
+		reply := make(chan int, 1)
+		m := NewModel([]string{"a.wav"})
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		updated, _ = updated.(Model).Update(RoomTonePickMsg{FileIndex: 0, Candidates: candidates, Reply: reply})
+		m = updated.(Model)
+		if m.pick == nil || m.pick.cursor != 1 {
+			t.Fatalf("pick = %+v, want prompt open on the automatic candidate", m.pick)
+		}
+		if view := m.View().Content; !strings.Contains(view, "Choose the room-tone region for a.wav") {
+			t.Fatalf("view does not show the picker:\n%s", view)
+		}
+		return m, reply
+	}
+	press := func(m Model, code rune) Model {
+		updated, _ := m.Update(tea.KeyPressMsg{Code: code})
+		return updated.(Model)
+	}
+
+	t.Run("enter replies with the highlighted candidate", func(t *testing.T) {
+		m, reply := open(t)
+		m = press(m, tea.KeyDown)
+		m = press(m, tea.KeyDown) // clamped at the last row
+		m = press(m, tea.KeyEnter)
+		if got := <-reply; got != 2 {
+			t.Errorf("reply = %d, want 2", got)
+		}
+		if m.pick != nil {
+			t.Error("prompt still open after enter")
+		}
+	})
+
+	t.Run("esc keeps the automatic region", func(t *testing.T) {
+		m, reply := open(t)
+		m = press(m, tea.KeyUp)
+		m = press(m, tea.KeyEscape)
+		if got := <-reply; got != -1 {
+			t.Errorf("reply = %d, want -1", got)
+		}
+		if m.pick != nil {
+			t.Error("prompt still open after esc")
+		}
+	})
+}
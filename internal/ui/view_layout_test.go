@@ -443,6 +443,67 @@ func TestDoneBoxRowOrder(t *testing.T) {
 	}
 }
 
+// TestDoneBoxAdviceLine confirms a non-empty Advice renders below the
+// Processed row, and that an empty one adds no line.
+func TestDoneBoxAdviceLine(t *testing.T) {
+	file := FileProgress{
+		Status: StatusComplete,
+		CompletionResult: CompletionResult{
+			OutputPath: "advice-out.flac",
+			Quality:    processor.QualityScore{Stars: 4, Label: "Great"},
+		},
+	}
+	without := ansi.Strip(renderDoneBox(file))
+
+	file.Advice = "Noisy room, floor at -48 ㏈: record somewhere quieter."
+	with := ansi.Strip(renderDoneBox(file))
+
+	idx := strings.Index(with, "Noisy room")
+	if idx < 0 {
+		t.Fatalf("done box missing advice line:\n%s", with)
+	}
+	if idx < strings.Index(with, "Processed") {
+		t.Errorf("advice line renders above the Processed row:\n%s", with)
+	}
+	if strings.Count(with, "\n") <= strings.Count(without, "\n") {
+		t.Errorf("advice added no lines:\nwithout:\n%s\nwith:\n%s", without, with)
+	}
+}
+
+// TestDoneBoxTargetPaths confirms extra --targets outputs are listed under the
+// first output's name, above the box.
+func TestDoneBoxTargetPaths(t *testing.T) {
+	file := FileProgress{
+		Status: StatusComplete,
+		CompletionResult: CompletionResult{
+			OutputPath:  "/tmp/show-LUFS-16-processed.flac",
+			TargetPaths: "/tmp/show-LUFS-14-processed.flac\n/tmp/show-LUFS-23-processed.flac",
+		},
+	}
+	plain := ansi.Strip(renderDoneBox(file))
+
+	first := strings.Index(plain, "show-LUFS-16-processed.flac")
+	extra := strings.Index(plain, "show-LUFS-14-processed.flac")
+	last := strings.Index(plain, "show-LUFS-23-processed.flac")
+	if first < 0 || extra < first || last < extra || last > strings.Index(plain, "Time") {
+		t.Errorf("extra target not listed under the first output:\n%s", plain)
+	}
+}
+
+// TestDoneBoxClarityRow confirms the Clarity row appears only with a clarity
+// score.
+func TestDoneBoxClarityRow(t *testing.T) {
+	file := FileProgress{Status: StatusComplete, CompletionResult: CompletionResult{OutputPath: "c.flac"}}
+	if plain := ansi.Strip(renderDoneBox(file)); strings.Contains(plain, "Clarity") {
+		t.Errorf("Clarity row drawn without a score:\n%s", plain)
+	}
+
+	file.InputClarity, file.OutputClarity, file.HaveClarity = 61.6, 81.2, true
+	if plain := ansi.Strip(renderDoneBox(file)); !strings.Contains(plain, "62 → 81") {
+		t.Errorf("Clarity row missing 62 → 81:\n%s", plain)
+	}
+}
+
 // TestDoneBoxColumnsAlign confirms the three before→after rows (Loudness, True
 // peak, Dynamics) form a mini-table: the → and the Δ sit at the same column
 // across all three rows. Right-aligned numeric columns and a display-width-padded
@@ -119,6 +119,61 @@ func renderScrollHint() string {
 	return lipgloss.NewStyle().Foreground(cli.ColorMuted).Render(scrollHintText)
 }
 
+// roomTonePickHint is the dim footer under the room-tone picker.
+const roomTonePickHint = "↑/↓ select · enter use region · esc keep automatic"
+
+// renderRoomTonePicker renders the open room-tone prompt: one row per candidate
+// with its bounds, levels, and flux, the cursor row highlighted and the
+// automatic election marked.
+func renderRoomTonePicker(m Model) string {
+	pick := m.pick
+	var b strings.Builder
+
+	name := ""
+	if pick.msg.FileIndex >= 0 && pick.msg.FileIndex < len(m.Files) {
+		name = filepath.Base(m.Files[pick.msg.FileIndex].InputPath)
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(cli.ColorSkyBlue).
+		Render(fmt.Sprintf("Choose the room-tone region for %s", name)))
+	b.WriteString("\n\n")
+
+	header := fmt.Sprintf("   %-17s %7s %9s %9s %7s", "Region", "Length", "RMS dBFS", "LUFS", "Flux")
+	b.WriteString(lipgloss.NewStyle().Foreground(cli.ColorMuted).Render(header))
+	b.WriteString("\n")
+
+	for i, c := range pick.msg.Candidates {
+		marker := "  "
+		if i == pick.cursor {
+			marker = "▸ "
+		}
+		auto := ""
+		if c.Automatic {
+			auto = " (auto)"
+		}
+		row := fmt.Sprintf("%s %-17s %6.1fs %9.1f %9.1f %7.4f%s",
+			marker,
+			fmt.Sprintf("%s-%s", formatClock(c.Start), formatClock(c.End)),
+			c.Duration.Seconds(), c.RMSLevel, c.MomentaryLUFS, c.Flux, auto)
+		style := lipgloss.NewStyle().Foreground(cli.ColorText)
+		if i == pick.cursor {
+			style = style.Bold(true).Foreground(cli.ColorCyanBright)
+		}
+		b.WriteString(style.Render(row))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(cli.ColorMuted).Render(roomTonePickHint))
+	return b.String()
+}
+
+// formatClock renders a timeline position as M:SS.s for the room-tone picker.
+func formatClock(d time.Duration) string {
+	secs := d.Seconds()
+	mins := int(secs) / 60
+	return fmt.Sprintf("%d:%04.1f", mins, secs-float64(mins*60))
+}
+
 // renderFileQueue renders the list of files with their status
 func renderFileQueue(m Model, prog progress.Model) string {
 	var b strings.Builder
@@ -164,6 +219,11 @@ func renderFileEntry(file *FileProgress, prog progress.Model, easedLevel, easedP
 		icon := lipgloss.NewStyle().Foreground(cli.ColorRed).Render("✗")
 		return fmt.Sprintf(" %s %s\n   Error: %v", icon, fileName, file.Error)
 
+	case StatusSkipped:
+		// ⤼ output already there (--on-exists=skip)
+		icon := lipgloss.NewStyle().Foreground(cli.ColorMuted).Render("⤼")
+		return fmt.Sprintf(" %s %s\n   Skipped: %v", icon, fileName, file.Error)
+
 	default:
 		// ⧗ queued file
 		icon := lipgloss.NewStyle().Foreground(cli.ColorMuted).Render("⧗")
@@ -636,6 +696,9 @@ func renderOverallProgress(m Model) string {
 
 	content := fmt.Sprintf("Processing %d files, %d complete, %d failed",
 		m.TotalFiles, m.CompletedFiles, m.FailedFiles)
+	if m.SkippedFiles > 0 {
+		content += fmt.Sprintf(", %d skipped", m.SkippedFiles)
+	}
 
 	return box.Render(content)
 }
@@ -661,7 +724,7 @@ func renderCompletionSummary(m Model) string {
 	b.WriteString("\n\n")
 
 	for i := range m.Files {
-		if m.Files[i].Status == StatusError {
+		if m.Files[i].Status == StatusError || m.Files[i].Status == StatusSkipped {
 			b.WriteString(renderFileEntry(&m.Files[i], m.progress, 0, 0, 0, m.Width))
 			b.WriteString("\n")
 			continue
@@ -767,6 +830,8 @@ func doneBoxNoiseFloorRow(input, output float64, haveInput, haveOutput bool) str
 // Dynamics, Noise floor, Recording, and Processed. The loudness-family
 // before→after rows are grouped first, then the input→output room-tone floor,
 // then the source-capture (Recording) and output-quality (Processed) star rows.
+// A non-empty Advice adds a wrapped muted line below the star rows. Extra
+// --targets outputs are listed under the filename; the rows describe the first.
 // Shared by the live processing view (StatusComplete) and the persisted final
 // summary so completed files look identical in both. The box matches the
 // active processing box (RoundedBorder, Padding(0,1), meterWidth inner width) but
@@ -776,6 +841,9 @@ func renderDoneBox(file FileProgress) string {
 
 	icon := lipgloss.NewStyle().Foreground(cli.ColorGreen).Render("🗸")
 	heading := fmt.Sprintf(" %s %s", icon, outputName)
+	for path := range strings.Lines(file.TargetPaths) {
+		heading += fmt.Sprintf("\n %s %s", icon, filepath.Base(strings.TrimSuffix(path, "\n")))
+	}
 
 	labelStyle := lipgloss.NewStyle().Foreground(cli.ColorMuted).Width(doneBoxLabelWidth)
 	valueStyle := lipgloss.NewStyle().Foreground(cli.ColorText)
@@ -836,6 +904,13 @@ func renderDoneBox(file FileProgress) string {
 	fmt.Fprintf(&content, "%s%s\n",
 		labelStyle.Render("Noise floor"), valueStyle.Render(noiseValue))
 
+	// Clarity row (--clarity): the input → output intelligibility proxy, a
+	// unitless 0-100 score, so no Δ.
+	if file.HaveClarity {
+		fmt.Fprintf(&content, "%s%s\n", labelStyle.Render("Clarity"),
+			valueStyle.Render(fmt.Sprintf("%.0f → %.0f", file.InputClarity, file.OutputClarity)))
+	}
+
 	// Quality rows: source-capture stars (Recording) above output-quality stars
 	// (Processed). The pair tells the value story: a low Recording beside a high
 	// Processed shows what the tool rescued. Both use the same star/label styling.
@@ -847,6 +922,12 @@ func renderDoneBox(file FileProgress) string {
 	fmt.Fprintf(&content, "%s%s  %s",
 		labelStyle.Render("Processed"), procStars, valueStyle.Render(file.Quality.Label))
 
+	// Advice line: what to change next time, separated from the rows by a blank
+	// line and wrapped to the box's inner width.
+	if file.Advice != "" {
+		fmt.Fprintf(&content, "\n\n%s", muted.Width(meterWidth).Render(file.Advice))
+	}
+
 	return heading + "\n" + box.Render(content.String())
 }
 
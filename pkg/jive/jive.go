@@ -0,0 +1,293 @@
+// Package jive is the public library surface of jivetalking, for Go programs
+// that embed the processing rather than run the binary. It wraps the four-pass
+// pipeline in internal/processor behind a small, stable set of types: Options
+// in, Result or Measurements out. The full detail travels as the run record,
+// the same schema-versioned JSON the CLI writes beside each output.
+//
+// Every call takes a context; cancelling it stops the FFmpeg passes and
+// removes any partly written output.
+package jive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	ffmpeg "github.com/linuxmatters/ffmpeg-statigo"
+	"github.com/linuxmatters/jivetalking/internal/processor"
+)
+
+// Process errors when the output file is already there, matched with
+// errors.Is: ErrOutputSkipped under OnExists "skip", ErrOutputExists under
+// "error".
+var (
+	ErrOutputSkipped = processor.ErrOutputSkipped
+	ErrOutputExists  = processor.ErrOutputExists
+)
+
+// Options selects the processing choices a caller can make. The zero value
+// processes like the CLI with no flags: -16 LUFS, 16-bit mono FLAC beside the
+// input, overwriting an existing output. Each field matches the CLI flag of
+// the same name.
+type Options struct {
+	// Spec names a delivery target: "spotify", "apple", "youtube",
+	// "ebu-r128", or "aes-podcast" (--spec). Empty keeps -16 LUFS.
+	Spec string
+
+	// BitDepth is 16 or 24 (--bit-depth); 0 keeps 16.
+	BitDepth int
+
+	// Channels is "mono", "stereo", "same", or "split" (--channels); empty
+	// keeps mono.
+	Channels string
+
+	// OutputRate is "44100", "48000", "96000", or "same" (--output-rate);
+	// empty keeps 44.1 kHz.
+	OutputRate string
+
+	// OutputFormat is "flac" or "wav" (--output-format); empty keeps FLAC.
+	OutputFormat string
+
+	// Mains is "50", "60", or "auto" to notch out mains hum (--mains); empty
+	// leaves the notch off.
+	Mains string
+
+	// Output is a directory to write into, or a .flac file to write (.wav with
+	// OutputFormat "wav"), instead of beside the input (--output). Missing
+	// directories are created.
+	Output string
+
+	// OnExists is "overwrite", "skip", "rename", or "error" (--on-exists);
+	// empty overwrites.
+	OnExists string
+
+	// CacheDir keeps Pass 1 analyses for re-runs (--cache-dir). Empty leaves
+	// the cache off; the library never writes to the user cache directory
+	// unasked.
+	CacheDir string
+
+	// Progress, when set, is called from the processing goroutine as each
+	// pass advances. It must return promptly.
+	Progress func(Progress)
+}
+
+// Progress is one progress report: the pass running (1 analysis, 2
+// processing, 3 measuring, 4 normalising), its name, and how far through it
+// is, 0 to 1.
+type Progress struct {
+	Pass     int
+	PassName string
+	Fraction float64
+}
+
+// Result describes one processed file.
+type Result struct {
+	// OutputPath is the written file.
+	OutputPath string
+
+	// InputLUFS and OutputLUFS are the integrated loudness before and after.
+	InputLUFS  float64
+	OutputLUFS float64
+
+	// OutputTruePeak is the final true peak in dBTP; 0 when normalisation did
+	// not run.
+	OutputTruePeak float64
+
+	// Warnings are the adaptation warnings the report lists, plus any
+	// feature the linked FFmpeg cannot support.
+	Warnings []string
+
+	// Record is the run record as JSON: the measurements by stage, the noise
+	// profile, the resolved filter settings, and the normalisation result.
+	// Non-finite values are null.
+	Record json.RawMessage
+}
+
+// Measurements describes one analysed file.
+type Measurements struct {
+	Duration   time.Duration
+	SampleRate int
+
+	// IntegratedLUFS, TruePeak (dBTP), and LRA (LU) are the input loudness.
+	IntegratedLUFS float64
+	TruePeak       float64
+	LRA            float64
+
+	// NoiseFloor is the elected noise floor in dBFS.
+	NoiseFloor float64
+
+	// Record is the analysis run record as JSON, as --analysis-only writes it.
+	Record json.RawMessage
+}
+
+var (
+	filtersOnce sync.Once
+	filters     processor.FilterAvailability
+)
+
+// checkFilters quietens FFmpeg's own logging, as the CLI does, and reads the
+// linked filters once per process.
+func checkFilters() processor.FilterAvailability {
+	filtersOnce.Do(func() {
+		ffmpeg.AVLogSetLevel(ffmpeg.AVLogError)
+		filters = processor.CheckFilters()
+	})
+	return filters
+}
+
+// newConfig builds the processing config for opts, rejecting any value the
+// matching CLI flag would reject.
+func newConfig(opts Options) (*processor.BaseFilterConfig, error) {
+	config := processor.DefaultFilterConfig()
+	if opts.Spec != "" {
+		if err := config.SetLoudnessSpec(opts.Spec); err != nil {
+			return nil, fmt.Errorf("invalid Spec: %w", err)
+		}
+	}
+	if opts.BitDepth != 0 {
+		if err := config.SetOutputBitDepth(opts.BitDepth); err != nil {
+			return nil, fmt.Errorf("invalid BitDepth: %w", err)
+		}
+	}
+	if opts.Channels != "" {
+		if err := config.SetOutputChannels(opts.Channels); err != nil {
+			return nil, fmt.Errorf("invalid Channels: %w", err)
+		}
+	}
+	if opts.OutputRate != "" {
+		if err := config.SetOutputRate(opts.OutputRate); err != nil {
+			return nil, fmt.Errorf("invalid OutputRate: %w", err)
+		}
+	}
+	if opts.OutputFormat != "" {
+		if err := config.SetOutputFormat(opts.OutputFormat); err != nil {
+			return nil, fmt.Errorf("invalid OutputFormat: %w", err)
+		}
+	}
+	if opts.Mains != "" {
+		if err := config.SetMains(opts.Mains); err != nil {
+			return nil, fmt.Errorf("invalid Mains: %w", err)
+		}
+	}
+	if opts.Output != "" {
+		if err := config.SetOutput(opts.Output); err != nil {
+			return nil, fmt.Errorf("invalid Output: %w", err)
+		}
+	}
+	if config.OutputFile != "" {
+		format := config.OutputFormat
+		if format == "" {
+			format = processor.OutputFormatFLAC
+		}
+		if !strings.EqualFold(filepath.Ext(config.OutputFile), "."+format) {
+			return nil, fmt.Errorf("invalid Output: %s does not end in .%s for OutputFormat %q", config.OutputFile, format, format)
+		}
+	}
+	if opts.OnExists != "" {
+		if err := config.SetOnExists(opts.OnExists); err != nil {
+			return nil, fmt.Errorf("invalid OnExists: %w", err)
+		}
+	}
+	config.AnalysisCacheDir = opts.CacheDir
+	return config, nil
+}
+
+// prepare applies the linked FFmpeg's limits to config, returning the
+// warnings for any feature switched off.
+func prepare(config *processor.BaseFilterConfig) ([]string, error) {
+	available := checkFilters()
+	if len(available.MissingRequired) > 0 {
+		return nil, fmt.Errorf("FFmpeg build lacks required filters: %s", strings.Join(available.MissingRequired, ", "))
+	}
+	return available.Degrade(config), nil
+}
+
+// progressCallback adapts fn to the processor's progress updates; nil when fn
+// is nil.
+func progressCallback(fn func(Progress)) processor.ProgressCallback {
+	if fn == nil {
+		return nil
+	}
+	return func(u processor.ProgressUpdate) {
+		fn(Progress{Pass: int(u.Pass), PassName: u.PassName, Fraction: u.Progress})
+	}
+}
+
+// Process runs the full pipeline on inputPath and writes the output beside it,
+// or under Options.Output, named for the loudness target as the CLI names it.
+func Process(ctx context.Context, inputPath string, opts Options) (*Result, error) {
+	config, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := prepare(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := processor.ProcessAudio(ctx, inputPath, config, progressCallback(opts.Progress))
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := processor.MarshalRunRecord(processor.NewRunRecord(result))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode run record: %w", err)
+	}
+	out := &Result{
+		OutputPath: result.OutputPath,
+		InputLUFS:  result.InputLUFS,
+		OutputLUFS: result.OutputLUFS,
+		Warnings:   warnings,
+		Record:     record,
+	}
+	if result.NormResult != nil {
+		out.OutputTruePeak = result.NormResult.OutputTP
+	}
+	if result.Diagnostics != nil {
+		out.Warnings = append(out.Warnings, result.Diagnostics.Warnings...)
+	}
+	return out, nil
+}
+
+// Analyse runs Pass 1 on inputPath and writes nothing.
+func Analyse(ctx context.Context, inputPath string) (*Measurements, error) {
+	config, err := newConfig(Options{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := prepare(config); err != nil {
+		return nil, err
+	}
+
+	result, err := processor.AnalyseOnlyDetailed(ctx, inputPath, config, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newMeasurements(inputPath, result.Measurements)
+}
+
+// newMeasurements copies the headline figures off m and encodes its record.
+func newMeasurements(inputPath string, m *processor.AudioMeasurements) (*Measurements, error) {
+	if m == nil {
+		return nil, errors.New("analysis returned no measurements")
+	}
+	record, err := processor.MarshalRunRecord(processor.NewAnalysisRunRecord(inputPath, m))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode run record: %w", err)
+	}
+	return &Measurements{
+		Duration:       time.Duration(m.Duration * float64(time.Second)),
+		SampleRate:     m.SampleRate,
+		IntegratedLUFS: m.Loudness.InputI,
+		TruePeak:       m.Loudness.InputTP,
+		LRA:            m.Loudness.InputLRA,
+		NoiseFloor:     m.Noise.Floor,
+		Record:         record,
+	}, nil
+}
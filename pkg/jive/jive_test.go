@@ -0,0 +1,112 @@
+package jive
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/linuxmatters/jivetalking/internal/processor"
+)
+
+func TestNewConfigDefaultsMatchCLI(t *testing.T) {
+	config, err := newConfig(Options{})
+	if err != nil {
+		t.Fatalf("newConfig: %v", err)
+	}
+	want := processor.DefaultFilterConfig()
+	if config.Loudnorm.TargetI != want.Loudnorm.TargetI || config.Resample.BitDepth != want.Resample.BitDepth {
+		t.Errorf("zero Options = %.0f LUFS %d-bit, want the CLI defaults", config.Loudnorm.TargetI, config.Resample.BitDepth)
+	}
+	if config.AnalysisCacheDir != "" {
+		t.Errorf("AnalysisCacheDir = %q, want the cache off unless asked", config.AnalysisCacheDir)
+	}
+}
+
+func TestNewConfigAppliesOptions(t *testing.T) {
+	config, err := newConfig(Options{
+		Spec:     "ebu-r128",
+		BitDepth: 24,
+		Channels: processor.OutputChannelsSame,
+		Mains:    processor.MainsAuto,
+		OnExists: processor.OnExistsSkip,
+		CacheDir: "/tmp/jive-cache",
+	})
+	if err != nil {
+		t.Fatalf("newConfig: %v", err)
+	}
+	if config.Loudnorm.TargetI != -23 {
+		t.Errorf("TargetI = %.0f, want -23 for ebu-r128", config.Loudnorm.TargetI)
+	}
+	if config.Resample.BitDepth != processor.OutputBitDepth24 || config.Resample.Channels != processor.OutputChannelsSame {
+		t.Errorf("Resample = %+v, want 24-bit, same channels", config.Resample)
+	}
+	if config.Mains != processor.MainsAuto || config.OnExists != processor.OnExistsSkip || config.AnalysisCacheDir != "/tmp/jive-cache" {
+		t.Errorf("Mains %q, OnExists %q, cache %q not carried over", config.Mains, config.OnExists, config.AnalysisCacheDir)
+	}
+}
+
+func TestNewConfigRejectsInvalidOptions(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"Spec":         {Spec: "radio-4"},
+		"BitDepth":     {BitDepth: 32},
+		"Channels":     {Channels: "5.1"},
+		"OutputRate":   {OutputRate: "22050"},
+		"OutputFormat": {OutputFormat: "mp3"},
+		"Mains":        {Mains: "55"},
+		"OnExists":     {OnExists: "append"},
+		"Output wav":   {Output: "/tmp/show.wav"},
+		"Output flac":  {Output: "/tmp/show.flac", OutputFormat: "wav"},
+	} {
+		if _, err := newConfig(opts); err == nil {
+			t.Errorf("%s: newConfig(%+v) = nil error", name, opts)
+		}
+	}
+}
+
+func TestProgressCallback(t *testing.T) {
+	if progressCallback(nil) != nil {
+		t.Error("nil Progress built a callback")
+	}
+	var got Progress
+	progressCallback(func(p Progress) { got = p })(processor.ProgressUpdate{
+		Pass:     processor.PassProcessing,
+		PassName: "Processing",
+		Progress: 0.25,
+	})
+	if got != (Progress{Pass: 2, PassName: "Processing", Fraction: 0.25}) {
+		t.Errorf("Progress = %+v", got)
+	}
+}
+
+func TestNewMeasurements(t *testing.T) {
+	m := &processor.AudioMeasurements{Duration: 90.5, SampleRate: 48000}
+	m.Loudness.InputI = -24
+	m.Loudness.InputTP = -3
+	m.Loudness.InputLRA = 8
+	m.Noise.Floor = -62
+	m.Dynamics.RMSTrough = math.Inf(-1)
+
+	got, err := newMeasurements("episode.wav", m)
+	if err != nil {
+		t.Fatalf("newMeasurements: %v", err)
+	}
+	if got.Duration != 90500*time.Millisecond || got.SampleRate != 48000 {
+		t.Errorf("Duration %v at %d Hz, want 1m30.5s at 48000", got.Duration, got.SampleRate)
+	}
+	if got.IntegratedLUFS != -24 || got.TruePeak != -3 || got.LRA != 8 || got.NoiseFloor != -62 {
+		t.Errorf("Measurements = %+v", got)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(got.Record, &record); err != nil {
+		t.Fatalf("Record is not JSON: %v", err)
+	}
+	if _, ok := record["schema_version"]; !ok {
+		t.Errorf("Record carries no schema_version: %s", got.Record)
+	}
+
+	if _, err := newMeasurements("episode.wav", nil); err == nil {
+		t.Error("newMeasurements(nil) = nil error")
+	}
+}